@@ -19,6 +19,7 @@ import (
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/store/rootmulti"
 	snapshottypes "cosmossdk.io/store/snapshots/types"
+	"cosmossdk.io/store/tracekv"
 	storetypes "cosmossdk.io/store/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -107,24 +108,12 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 		}
 	}
 
-	// In the case of a new chain, AppHash will be the hash of an empty string.
-	// During an upgrade, it'll be the hash of the last committed block.
-	var appHash []byte
-	if !app.LastCommitID().IsZero() {
-		appHash = app.LastCommitID().Hash
-	} else {
-		// $ echo -n '' | sha256sum
-		// e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
-		emptyHash := sha256.Sum256([]byte{})
-		appHash = emptyHash[:]
-	}
-
 	// NOTE: We don't commit, but BeginBlock for block `initial_height` starts from this
 	// deliverState.
 	return abci.ResponseInitChain{
 		ConsensusParams: res.ConsensusParams,
 		Validators:      res.Validators,
-		AppHash:         appHash,
+		AppHash:         app.lastAppHash(),
 	}
 }
 
@@ -167,7 +156,7 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 
 	if app.cms.TracingEnabled() {
 		app.cms.SetTracingContext(storetypes.TraceContext(
-			map[string]interface{}{"blockHeight": req.Header.Height},
+			map[string]interface{}{TraceContextBlockHeightKey: req.Header.Height},
 		))
 	}
 
@@ -175,41 +164,54 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		panic(err)
 	}
 
-	// Initialize the DeliverTx state. If this is the first block, it should
-	// already be initialized in InitChain. Otherwise app.deliverState will be
-	// nil, since it is reset on Commit.
-	if app.deliverState == nil {
-		app.setState(runTxModeDeliver, req.Header)
-	} else {
-		// In the first block, app.deliverState.ctx will already be initialized
-		// by InitChain. Context is now updated with Header information.
-		app.deliverState.ctx = app.deliverState.ctx.
-			WithBlockHeader(req.Header).
-			WithBlockHeight(req.Header.Height)
+	if app.restrictsHeader(req.Header.Height) {
+		// The header is restricted to the fields known in ProcessProposal so that
+		// the block executes identically whether it is executed optimistically
+		// or not, on every node.
+		req.Header = optimisticHeader(req.Header)
 	}
 
-	gasMeter := app.getBlockGasMeter(app.deliverState.ctx)
+	if app.adoptOptimisticExecution(req) {
+		res = app.oe.resBeginBlock
+	} else {
+		// Initialize the DeliverTx state. If this is the first block, it should
+		// already be initialized in InitChain. Otherwise app.deliverState will be
+		// nil, since it is reset on Commit.
+		if app.deliverState == nil {
+			app.setState(runTxModeDeliver, req.Header)
+		} else {
+			// In the first block, app.deliverState.ctx will already be initialized
+			// by InitChain. Context is now updated with Header information.
+			app.deliverState.ctx = app.deliverState.ctx.
+				WithBlockHeader(req.Header).
+				WithBlockHeight(req.Header.Height)
+		}
 
-	app.deliverState.ctx = app.deliverState.ctx.
-		WithBlockGasMeter(gasMeter).
-		WithHeaderHash(req.Hash).
-		WithConsensusParams(app.GetConsensusParams(app.deliverState.ctx)).
-		WithVoteInfos(req.LastCommitInfo.GetVotes())
+		app.deliverState.ctx = app.deliverState.ctx.
+			WithBlockGasMeter(app.getBlockGasMeter(app.deliverState.ctx)).
+			WithHeaderHash(req.Hash).
+			WithConsensusParams(app.GetConsensusParams(app.deliverState.ctx)).
+			WithVoteInfos(req.LastCommitInfo.GetVotes()).
+			WithAccessTracer(app.blockAccessTracer(req.Header.Height))
+
+		if app.beginBlocker != nil {
+			var err error
+			ctx := withAccessTraceContext(app.deliverState.ctx, storetypes.TraceContext{
+				TraceContextPhaseKey: AccessPhaseBeginBlock,
+			})
+			res, err = app.beginBlocker(ctx, req)
+			if err != nil {
+				panic(err)
+			}
+			res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
+		}
+	}
 
 	if app.checkState != nil {
 		app.checkState.ctx = app.checkState.ctx.
-			WithBlockGasMeter(gasMeter).
+			WithBlockGasMeter(app.deliverState.ctx.BlockGasMeter()).
 			WithHeaderHash(req.Hash)
 	}
-
-	if app.beginBlocker != nil {
-		var err error
-		res, err = app.beginBlocker(app.deliverState.ctx, req)
-		if err != nil {
-			panic(err)
-		}
-		res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
-	}
 	// set the signed validators for addition to context in deliverTx
 	app.voteInfos = req.LastCommitInfo.GetVotes()
 
@@ -231,17 +233,24 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 		app.deliverState.ms = app.deliverState.ms.SetTracingContext(nil).(storetypes.CacheMultiStore)
 	}
 
-	if app.endBlocker != nil {
-		var err error
-		res, err = app.endBlocker(app.deliverState.ctx, req)
-		if err != nil {
-			panic(err)
+	if app.oe != nil {
+		res = app.endOptimisticBlock()
+	} else {
+		if app.endBlocker != nil {
+			var err error
+			ctx := withAccessTraceContext(app.deliverState.ctx, storetypes.TraceContext{
+				TraceContextPhaseKey: AccessPhaseEndBlock,
+			})
+			res, err = app.endBlocker(ctx, req)
+			if err != nil {
+				panic(err)
+			}
+			res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
 		}
-		res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
-	}
 
-	cp := app.GetConsensusParams(app.deliverState.ctx)
-	res.ConsensusParamUpdates = &cp
+		cp := app.GetConsensusParams(app.deliverState.ctx)
+		res.ConsensusParamUpdates = &cp
+	}
 
 	// call the streaming service hook with the EndBlock messages
 	for _, abciListener := range app.streamingManager.ABCIListeners {
@@ -285,6 +294,7 @@ func (app *BaseApp) PrepareProposal(req abci.RequestPrepareProposal) (resp abci.
 
 	app.prepareProposalState.ctx = app.getContextForProposal(app.prepareProposalState.ctx, req.Height).
 		WithVoteInfos(toVoteInfo(req.LocalLastCommit.Votes)). // this is a set of votes that are not finalized yet, wait for commit
+		WithExtendedVoteInfos(req.LocalLastCommit.Votes).
 		WithBlockHeight(req.Height).
 		WithBlockTime(req.Time).
 		WithProposer(req.ProposerAddress)
@@ -323,6 +333,10 @@ func (app *BaseApp) PrepareProposal(req abci.RequestPrepareProposal) (resp abci.
 // If a panic is detected during execution of an application's ProcessProposal
 // handler, it will be recovered and we will reject the proposal.
 //
+// If optimistic execution is enabled, the execution of an accepted proposal
+// starts in the background, and its results are reused by BeginBlock, DeliverTx
+// and EndBlock if the proposal is the block being finalized.
+//
 // Ref: https://github.com/cosmos/cosmos-sdk/blob/main/docs/architecture/adr-060-abci-1.0.md
 // Ref: https://github.com/cometbft/cometbft/blob/main/spec/abci/abci%2B%2B_basic_concepts.md
 func (app *BaseApp) ProcessProposal(req abci.RequestProcessProposal) (resp abci.ResponseProcessProposal) {
@@ -336,6 +350,9 @@ func (app *BaseApp) ProcessProposal(req abci.RequestProcessProposal) (resp abci.
 		panic("ProcessProposal called with invalid height")
 	}
 
+	// a previous proposal, if any, was not finalized
+	app.abortOptimisticExecution()
+
 	// always reset state given that ProcessProposal can timeout and be called again
 	emptyHeader := cmtproto.Header{ChainID: app.chainID}
 	app.setState(runTxProcessProposal, emptyHeader)
@@ -365,6 +382,11 @@ func (app *BaseApp) ProcessProposal(req abci.RequestProcessProposal) (resp abci.
 	}()
 
 	resp = app.processProposal(app.processProposalState.ctx, req)
+	if app.optimisticExec && app.restrictsHeader(req.Height) && app.accessTracer == nil &&
+		resp.Status == abci.ResponseProcessProposal_ACCEPT {
+		app.startOptimisticExecution(req)
+	}
+
 	return resp
 }
 
@@ -408,11 +430,7 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 // Otherwise, the ResponseDeliverTx will contain relevant error information.
 // Regardless of tx execution outcome, the ResponseDeliverTx will contain relevant
 // gas execution context.
-func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
-	gInfo := sdk.GasInfo{}
-	resultStr := "successful"
-
-	var res abci.ResponseDeliverTx
+func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
 	defer func() {
 		// call the streaming service hook with the EndBlock messages
 		for _, abciListener := range app.streamingManager.ABCIListeners {
@@ -425,15 +443,29 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx
 	}()
 
 	defer func() {
+		resultStr := "successful"
+		if res.IsErr() {
+			resultStr = "failed"
+		}
+
 		telemetry.IncrCounter(1, "tx", "count")
 		telemetry.IncrCounter(1, "tx", resultStr)
-		telemetry.SetGauge(float32(gInfo.GasUsed), "tx", "gas", "used")
-		telemetry.SetGauge(float32(gInfo.GasWanted), "tx", "gas", "wanted")
+		telemetry.SetGauge(float32(res.GasUsed), "tx", "gas", "used")
+		telemetry.SetGauge(float32(res.GasWanted), "tx", "gas", "wanted")
 	}()
 
+	if app.oe != nil {
+		return app.deliverOptimisticTx(req.Tx)
+	}
+
 	gInfo, result, anteEvents, _, err := app.runTx(runTxModeDeliver, req.Tx)
+	return app.deliverTxResponse(gInfo, result, anteEvents, err)
+}
+
+// deliverTxResponse returns the DeliverTx response of a transaction executed
+// by runTx.
+func (app *BaseApp) deliverTxResponse(gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, err error) abci.ResponseDeliverTx {
 	if err != nil {
-		resultStr = "failed"
 		return sdkerrors.ResponseDeliverTxWithEvents(err, gInfo.GasWanted, gInfo.GasUsed, sdk.MarkEventsToIndex(anteEvents, app.indexEvents), app.trace)
 	}
 
@@ -500,11 +532,14 @@ func (app *BaseApp) Commit() abci.ResponseCommit {
 
 	// empty/reset the deliver state
 	app.deliverState = nil
+	app.oe = nil
 
 	if app.prepareCheckStater != nil {
 		app.prepareCheckStater(app.checkState.ctx)
 	}
 
+	app.recheckMempool()
+
 	var halt bool
 
 	switch {
@@ -730,6 +765,12 @@ func (app *BaseApp) handleQueryGRPC(handler GRPCQueryHandler, req abci.RequestQu
 		return sdkerrors.QueryResult(err, app.trace)
 	}
 
+	// the proofs of the keys read by the query are returned as its ProofOps
+	var recorder *tracekv.AccessRecorder
+	if req.Prove {
+		ctx, recorder = withQueryProofRecorder(ctx)
+	}
+
 	res, err := handler(ctx, req)
 	if err != nil {
 		res = sdkerrors.QueryResult(gRPCErrorToSDKError(err), app.trace)
@@ -737,6 +778,17 @@ func (app *BaseApp) handleQueryGRPC(handler GRPCQueryHandler, req abci.RequestQu
 		return res
 	}
 
+	if req.Prove {
+		proofs, err := app.queryProofs(ctx.BlockHeight(), recorder.Accesses())
+		if err == nil {
+			res.ProofOps, err = queryProofsOps(proofs)
+		}
+		if err != nil {
+			return sdkerrors.QueryResult(err, app.trace)
+		}
+		res.Height = ctx.BlockHeight()
+	}
+
 	return res
 }
 
@@ -1037,7 +1089,23 @@ func (app *BaseApp) getContextForProposal(ctx sdk.Context, height int64) sdk.Con
 	return ctx
 }
 
-// toVoteInfo converts the new ExtendedVoteInfo to VoteInfo.
+// lastAppHash returns the app hash of the last committed block, i.e. the app
+// hash of the header of the next block. In the case of a new chain, AppHash
+// will be the hash of an empty string. During an upgrade, it'll be the hash of
+// the last committed block.
+func (app *BaseApp) lastAppHash() []byte {
+	if !app.LastCommitID().IsZero() {
+		return app.LastCommitID().Hash
+	}
+
+	// $ echo -n '' | sha256sum
+	// e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+	emptyHash := sha256.Sum256([]byte{})
+	return emptyHash[:]
+}
+
+// toVoteInfo converts the new ExtendedVoteInfo to VoteInfo. The vote extensions
+// are dropped, they are exposed by Context.ExtendedVoteInfos in PrepareProposal.
 func toVoteInfo(votes []abci.ExtendedVoteInfo) []abci.VoteInfo {
 	legacyVotes := make([]abci.VoteInfo, len(votes))
 	for i, vote := range votes {
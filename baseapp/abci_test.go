@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	dbm "github.com/cosmos/cosmos-db"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cosmos/gogoproto/jsonpb"
 	"github.com/stretchr/testify/require"
@@ -19,6 +21,7 @@ import (
 	pruningtypes "cosmossdk.io/store/pruning/types"
 	"cosmossdk.io/store/snapshots"
 	snapshottypes "cosmossdk.io/store/snapshots/types"
+	"cosmossdk.io/store/tracekv"
 	storetypes "cosmossdk.io/store/types"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
@@ -739,6 +742,69 @@ func TestABCI_DeliverTx(t *testing.T) {
 	}
 }
 
+func TestABCI_AccessTracer(t *testing.T) {
+	anteKey := []byte("ante-key")
+	recorder := tracekv.NewAccessRecorder()
+	anteOpt := func(bapp *baseapp.BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
+	tracerOpt := func(bapp *baseapp.BaseApp) {
+		bapp.SetEndBlocker(func(ctx sdk.Context, _ abci.RequestEndBlock) (abci.ResponseEndBlock, error) {
+			ctx.KVStore(capKey1).Get(anteKey)
+			return abci.ResponseEndBlock{}, nil
+		})
+		bapp.SetAccessTracer(recorder)
+	}
+	suite := NewBaseAppSuite(t, anteOpt, tracerOpt)
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{},
+	})
+
+	deliverKey := []byte("deliver-key")
+	baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), CounterServerImpl{t, capKey1, deliverKey})
+
+	suite.baseApp.BeginBlock(abci.RequestBeginBlock{Header: cmtproto.Header{Height: 1}})
+
+	// the accesses of CheckTx are not traced
+	tx := newTxCounter(t, suite.txConfig, 0, 0, 1)
+	txBytes, err := suite.txConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+	require.True(t, suite.baseApp.CheckTx(abci.RequestCheckTx{Tx: txBytes}).IsOK())
+	require.Empty(t, recorder.Accesses())
+
+	res := suite.baseApp.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	suite.baseApp.EndBlock(abci.RequestEndBlock{})
+
+	txHash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
+	var (
+		gas     storetypes.Gas
+		msgKeys = make(map[interface{}][]string)
+	)
+	for _, access := range recorder.Accesses() {
+		require.Equal(t, capKey1.Name(), access.StoreKey)
+		require.Equal(t, int64(1), access.Context[baseapp.TraceContextBlockHeightKey])
+
+		if phase, ok := access.Context[baseapp.TraceContextPhaseKey]; ok {
+			require.Equal(t, baseapp.AccessPhaseEndBlock, phase)
+			require.Equal(t, anteKey, access.Key)
+			continue
+		}
+
+		require.Equal(t, txHash, access.Context[baseapp.TraceContextTxHashKey])
+		msgKeys[access.Context[baseapp.TraceContextMsgIndexKey]] = append(
+			msgKeys[access.Context[baseapp.TraceContextMsgIndexKey]], string(access.Key))
+		gas += access.Gas
+	}
+
+	// the accesses of the AnteHandler have no message index
+	require.Equal(t, map[interface{}][]string{
+		nil: {string(anteKey), string(anteKey)},
+		0:   {string(deliverKey), string(deliverKey)},
+		1:   {string(deliverKey), string(deliverKey)},
+	}, msgKeys)
+	require.LessOrEqual(t, uint64(gas), uint64(res.GasUsed))
+}
+
 func TestABCI_DeliverTx_MultiMsg(t *testing.T) {
 	anteKey := []byte("ante-key")
 	anteOpt := func(bapp *baseapp.BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
@@ -1640,6 +1706,43 @@ func TestABCI_PrepareProposal_PanicRecovery(t *testing.T) {
 	})
 }
 
+func TestABCI_PrepareProposal_VoteExtensions(t *testing.T) {
+	votes := []abci.ExtendedVoteInfo{
+		{Validator: abci.Validator{Address: []byte("val1"), Power: 10}, SignedLastBlock: true, VoteExtension: []byte("ext1")},
+		{Validator: abci.Validator{Address: []byte("val2"), Power: 10}, SignedLastBlock: true, VoteExtension: []byte("ext2")},
+		{Validator: abci.Validator{Address: []byte("val3"), Power: 10}, SignedLastBlock: false},
+	}
+
+	var (
+		extVoteInfos []abci.ExtendedVoteInfo
+		voteInfos    []abci.VoteInfo
+	)
+	prepareOpt := func(app *baseapp.BaseApp) {
+		app.SetPrepareProposal(func(ctx sdk.Context, req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+			extVoteInfos = ctx.ExtendedVoteInfos()
+			voteInfos = ctx.VoteInfos()
+			return abci.ResponsePrepareProposal{Txs: req.Txs}
+		})
+	}
+	suite := NewBaseAppSuite(t, prepareOpt)
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{},
+	})
+
+	suite.baseApp.PrepareProposal(abci.RequestPrepareProposal{
+		MaxTxBytes:      1000,
+		Height:          2,
+		LocalLastCommit: abci.ExtendedCommitInfo{Votes: votes},
+	})
+	require.Equal(t, votes, extVoteInfos)
+	require.Len(t, voteInfos, len(votes))
+	for i, vote := range votes {
+		require.Equal(t, vote.Validator, voteInfos[i].Validator)
+		require.Equal(t, vote.SignedLastBlock, voteInfos[i].SignedLastBlock)
+	}
+}
+
 func TestABCI_ProcessProposal_PanicRecovery(t *testing.T) {
 	processOpt := func(app *baseapp.BaseApp) {
 		app.SetProcessProposal(func(ctx sdk.Context, rpp abci.RequestProcessProposal) abci.ResponseProcessProposal {
@@ -1718,3 +1821,571 @@ func TestABCI_Proposal_Reset_State_Between_Calls(t *testing.T) {
 		Header: cmtproto.Header{Height: suite.baseApp.LastBlockHeight() + 1},
 	})
 }
+
+func TestABCI_Commit_MempoolRecheck(t *testing.T) {
+	anteKey := []byte("ante-key")
+	pool := mempool.NewSenderNonceMempool()
+	anteOpt := func(bapp *baseapp.BaseApp) {
+		bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey))
+		bapp.SetMempoolRecheck(bapp.AnteRecheckHandler())
+	}
+	suite := NewBaseAppSuite(t, anteOpt, baseapp.SetMempool(pool))
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{},
+	})
+
+	tx := newTxCounter(t, suite.txConfig, 0, 0)
+	txBytes, err := suite.txConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+
+	checkTxRes := suite.baseApp.CheckTx(abci.RequestCheckTx{Tx: txBytes, Type: abci.CheckTxType_New})
+	require.True(t, checkTxRes.IsOK())
+
+	// a tx which became invalid since it was inserted is evicted on commit
+	failTx := newTxCounter(t, suite.txConfig, 1, 1)
+	failTx = setFailOnAnte(t, suite.txConfig, failTx, true)
+	require.NoError(t, pool.Insert(sdk.Context{}, failTx))
+	require.Equal(t, 2, pool.CountTx())
+
+	suite.baseApp.BeginBlock(abci.RequestBeginBlock{Header: cmtproto.Header{Height: 1}})
+	suite.baseApp.Commit()
+
+	require.Equal(t, 1, pool.CountTx())
+	require.Equal(t, tx.GetMemo(), pool.Select(sdk.Context{}, nil).Tx().(sdk.TxWithMemo).GetMemo())
+
+	// state changes of the recheck pass must not leak into the check state
+	store := getCheckStateCtx(suite.baseApp).KVStore(capKey1)
+	require.Equal(t, int64(0), getIntFromStore(t, store, anteKey))
+}
+
+func TestABCI_LaneProposal(t *testing.T) {
+	isKeyValueTx := func(tx sdk.Tx) bool {
+		_, ok := tx.GetMsgs()[0].(*baseapptestutil.MsgKeyValue)
+		return ok
+	}
+
+	pool, err := mempool.NewLaneMempool(
+		mempool.Lane{Name: "kv", Mempool: mempool.NewSenderNonceMempool(), Match: isKeyValueTx, MaxGasPercent: 30},
+		mempool.Lane{Name: "default", Mempool: mempool.NewSenderNonceMempool(), Match: mempool.MatchAll},
+	)
+	require.NoError(t, err)
+
+	proposalOpt := func(bapp *baseapp.BaseApp) {
+		handler := baseapp.NewLaneProposalHandler(pool, bapp)
+		bapp.SetPrepareProposal(handler.PrepareProposalHandler())
+		bapp.SetProcessProposal(handler.ProcessProposalHandler())
+	}
+	suite := NewBaseAppSuite(t, baseapp.SetMempool(pool), proposalOpt)
+	baseapptestutil.RegisterKeyValueServer(suite.baseApp.MsgServiceRouter(), MsgKeyValueImpl{})
+	baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), NoopCounterServerImpl{})
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{
+			Block: &cmtproto.BlockParams{MaxBytes: 1_000_000, MaxGas: 1000},
+		},
+	})
+
+	encode := func(tx sdk.Tx) []byte {
+		bz, err := suite.txConfig.TxEncoder()(tx)
+		require.NoError(t, err)
+		return bz
+	}
+
+	// 4 txs for the kv lane, which may use 300 gas, and 8 txs for the default
+	// lane, which may use the remaining 700 gas.
+	var kvTxs, defaultTxs [][]byte
+	for i := uint64(0); i < 4; i++ {
+		tx := newTxWithGas(t, suite.txConfig, i, 100, &baseapptestutil.MsgKeyValue{Key: []byte("k"), Value: []byte("v")})
+		require.NoError(t, pool.Insert(sdk.Context{}, tx))
+		kvTxs = append(kvTxs, encode(tx))
+	}
+	for i := uint64(0); i < 8; i++ {
+		tx := newTxWithGas(t, suite.txConfig, i, 100, &baseapptestutil.MsgCounter{Counter: int64(i)})
+		require.NoError(t, pool.Insert(sdk.Context{}, tx))
+		defaultTxs = append(defaultTxs, encode(tx))
+	}
+	require.Equal(t, 12, pool.CountTx())
+
+	resPrepareProposal := suite.baseApp.PrepareProposal(abci.RequestPrepareProposal{MaxTxBytes: 1_000_000, Height: 1})
+	require.Len(t, resPrepareProposal.Txs, 10)
+	require.Equal(t, kvTxs[:3], resPrepareProposal.Txs[:3])
+	require.ElementsMatch(t, defaultTxs[:7], resPrepareProposal.Txs[3:])
+
+	testCases := map[string]struct {
+		txs    [][]byte
+		status abci.ResponseProcessProposal_ProposalStatus
+	}{
+		"prepared proposal": {
+			txs:    resPrepareProposal.Txs,
+			status: abci.ResponseProcessProposal_ACCEPT,
+		},
+		"only the default lane": {
+			txs:    defaultTxs[:7],
+			status: abci.ResponseProcessProposal_ACCEPT,
+		},
+		"lanes out of order": {
+			txs:    [][]byte{defaultTxs[0], kvTxs[0]},
+			status: abci.ResponseProcessProposal_REJECT,
+		},
+		"lane exceeds its share": {
+			txs:    kvTxs,
+			status: abci.ResponseProcessProposal_REJECT,
+		},
+		"block exceeds its gas": {
+			txs:    append(append([][]byte{}, kvTxs[:3]...), defaultTxs...),
+			status: abci.ResponseProcessProposal_REJECT,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res := suite.baseApp.ProcessProposal(abci.RequestProcessProposal{Txs: tc.txs, Height: 1})
+			require.Equal(t, tc.status, res.Status)
+		})
+	}
+}
+
+func TestABCI_Proposal_MaxGas(t *testing.T) {
+	pool := mempool.NewSenderNonceMempool()
+	suite := NewBaseAppSuite(t, baseapp.SetMempool(pool))
+	baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), NoopCounterServerImpl{})
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{
+			Block: &cmtproto.BlockParams{MaxBytes: 1_000_000, MaxGas: 1000},
+		},
+	})
+
+	var txs [][]byte
+	for i, gas := range []uint64{300, 300, 300, 300, 100} {
+		tx := newTxWithGas(t, suite.txConfig, uint64(i), gas, &baseapptestutil.MsgCounter{Counter: int64(i)})
+		require.NoError(t, pool.Insert(sdk.Context{}, tx))
+
+		bz, err := suite.txConfig.TxEncoder()(tx)
+		require.NoError(t, err)
+		txs = append(txs, bz)
+	}
+
+	// the 4th tx exceeds the block gas and is skipped, the 5th one still fits
+	resPrepareProposal := suite.baseApp.PrepareProposal(abci.RequestPrepareProposal{MaxTxBytes: 1_000_000, Height: 1})
+	require.Equal(t, [][]byte{txs[0], txs[1], txs[2], txs[4]}, resPrepareProposal.Txs)
+
+	resProcessProposal := suite.baseApp.ProcessProposal(abci.RequestProcessProposal{Txs: resPrepareProposal.Txs, Height: 1})
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, resProcessProposal.Status)
+
+	resProcessProposal = suite.baseApp.ProcessProposal(abci.RequestProcessProposal{Txs: txs[:4], Height: 1})
+	require.Equal(t, abci.ResponseProcessProposal_REJECT, resProcessProposal.Status)
+}
+
+func TestABCI_ExtendVote(t *testing.T) {
+	voteExtOpt := func(app *baseapp.BaseApp) {
+		app.SetExtendVoteHandler(func(ctx sdk.Context, height int64, hash []byte) ([]byte, error) {
+			if height == 3 {
+				panic(errors.New("test"))
+			}
+
+			// writes to the state are discarded
+			ctx.KVStore(capKey1).Set([]byte("foo"), []byte("bar"))
+			return []byte(fmt.Sprintf("%d-%X", ctx.BlockHeight(), ctx.HeaderHash())), nil
+		})
+		app.SetVerifyVoteExtensionHandler(func(ctx sdk.Context, valAddr []byte, height int64, voteExt []byte) error {
+			if !bytes.HasPrefix(voteExt, []byte(fmt.Sprintf("%d-", height))) {
+				return errors.New("invalid vote extension")
+			}
+			return nil
+		})
+	}
+	suite := NewBaseAppSuite(t, voteExtOpt)
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{},
+	})
+
+	voteExt, err := suite.baseApp.ExtendVote(1, []byte{0xab})
+	require.NoError(t, err)
+	require.Equal(t, []byte("1-AB"), voteExt)
+	require.NoError(t, suite.baseApp.VerifyVoteExtension([]byte("val"), 1, voteExt))
+	require.Error(t, suite.baseApp.VerifyVoteExtension([]byte("val"), 2, voteExt))
+
+	suite.baseApp.BeginBlock(abci.RequestBeginBlock{Header: cmtproto.Header{Height: 1}})
+	suite.baseApp.EndBlock(abci.RequestEndBlock{Height: 1})
+	suite.baseApp.Commit()
+
+	voteExt, err = suite.baseApp.ExtendVote(2, []byte{0xcd})
+	require.NoError(t, err)
+	require.Equal(t, []byte("2-CD"), voteExt)
+	require.Nil(t, getCheckStateCtx(suite.baseApp).KVStore(capKey1).Get([]byte("foo")))
+
+	require.NotPanics(t, func() {
+		_, err = suite.baseApp.ExtendVote(3, nil)
+		require.ErrorContains(t, err, "recovered application panic")
+	})
+
+	// without handlers, vote extensions are not supported
+	suite = NewBaseAppSuite(t)
+	_, err = suite.baseApp.ExtendVote(1, nil)
+	require.Error(t, err)
+	require.Error(t, suite.baseApp.VerifyVoteExtension(nil, 1, nil))
+}
+
+func TestValidateVoteExtensions(t *testing.T) {
+	verifyVoteExt := func(_ sdk.Context, _ []byte, height int64, voteExt []byte) error {
+		if height != 1 || !bytes.Equal(voteExt, []byte("ext")) {
+			return errors.New("invalid vote extension")
+		}
+		return nil
+	}
+	vote := func(addr string, power int64, signed bool, voteExt string) abci.ExtendedVoteInfo {
+		return abci.ExtendedVoteInfo{
+			Validator:       abci.Validator{Address: []byte(addr), Power: power},
+			SignedLastBlock: signed,
+			VoteExtension:   []byte(voteExt),
+		}
+	}
+
+	valSet := []abci.Validator{
+		{Address: []byte("a"), Power: 10},
+		{Address: []byte("b"), Power: 10},
+		{Address: []byte("c"), Power: 5},
+	}
+
+	testCases := []struct {
+		name   string
+		valSet []abci.Validator
+		votes  []abci.ExtendedVoteInfo
+		expErr string
+	}{
+		{
+			name:  "valid",
+			votes: []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("b", 10, true, "ext"), vote("c", 5, false, "")},
+		},
+		{
+			name:   "no votes",
+			expErr: "do not match the validator set",
+		},
+		{
+			name:   "empty validator set",
+			valSet: []abci.Validator{},
+			expErr: "insufficient cumulative voting power",
+		},
+		{
+			name:   "missing validator",
+			votes:  []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("b", 10, true, "ext")},
+			expErr: "do not match the validator set",
+		},
+		{
+			name:   "validator not in the set",
+			votes:  []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("b", 10, true, "ext"), vote("d", 5, false, "")},
+			expErr: "not in the validator set",
+		},
+		{
+			name:   "invalid power",
+			votes:  []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("b", 10, true, "ext"), vote("c", 50, false, "")},
+			expErr: "invalid power",
+		},
+		{
+			name:   "duplicate validator",
+			votes:  []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("a", 10, true, "ext"), vote("c", 5, false, "")},
+			expErr: "duplicate vote",
+		},
+		{
+			name:   "vote extension without signature",
+			votes:  []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("b", 10, true, "ext"), vote("c", 5, false, "ext")},
+			expErr: "did not sign the previous block",
+		},
+		{
+			name:   "invalid vote extension",
+			votes:  []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("b", 10, true, "bad"), vote("c", 5, false, "")},
+			expErr: "invalid vote extension",
+		},
+		{
+			name:   "exactly 2/3 of the voting power",
+			valSet: []abci.Validator{{Address: []byte("a"), Power: 20}, {Address: []byte("b"), Power: 10}},
+			votes:  []abci.ExtendedVoteInfo{vote("a", 20, true, "ext"), vote("b", 10, false, "")},
+			expErr: "insufficient cumulative voting power",
+		},
+		{
+			name:   "insufficient voting power",
+			votes:  []abci.ExtendedVoteInfo{vote("a", 10, true, "ext"), vote("b", 10, false, ""), vote("c", 5, true, "ext")},
+			expErr: "insufficient cumulative voting power",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vals := valSet
+			if tc.valSet != nil {
+				vals = tc.valSet
+			}
+
+			err := baseapp.ValidateVoteExtensions(sdk.Context{}, 2, vals, abci.ExtendedCommitInfo{Votes: tc.votes}, verifyVoteExt)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestABCI_OptimisticExecution(t *testing.T) {
+	anteKey := []byte("ante-key")
+	deliverKey := []byte("deliver-key")
+	beginKey := []byte("begin-key")
+
+	// the header is restricted from height 3 on every node, whether or not it
+	// executes blocks optimistically
+	const optimisticHeaderHeight = 3
+
+	newSuite := func(optimisticExec bool, beginBlocks *int, lastCommitHashes map[int64][]byte) *BaseAppSuite {
+		opts := func(app *baseapp.BaseApp) {
+			app.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey))
+			app.SetBeginBlocker(func(ctx sdk.Context, _ abci.RequestBeginBlock) (abci.ResponseBeginBlock, error) {
+				*beginBlocks++
+				lastCommitHashes[ctx.BlockHeight()] = ctx.BlockHeader().LastCommitHash
+				store := ctx.KVStore(capKey1)
+				setIntOnStore(store, beginKey, getIntFromStore(t, store, beginKey)+1)
+				return abci.ResponseBeginBlock{}, nil
+			})
+			app.SetOptimisticExecution(optimisticExec)
+			app.SetOptimisticHeaderHeight(optimisticHeaderHeight)
+		}
+		suite := NewBaseAppSuite(t, opts)
+		baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), CounterServerImpl{t, capKey1, deliverKey})
+
+		suite.baseApp.InitChain(abci.RequestInitChain{
+			ConsensusParams: &cmtproto.ConsensusParams{},
+		})
+
+		return suite
+	}
+
+	var optimisticBeginBlocks, beginBlocks int
+	optimisticLastCommitHashes, lastCommitHashes := map[int64][]byte{}, map[int64][]byte{}
+	suite := newSuite(true, &optimisticBeginBlocks, optimisticLastCommitHashes)
+	refSuite := newSuite(false, &beginBlocks, lastCommitHashes)
+
+	blockTxs := func(height int64) [][]byte {
+		var txs [][]byte
+		for i := int64(0); i < 3; i++ {
+			counter := (height-1)*3 + i
+			bz, err := suite.txConfig.TxEncoder()(newTxCounter(t, suite.txConfig, counter, counter))
+			require.NoError(t, err)
+			txs = append(txs, bz)
+		}
+		return txs
+	}
+
+	// executeBlock finalizes a block on the BaseApp and on the reference BaseApp
+	// without optimistic execution, and checks that they have the same state.
+	executeBlock := func(height int64, hash []byte, txs [][]byte) {
+		for _, app := range []*baseapp.BaseApp{suite.baseApp, refSuite.baseApp} {
+			app.BeginBlock(abci.RequestBeginBlock{
+				Hash: hash,
+				Header: cmtproto.Header{
+					Height:         height,
+					AppHash:        app.LastCommitID().Hash,
+					LastCommitHash: []byte("last-commit-hash"),
+				},
+			})
+			for _, tx := range txs {
+				res := app.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+				require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+				require.Len(t, res.Events, 3)
+			}
+			app.EndBlock(abci.RequestEndBlock{Height: height})
+			app.Commit()
+		}
+
+		require.Equal(t, refSuite.baseApp.LastCommitID(), suite.baseApp.LastCommitID())
+	}
+
+	// without ProcessProposal, e.g. when syncing blocks, blocks are executed
+	// normally
+	executeBlock(1, []byte("block1"), blockTxs(1))
+	require.Equal(t, 1, optimisticBeginBlocks)
+
+	// below the optimistic header height, the full header is exposed and an
+	// accepted proposal is not executed optimistically
+	txs := blockTxs(2)
+	res := suite.baseApp.ProcessProposal(abci.RequestProcessProposal{Height: 2, Hash: []byte("block2"), Txs: txs})
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, res.Status)
+	require.Equal(t, 1, optimisticBeginBlocks)
+	executeBlock(2, []byte("block2"), txs)
+	require.Equal(t, 2, optimisticBeginBlocks)
+	require.Equal(t, []byte("last-commit-hash"), optimisticLastCommitHashes[2])
+	require.Equal(t, []byte("last-commit-hash"), lastCommitHashes[2])
+
+	// the accepted proposal is executed optimistically, and its execution is
+	// reused when it is finalized
+	txs = blockTxs(3)
+	res = suite.baseApp.ProcessProposal(abci.RequestProcessProposal{Height: 3, Hash: []byte("block3"), Txs: txs})
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, res.Status)
+	executeBlock(3, []byte("block3"), txs)
+	require.Equal(t, 3, optimisticBeginBlocks)
+	require.Empty(t, optimisticLastCommitHashes[3])
+	require.Empty(t, lastCommitHashes[3])
+
+	// the optimistic execution of a proposal which is not finalized is discarded
+	txs = blockTxs(4)
+	res = suite.baseApp.ProcessProposal(abci.RequestProcessProposal{Height: 4, Hash: []byte("block4a"), Txs: txs[:1]})
+	require.Equal(t, abci.ResponseProcessProposal_ACCEPT, res.Status)
+	executeBlock(4, []byte("block4b"), txs)
+	require.Equal(t, 5, optimisticBeginBlocks)
+
+	store := getCheckStateCtx(suite.baseApp).KVStore(capKey1)
+	require.Equal(t, int64(4), getIntFromStore(t, store, beginKey))
+	require.Equal(t, int64(12), getIntFromStore(t, store, deliverKey))
+}
+
+func TestABCI_ParallelExecution(t *testing.T) {
+	deliverKey := []byte("deliver-key")
+
+	type parallelSuite struct {
+		*BaseAppSuite
+		kvCalls, counterCalls atomic.Int64
+	}
+
+	newSuite := func(workers int) *parallelSuite {
+		s := &parallelSuite{}
+		opts := func(app *baseapp.BaseApp) {
+			app.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, _ bool) (sdk.Context, error) {
+				counter, _ := parseTxMemo(t, tx)
+				ctx = ctx.WithGasMeter(storetypes.NewGasMeter(tx.(signing.Tx).GetGas()))
+				ctx.KVStore(capKey1).Set([]byte(fmt.Sprintf("nonce-%d", counter)), []byte{1})
+				ctx.EventManager().EmitEvents(counterEvent("ante_handler", counter))
+				return ctx, nil
+			})
+			// the gas consumed by the begin blocker is shared with the txs
+			app.SetBeginBlocker(func(ctx sdk.Context, _ abci.RequestBeginBlock) (abci.ResponseBeginBlock, error) {
+				ctx.GasMeter().ConsumeGas(1_000, "begin blocker")
+				return abci.ResponseBeginBlock{}, nil
+			})
+			app.SetProcessProposal(baseapp.NoOpProcessProposal())
+			app.SetOptimisticExecution(workers > 0)
+			app.SetOptimisticHeaderHeight(1)
+			app.SetParallelExecution(workers)
+		}
+		s.BaseAppSuite = NewBaseAppSuite(t, opts)
+		baseapptestutil.RegisterKeyValueServer(s.baseApp.MsgServiceRouter(), CountingKeyValueImpl{&s.kvCalls})
+		baseapptestutil.RegisterCounterServer(s.baseApp.MsgServiceRouter(), SumCounterServerImpl{t, deliverKey, &s.counterCalls})
+
+		s.baseApp.InitChain(abci.RequestInitChain{
+			ConsensusParams: &cmtproto.ConsensusParams{
+				Block: &cmtproto.BlockParams{MaxGas: 100_000},
+			},
+		})
+
+		return s
+	}
+
+	suite := newSuite(4)
+	refSuite := newSuite(0)
+
+	var nonce uint64
+	kvTx := func() []byte {
+		nonce++
+		msg := &baseapptestutil.MsgKeyValue{Key: []byte(fmt.Sprintf("key-%d", nonce)), Value: []byte("value")}
+		bz, err := suite.txConfig.TxEncoder()(newTxWithGas(t, suite.txConfig, nonce, 10_000, msg))
+		require.NoError(t, err)
+		return bz
+	}
+	counterTx := func(counter int64, fail bool) []byte {
+		nonce++
+		msg := &baseapptestutil.MsgCounter{Counter: counter, FailOnHandler: fail}
+		bz, err := suite.txConfig.TxEncoder()(newTxWithGas(t, suite.txConfig, nonce, 10_000, msg))
+		require.NoError(t, err)
+		return bz
+	}
+
+	// executeBlock executes a block optimistically with parallel execution,
+	// and serially on the reference BaseApp, and checks that the responses
+	// and the resulting states are the same.
+	executeBlock := func(height int64, txs [][]byte) []abci.ResponseDeliverTx {
+		hash := []byte(fmt.Sprintf("block%d", height))
+		if height > 1 {
+			res := suite.baseApp.ProcessProposal(abci.RequestProcessProposal{Height: height, Hash: hash, Txs: txs})
+			require.Equal(t, abci.ResponseProcessProposal_ACCEPT, res.Status)
+		}
+
+		var responses [][]abci.ResponseDeliverTx
+		for _, app := range []*baseapp.BaseApp{suite.baseApp, refSuite.baseApp} {
+			app.BeginBlock(abci.RequestBeginBlock{
+				Hash:   hash,
+				Header: cmtproto.Header{Height: height, AppHash: app.LastCommitID().Hash},
+			})
+
+			var txResponses []abci.ResponseDeliverTx
+			for _, tx := range txs {
+				txResponses = append(txResponses, app.DeliverTx(abci.RequestDeliverTx{Tx: tx}))
+			}
+			responses = append(responses, txResponses)
+
+			app.EndBlock(abci.RequestEndBlock{Height: height})
+			app.Commit()
+		}
+
+		require.Equal(t, responses[1], responses[0])
+		require.Equal(t, refSuite.baseApp.LastCommitID(), suite.baseApp.LastCommitID())
+		return responses[0]
+	}
+
+	// the first block is executed normally
+	executeBlock(1, nil)
+
+	// independent transactions are not re-executed
+	var txs [][]byte
+	for i := 0; i < 8; i++ {
+		txs = append(txs, kvTx())
+	}
+	for _, res := range executeBlock(2, txs) {
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	}
+	require.Equal(t, int64(8), suite.kvCalls.Load())
+
+	// transactions reading a key written by a previous transaction of the block
+	// are re-executed, failed transactions do not conflict
+	txs = [][]byte{
+		counterTx(1, false), kvTx(),
+		counterTx(2, false), kvTx(),
+		counterTx(3, true), kvTx(),
+		counterTx(4, false), kvTx(),
+	}
+	responses := executeBlock(3, txs)
+	require.False(t, responses[4].IsOK())
+	require.Equal(t, int64(12), suite.kvCalls.Load())
+	require.Equal(t, int64(6), suite.counterCalls.Load())
+	require.Equal(t, int64(4), refSuite.counterCalls.Load())
+
+	store := getCheckStateCtx(suite.baseApp).KVStore(capKey1)
+	require.Equal(t, int64(7), getIntFromStore(t, store, deliverKey))
+
+	// transactions exceeding the block gas limit fail as in a serial execution
+	txs = nil
+	for i := 0; i < 40; i++ {
+		txs = append(txs, kvTx())
+	}
+	kvCalls, refKVCalls := suite.kvCalls.Load(), refSuite.kvCalls.Load()
+	responses = executeBlock(4, txs)
+	require.True(t, responses[0].IsOK())
+	require.False(t, responses[len(responses)-1].IsOK())
+
+	// only the transaction exceeding the limit is re-executed, the following
+	// ones failing before their messages are executed
+	var delivered int64
+	for _, res := range responses {
+		if res.IsOK() {
+			delivered++
+		}
+	}
+	require.Equal(t, int64(len(txs))+refSuite.kvCalls.Load()-refKVCalls-delivered, suite.kvCalls.Load()-kvCalls)
+
+	// transactions failing before the ante handler, which use the gas meter of
+	// the block, have the gas used of a serial execution
+	txs = [][]byte{kvTx(), []byte("invalid tx"), kvTx(), counterTx(-1, false), kvTx()}
+	responses = executeBlock(5, txs)
+	require.False(t, responses[1].IsOK())
+	require.False(t, responses[3].IsOK())
+	require.NotZero(t, responses[3].GasUsed)
+}
@@ -0,0 +1,59 @@
+package baseapp
+
+import (
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The metadata keys of the TraceContext of the accesses traced to the
+// AccessTracer of the BaseApp.
+const (
+	// TraceContextBlockHeightKey is the height of the block executed.
+	TraceContextBlockHeightKey = "blockHeight"
+	// TraceContextPhaseKey is the phase of the block executing outside of
+	// the transactions, i.e. AccessPhaseBeginBlock or AccessPhaseEndBlock.
+	TraceContextPhaseKey = "phase"
+	// TraceContextTxHashKey is the hex encoded hash of the transaction
+	// executed.
+	TraceContextTxHashKey = "txHash"
+	// TraceContextMsgIndexKey is the index of the message executed in the
+	// transaction. The accesses of a transaction made outside of its messages,
+	// e.g. by the AnteHandler, have no message index.
+	TraceContextMsgIndexKey = "msgIndex"
+
+	AccessPhaseBeginBlock = "beginBlock"
+	AccessPhaseEndBlock   = "endBlock"
+)
+
+// SetAccessTracer sets the tracer of the accesses made to the KVStores by the
+// blocks delivered, each access being attributed to the block height, the
+// transaction hash and message index, or the phase of the block, through the
+// TraceContext metadata keys above. Optimistic execution is disabled while a
+// tracer is set. A nil tracer disables the tracing.
+func (app *BaseApp) SetAccessTracer(tracer storetypes.AccessTracer) {
+	app.accessTracer = tracer
+}
+
+// blockAccessTracer returns the AccessTracer of the block of the given height,
+// or nil if the BaseApp has no tracer.
+func (app *BaseApp) blockAccessTracer(height int64) storetypes.AccessTracer {
+	if app.accessTracer == nil {
+		return nil
+	}
+
+	return storetypes.AccessTracerWithContext(app.accessTracer, storetypes.TraceContext{
+		TraceContextBlockHeightKey: height,
+	})
+}
+
+// withAccessTraceContext returns ctx with its AccessTracer, if any, adding the
+// metadata of tc to the accesses traced.
+func withAccessTraceContext(ctx sdk.Context, tc storetypes.TraceContext) sdk.Context {
+	tracer := ctx.AccessTracer()
+	if tracer == nil {
+		return ctx
+	}
+
+	return ctx.WithAccessTracer(storetypes.AccessTracerWithContext(tracer, tc))
+}
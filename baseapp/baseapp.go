@@ -62,19 +62,22 @@ type BaseApp struct {
 	txDecoder         sdk.TxDecoder // unmarshal []byte into sdk.Tx
 	txEncoder         sdk.TxEncoder // marshal sdk.Tx into []byte
 
-	mempool            mempool.Mempool            // application side mempool
-	anteHandler        sdk.AnteHandler            // ante handler for fee and auth
-	postHandler        sdk.PostHandler            // post handler, optional, e.g. for tips
-	initChainer        sdk.InitChainer            // initialize state with validators and state blob
-	beginBlocker       sdk.BeginBlocker           // logic to run before any txs
-	processProposal    sdk.ProcessProposalHandler // the handler which runs on ABCI ProcessProposal
-	prepareProposal    sdk.PrepareProposalHandler // the handler which runs on ABCI PrepareProposal
-	endBlocker         sdk.EndBlocker             // logic to run after all txs, and to determine valset changes
-	prepareCheckStater sdk.PrepareCheckStater     // logic to run during commit using the checkState
-	precommiter        sdk.Precommiter            // logic to run during commit using the deliverState
-	addrPeerFilter     sdk.PeerFilter             // filter peers by address and port
-	idPeerFilter       sdk.PeerFilter             // filter peers by node ID
-	fauxMerkleMode     bool                       // if true, IAVL MountStores uses MountStoresDB for simulation speed.
+	mempool            mempool.Mempool                // application side mempool
+	mempoolRecheck     mempool.RecheckFn              // optional, re-validates mempool txs after Commit
+	anteHandler        sdk.AnteHandler                // ante handler for fee and auth
+	postHandler        sdk.PostHandler                // post handler, optional, e.g. for tips
+	initChainer        sdk.InitChainer                // initialize state with validators and state blob
+	beginBlocker       sdk.BeginBlocker               // logic to run before any txs
+	processProposal    sdk.ProcessProposalHandler     // the handler which runs on ABCI ProcessProposal
+	prepareProposal    sdk.PrepareProposalHandler     // the handler which runs on ABCI PrepareProposal
+	extendVote         sdk.ExtendVoteHandler          // the handler which runs on ABCI ExtendVote
+	verifyVoteExt      sdk.VerifyVoteExtensionHandler // the handler which runs on ABCI VerifyVoteExtension
+	endBlocker         sdk.EndBlocker                 // logic to run after all txs, and to determine valset changes
+	prepareCheckStater sdk.PrepareCheckStater         // logic to run during commit using the checkState
+	precommiter        sdk.Precommiter                // logic to run during commit using the deliverState
+	addrPeerFilter     sdk.PeerFilter                 // filter peers by address and port
+	idPeerFilter       sdk.PeerFilter                 // filter peers by node ID
+	fauxMerkleMode     bool                           // if true, IAVL MountStores uses MountStoresDB for simulation speed.
 
 	// manages snapshots, i.e. dumps of app state at certain intervals
 	snapshotManager *snapshots.Manager
@@ -88,6 +91,23 @@ type BaseApp struct {
 	processProposalState *state // for ProcessProposal
 	prepareProposalState *state // for PrepareProposal
 
+	// optimisticExec enables the optimistic execution of the blocks accepted in
+	// ProcessProposal, and oe is the optimistic execution of the current height
+	optimisticExec bool
+	oe             *optimisticExecution
+
+	// optimisticHeaderHeight is the height from which the block header exposed
+	// to the application is restricted to the fields known in ProcessProposal
+	optimisticHeaderHeight int64
+
+	// parallelWorkers is the number of transactions of an optimistically
+	// executed block which can be executed concurrently
+	parallelWorkers int
+
+	// accessTracer traces the accesses made to the KVStores by the blocks
+	// delivered
+	accessTracer storetypes.AccessTracer
+
 	// an inter-block write-through cache provided to the context during deliverState
 	interBlockCache storetypes.MultiStorePersistentCache
 
@@ -367,6 +387,18 @@ func (app *BaseApp) Init() error {
 	return app.cms.GetPruning().Validate()
 }
 
+// Close releases the resources of the app once it is stopped. The streaming
+// listeners are closed, after the blocks they buffer are delivered, and then
+// the CommitMultiStore.
+func (app *BaseApp) Close() error {
+	err := app.closeABCIListeners()
+	if app.cms != nil {
+		err = errors.CombineErrors(err, app.cms.Close())
+	}
+
+	return err
+}
+
 func (app *BaseApp) setMinGasPrices(gasPrices sdk.DecCoins) {
 	app.minGasPrices = gasPrices
 }
@@ -599,11 +631,16 @@ func (app *BaseApp) cacheTxContext(ctx sdk.Context, txBytes []byte) (sdk.Context
 		msCache = msCache.SetTracingContext(
 			storetypes.TraceContext(
 				map[string]interface{}{
-					"txHash": fmt.Sprintf("%X", tmhash.Sum(txBytes)),
+					TraceContextTxHashKey: fmt.Sprintf("%X", tmhash.Sum(txBytes)),
 				},
 			),
 		).(storetypes.CacheMultiStore)
 	}
+	if ctx.AccessTracer() != nil {
+		ctx = withAccessTraceContext(ctx, storetypes.TraceContext{
+			TraceContextTxHashKey: fmt.Sprintf("%X", tmhash.Sum(txBytes)),
+		})
+	}
 
 	return ctx.WithMultiStore(msCache), msCache
 }
@@ -616,12 +653,19 @@ func (app *BaseApp) cacheTxContext(ctx sdk.Context, txBytes []byte) (sdk.Context
 // returned if the tx does not run out of gas and if all the messages are valid
 // and execute successfully. An error is returned otherwise.
 func (app *BaseApp) runTx(mode runTxMode, txBytes []byte) (gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, priority int64, err error) {
+	return app.runTxWithContext(app.getContextForTx(mode, txBytes), mode, txBytes, app.mempool)
+}
+
+// runTxWithContext processes a transaction like runTx, using the given context
+// and mempool instead of the ones of the BaseApp's state for the mode.
+func (app *BaseApp) runTxWithContext(
+	ctx sdk.Context, mode runTxMode, txBytes []byte, mp mempool.Mempool,
+) (gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, priority int64, err error) {
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter, so we initialize upfront.
 	var gasWanted uint64
 
-	ctx := app.getContextForTx(mode, txBytes)
 	ms := ctx.MultiStore()
 
 	// only run the tx if there is block gas remaining
@@ -714,12 +758,12 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte) (gInfo sdk.GasInfo, re
 	}
 
 	if mode == runTxModeCheck {
-		err = app.mempool.Insert(ctx, tx)
+		err = mp.Insert(ctx, tx)
 		if err != nil {
 			return gInfo, nil, anteEvents, priority, err
 		}
 	} else if mode == runTxModeDeliver {
-		err = app.mempool.Remove(tx)
+		err = mp.Remove(tx)
 		if err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
 			return gInfo, nil, anteEvents, priority,
 				fmt.Errorf("failed to remove tx from mempool: %w", err)
@@ -790,7 +834,8 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (*s
 		}
 
 		// ADR 031 request type routing
-		msgResult, err := handler(ctx, msg)
+		msgCtx := withAccessTraceContext(ctx, storetypes.TraceContext{TraceContextMsgIndexKey: i})
+		msgResult, err := handler(msgCtx, msg)
 		if err != nil {
 			return nil, errorsmod.Wrapf(err, "failed to execute message; message index: %d", i)
 		}
@@ -912,16 +957,24 @@ type (
 	DefaultProposalHandler struct {
 		mempool    mempool.Mempool
 		txVerifier ProposalTxVerifier
+		txSelector TxSelector
 	}
 )
 
-func NewDefaultProposalHandler(mp mempool.Mempool, txVerifier ProposalTxVerifier) DefaultProposalHandler {
-	return DefaultProposalHandler{
+func NewDefaultProposalHandler(mp mempool.Mempool, txVerifier ProposalTxVerifier) *DefaultProposalHandler {
+	return &DefaultProposalHandler{
 		mempool:    mp,
 		txVerifier: txVerifier,
+		txSelector: NewDefaultTxSelector(),
 	}
 }
 
+// SetTxSelector sets the TxSelector used by the PrepareProposal handler to
+// select the mempool's transactions.
+func (h *DefaultProposalHandler) SetTxSelector(ts TxSelector) {
+	h.txSelector = ts
+}
+
 // PrepareProposalHandler returns the default implementation for processing an
 // ABCI proposal. The application's mempool is enumerated and all valid
 // transactions are added to the proposal. Transactions are valid if they:
@@ -929,8 +982,10 @@ func NewDefaultProposalHandler(mp mempool.Mempool, txVerifier ProposalTxVerifier
 // 1) Successfully encode to bytes.
 // 2) Are valid (i.e. pass runTx, AnteHandler only).
 //
-// Enumeration is halted once RequestPrepareProposal.MaxBytes of transactions is
-// reached or the mempool is exhausted.
+// Transactions are selected by the handler's TxSelector, by default until
+// RequestPrepareProposal.MaxTxBytes of transactions or the block's MaxGas
+// consensus parameter, computed using the transactions' gas limits, is reached
+// or the mempool is exhausted.
 //
 // Note:
 //
@@ -939,10 +994,14 @@ func NewDefaultProposalHandler(mp mempool.Mempool, txVerifier ProposalTxVerifier
 // is used in both steps, and applications must ensure that this is the case in
 // non-default handlers.
 //
+// - A valid transaction which is not selected may have changed the state later
+// transactions are verified against, e.g. by incrementing its signers'
+// sequences, so no later transaction of its signers is selected.
+//
 // - If no mempool is set or if the mempool is a no-op mempool, the transactions
 // requested from CometBFT will simply be returned, which, by default, are in
 // FIFO order.
-func (h DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHandler {
+func (h *DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHandler {
 	return func(ctx sdk.Context, req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
 		// If the mempool is nil or a no-op mempool, we simply return the transactions
 		// requested from CometBFT, which, by default, should be in FIFO order.
@@ -951,15 +1010,25 @@ func (h DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHand
 			return abci.ResponsePrepareProposal{Txs: req.Txs}
 		}
 
+		defer h.txSelector.Clear()
+
 		var (
-			selectedTxs  [][]byte
-			totalTxBytes int64
+			maxTxBytes     = uint64(req.MaxTxBytes)
+			maxBlockGas    uint64
+			skippedSigners = make(map[string]struct{})
 		)
+		if _, maxGas := blockLimits(ctx); maxGas > 0 {
+			maxBlockGas = uint64(maxGas)
+		}
 
 		iterator := h.mempool.Select(ctx, req.Txs)
 
 		for iterator != nil {
 			memTx := iterator.Tx()
+			if hasSkippedSigner(memTx, skippedSigners) {
+				iterator = iterator.Next()
+				continue
+			}
 
 			// NOTE: Since transaction verification was already executed in CheckTx,
 			// which calls mempool.Insert, in theory everything in the pool should be
@@ -972,12 +1041,13 @@ func (h DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHand
 					panic(err)
 				}
 			} else {
-				txSize := int64(len(bz))
-				if totalTxBytes += txSize; totalTxBytes <= req.MaxTxBytes {
-					selectedTxs = append(selectedTxs, bz)
-				} else {
-					// We've reached capacity per req.MaxTxBytes so we cannot select any
-					// more transactions.
+				selected, stop := h.txSelector.SelectTxForProposal(maxTxBytes, maxBlockGas, memTx, bz)
+				if !selected {
+					skipSigners(memTx, skippedSigners)
+				}
+				if stop {
+					// We've reached capacity per req.MaxTxBytes or the block's gas so we
+					// cannot select any more transactions.
 					break
 				}
 			}
@@ -985,7 +1055,7 @@ func (h DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHand
 			iterator = iterator.Next()
 		}
 
-		return abci.ResponsePrepareProposal{Txs: selectedTxs}
+		return abci.ResponsePrepareProposal{Txs: h.txSelector.SelectedTxs()}
 	}
 }
 
@@ -995,18 +1065,29 @@ func (h DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHand
 // 1. The transaction bytes must decode to a valid transaction.
 // 2. The transaction must be valid (i.e. pass runTx, AnteHandler only)
 //
-// If any transaction fails to pass either condition, the proposal is rejected.
-// Note that step (2) is identical to the validation step performed in
-// DefaultPrepareProposal. It is very important that the same validation logic
-// is used in both steps, and applications must ensure that this is the case in
-// non-default handlers.
-func (h DefaultProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHandler {
+// If any transaction fails to pass either condition, or if the sum of the
+// transactions' gas limits exceeds the block's MaxGas consensus parameter, the
+// proposal is rejected. Note that step (2) is identical to the validation step
+// performed in DefaultPrepareProposal. It is very important that the same
+// validation logic is used in both steps, and applications must ensure that
+// this is the case in non-default handlers.
+func (h *DefaultProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHandler {
 	return func(ctx sdk.Context, req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+		_, maxGas := blockLimits(ctx)
+
+		var totalTxGas int64
 		for _, txBytes := range req.Txs {
-			_, err := h.txVerifier.ProcessProposalVerifyTx(txBytes)
+			tx, err := h.txVerifier.ProcessProposalVerifyTx(txBytes)
 			if err != nil {
 				return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
 			}
+
+			if gas := txGas(tx); maxGas > 0 {
+				if gas > maxGas-totalTxGas {
+					return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+				}
+				totalTxGas += gas
+			}
 		}
 
 		return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/tracekv"
 	gogogrpc "github.com/cosmos/gogoproto/grpc"
 	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
@@ -46,13 +47,29 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 			}
 		}
 
-		// Create the sdk.Context. Passing false as 2nd arg, as we can't
-		// actually support proofs with gRPC right now.
-		sdkCtx, err := app.CreateQueryContext(height, false)
+		// The proofs of the keys read by the query are returned in a trailer
+		// if requested.
+		var prove bool
+		if proveHeaders := md.Get(grpctypes.GRPCQueryProveHeader); len(proveHeaders) == 1 {
+			prove, err = strconv.ParseBool(proveHeaders[0])
+			if err != nil {
+				return nil, errorsmod.Wrapf(
+					sdkerrors.ErrInvalidRequest,
+					"Baseapp.RegisterGRPCServer: invalid prove header %q: %v", grpctypes.GRPCQueryProveHeader, err)
+			}
+		}
+
+		// Create the sdk.Context.
+		sdkCtx, err := app.CreateQueryContext(height, prove)
 		if err != nil {
 			return nil, err
 		}
 
+		var recorder *tracekv.AccessRecorder
+		if prove {
+			sdkCtx, recorder = withQueryProofRecorder(sdkCtx)
+		}
+
 		// Add relevant gRPC headers
 		if height == 0 {
 			height = sdkCtx.BlockHeight() // If height was not set in the request, set it to the latest
@@ -66,7 +83,24 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 			app.logger.Error("failed to set gRPC header", "err", err)
 		}
 
-		return handler(grpcCtx, req)
+		resp, err = handler(grpcCtx, req)
+		if err != nil || !prove {
+			return resp, err
+		}
+
+		proofs, err := app.queryProofs(sdkCtx.BlockHeight(), recorder.Accesses())
+		if err != nil {
+			return nil, err
+		}
+		bz, err := proofs.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		if err = grpc.SetTrailer(grpcCtx, metadata.Pairs(grpctypes.GRPCQueryProofsTrailer, string(bz))); err != nil {
+			app.logger.Error("failed to set gRPC trailer", "err", err)
+		}
+
+		return resp, nil
 	}
 
 	// Loop through all services and methods, add the interceptor, and register
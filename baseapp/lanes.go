@@ -0,0 +1,160 @@
+package baseapp
+
+import (
+	"errors"
+	"math"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+// LaneProposalHandler defines ABCI PrepareProposal and ProcessProposal handlers
+// for a mempool.LaneMempool. Proposals contain the transactions of each lane
+// one lane after the other, in the lanes' order of precedence, and the
+// transactions of a lane may use at most the lane's share of the block's bytes,
+// as defined by the MaxBytes consensus parameter, and of the block's gas, as
+// defined by the MaxGas consensus parameter. The gas of a transaction is the
+// gas limit of sdk.FeeTx transactions, other transactions have no gas.
+type LaneProposalHandler struct {
+	mempool    *mempool.LaneMempool
+	txVerifier ProposalTxVerifier
+}
+
+// NewLaneProposalHandler returns the PrepareProposal and ProcessProposal
+// handlers enforcing the lane ordering and block space shares of mp.
+func NewLaneProposalHandler(mp *mempool.LaneMempool, txVerifier ProposalTxVerifier) LaneProposalHandler {
+	return LaneProposalHandler{
+		mempool:    mp,
+		txVerifier: txVerifier,
+	}
+}
+
+// PrepareProposalHandler returns a PrepareProposal handler which fills the
+// proposal lane by lane. The transactions of a lane are selected in the order
+// of the lane's mempool, invalid transactions are removed from the mempool, and
+// the lane's selection stops once the next transaction does not fit in the
+// lane's share of the block or in RequestPrepareProposal.MaxTxBytes.
+//
+// A transaction which passed verification but did not fit in the proposal may
+// have changed the state later transactions are verified against, e.g. by
+// incrementing its signers' sequences, so no later transaction of its signers
+// is selected in the remaining lanes.
+func (h LaneProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHandler {
+	return func(ctx sdk.Context, req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+		maxBytes, maxGas := blockLimits(ctx)
+
+		var (
+			selectedTxs    [][]byte
+			totalTxBytes   int64
+			totalGas       int64
+			skippedSigners = make(map[string]struct{})
+		)
+
+		for _, lane := range h.mempool.Lanes() {
+			laneMaxTxBytes := laneLimit(maxBytes, totalTxBytes, lane.MaxTxBytesPercent)
+			laneMaxGas := laneLimit(maxGas, totalGas, lane.MaxGasPercent)
+
+			var laneTxBytes, laneGas int64
+			for iterator := lane.Mempool.Select(ctx, req.Txs); iterator != nil; iterator = iterator.Next() {
+				memTx := iterator.Tx()
+				if hasSkippedSigner(memTx, skippedSigners) {
+					continue
+				}
+
+				bz, err := h.txVerifier.PrepareProposalVerifyTx(memTx)
+				if err != nil {
+					err := h.mempool.Remove(memTx)
+					if err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
+						panic(err)
+					}
+					continue
+				}
+
+				txSize, gas := int64(len(bz)), txGas(memTx)
+				if txSize > laneMaxTxBytes-laneTxBytes || gas > laneMaxGas-laneGas ||
+					txSize > req.MaxTxBytes-totalTxBytes-laneTxBytes {
+					// We've reached the capacity of the lane so we cannot select any more
+					// transactions from it.
+					skipSigners(memTx, skippedSigners)
+					break
+				}
+
+				selectedTxs = append(selectedTxs, bz)
+				laneTxBytes += txSize
+				laneGas += gas
+			}
+
+			totalTxBytes += laneTxBytes
+			totalGas += laneGas
+		}
+
+		return abci.ResponsePrepareProposal{Txs: selectedTxs}
+	}
+}
+
+// ProcessProposalHandler returns a ProcessProposal handler which accepts a
+// proposal only if all of its transactions are valid, every transaction
+// matches a lane, the transactions are ordered by lane and no lane exceeds its
+// share of the block.
+func (h LaneProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHandler {
+	return func(ctx sdk.Context, req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+		var (
+			maxBytes, maxGas       = blockLimits(ctx)
+			lanes                  = h.mempool.Lanes()
+			current                int
+			totalTxBytes, totalGas int64
+			laneTxBytes, laneGas   int64
+		)
+
+		for _, txBytes := range req.Txs {
+			tx, err := h.txVerifier.ProcessProposalVerifyTx(txBytes)
+			if err != nil {
+				return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+			}
+
+			i, ok := h.mempool.LaneIndex(tx)
+			if !ok || i < current {
+				return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+			}
+
+			for ; current < i; current++ {
+				totalTxBytes += laneTxBytes
+				totalGas += laneGas
+				laneTxBytes, laneGas = 0, 0
+			}
+
+			txSize, gas := int64(len(txBytes)), txGas(tx)
+			if txSize > laneLimit(maxBytes, totalTxBytes, lanes[current].MaxTxBytesPercent)-laneTxBytes ||
+				gas > laneLimit(maxGas, totalGas, lanes[current].MaxGasPercent)-laneGas {
+				return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+			}
+
+			laneTxBytes += txSize
+			laneGas += gas
+		}
+
+		return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
+	}
+}
+
+// laneLimit returns the amount of a block resource, of which used was already
+// consumed by the previous lanes, a lane with the given share may use. A
+// non-positive total means the resource is unlimited.
+func laneLimit(total, used int64, percent uint64) int64 {
+	if total <= 0 {
+		return math.MaxInt64
+	}
+
+	limit := total - used
+	if percent > 0 {
+		// computes total * percent / 100 without overflowing
+		share := total/100*int64(percent) + total%100*int64(percent)/100
+		if share < limit {
+			limit = share
+		}
+	}
+
+	return limit
+}
@@ -26,6 +26,7 @@ type MessageRouter interface {
 type MsgServiceRouter struct {
 	interfaceRegistry codectypes.InterfaceRegistry
 	routes            map[string]MsgServiceHandler
+	circuitBreaker    CircuitBreaker
 }
 
 var _ gogogrpc.Server = &MsgServiceRouter{}
@@ -37,6 +38,12 @@ func NewMsgServiceRouter() *MsgServiceRouter {
 	}
 }
 
+// SetCircuit sets the circuit breaker consulted before every Msg is routed to
+// its handler.
+func (msr *MsgServiceRouter) SetCircuit(cb CircuitBreaker) {
+	msr.circuitBreaker = cb
+}
+
 // MsgServiceHandler defines a function type which handles Msg service message.
 type MsgServiceHandler = func(ctx sdk.Context, req sdk.Msg) (*sdk.Result, error)
 
@@ -128,6 +135,19 @@ func (msr *MsgServiceRouter) RegisterService(sd *grpc.ServiceDesc, handler inter
 				}
 			}
 
+			if msr.circuitBreaker != nil {
+				msgURL := sdk.MsgTypeURL(msg)
+
+				isAllowed, err := msr.circuitBreaker.IsAllowed(ctx, msgURL)
+				if err != nil {
+					return nil, err
+				}
+
+				if !isAllowed {
+					return nil, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "circuit breaker disables execution of this message: %s", msgURL)
+				}
+			}
+
 			// Call the method handler from the service description with the handler object.
 			// We don't do any decoding here because the decoding was already done.
 			res, err := methodHandler(handler, ctx, noopDecoder, interceptor)
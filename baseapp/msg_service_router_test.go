@@ -12,11 +12,14 @@ import (
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/log"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/runtime"
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
@@ -160,3 +163,39 @@ func TestMsgService(t *testing.T) {
 	res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
 	require.Equal(t, abci.CodeTypeOK, res.Code, "res=%+v", res)
 }
+
+type mockCircuitBreaker struct {
+	disabled map[string]bool
+}
+
+func (cb mockCircuitBreaker) IsAllowed(_ context.Context, typeURL string) (bool, error) {
+	return !cb.disabled[typeURL], nil
+}
+
+func TestMsgServiceCircuitBreaker(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	testdata.RegisterInterfaces(registry)
+
+	router := baseapp.NewMsgServiceRouter()
+	router.SetInterfaceRegistry(registry)
+	testdata.RegisterMsgServer(router, testdata.MsgServerImpl{})
+
+	msg := &testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "Spot"}}
+	handler := router.Handler(msg)
+	require.NotNil(t, handler)
+
+	ctx := sdk.Context{}.WithContext(context.Background())
+
+	// without a circuit breaker every msg is allowed
+	_, err := handler(ctx, msg)
+	require.NoError(t, err)
+
+	router.SetCircuit(mockCircuitBreaker{disabled: map[string]bool{}})
+	_, err = handler(ctx, msg)
+	require.NoError(t, err)
+
+	router.SetCircuit(mockCircuitBreaker{disabled: map[string]bool{sdk.MsgTypeURL(msg): true}})
+	_, err = handler(ctx, msg)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	require.ErrorContains(t, err, "circuit breaker disables execution of this message")
+}
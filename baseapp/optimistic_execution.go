@@ -0,0 +1,272 @@
+package baseapp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+// errOptimisticExecutionAborted is the error of an optimistic execution which
+// was aborted before completion.
+var errOptimisticExecutionAborted = errors.New("optimistic execution aborted")
+
+// optimisticExecution is the execution of a block accepted in ProcessProposal,
+// run in the background on a branch of the state before the block is
+// finalized. If the same block is then finalized, its BeginBlock, DeliverTx and
+// EndBlock responses are reused instead of executing the block again,
+// otherwise the execution is discarded.
+type optimisticExecution struct {
+	req   abci.RequestBeginBlock
+	txs   [][]byte
+	state *state
+
+	resBeginBlock abci.ResponseBeginBlock
+	resDeliverTxs []abci.ResponseDeliverTx
+	resEndBlock   abci.ResponseEndBlock
+
+	// next is the index of the next transaction to deliver once the execution
+	// is adopted.
+	next int
+
+	// err is non-nil if the execution did not complete, it must only be read
+	// once done is closed.
+	err     error
+	aborted atomic.Bool
+	done    chan struct{}
+}
+
+// restrictsHeader returns true if the block header exposed to the application at
+// the given height is restricted by optimisticHeader, which is required to
+// execute the block optimistically.
+func (app *BaseApp) restrictsHeader(height int64) bool {
+	return app.optimisticHeaderHeight > 0 && height >= app.optimisticHeaderHeight
+}
+
+// optimisticHeader returns the block header exposed to the application from the
+// optimistic header height. It only contains the fields known when
+// ProcessProposal is called, so that a block executes identically whether it
+// was executed optimistically or not.
+func optimisticHeader(header cmtproto.Header) cmtproto.Header {
+	return cmtproto.Header{
+		ChainID:            header.ChainID,
+		Height:             header.Height,
+		Time:               header.Time,
+		NextValidatorsHash: header.NextValidatorsHash,
+		AppHash:            header.AppHash,
+		ProposerAddress:    header.ProposerAddress,
+	}
+}
+
+// startOptimisticExecution starts executing the block of an accepted proposal
+// in the background. The execution is branched from the latest committed
+// state, or from the state written by InitChain for the initial height.
+func (app *BaseApp) startOptimisticExecution(req abci.RequestProcessProposal) {
+	header := optimisticHeader(cmtproto.Header{
+		ChainID:            app.chainID,
+		Height:             req.Height,
+		Time:               req.Time,
+		NextValidatorsHash: req.NextValidatorsHash,
+		AppHash:            app.lastAppHash(),
+		ProposerAddress:    req.ProposerAddress,
+	})
+
+	var st *state
+	if req.Height == app.initialHeight && app.deliverState != nil {
+		ms := app.deliverState.CacheMultiStore()
+		st = &state{
+			ms: ms,
+			ctx: app.deliverState.ctx.
+				WithMultiStore(ms).
+				WithBlockHeader(header).
+				WithBlockHeight(header.Height),
+		}
+	} else {
+		ms := app.cms.CacheMultiStore()
+		st = &state{
+			ms:  ms,
+			ctx: sdk.NewContext(ms, header, false, app.logger).WithStreamingManager(app.streamingManager),
+		}
+	}
+
+	app.oe = &optimisticExecution{
+		req: abci.RequestBeginBlock{
+			Hash:                req.Hash,
+			Header:              header,
+			LastCommitInfo:      req.ProposedLastCommit,
+			ByzantineValidators: req.Misbehavior,
+		},
+		txs:   req.Txs,
+		state: st,
+		done:  make(chan struct{}),
+	}
+
+	go app.runOptimisticExecution(app.oe)
+}
+
+// runOptimisticExecution executes the block of oe. It must only access the
+// state of oe since ABCI methods, e.g. CheckTx, run concurrently. In
+// particular, it does not remove the block's transactions from the mempool,
+// they are removed when delivered.
+func (app *BaseApp) runOptimisticExecution(oe *optimisticExecution) {
+	defer close(oe.done)
+	defer func() {
+		if r := recover(); r != nil {
+			oe.err = fmt.Errorf("recovered panic in optimistic execution: %v", r)
+		}
+	}()
+
+	ctx := oe.state.ctx
+	ctx = ctx.
+		WithBlockGasMeter(app.getBlockGasMeter(ctx)).
+		WithHeaderHash(oe.req.Hash).
+		WithConsensusParams(app.GetConsensusParams(ctx)).
+		WithVoteInfos(oe.req.LastCommitInfo.GetVotes())
+	oe.state.ctx = ctx
+
+	if app.beginBlocker != nil {
+		res, err := app.beginBlocker(ctx, oe.req)
+		if err != nil {
+			oe.err = err
+			return
+		}
+		res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
+		oe.resBeginBlock = res
+	}
+
+	if app.canParallelize(oe.state) {
+		app.deliverTxsInParallel(oe, ctx)
+	} else {
+		app.deliverTxsSerially(oe, ctx)
+	}
+
+	if oe.aborted.Load() {
+		oe.err = errOptimisticExecutionAborted
+		return
+	}
+
+	if app.endBlocker != nil {
+		res, err := app.endBlocker(ctx, abci.RequestEndBlock{Height: oe.req.Header.Height})
+		if err != nil {
+			oe.err = err
+			return
+		}
+		res.Events = sdk.MarkEventsToIndex(res.Events, app.indexEvents)
+		oe.resEndBlock = res
+	}
+
+	cp := app.GetConsensusParams(ctx)
+	oe.resEndBlock.ConsensusParamUpdates = &cp
+}
+
+// deliverTxsSerially delivers the transactions of oe one after the other,
+// using the block context ctx. It stops early if oe is aborted.
+func (app *BaseApp) deliverTxsSerially(oe *optimisticExecution, ctx sdk.Context) {
+	oe.resDeliverTxs = make([]abci.ResponseDeliverTx, 0, len(oe.txs))
+	for _, tx := range oe.txs {
+		if oe.aborted.Load() {
+			return
+		}
+
+		txCtx := ctx.WithTxBytes(tx)
+		txCtx = txCtx.WithConsensusParams(app.GetConsensusParams(txCtx))
+
+		gInfo, result, anteEvents, _, err := app.runTxWithContext(txCtx, runTxModeDeliver, tx, mempool.NoOpMempool{})
+		oe.resDeliverTxs = append(oe.resDeliverTxs, app.deliverTxResponse(gInfo, result, anteEvents, err))
+	}
+}
+
+// abortOptimisticExecution aborts the optimistic execution in progress, if
+// any, and waits for it to stop.
+func (app *BaseApp) abortOptimisticExecution() {
+	if app.oe == nil {
+		return
+	}
+
+	app.oe.aborted.Store(true)
+	<-app.oe.done
+	app.oe = nil
+}
+
+// adoptOptimisticExecution waits for the optimistic execution of the block
+// being finalized and sets its state as the deliver state. It returns false,
+// discarding the optimistic execution, if there is none, if it executed a
+// different block or if it did not complete, in which case the block must be
+// executed normally.
+func (app *BaseApp) adoptOptimisticExecution(req abci.RequestBeginBlock) bool {
+	oe := app.oe
+	if oe == nil {
+		return false
+	}
+
+	if !bytes.Equal(oe.req.Hash, req.Hash) || !sameOptimisticHeader(oe.req.Header, req.Header) {
+		app.logger.Debug("discarding optimistic execution of a different block", "height", req.Header.Height)
+		app.abortOptimisticExecution()
+		return false
+	}
+
+	<-oe.done
+	if oe.err != nil {
+		app.logger.Error("discarding incomplete optimistic execution", "height", req.Header.Height, "err", oe.err)
+		app.oe = nil
+		return false
+	}
+
+	if app.deliverState == nil {
+		app.deliverState = oe.state
+	} else {
+		// In the first block, the execution is branched from the deliver state
+		// initialized by InitChain.
+		oe.state.ms.Write()
+		app.deliverState.ctx = oe.state.ctx.WithMultiStore(app.deliverState.ms)
+	}
+
+	return true
+}
+
+// deliverOptimisticTx returns the response of the next transaction of the
+// adopted optimistic execution and removes the transaction from the mempool.
+func (app *BaseApp) deliverOptimisticTx(txBytes []byte) abci.ResponseDeliverTx {
+	oe := app.oe
+	if oe.next >= len(oe.txs) || !bytes.Equal(oe.txs[oe.next], txBytes) {
+		panic(fmt.Sprintf("delivered tx %d does not match the optimistically executed block", oe.next))
+	}
+
+	res := oe.resDeliverTxs[oe.next]
+	oe.next++
+
+	if tx, err := app.txDecoder(txBytes); err == nil {
+		if err := app.mempool.Remove(tx); err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
+			app.logger.Error("failed to remove tx from mempool", "err", err)
+		}
+	}
+
+	return res
+}
+
+// endOptimisticBlock returns the EndBlock response of the adopted optimistic
+// execution.
+func (app *BaseApp) endOptimisticBlock() abci.ResponseEndBlock {
+	oe := app.oe
+	if oe.next != len(oe.txs) {
+		panic(fmt.Sprintf("EndBlock called after %d of the %d optimistically executed txs", oe.next, len(oe.txs)))
+	}
+
+	return oe.resEndBlock
+}
+
+// sameOptimisticHeader returns true if the optimistic headers a and b are equal.
+func sameOptimisticHeader(a, b cmtproto.Header) bool {
+	return a.ChainID == b.ChainID &&
+		a.Height == b.Height &&
+		a.Time.Equal(b.Time) &&
+		bytes.Equal(a.NextValidatorsHash, b.NextValidatorsHash) &&
+		bytes.Equal(a.AppHash, b.AppHash) &&
+		bytes.Equal(a.ProposerAddress, b.ProposerAddress)
+}
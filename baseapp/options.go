@@ -76,6 +76,19 @@ func SetIAVLLazyLoading(lazyLoading bool) func(*BaseApp) {
 	return func(bapp *BaseApp) { bapp.cms.SetLazyLoading(lazyLoading) }
 }
 
+// SetStateStorage provides a BaseApp option function that enables the state
+// storage of the CommitMultiStore, storing the key/values of every version in
+// db to serve the historical queries.
+func SetStateStorage(db dbm.DB) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.cms.SetStateStorage(db) }
+}
+
+// SetMemIAVL provides a BaseApp option function that loads the IAVL stores of
+// the CommitMultiStore as memiavl stores, migrating them the first time.
+func SetMemIAVL(opts storetypes.MemIAVLOptions) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.cms.SetMemIAVL(opts) }
+}
+
 // SetInterBlockCache provides a BaseApp option function that sets the
 // inter-block cache.
 func SetInterBlockCache(cache storetypes.MultiStorePersistentCache) func(*BaseApp) {
@@ -92,6 +105,40 @@ func SetMempool(mempool mempool.Mempool) func(*BaseApp) {
 	return func(app *BaseApp) { app.SetMempool(mempool) }
 }
 
+// SetMempoolRecheck sets the function used to re-validate the mempool's
+// transactions after Commit.
+func SetMempoolRecheck(fn mempool.RecheckFn) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetMempoolRecheck(fn) }
+}
+
+// SetExtendVoteHandler sets the ExtendVote handler on BaseApp.
+func SetExtendVoteHandler(handler sdk.ExtendVoteHandler) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetExtendVoteHandler(handler) }
+}
+
+// SetVerifyVoteExtensionHandler sets the VerifyVoteExtension handler on BaseApp.
+func SetVerifyVoteExtensionHandler(handler sdk.VerifyVoteExtensionHandler) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetVerifyVoteExtensionHandler(handler) }
+}
+
+// SetOptimisticExecution enables or disables the optimistic execution of blocks
+// on BaseApp.
+func SetOptimisticExecution(enabled bool) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetOptimisticExecution(enabled) }
+}
+
+// SetOptimisticHeaderHeight sets the height from which the block header exposed
+// to the application is restricted to the fields known in ProcessProposal.
+func SetOptimisticHeaderHeight(height int64) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetOptimisticHeaderHeight(height) }
+}
+
+// SetParallelExecution sets the number of transactions of optimistically
+// executed blocks which can be executed in parallel on BaseApp.
+func SetParallelExecution(workers int) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetParallelExecution(workers) }
+}
+
 // SetChainID sets the chain ID in BaseApp.
 func SetChainID(chainID string) func(*BaseApp) {
 	return func(app *BaseApp) { app.chainID = chainID }
@@ -283,6 +330,17 @@ func (app *BaseApp) SetMempool(mempool mempool.Mempool) {
 	app.mempool = mempool
 }
 
+// SetMempoolRecheck sets the function used to re-validate the transactions
+// remaining in the mempool after Commit. Transactions for which it returns an
+// error are removed. It is only used if the mempool implements
+// mempool.RecheckMempool. See AnteRecheckHandler for a default implementation.
+func (app *BaseApp) SetMempoolRecheck(fn mempool.RecheckFn) {
+	if app.sealed {
+		panic("SetMempoolRecheck() on sealed BaseApp")
+	}
+	app.mempoolRecheck = fn
+}
+
 // SetProcessProposal sets the process proposal function for the BaseApp.
 func (app *BaseApp) SetProcessProposal(handler sdk.ProcessProposalHandler) {
 	if app.sealed {
@@ -300,6 +358,84 @@ func (app *BaseApp) SetPrepareProposal(handler sdk.PrepareProposalHandler) {
 	app.prepareProposal = handler
 }
 
+// SetExtendVoteHandler sets the ExtendVote handler for the BaseApp.
+func (app *BaseApp) SetExtendVoteHandler(handler sdk.ExtendVoteHandler) {
+	if app.sealed {
+		panic("SetExtendVoteHandler() on sealed BaseApp")
+	}
+
+	app.extendVote = handler
+}
+
+// SetVerifyVoteExtensionHandler sets the VerifyVoteExtension handler for the
+// BaseApp.
+func (app *BaseApp) SetVerifyVoteExtensionHandler(handler sdk.VerifyVoteExtensionHandler) {
+	if app.sealed {
+		panic("SetVerifyVoteExtensionHandler() on sealed BaseApp")
+	}
+
+	app.verifyVoteExt = handler
+}
+
+// SetOptimisticExecution enables or disables the optimistic execution of
+// blocks. When enabled, the execution of a block accepted in ProcessProposal
+// starts in the background, on a branch of the state, and its results are
+// reused if the same block is finalized, taking the block execution time off
+// the consensus critical path.
+//
+// NOTE: Blocks are only executed optimistically from the height set with
+// SetOptimisticHeaderHeight, since the other header fields are not known in
+// ProcessProposal.
+func (app *BaseApp) SetOptimisticExecution(enabled bool) {
+	if app.sealed {
+		panic("SetOptimisticExecution() on sealed BaseApp")
+	}
+
+	app.optimisticExec = enabled
+}
+
+// SetOptimisticHeaderHeight sets the height from which the block header exposed
+// to the application is restricted to the fields known in ProcessProposal, i.e.
+// the chain ID, height, time, proposer address, next validators hash and app
+// hash, allowing the blocks to be executed optimistically. A height of 0, the
+// default, never restricts the header.
+//
+// NOTE: This is state machine breaking, since modules storing the header, e.g.
+// x/staking's historical info, store a different header. All the nodes of a
+// network must use the same height, e.g. the height of a coordinated upgrade,
+// whether or not they enable optimistic execution.
+func (app *BaseApp) SetOptimisticHeaderHeight(height int64) {
+	if app.sealed {
+		panic("SetOptimisticHeaderHeight() on sealed BaseApp")
+	}
+	if height < 0 {
+		panic("optimistic header height must be non-negative")
+	}
+
+	app.optimisticHeaderHeight = height
+}
+
+// SetParallelExecution sets the number of workers executing the transactions
+// of optimistically executed blocks concurrently, a value lower than 2
+// disabling parallel execution. It has no effect unless optimistic execution
+// is enabled.
+//
+// Each transaction is executed on its own branch of the block state which
+// records the keys it reads and writes, and the branches are then written in
+// the order of the block. A transaction which read a key written by a previous
+// transaction of the block is re-executed, so that the state and the responses
+// are the same as the ones of a serial execution. Transactions must therefore
+// not depend on the gas consumed by the previous transactions of the block,
+// other than through the block gas limit, nor on state kept outside of the
+// multi store. Parallel execution is disabled when store tracing is enabled.
+func (app *BaseApp) SetParallelExecution(workers int) {
+	if app.sealed {
+		panic("SetParallelExecution() on sealed BaseApp")
+	}
+
+	app.parallelWorkers = workers
+}
+
 // SetStoreMetrics sets the prepare proposal function for the BaseApp.
 func (app *BaseApp) SetStoreMetrics(gatherer metrics.StoreMetrics) {
 	if app.sealed {
@@ -313,3 +449,13 @@ func (app *BaseApp) SetStoreMetrics(gatherer metrics.StoreMetrics) {
 func (app *BaseApp) SetStreamingManager(manager storetypes.StreamingManager) {
 	app.streamingManager = manager
 }
+
+// SetCircuitBreaker sets the circuit breaker for the BaseApp.
+// The circuit breaker is checked on every message execution to verify if a transaction should be executed or not.
+func (app *BaseApp) SetCircuitBreaker(cb CircuitBreaker) {
+	if app.msgServiceRouter == nil {
+		panic("cannot set circuit breaker with no msg service router set")
+	}
+
+	app.msgServiceRouter.SetCircuit(cb)
+}
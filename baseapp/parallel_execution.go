@@ -0,0 +1,188 @@
+package baseapp
+
+import (
+	"fmt"
+	"sync"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+// txExecution is the execution of a transaction of a block on its own branch
+// of the block state, concurrently with the other transactions of the block.
+type txExecution struct {
+	ms  storetypes.AccessTrackingMultiStore
+	res abci.ResponseDeliverTx
+
+	// blockGas is the gas consumed by the transaction on the block gas meter,
+	// and paramsGas the gas consumed on the gas meter of the block context when
+	// loading the consensus params of the transaction.
+	blockGas  uint64
+	paramsGas uint64
+
+	// shared is true if the transaction used the gas meter or the event
+	// manager of the block context, which are shared by the transactions of
+	// the block.
+	shared bool
+}
+
+// sharedGasMeter is the gas meter of the block context of a transaction
+// executed on a branch. It records whether the gas consumed was read, e.g. as
+// the gas used by a transaction failing before the ante handler sets its own
+// gas meter, which is the gas consumed by the block so far.
+type sharedGasMeter struct {
+	storetypes.GasMeter
+	read bool
+}
+
+func (m *sharedGasMeter) GasConsumed() storetypes.Gas {
+	m.read = true
+	return m.GasMeter.GasConsumed()
+}
+
+func (m *sharedGasMeter) GasConsumedToLimit() storetypes.Gas {
+	m.read = true
+	return m.GasMeter.GasConsumedToLimit()
+}
+
+// canParallelize returns true if the transactions of the block executed in
+// the given state can be executed in parallel.
+func (app *BaseApp) canParallelize(st *state) bool {
+	if app.parallelWorkers < 2 || st.ms.TracingEnabled() {
+		return false
+	}
+
+	_, ok := st.ms.(storetypes.AccessTrackingBrancher)
+	return ok
+}
+
+// deliverTxsInParallel delivers the transactions of oe with the results of a
+// serial execution, using the block context ctx.
+//
+// The transactions are first executed concurrently, each on a branch of the
+// block state recording the keys it reads and writes. The branches are then
+// written in the order of the block. A transaction which read a key written by
+// a previous transaction of the block, which used the state shared by the
+// transactions, e.g. failing before the ante handler, or which could exceed the
+// block gas limit, is discarded and re-executed on the block state instead. It stops early if oe is aborted.
+func (app *BaseApp) deliverTxsInParallel(oe *optimisticExecution, ctx sdk.Context) {
+	brancher := oe.state.ms.(storetypes.AccessTrackingBrancher)
+
+	execs := make([]*txExecution, len(oe.txs))
+	indexes := make(chan int, len(oe.txs))
+	for i := range oe.txs {
+		indexes <- i
+	}
+	close(indexes)
+
+	var wg sync.WaitGroup
+	for w := 0; w < app.parallelWorkers && w < len(oe.txs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if oe.aborted.Load() {
+					return
+				}
+
+				execs[i] = app.executeTxOnBranch(ctx, brancher, oe.txs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	// writes is the set of keys written by the delivered transactions, by store
+	writes := make(map[storetypes.StoreKey]map[string]struct{})
+	blockGasMeter := ctx.BlockGasMeter()
+
+	var reexecuted int
+	oe.resDeliverTxs = make([]abci.ResponseDeliverTx, 0, len(oe.txs))
+	for i, tx := range oe.txs {
+		if oe.aborted.Load() {
+			return
+		}
+
+		exec := execs[i]
+		if exec == nil || exec.shared || dependsOnWrites(exec.ms, writes) ||
+			blockGasMeter.IsOutOfGas() || exec.blockGas > blockGasMeter.GasRemaining() {
+			ms := brancher.CacheMultiStoreWithAccessTracking()
+			txCtx := ctx.WithTxBytes(tx).WithMultiStore(ms)
+			txCtx = txCtx.WithConsensusParams(app.GetConsensusParams(txCtx))
+
+			gInfo, result, anteEvents, _, err := app.runTxWithContext(txCtx, runTxModeDeliver, tx, mempool.NoOpMempool{})
+			exec = &txExecution{ms: ms, res: app.deliverTxResponse(gInfo, result, anteEvents, err)}
+			reexecuted++
+		} else {
+			ctx.GasMeter().ConsumeGas(exec.paramsGas, "consensus params")
+			blockGasMeter.ConsumeGas(exec.blockGas, "block gas meter")
+		}
+
+		exec.ms.Write()
+		for key, set := range exec.ms.AccessSets() {
+			if len(set.Writes) == 0 {
+				continue
+			}
+
+			if writes[key] == nil {
+				writes[key] = make(map[string]struct{})
+			}
+			for k := range set.Writes {
+				writes[key][k] = struct{}{}
+			}
+		}
+
+		oe.resDeliverTxs = append(oe.resDeliverTxs, exec.res)
+	}
+
+	app.logger.Debug("delivered txs in parallel", "height", oe.req.Header.Height, "txs", len(oe.txs), "reexecuted", reexecuted)
+}
+
+// executeTxOnBranch executes tx on a branch of the block state. It returns nil
+// if the execution panicked, in which case the transaction must be
+// re-executed.
+func (app *BaseApp) executeTxOnBranch(ctx sdk.Context, brancher storetypes.AccessTrackingBrancher, tx []byte) (exec *txExecution) {
+	defer func() {
+		if r := recover(); r != nil {
+			app.logger.Debug("recovered panic in parallel tx execution", "err", fmt.Sprintf("%v", r))
+			exec = nil
+		}
+	}()
+
+	ms := brancher.CacheMultiStoreWithAccessTracking()
+	paramsGasMeter := storetypes.NewInfiniteGasMeter()
+	txCtx := ctx.WithTxBytes(tx).WithMultiStore(ms).WithGasMeter(paramsGasMeter)
+	txCtx = txCtx.WithConsensusParams(app.GetConsensusParams(txCtx))
+
+	blockGasMeter := storetypes.NewInfiniteGasMeter()
+	gasMeter := &sharedGasMeter{GasMeter: storetypes.NewInfiniteGasMeter()}
+	eventManager := sdk.NewEventManager()
+	txCtx = txCtx.
+		WithBlockGasMeter(blockGasMeter).
+		WithGasMeter(gasMeter).
+		WithEventManager(eventManager)
+
+	gInfo, result, anteEvents, _, err := app.runTxWithContext(txCtx, runTxModeDeliver, tx, mempool.NoOpMempool{})
+
+	return &txExecution{
+		ms:        ms,
+		res:       app.deliverTxResponse(gInfo, result, anteEvents, err),
+		blockGas:  blockGasMeter.GasConsumed(),
+		paramsGas: paramsGasMeter.GasConsumed(),
+		shared:    gasMeter.read || gasMeter.GasMeter.GasConsumed() > 0 || len(eventManager.Events()) > 0,
+	}
+}
+
+// dependsOnWrites returns true if a key of writes was read through ms.
+func dependsOnWrites(ms storetypes.AccessTrackingMultiStore, writes map[storetypes.StoreKey]map[string]struct{}) bool {
+	for key, set := range ms.AccessSets() {
+		if set.DependsOn(writes[key]) {
+			return true
+		}
+	}
+
+	return false
+}
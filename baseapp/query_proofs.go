@@ -0,0 +1,118 @@
+package baseapp
+
+import (
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/proto/tendermint/crypto"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/rootmulti"
+	"cosmossdk.io/store/tracekv"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// withQueryProofRecorder returns ctx recording the accesses made to the stores,
+// from which the proofs of the keys read by a query are built.
+func withQueryProofRecorder(ctx sdk.Context) (sdk.Context, *tracekv.AccessRecorder) {
+	recorder := tracekv.NewAccessRecorder()
+	return ctx.WithAccessTracer(recorder), recorder
+}
+
+// queryProofs returns the proofs of the keys of the IAVL stores read through
+// the accesses recorded while executing a query on the state at height. The
+// keys reached by iterators are proven, but not the absence of other keys in
+// their ranges. The keys of the other stores, e.g. the memory stores, are not
+// part of the app hash and have no proofs.
+func (app *BaseApp) queryProofs(height int64, accesses []storetypes.Access) (*query.QueryProofs, error) {
+	rms, ok := app.cms.(*rootmulti.Store)
+	if !ok {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "cannot prove queries of a %T", app.cms)
+	}
+
+	// the commit info of the latest height may not be flushed to disk yet
+	lastCommitID := rms.LastCommitID()
+	appHash := lastCommitID.Hash
+	if height != lastCommitID.Version {
+		commitInfo, err := rms.GetCommitInfo(height)
+		if err != nil {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "failed to load the commit info of height %d: %s", height, err)
+		}
+		appHash = commitInfo.Hash()
+	}
+
+	proofs := &query.QueryProofs{Height: height, AppHash: appHash}
+	proven := make(map[string]bool)
+	for _, access := range accesses {
+		switch access.Operation {
+		case storetypes.AccessRead, storetypes.AccessHas, storetypes.AccessIterKey:
+		default:
+			continue
+		}
+		if access.Key == nil {
+			continue
+		}
+
+		id := fmt.Sprintf("%s/%X", access.StoreKey, access.Key)
+		if proven[id] {
+			continue
+		}
+		proven[id] = true
+
+		store := rms.GetStoreByName(access.StoreKey)
+		if store == nil || (store.GetStoreType() != storetypes.StoreTypeIAVL && store.GetStoreType() != storetypes.StoreTypeMemIAVL) {
+			continue
+		}
+
+		res := rms.Query(abci.RequestQuery{
+			Path:   fmt.Sprintf("/%s/key", access.StoreKey),
+			Data:   access.Key,
+			Height: height,
+			Prove:  true,
+		})
+		if !res.IsOK() {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "failed to prove the key %X of the store %s: %s",
+				access.Key, access.StoreKey, res.Log)
+		}
+
+		proofs.Proofs = append(proofs.Proofs, &query.KVProof{
+			StoreKey: access.StoreKey,
+			Key:      access.Key,
+			Value:    res.Value,
+			ProofOps: res.ProofOps,
+			Exists:   provesExistence(res.ProofOps),
+		})
+	}
+
+	return proofs, nil
+}
+
+// provesExistence returns true if ops prove the presence of their key in its
+// store, rather than its absence, which the value cannot tell for a key with an
+// empty value.
+func provesExistence(ops *crypto.ProofOps) bool {
+	if ops == nil || len(ops.Ops) == 0 {
+		return false
+	}
+
+	op, err := storetypes.CommitmentOpDecoder(ops.Ops[0])
+	if err != nil {
+		return false
+	}
+	commitmentOp, ok := op.(storetypes.CommitmentOp)
+	return ok && commitmentOp.Proof.GetExist() != nil
+}
+
+// queryProofsOps returns the proofs as the ProofOps of an ABCI query response.
+func queryProofsOps(proofs *query.QueryProofs) (*crypto.ProofOps, error) {
+	bz, err := proofs.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &crypto.ProofOps{Ops: []crypto.ProofOp{{Type: query.ProofOpQueryProofs, Data: bz}}}, nil
+}
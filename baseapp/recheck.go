@@ -0,0 +1,77 @@
+package baseapp
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+// AnteRecheckHandler returns a mempool.RecheckFn which runs the AnteHandler on
+// a transaction in ReCheckTx mode, mirroring what CheckTx does for transactions
+// rechecked by CometBFT. It is meant to be set with SetMempoolRecheck so that
+// transactions which became invalid in the application-side mempool, e.g.
+// because their sequence became stale or their fee payer ran out of funds, are
+// evicted after every Commit.
+//
+// State changes made by the AnteHandler are kept for the remainder of the
+// recheck pass, so that subsequent transactions of the same sender are checked
+// against them, but they never reach the check state.
+func (app *BaseApp) AnteRecheckHandler() mempool.RecheckFn {
+	return func(goCtx context.Context, tx sdk.Tx) (err error) {
+		if app.anteHandler == nil {
+			return nil
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic while rechecking tx: %v", r)
+			}
+		}()
+
+		ctx := sdk.UnwrapSDKContext(goCtx)
+		if app.txEncoder != nil {
+			txBytes, err := app.txEncoder(tx)
+			if err != nil {
+				return err
+			}
+
+			ctx = ctx.WithTxBytes(txBytes)
+		}
+
+		anteCtx, msCache := app.cacheTxContext(ctx, ctx.TxBytes())
+		anteCtx = anteCtx.WithEventManager(sdk.NewEventManager())
+		if _, err := app.anteHandler(anteCtx, tx, false); err != nil {
+			return err
+		}
+
+		msCache.Write()
+		return nil
+	}
+}
+
+// recheckMempool runs a recheck pass over the application-side mempool, if it
+// supports one, against the state of the block just committed. The pass runs on
+// a branch of the check state which is discarded afterwards.
+func (app *BaseApp) recheckMempool() {
+	mp, ok := app.mempool.(mempool.RecheckMempool)
+	if !ok {
+		return
+	}
+
+	ctx := app.checkState.ctx.
+		WithMultiStore(app.checkState.ms.CacheMultiStore()).
+		WithIsReCheckTx(true)
+	ctx = ctx.WithConsensusParams(app.GetConsensusParams(ctx))
+
+	removed, err := mp.Recheck(ctx, app.mempoolRecheck)
+	if err != nil {
+		app.logger.Error("failed to recheck mempool", "height", ctx.BlockHeight(), "err", err)
+		return
+	}
+
+	if removed > 0 {
+		app.logger.Debug("removed txs from mempool", "height", ctx.BlockHeight(), "count", removed)
+	}
+}
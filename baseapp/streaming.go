@@ -1,11 +1,15 @@
 package baseapp
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"cosmossdk.io/store/streaming"
+	"cosmossdk.io/store/streaming/file"
 	storetypes "cosmossdk.io/store/types"
 	"github.com/spf13/cast"
 
@@ -19,6 +23,14 @@ const (
 	StreamingABCIPluginTomlKey        = "plugin"
 	StreamingABCIKeysTomlKey          = "keys"
 	StreamingABCIStopNodeOnErrTomlKey = "stop-node-on-err"
+	StreamingABCIBufferSizeTomlKey    = "buffer-size"
+
+	StreamingFileTomlKey            = "file"
+	StreamingFileDirTomlKey         = "dir"
+	StreamingFileKeysTomlKey        = "keys"
+	StreamingFileMaxFileSizeTomlKey = "max-file-size"
+	StreamingFileFsyncTomlKey       = "fsync"
+	StreamingFileBufferSizeTomlKey  = "buffer-size"
 )
 
 // RegisterStreamingServices registers streaming services with the BaseApp.
@@ -40,7 +52,7 @@ func (app *BaseApp) RegisterStreamingServices(appOpts servertypes.AppOptions, ke
 		}
 	}
 
-	return nil
+	return app.registerStreamingFile(appOpts, keys)
 }
 
 // registerStreamingPlugin registers streaming plugins with the BaseApp.
@@ -59,6 +71,7 @@ func (app *BaseApp) registerStreamingPlugin(
 }
 
 // registerABCIListenerPlugin registers plugins that implement the ABCIListener interface.
+// The plugin is called asynchronously when a buffer size is configured.
 func (app *BaseApp) registerABCIListenerPlugin(
 	appOpts servertypes.AppOptions,
 	keys map[string]*storetypes.KVStoreKey,
@@ -68,14 +81,73 @@ func (app *BaseApp) registerABCIListenerPlugin(
 	stopNodeOnErr := cast.ToBool(appOpts.Get(stopNodeOnErrKey))
 	keysKey := fmt.Sprintf("%s.%s.%s", StreamingTomlKey, StreamingABCITomlKey, StreamingABCIKeysTomlKey)
 	exposeKeysStr := cast.ToStringSlice(appOpts.Get(keysKey))
+	bufferSizeKey := fmt.Sprintf("%s.%s.%s", StreamingTomlKey, StreamingABCITomlKey, StreamingABCIBufferSizeTomlKey)
+	if bufferSize := cast.ToInt(appOpts.Get(bufferSizeKey)); bufferSize > 0 {
+		abciListener = streaming.NewAsyncListener(abciListener, bufferSize, app.logger.With("module", "streaming"))
+	}
+
+	app.addABCIListener(exposeKeysStr, keys, abciListener)
+	app.streamingManager.StopNodeOnErr = stopNodeOnErr
+}
+
+// registerStreamingFile registers the file streaming service with the BaseApp,
+// if a directory is configured. A relative directory is resolved from the
+// home directory.
+func (app *BaseApp) registerStreamingFile(appOpts servertypes.AppOptions, keys map[string]*storetypes.KVStoreKey) error {
+	tomlKey := func(key string) string {
+		return fmt.Sprintf("%s.%s.%s", StreamingTomlKey, StreamingFileTomlKey, key)
+	}
+
+	dir := strings.TrimSpace(cast.ToString(appOpts.Get(tomlKey(StreamingFileDirTomlKey))))
+	if len(dir) == 0 {
+		return nil
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cast.ToString(appOpts.Get(flags.FlagHome)), dir)
+	}
+
+	exposeKeysStr := cast.ToStringSlice(appOpts.Get(tomlKey(StreamingFileKeysTomlKey)))
+	writer, err := file.NewWriter(file.Config{
+		Dir:         dir,
+		Keys:        exposeKeysStr,
+		MaxFileSize: cast.ToInt64(appOpts.Get(tomlKey(StreamingFileMaxFileSizeTomlKey))),
+		Fsync:       cast.ToBool(appOpts.Get(tomlKey(StreamingFileFsyncTomlKey))),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open streaming files: %w", err)
+	}
+
+	var abciListener storetypes.ABCIListener = writer
+	if bufferSize := cast.ToInt(appOpts.Get(tomlKey(StreamingFileBufferSizeTomlKey))); bufferSize > 0 {
+		abciListener = streaming.NewAsyncListener(writer, bufferSize, app.logger.With("module", "streaming"))
+	}
+
+	app.addABCIListener(exposeKeysStr, keys, abciListener)
+	return nil
+}
+
+// closeABCIListeners closes the ABCI listeners of the streaming manager which
+// are io.Closer, e.g. the asynchronous listeners, which first deliver the
+// blocks they buffer, and the file streaming service.
+func (app *BaseApp) closeABCIListeners() error {
+	var errs []error
+	for _, abciListener := range app.streamingManager.ABCIListeners {
+		if closer, ok := abciListener.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// addABCIListener adds abciListener to the streaming manager, listening to the
+// stores of exposeKeysStr.
+func (app *BaseApp) addABCIListener(exposeKeysStr []string, keys map[string]*storetypes.KVStoreKey, abciListener storetypes.ABCIListener) {
 	exposedKeys := exposeStoreKeysSorted(exposeKeysStr, keys)
 	app.cms.AddListeners(exposedKeys)
-	app.SetStreamingManager(
-		storetypes.StreamingManager{
-			ABCIListeners: []storetypes.ABCIListener{abciListener},
-			StopNodeOnErr: stopNodeOnErr,
-		},
-	)
+	app.streamingManager.ABCIListeners = append(app.streamingManager.ABCIListeners, abciListener)
 }
 
 func exposeAll(list []string) bool {
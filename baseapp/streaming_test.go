@@ -3,15 +3,20 @@ package baseapp_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/stretchr/testify/require"
 
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/streaming"
+	"cosmossdk.io/store/streaming/file"
 	storetypes "cosmossdk.io/store/types"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -145,3 +150,98 @@ func Test_Ctx_with_StreamingManager(t *testing.T) {
 		suite.baseApp.Commit()
 	}
 }
+
+func TestABCI_StreamingFile(t *testing.T) {
+	dir := t.TempDir()
+	appOpts := simtestutil.AppOptionsMap{
+		baseapp.StreamingTomlKey: map[string]interface{}{baseapp.StreamingFileTomlKey: map[string]interface{}{}},
+		"streaming.file.dir":     dir,
+		"streaming.file.keys":    []string{distKey1.Name()},
+	}
+	distOpt := func(bapp *baseapp.BaseApp) { bapp.MountStores(distKey1) }
+	streamingOpt := func(bapp *baseapp.BaseApp) {
+		keys := map[string]*storetypes.KVStoreKey{distKey1.Name(): distKey1}
+		require.NoError(t, bapp.RegisterStreamingServices(appOpts, keys))
+	}
+	suite := NewBaseAppSuite(t, distOpt, streamingOpt)
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &tmproto.ConsensusParams{},
+	})
+
+	for height := int64(1); height <= 2; height++ {
+		suite.baseApp.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{Height: height}})
+		getDeliverStateCtx(suite.baseApp).KVStore(distKey1).Set([]byte("key"), []byte{byte(height)})
+		suite.baseApp.EndBlock(abci.RequestEndBlock{})
+		suite.baseApp.Commit()
+	}
+
+	r, err := file.NewReader(dir, 1)
+	require.NoError(t, err)
+	defer r.Close()
+	for height := int64(1); height <= 2; height++ {
+		block, err := r.Next()
+		require.NoError(t, err)
+		require.Equal(t, height, block.Height)
+		require.Equal(t, []*storetypes.StoreKVPair{
+			{StoreKey: distKey1.Name(), Key: []byte("key"), Value: []byte{byte(height)}},
+		}, block.Commit.ChangeSet)
+	}
+}
+
+// blockingListener holds the streaming of the blocks until release is closed,
+// and counts the commits it receives.
+type blockingListener struct {
+	MockABCIListener
+
+	release chan struct{}
+	mtx     sync.Mutex
+	commits int
+	closed  bool
+}
+
+func (l *blockingListener) ListenBeginBlock(context.Context, abci.RequestBeginBlock, abci.ResponseBeginBlock) error {
+	<-l.release
+	return nil
+}
+
+func (l *blockingListener) ListenCommit(context.Context, abci.ResponseCommit, []*storetypes.StoreKVPair) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.commits++
+	return nil
+}
+
+func (l *blockingListener) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.closed = true
+	return nil
+}
+
+func TestABCI_CloseStreaming(t *testing.T) {
+	// the listener holds the first block, the next ones fill the buffer
+	listener := &blockingListener{release: make(chan struct{})}
+	asyncListener := streaming.NewAsyncListener(listener, 2, log.NewNopLogger())
+	streamingManagerOpt := func(bapp *baseapp.BaseApp) {
+		bapp.SetStreamingManager(storetypes.StreamingManager{ABCIListeners: []storetypes.ABCIListener{asyncListener}})
+	}
+	suite := NewBaseAppSuite(t, streamingManagerOpt)
+
+	suite.baseApp.InitChain(abci.RequestInitChain{
+		ConsensusParams: &tmproto.ConsensusParams{},
+	})
+
+	for height := int64(1); height <= 3; height++ {
+		suite.baseApp.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{Height: height}})
+		suite.baseApp.EndBlock(abci.RequestEndBlock{})
+		suite.baseApp.Commit()
+	}
+	require.Zero(t, listener.commits)
+
+	// the buffered blocks are delivered before the listener is closed
+	close(listener.release)
+	require.NoError(t, suite.baseApp.Close())
+	require.Equal(t, 3, listener.commits)
+	require.True(t, listener.closed)
+}
@@ -0,0 +1,120 @@
+package baseapp
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// TxSelector defines a helper type that assists in selecting transactions
+// during mempool transaction selection in PrepareProposal. It keeps track of
+// the selected transactions along with their total bytes and gas.
+type TxSelector interface {
+	// SelectedTxs returns a copy of the selected transactions.
+	SelectedTxs() [][]byte
+
+	// Clear clears the TxSelector, nulling out all relevant fields.
+	Clear()
+
+	// SelectTxForProposal attempts to select a transaction for inclusion in a
+	// proposal based on the inclusion criteria defined by the TxSelector. It
+	// returns whether the transaction was selected and whether the caller should
+	// halt the transaction selection loop. A maxBlockGas of zero means the block
+	// gas is unlimited.
+	SelectTxForProposal(maxTxBytes, maxBlockGas uint64, memTx sdk.Tx, txBz []byte) (selected, stop bool)
+}
+
+type defaultTxSelector struct {
+	totalTxBytes uint64
+	totalTxGas   uint64
+	selectedTxs  [][]byte
+}
+
+// NewDefaultTxSelector returns a TxSelector which selects every transaction
+// that fits in the remaining bytes and gas of the proposal, using the gas limit
+// of sdk.FeeTx transactions. Transactions that do not fit are skipped, and the
+// selection halts once the proposal's bytes or gas are exhausted.
+func NewDefaultTxSelector() TxSelector {
+	return &defaultTxSelector{}
+}
+
+func (ts *defaultTxSelector) SelectedTxs() [][]byte {
+	txs := make([][]byte, len(ts.selectedTxs))
+	copy(txs, ts.selectedTxs)
+	return txs
+}
+
+func (ts *defaultTxSelector) Clear() {
+	ts.totalTxBytes = 0
+	ts.totalTxGas = 0
+	ts.selectedTxs = nil
+}
+
+func (ts *defaultTxSelector) SelectTxForProposal(maxTxBytes, maxBlockGas uint64, memTx sdk.Tx, txBz []byte) (selected, stop bool) {
+	txSize := uint64(len(txBz))
+	gas := uint64(txGas(memTx))
+
+	// only add the transaction to the proposal if we have enough capacity
+	if txSize <= maxTxBytes-ts.totalTxBytes && (maxBlockGas == 0 || gas <= maxBlockGas-ts.totalTxGas) {
+		ts.totalTxBytes += txSize
+		ts.totalTxGas += gas
+		ts.selectedTxs = append(ts.selectedTxs, txBz)
+		selected = true
+	}
+
+	// check if we've reached capacity; if so, we cannot select any more transactions
+	stop = ts.totalTxBytes >= maxTxBytes || (maxBlockGas > 0 && ts.totalTxGas >= maxBlockGas)
+	return selected, stop
+}
+
+// blockLimits returns the maximum bytes and gas of a block as defined by the
+// consensus parameters. A non-positive value means the block is unlimited.
+func blockLimits(ctx sdk.Context) (maxBytes, maxGas int64) {
+	block := ctx.ConsensusParams().Block
+	if block == nil {
+		return -1, -1
+	}
+
+	return block.MaxBytes, block.MaxGas
+}
+
+// txGas returns the gas limit of a transaction, or 0 if it is not a FeeTx.
+func txGas(tx sdk.Tx) int64 {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return 0
+	}
+
+	if gas := feeTx.GetGas(); gas <= math.MaxInt64 {
+		return int64(gas)
+	}
+
+	return math.MaxInt64
+}
+
+func skipSigners(tx sdk.Tx, skipped map[string]struct{}) {
+	sigTx, ok := tx.(signing.SigVerifiableTx)
+	if !ok {
+		return
+	}
+
+	for _, signer := range sigTx.GetSigners() {
+		skipped[signer.String()] = struct{}{}
+	}
+}
+
+func hasSkippedSigner(tx sdk.Tx, skipped map[string]struct{}) bool {
+	sigTx, ok := tx.(signing.SigVerifiableTx)
+	if !ok || len(skipped) == 0 {
+		return false
+	}
+
+	for _, signer := range sigTx.GetSigners() {
+		if _, ok := skipped[signer.String()]; ok {
+			return true
+		}
+	}
+
+	return false
+}
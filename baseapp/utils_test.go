@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"unsafe"
 
@@ -106,6 +107,42 @@ func (m MsgKeyValueImpl) Set(ctx context.Context, msg *baseapptestutil.MsgKeyVal
 	return &baseapptestutil.MsgCreateKeyValueResponse{}, nil
 }
 
+// CountingKeyValueImpl sets the key of MsgKeyValue on capKey2, counting the
+// messages it executes.
+type CountingKeyValueImpl struct {
+	calls *atomic.Int64
+}
+
+func (m CountingKeyValueImpl) Set(ctx context.Context, msg *baseapptestutil.MsgKeyValue) (*baseapptestutil.MsgCreateKeyValueResponse, error) {
+	m.calls.Add(1)
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx.KVStore(capKey2).Set(msg.Key, msg.Value)
+	return &baseapptestutil.MsgCreateKeyValueResponse{}, nil
+}
+
+// SumCounterServerImpl adds the counter of MsgCounter to the value of
+// deliverKey on capKey, counting the messages it executes.
+type SumCounterServerImpl struct {
+	t          *testing.T
+	deliverKey []byte
+	calls      *atomic.Int64
+}
+
+func (m SumCounterServerImpl) IncrementCounter(ctx context.Context, msg *baseapptestutil.MsgCounter) (*baseapptestutil.MsgCreateCounterResponse, error) {
+	m.calls.Add(1)
+	if msg.FailOnHandler {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "message handler failure")
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	store := sdkCtx.KVStore(capKey1)
+	sum := getIntFromStore(m.t, store, m.deliverKey) + msg.Counter
+	setIntOnStore(store, m.deliverKey, sum)
+
+	sdkCtx.EventManager().EmitEvents(counterEvent(sdk.EventTypeMessage, sum))
+	return &baseapptestutil.MsgCreateCounterResponse{}, nil
+}
+
 type CounterServerImplGasMeterOnly struct {
 	gas uint64
 }
@@ -336,6 +373,16 @@ func newTxCounter(t *testing.T, cfg client.TxConfig, counter int64, msgCounters
 	return builder.GetTx()
 }
 
+func newTxWithGas(t *testing.T, cfg client.TxConfig, nonce, gas uint64, msgs ...sdk.Msg) signing.Tx {
+	builder := cfg.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(msgs...))
+	builder.SetMemo("counter=" + strconv.FormatUint(nonce, 10) + "&failOnAnte=false")
+	builder.SetGasLimit(gas)
+	setTxSignature(t, builder, nonce)
+
+	return builder.GetTx()
+}
+
 func getIntFromStore(t *testing.T, store storetypes.KVStore, key []byte) int64 {
 	bz := store.Get(key)
 	if len(bz) == 0 {
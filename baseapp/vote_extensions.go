@@ -0,0 +1,180 @@
+package baseapp
+
+import (
+	"errors"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExtendVote implements the ABCI 2.0 ExtendVote method. It returns the
+// application data the validator attaches to its pre-commit vote for the block
+// at the given height and hash, as computed by the ExtendVoteHandler. The
+// handler runs against a branch of the latest committed state which is
+// discarded afterwards.
+//
+// NOTE: CometBFT v0.37 does not call ExtendVote yet, applications may call it
+// from their own consensus integration until it does.
+func (app *BaseApp) ExtendVote(height int64, hash []byte) (voteExt []byte, err error) {
+	if app.extendVote == nil {
+		return nil, errors.New("application ExtendVote handler not set")
+	}
+
+	ctx := app.getContextForVoteExtension(height).WithHeaderHash(hash)
+
+	defer func() {
+		if r := recover(); r != nil {
+			app.logger.Error(
+				"panic recovered in ExtendVote",
+				"height", height,
+				"hash", fmt.Sprintf("%X", hash),
+				"panic", r,
+			)
+			voteExt, err = nil, fmt.Errorf("recovered application panic in ExtendVote: %v", r)
+		}
+	}()
+
+	return app.extendVote(ctx, height, hash)
+}
+
+// VerifyVoteExtension implements the ABCI 2.0 VerifyVoteExtension method. It
+// verifies, using the VerifyVoteExtensionHandler, the vote extension the given
+// validator attached to its pre-commit vote for the block at the given height.
+// The handler runs against a branch of the latest committed state which is
+// discarded afterwards.
+//
+// NOTE: CometBFT v0.37 does not call VerifyVoteExtension yet, applications may
+// call it from their own consensus integration until it does.
+func (app *BaseApp) VerifyVoteExtension(valAddr []byte, height int64, voteExt []byte) (err error) {
+	if app.verifyVoteExt == nil {
+		return errors.New("application VerifyVoteExtension handler not set")
+	}
+
+	ctx := app.getContextForVoteExtension(height)
+
+	defer func() {
+		if r := recover(); r != nil {
+			app.logger.Error(
+				"panic recovered in VerifyVoteExtension",
+				"height", height,
+				"validator", fmt.Sprintf("%X", valAddr),
+				"panic", r,
+			)
+			err = fmt.Errorf("recovered application panic in VerifyVoteExtension: %v", r)
+		}
+	}()
+
+	return app.verifyVoteExt(ctx, valAddr, height, voteExt)
+}
+
+// getContextForVoteExtension returns a context for the vote extension handlers
+// on a branch of the latest committed state, or of the state written by
+// InitChain for the initial height.
+func (app *BaseApp) getContextForVoteExtension(height int64) sdk.Context {
+	var ctx sdk.Context
+	if height == app.initialHeight && app.deliverState != nil {
+		ctx, _ = app.deliverState.ctx.CacheContext()
+	} else {
+		header := cmtproto.Header{ChainID: app.chainID, Height: height}
+		ctx = sdk.NewContext(app.cms.CacheMultiStore(), header, false, app.logger)
+	}
+
+	return ctx.
+		WithBlockHeight(height).
+		WithConsensusParams(app.GetConsensusParams(ctx)).
+		WithBlockGasMeter(storetypes.NewInfiniteGasMeter())
+}
+
+// ValidateVoteExtensions validates the extended commit info of the previous
+// block, e.g. Context.ExtendedVoteInfos in PrepareProposal, before a proposer
+// aggregates the vote extensions it contains into the block at currentHeight,
+// and in ProcessProposal once the other validators read them from the block.
+// valSet is the validator set of the previous block, at currentHeight-1, which
+// the application must track itself, e.g. from the validator updates returned
+// by EndBlock. It checks that:
+//
+// 1. The votes are those of the validators of valSet, each with its power,
+// which prevents a proposer from leaving out the validators which did not sign.
+// 2. Validators which did not sign the previous block have no vote extension.
+// 3. The vote extension of every validator which signed the previous block is
+// valid according to verifyVoteExt, if given.
+// 4. The validators which signed the previous block have more than 2/3 of the
+// total voting power of valSet.
+//
+// NOTE: CometBFT v0.37 does not sign vote extensions, so the authenticity of
+// the vote extensions cannot be verified. Applications must not call it for the
+// initial height, as there is no previous block.
+func ValidateVoteExtensions(
+	ctx sdk.Context,
+	currentHeight int64,
+	valSet []abci.Validator,
+	extCommit abci.ExtendedCommitInfo,
+	verifyVoteExt sdk.VerifyVoteExtensionHandler,
+) error {
+	var (
+		totalPower, signedPower int64
+		powers                  = make(map[string]int64, len(valSet))
+	)
+
+	for _, val := range valSet {
+		if _, ok := powers[string(val.Address)]; ok {
+			return fmt.Errorf("duplicate validator %X in the validator set", val.Address)
+		}
+		powers[string(val.Address)] = val.Power
+		totalPower += val.Power
+	}
+
+	if len(extCommit.Votes) != len(valSet) {
+		return fmt.Errorf(
+			"the votes do not match the validator set; got: %d votes, expected: %d",
+			len(extCommit.Votes), len(valSet),
+		)
+	}
+
+	seen := make(map[string]struct{}, len(extCommit.Votes))
+	for _, vote := range extCommit.Votes {
+		valAddr := vote.Validator.Address
+		if _, ok := seen[string(valAddr)]; ok {
+			return fmt.Errorf("duplicate vote of validator %X", valAddr)
+		}
+		seen[string(valAddr)] = struct{}{}
+
+		power, ok := powers[string(valAddr)]
+		if !ok {
+			return fmt.Errorf("validator %X is not in the validator set", valAddr)
+		}
+		if vote.Validator.Power != power {
+			return fmt.Errorf("invalid power of validator %X; got: %d, expected: %d", valAddr, vote.Validator.Power, power)
+		}
+
+		if !vote.SignedLastBlock {
+			if len(vote.VoteExtension) > 0 {
+				return fmt.Errorf("validator %X did not sign the previous block but has a vote extension", valAddr)
+			}
+			continue
+		}
+
+		if verifyVoteExt != nil {
+			if err := verifyVoteExt(ctx, valAddr, currentHeight-1, vote.VoteExtension); err != nil {
+				return fmt.Errorf("invalid vote extension of validator %X: %w", valAddr, err)
+			}
+		}
+
+		signedPower += power
+	}
+
+	// signedPower / totalPower > 2/3
+	if totalPower <= 0 || signedPower*3 <= totalPower*2 {
+		return fmt.Errorf(
+			"insufficient cumulative voting power received to verify vote extensions; got: %d, expected: >2/3 of %d",
+			signedPower, totalPower,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package node
+
+import (
+	context "context"
+	"fmt"
+
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pruningtypes "cosmossdk.io/store/pruning/types"
+)
+
+// PruningUpdater is implemented by the stores whose pruning options can be
+// changed while the node is running, e.g. the rootmulti.Store.
+type PruningUpdater interface {
+	UpdatePruning(pruningtypes.PruningOptions) error
+}
+
+// RegisterAdminService registers the node Admin gRPC service, changing the
+// pruning options of store, on the provided gRPC server. It must not be
+// registered on the gRPC query router of the app, which also serves the
+// queries of the ABCI clients.
+func RegisterAdminService(server gogogrpc.Server, store PruningUpdater) {
+	RegisterAdminServer(server, NewAdminServer(store))
+}
+
+var _ AdminServer = adminServer{}
+
+type adminServer struct {
+	store PruningUpdater
+}
+
+func NewAdminServer(store PruningUpdater) AdminServer {
+	return adminServer{
+		store: store,
+	}
+}
+
+func (s adminServer) UpdatePruning(_ context.Context, req *UpdatePruningRequest) (*UpdatePruningResponse, error) {
+	var opts pruningtypes.PruningOptions
+	switch req.Pruning {
+	case pruningtypes.PruningOptionDefault, pruningtypes.PruningOptionEverything, pruningtypes.PruningOptionNothing:
+		opts = pruningtypes.NewPruningOptionsFromString(req.Pruning)
+	case pruningtypes.PruningOptionCustom:
+		opts = pruningtypes.NewCustomPruningOptions(req.PruningKeepRecent, req.PruningInterval)
+	default:
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown pruning strategy %q", req.Pruning))
+	}
+
+	if err := s.store.UpdatePruning(opts); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &UpdatePruningResponse{
+		PruningKeepRecent: opts.KeepRecent,
+		PruningInterval:   opts.Interval,
+	}, nil
+}
@@ -0,0 +1,681 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/base/node/v1beta1/admin.proto
+
+package node
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// UpdatePruningRequest defines the request structure for the UpdatePruning gRPC
+// method.
+type UpdatePruningRequest struct {
+	// pruning is the pruning strategy: default, nothing, everything or custom.
+	Pruning string `protobuf:"bytes,1,opt,name=pruning,proto3" json:"pruning,omitempty"`
+	// pruning_keep_recent and pruning_interval are the options of the custom
+	// strategy, ignored by the other strategies.
+	PruningKeepRecent uint64 `protobuf:"varint,2,opt,name=pruning_keep_recent,json=pruningKeepRecent,proto3" json:"pruning_keep_recent,omitempty"`
+	PruningInterval   uint64 `protobuf:"varint,3,opt,name=pruning_interval,json=pruningInterval,proto3" json:"pruning_interval,omitempty"`
+}
+
+func (m *UpdatePruningRequest) Reset()         { *m = UpdatePruningRequest{} }
+func (m *UpdatePruningRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdatePruningRequest) ProtoMessage()    {}
+func (*UpdatePruningRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f021e0e6fe28b785, []int{0}
+}
+func (m *UpdatePruningRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdatePruningRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdatePruningRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdatePruningRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePruningRequest.Merge(m, src)
+}
+func (m *UpdatePruningRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdatePruningRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePruningRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePruningRequest proto.InternalMessageInfo
+
+func (m *UpdatePruningRequest) GetPruning() string {
+	if m != nil {
+		return m.Pruning
+	}
+	return ""
+}
+
+func (m *UpdatePruningRequest) GetPruningKeepRecent() uint64 {
+	if m != nil {
+		return m.PruningKeepRecent
+	}
+	return 0
+}
+
+func (m *UpdatePruningRequest) GetPruningInterval() uint64 {
+	if m != nil {
+		return m.PruningInterval
+	}
+	return 0
+}
+
+// UpdatePruningResponse defines the response structure for the UpdatePruning
+// gRPC method.
+type UpdatePruningResponse struct {
+	// pruning_keep_recent and pruning_interval are the options applied.
+	PruningKeepRecent uint64 `protobuf:"varint,1,opt,name=pruning_keep_recent,json=pruningKeepRecent,proto3" json:"pruning_keep_recent,omitempty"`
+	PruningInterval   uint64 `protobuf:"varint,2,opt,name=pruning_interval,json=pruningInterval,proto3" json:"pruning_interval,omitempty"`
+}
+
+func (m *UpdatePruningResponse) Reset()         { *m = UpdatePruningResponse{} }
+func (m *UpdatePruningResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdatePruningResponse) ProtoMessage()    {}
+func (*UpdatePruningResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f021e0e6fe28b785, []int{1}
+}
+func (m *UpdatePruningResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdatePruningResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdatePruningResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdatePruningResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePruningResponse.Merge(m, src)
+}
+func (m *UpdatePruningResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdatePruningResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePruningResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePruningResponse proto.InternalMessageInfo
+
+func (m *UpdatePruningResponse) GetPruningKeepRecent() uint64 {
+	if m != nil {
+		return m.PruningKeepRecent
+	}
+	return 0
+}
+
+func (m *UpdatePruningResponse) GetPruningInterval() uint64 {
+	if m != nil {
+		return m.PruningInterval
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*UpdatePruningRequest)(nil), "cosmos.base.node.v1beta1.UpdatePruningRequest")
+	proto.RegisterType((*UpdatePruningResponse)(nil), "cosmos.base.node.v1beta1.UpdatePruningResponse")
+}
+
+func init() {
+	proto.RegisterFile("cosmos/base/node/v1beta1/admin.proto", fileDescriptor_f021e0e6fe28b785)
+}
+
+var fileDescriptor_f021e0e6fe28b785 = []byte{
+	// 289 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcd, 0x4a, 0x03, 0x31,
+	0x14, 0x85, 0x27, 0xf5, 0x0f, 0x03, 0xa2, 0x46, 0x85, 0xc1, 0x45, 0x28, 0xc5, 0x45, 0x5d, 0x34,
+	0xa1, 0xfa, 0x04, 0xba, 0x11, 0x71, 0x23, 0x03, 0x6e, 0xdc, 0x94, 0xf9, 0xb9, 0x8c, 0xa1, 0x9d,
+	0x24, 0x26, 0x99, 0x82, 0xcf, 0xe0, 0xc6, 0xc7, 0x72, 0xd9, 0xa5, 0x4b, 0x99, 0x79, 0x11, 0x99,
+	0x99, 0x74, 0xa1, 0xb4, 0x60, 0x57, 0x49, 0xce, 0xfd, 0xe0, 0xe4, 0x9e, 0x83, 0x2f, 0x52, 0x65,
+	0x0b, 0x65, 0x79, 0x12, 0x5b, 0xe0, 0x52, 0x65, 0xc0, 0xe7, 0xe3, 0x04, 0x5c, 0x3c, 0xe6, 0x71,
+	0x56, 0x08, 0xc9, 0xb4, 0x51, 0x4e, 0x91, 0xb0, 0xa3, 0x58, 0x43, 0xb1, 0x86, 0x62, 0x9e, 0x1a,
+	0xbc, 0x23, 0x7c, 0xfa, 0xa4, 0xb3, 0xd8, 0xc1, 0xa3, 0x29, 0xa5, 0x90, 0x79, 0x04, 0xaf, 0x25,
+	0x58, 0x47, 0x42, 0xbc, 0xa7, 0x3b, 0x25, 0x44, 0x7d, 0x34, 0xdc, 0x8f, 0x96, 0x4f, 0xc2, 0xf0,
+	0x89, 0xbf, 0x4e, 0xa6, 0x00, 0x7a, 0x62, 0x20, 0x05, 0xe9, 0xc2, 0x5e, 0x1f, 0x0d, 0xb7, 0xa3,
+	0x63, 0x3f, 0x7a, 0x00, 0xd0, 0x51, 0x3b, 0x20, 0x97, 0xf8, 0x68, 0xc9, 0x0b, 0xe9, 0xc0, 0xcc,
+	0xe3, 0x59, 0xb8, 0xd5, 0xc2, 0x87, 0x5e, 0xbf, 0xf7, 0xf2, 0xc0, 0xe0, 0xb3, 0x3f, 0x9f, 0xb1,
+	0x5a, 0x49, 0x0b, 0xeb, 0x3c, 0xd1, 0x26, 0x9e, 0xbd, 0x95, 0x9e, 0x57, 0x6f, 0x78, 0xe7, 0xa6,
+	0x89, 0x8a, 0x68, 0x7c, 0xf0, 0xcb, 0x9c, 0x30, 0xb6, 0x2e, 0x36, 0xb6, 0x2a, 0xb2, 0x73, 0xfe,
+	0x6f, 0xbe, 0xdb, 0xea, 0xf6, 0xee, 0xb3, 0xa2, 0x68, 0x51, 0x51, 0xf4, 0x5d, 0x51, 0xf4, 0x51,
+	0xd3, 0x60, 0x51, 0xd3, 0xe0, 0xab, 0xa6, 0xc1, 0xf3, 0x28, 0x17, 0xee, 0xa5, 0x4c, 0x58, 0xaa,
+	0x0a, 0xee, 0x1b, 0xee, 0x8e, 0x91, 0xcd, 0xa6, 0x3c, 0x9d, 0x09, 0x90, 0x8e, 0xe7, 0x46, 0xa7,
+	0x6d, 0xe7, 0xc9, 0x6e, 0x5b, 0xf3, 0xf5, 0xcf, 0x00, 0x48, 0x82, 0x34, 0xed, 0x0e, 0x02, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	// UpdatePruning changes the pruning options of the node from the next block,
+	// without a restart. The options are not persisted to app.toml.
+	UpdatePruning(ctx context.Context, in *UpdatePruningRequest, opts ...grpc.CallOption) (*UpdatePruningResponse, error)
+}
+
+type adminClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewAdminClient(cc grpc1.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) UpdatePruning(ctx context.Context, in *UpdatePruningRequest, opts ...grpc.CallOption) (*UpdatePruningResponse, error) {
+	out := new(UpdatePruningResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.base.node.v1beta1.Admin/UpdatePruning", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	// UpdatePruning changes the pruning options of the node from the next block,
+	// without a restart. The options are not persisted to app.toml.
+	UpdatePruning(context.Context, *UpdatePruningRequest) (*UpdatePruningResponse, error)
+}
+
+// UnimplementedAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (*UnimplementedAdminServer) UpdatePruning(ctx context.Context, req *UpdatePruningRequest) (*UpdatePruningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePruning not implemented")
+}
+
+func RegisterAdminServer(s grpc1.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_UpdatePruning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePruningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UpdatePruning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.base.node.v1beta1.Admin/UpdatePruning",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UpdatePruning(ctx, req.(*UpdatePruningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.base.node.v1beta1.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpdatePruning",
+			Handler:    _Admin_UpdatePruning_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/base/node/v1beta1/admin.proto",
+}
+
+func (m *UpdatePruningRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdatePruningRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdatePruningRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PruningInterval != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.PruningInterval))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.PruningKeepRecent != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.PruningKeepRecent))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Pruning) > 0 {
+		i -= len(m.Pruning)
+		copy(dAtA[i:], m.Pruning)
+		i = encodeVarintAdmin(dAtA, i, uint64(len(m.Pruning)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdatePruningResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdatePruningResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdatePruningResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PruningInterval != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.PruningInterval))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.PruningKeepRecent != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.PruningKeepRecent))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAdmin(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAdmin(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *UpdatePruningRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Pruning)
+	if l > 0 {
+		n += 1 + l + sovAdmin(uint64(l))
+	}
+	if m.PruningKeepRecent != 0 {
+		n += 1 + sovAdmin(uint64(m.PruningKeepRecent))
+	}
+	if m.PruningInterval != 0 {
+		n += 1 + sovAdmin(uint64(m.PruningInterval))
+	}
+	return n
+}
+
+func (m *UpdatePruningResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PruningKeepRecent != 0 {
+		n += 1 + sovAdmin(uint64(m.PruningKeepRecent))
+	}
+	if m.PruningInterval != 0 {
+		n += 1 + sovAdmin(uint64(m.PruningInterval))
+	}
+	return n
+}
+
+func sovAdmin(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAdmin(x uint64) (n int) {
+	return sovAdmin(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *UpdatePruningRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdatePruningRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdatePruningRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pruning", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pruning = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PruningKeepRecent", wireType)
+			}
+			m.PruningKeepRecent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PruningKeepRecent |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PruningInterval", wireType)
+			}
+			m.PruningInterval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PruningInterval |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdatePruningResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdatePruningResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdatePruningResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PruningKeepRecent", wireType)
+			}
+			m.PruningKeepRecent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PruningKeepRecent |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PruningInterval", wireType)
+			}
+			m.PruningInterval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PruningInterval |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAdmin(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthAdmin
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAdmin
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAdmin
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthAdmin        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAdmin          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAdmin = fmt.Errorf("proto: unexpected end of group")
+)
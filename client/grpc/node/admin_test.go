@@ -0,0 +1,48 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pruningtypes "cosmossdk.io/store/pruning/types"
+)
+
+type mockPruningUpdater struct {
+	opts pruningtypes.PruningOptions
+}
+
+func (m *mockPruningUpdater) UpdatePruning(opts pruningtypes.PruningOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	m.opts = opts
+	return nil
+}
+
+func TestAdminServer_UpdatePruning(t *testing.T) {
+	store := &mockPruningUpdater{}
+	svr := NewAdminServer(store)
+
+	resp, err := svr.UpdatePruning(context.Background(), &UpdatePruningRequest{
+		Pruning:           pruningtypes.PruningOptionCustom,
+		PruningKeepRecent: 100,
+		PruningInterval:   10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &UpdatePruningResponse{PruningKeepRecent: 100, PruningInterval: 10}, resp)
+	require.Equal(t, pruningtypes.NewCustomPruningOptions(100, 10), store.opts)
+
+	resp, err = svr.UpdatePruning(context.Background(), &UpdatePruningRequest{Pruning: pruningtypes.PruningOptionEverything})
+	require.NoError(t, err)
+	require.Equal(t, pruningtypes.NewPruningOptions(pruningtypes.PruningEverything), store.opts)
+	require.Equal(t, store.opts.KeepRecent, resp.PruningKeepRecent)
+
+	_, err = svr.UpdatePruning(context.Background(), &UpdatePruningRequest{Pruning: "unknown"})
+	require.ErrorContains(t, err, "unknown pruning strategy")
+
+	_, err = svr.UpdatePruning(context.Background(), &UpdatePruningRequest{Pruning: pruningtypes.PruningOptionCustom, PruningKeepRecent: 100})
+	require.Error(t, err)
+	require.Equal(t, pruningtypes.NewPruningOptions(pruningtypes.PruningEverything), store.opts)
+}
@@ -12,6 +12,7 @@ import (
 
 	errorsmod "cosmossdk.io/errors"
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/proto/tendermint/crypto"
 	gogogrpc "github.com/cosmos/gogoproto/grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -19,6 +20,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 )
 
@@ -84,10 +86,20 @@ func (ctx Context) Invoke(grpcCtx gocontext.Context, method string, req, reply i
 		ctx = ctx.WithHeight(height)
 	}
 
+	// parse prove header
+	var prove bool
+	if proves := md.Get(grpctypes.GRPCQueryProveHeader); len(proves) > 0 {
+		prove, err = strconv.ParseBool(proves[0])
+		if err != nil {
+			return err
+		}
+	}
+
 	abciReq := abci.RequestQuery{
 		Path:   method,
 		Data:   reqBz,
 		Height: ctx.Height,
+		Prove:  prove,
 	}
 
 	res, err := ctx.QueryABCI(abciReq)
@@ -103,16 +115,24 @@ func (ctx Context) Invoke(grpcCtx gocontext.Context, method string, req, reply i
 	// Create header metadata. For now the headers contain:
 	// - block height
 	// We then parse all the call options, if the call option is a
-	// HeaderCallOption, then we manually set the value of that header to the
-	// metadata.
+	// HeaderCallOption or a TrailerCallOption, then we manually set the value
+	// of that header or trailer to the metadata.
 	md = metadata.Pairs(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(res.Height, 10))
+	// The trailer contains the proofs of the query, if requested.
+	var trailer metadata.MD
+	if prove {
+		trailer, err = proofsTrailer(res.ProofOps)
+		if err != nil {
+			return err
+		}
+	}
 	for _, callOpt := range opts {
-		header, ok := callOpt.(grpc.HeaderCallOption)
-		if !ok {
-			continue
+		switch callOpt := callOpt.(type) {
+		case grpc.HeaderCallOption:
+			*callOpt.HeaderAddr = md
+		case grpc.TrailerCallOption:
+			*callOpt.TrailerAddr = trailer
 		}
-
-		*header.HeaderAddr = md
 	}
 
 	if ctx.InterfaceRegistry != nil {
@@ -122,6 +142,16 @@ func (ctx Context) Invoke(grpcCtx gocontext.Context, method string, req, reply i
 	return nil
 }
 
+// proofsTrailer returns the gRPC trailer holding the proofs of a query made
+// through ABCI, from the ProofOps of its response.
+func proofsTrailer(ops *crypto.ProofOps) (metadata.MD, error) {
+	if ops == nil || len(ops.Ops) != 1 || ops.Ops[0].Type != query.ProofOpQueryProofs {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "the query response has no proofs")
+	}
+
+	return metadata.Pairs(grpctypes.GRPCQueryProofsTrailer, string(ops.Ops[0].Data)), nil
+}
+
 // NewStream implements the grpc ClientConn.NewStream method
 func (Context) NewStream(gocontext.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
 	return nil, fmt.Errorf("streaming rpc not supported")
@@ -0,0 +1,160 @@
+// Package proof verifies the proofs of the keys read by the gRPC queries of
+// a node against a trusted app hash, e.g. the one of a header verified by a
+// light client, so that the responses of untrusted nodes can be checked.
+//
+// The proofs are requested with WithProofs and received in the trailer of
+// the query, e.g.
+//
+//	var trailer metadata.MD
+//	res, err := bankClient.Balance(proof.WithProofs(ctx), req, grpc.Trailer(&trailer))
+//	proofs, err := proof.FromTrailer(trailer)
+//	err = proof.Verify(proofs, trustedAppHash)
+//	value, found := proofs.Value(banktypes.StoreKey, balanceKey)
+//
+// The proofs prove the values of the keys read by the query at the height of
+// the proofs, but neither that the query read all the keys it should have, e.g.
+// all the keys in the range of an iterator, nor that its response was computed
+// from them. Clients should therefore compare the response to the values
+// proven for the keys they expect the query to read.
+package proof
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/cometbft/cometbft/proto/tendermint/crypto"
+	"google.golang.org/grpc/metadata"
+
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// WithProofs returns ctx requesting the proofs of the keys read by the gRPC
+// queries made with it.
+func WithProofs(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCQueryProveHeader, "true")
+}
+
+// FromTrailer returns the proofs of a gRPC query from its trailer, received
+// with the grpc.Trailer call option.
+func FromTrailer(trailer metadata.MD) (*query.QueryProofs, error) {
+	values := trailer.Get(grpctypes.GRPCQueryProofsTrailer)
+	if len(values) != 1 {
+		return nil, errors.New("the query response has no proofs")
+	}
+
+	proofs := &query.QueryProofs{}
+	if err := proofs.Unmarshal([]byte(values[0])); err != nil {
+		return nil, fmt.Errorf("invalid query proofs: %w", err)
+	}
+
+	return proofs, nil
+}
+
+// FromProofOps returns the proofs of a gRPC query made through ABCI with
+// Prove set, from the ProofOps of its response.
+func FromProofOps(ops *crypto.ProofOps) (*query.QueryProofs, error) {
+	if ops == nil || len(ops.Ops) != 1 || ops.Ops[0].Type != query.ProofOpQueryProofs {
+		return nil, errors.New("the query response has no proofs")
+	}
+
+	proofs := &query.QueryProofs{}
+	if err := proofs.Unmarshal(ops.Ops[0].Data); err != nil {
+		return nil, fmt.Errorf("invalid query proofs: %w", err)
+	}
+
+	return proofs, nil
+}
+
+// Verify verifies proofs against appHash, the trusted app hash of the state at
+// the height of the proofs, i.e. the app hash of the header of the block at
+// the next height.
+func Verify(proofs *query.QueryProofs, appHash []byte) error {
+	if !bytes.Equal(proofs.AppHash, appHash) {
+		return fmt.Errorf("the app hash of the proofs %X is not the trusted app hash %X", proofs.AppHash, appHash)
+	}
+
+	prt := rootmulti.DefaultProofRuntime()
+	for _, p := range proofs.Proofs {
+		if p.ProofOps == nil {
+			return fmt.Errorf("missing proof of the key %X of the store %s", p.Key, p.StoreKey)
+		}
+
+		keyPath := merkle.KeyPath{}.
+			AppendKey([]byte(p.StoreKey), merkle.KeyEncodingURL).
+			AppendKey(p.Key, merkle.KeyEncodingURL).
+			String()
+
+		var err error
+		switch {
+		case p.Exists && len(p.Value) == 0:
+			err = verifyEmptyValue(prt, p, appHash)
+		case p.Exists:
+			err = prt.VerifyValue(p.ProofOps, appHash, keyPath, p.Value)
+		case len(p.Value) == 0:
+			err = prt.VerifyAbsence(p.ProofOps, appHash, keyPath)
+		default:
+			err = errors.New("the key is absent but has a value")
+		}
+		if err != nil {
+			return fmt.Errorf("invalid proof of the key %X of the store %s: %w", p.Key, p.StoreKey, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyEmptyValue verifies the proof of a key present with an empty value,
+// whose leaf the ics23 operations refuse to hash. The leaf of the IAVL
+// existence proof is hashed here, and the rest of the proof is verified from
+// the root of the store it yields.
+func verifyEmptyValue(prt *merkle.ProofRuntime, p *query.KVProof, appHash []byte) error {
+	if len(p.ProofOps.Ops) == 0 || p.ProofOps.Ops[0].Type != storetypes.ProofOpIAVLCommitment {
+		return errors.New("the proof of a key with an empty value must start with an IAVL proof")
+	}
+
+	op, err := storetypes.CommitmentOpDecoder(p.ProofOps.Ops[0])
+	if err != nil {
+		return err
+	}
+	commitmentOp := op.(storetypes.CommitmentOp)
+	exist := commitmentOp.Proof.GetExist()
+	if exist == nil || !bytes.Equal(commitmentOp.Key, p.Key) || !bytes.Equal(exist.Key, p.Key) || len(exist.Value) != 0 {
+		return errors.New("the proof does not prove the key with an empty value")
+	}
+	if err := exist.CheckAgainstSpec(commitmentOp.Spec); err != nil {
+		return err
+	}
+
+	// the leaf of the IAVL spec is the hash of its prefix, the length prefixed
+	// key and the length prefixed hash of the value
+	leaf := append([]byte{}, exist.Leaf.Prefix...)
+	leaf = binary.AppendUvarint(leaf, uint64(len(exist.Key)))
+	leaf = append(leaf, exist.Key...)
+	valueHash := sha256.Sum256(nil)
+	leaf = binary.AppendUvarint(leaf, uint64(len(valueHash)))
+	leaf = append(leaf, valueHash[:]...)
+	leafHash := sha256.Sum256(leaf)
+
+	root := leafHash[:]
+	for _, step := range exist.Path {
+		if root, err = step.Apply(root); err != nil {
+			return err
+		}
+	}
+
+	storeOps, err := prt.DecodeProof(&crypto.ProofOps{Ops: p.ProofOps.Ops[1:]})
+	if err != nil {
+		return err
+	}
+	keyPath := merkle.KeyPath{}.AppendKey([]byte(p.StoreKey), merkle.KeyEncodingURL).String()
+	return storeOps.Verify(appHash, keyPath, [][]byte{root})
+}
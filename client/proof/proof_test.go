@@ -0,0 +1,127 @@
+package proof_test
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/log"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/proto/tendermint/crypto"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/client/proof"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// kvProof returns the proof of key in the store named bank at height.
+func kvProof(t *testing.T, ms *rootmulti.Store, height int64, key []byte, exists bool) *query.KVProof {
+	t.Helper()
+
+	res := ms.Query(abci.RequestQuery{Path: "/bank/key", Data: key, Height: height, Prove: true})
+	require.True(t, res.IsOK(), res.Log)
+
+	return &query.KVProof{StoreKey: "bank", Key: key, Value: res.Value, ProofOps: res.ProofOps, Exists: exists}
+}
+
+func TestVerify(t *testing.T) {
+	ms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	bankKey, authKey := storetypes.NewKVStoreKey("bank"), storetypes.NewKVStoreKey("auth")
+	ms.MountStoreWithDB(bankKey, storetypes.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(authKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	ms.GetKVStore(bankKey).Set([]byte("a"), []byte("1"))
+	ms.GetKVStore(bankKey).Set([]byte("c"), []byte("3"))
+	ms.GetKVStore(bankKey).Set([]byte("e"), []byte{})
+	ms.GetKVStore(authKey).Set([]byte("a"), []byte("x"))
+	commitID := ms.Commit()
+	// the proofs are made against the state of the first height
+	ms.GetKVStore(bankKey).Set([]byte("a"), []byte("2"))
+	ms.Commit()
+
+	proofs := &query.QueryProofs{
+		Height:  commitID.Version,
+		AppHash: commitID.Hash,
+		Proofs: []*query.KVProof{
+			kvProof(t, ms, commitID.Version, []byte("a"), true),
+			kvProof(t, ms, commitID.Version, []byte("b"), false),
+			kvProof(t, ms, commitID.Version, []byte("e"), true),
+		},
+	}
+	require.NoError(t, proof.Verify(proofs, commitID.Hash))
+
+	value, found := proofs.Value("bank", []byte("a"))
+	require.True(t, found)
+	require.Equal(t, []byte("1"), value)
+	value, found = proofs.Value("bank", []byte("b"))
+	require.True(t, found)
+	require.Nil(t, value)
+	value, found = proofs.Value("bank", []byte("e"))
+	require.True(t, found)
+	require.Equal(t, []byte{}, value)
+	_, found = proofs.Value("auth", []byte("a"))
+	require.False(t, found)
+
+	// the proofs must be verified against the trusted app hash
+	require.ErrorContains(t, proof.Verify(proofs, ms.LastCommitID().Hash), "not the trusted app hash")
+
+	// the values must match their proofs
+	proofs.Proofs[0].Value = []byte("2")
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[0].Value = []byte("1")
+	proofs.Proofs[1].Value = []byte("2")
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[1].Value = nil
+
+	// the presence of the keys must match their proofs, even for an empty value
+	proofs.Proofs[2].Exists = false
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[2].Exists = true
+	proofs.Proofs[1].Exists = true
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[1].Exists = false
+
+	// the proofs must be made for their keys and stores
+	proofs.Proofs[1].Key = []byte("c")
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[1].Key = []byte("b")
+	proofs.Proofs[2].Key = []byte("c")
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[2].Key = []byte("e")
+	proofs.Proofs[2].StoreKey = "auth"
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[2].StoreKey = "bank"
+	require.NoError(t, proof.Verify(proofs, commitID.Hash))
+	proofs.Proofs[0].StoreKey = "auth"
+	require.Error(t, proof.Verify(proofs, commitID.Hash))
+}
+
+func TestFromTrailer(t *testing.T) {
+	proofs := &query.QueryProofs{Height: 3, AppHash: []byte("hash"), Proofs: []*query.KVProof{{StoreKey: "bank", Key: []byte("a")}}}
+	bz, err := proofs.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := proof.FromTrailer(metadata.Pairs(grpctypes.GRPCQueryProofsTrailer, string(bz)))
+	require.NoError(t, err)
+	require.Equal(t, proofs, decoded)
+
+	decoded, err = proof.FromProofOps(&crypto.ProofOps{Ops: []crypto.ProofOp{{Type: query.ProofOpQueryProofs, Data: bz}}})
+	require.NoError(t, err)
+	require.Equal(t, proofs, decoded)
+
+	_, err = proof.FromTrailer(metadata.MD{})
+	require.Error(t, err)
+	_, err = proof.FromProofOps(&crypto.ProofOps{Ops: []crypto.ProofOp{{Type: "ics23:iavl", Data: bz}}})
+	require.Error(t, err)
+
+	md, ok := metadata.FromOutgoingContext(proof.WithProofs(context.Background()))
+	require.True(t, ok)
+	require.Equal(t, []string{"true"}, md.Get(grpctypes.GRPCQueryProveHeader))
+}
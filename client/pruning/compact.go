@@ -0,0 +1,104 @@
+package pruning
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"cosmossdk.io/store/storage"
+)
+
+const stateStorageDBName = "state_storage"
+
+// compacter is implemented by the databases which can be compacted on demand,
+// e.g. goleveldb.
+type compacter interface {
+	ForceCompact(start, limit []byte) error
+}
+
+// compactDB compacts db to reclaim the disk space of the entries deleted, if
+// its backend supports it.
+func compactDB(db dbm.DB, name string) error {
+	c, ok := db.(compacter)
+	if !ok {
+		fmt.Printf("the backend of the %s database does not support compaction\n", name)
+		return nil
+	}
+
+	fmt.Printf("compacting the %s database\n", name)
+	if err := c.ForceCompact(nil, nil); err != nil {
+		return fmt.Errorf("failed to compact the %s database: %w", name, err)
+	}
+	return nil
+}
+
+// pruneStateStorage prunes the versions of the state storage older than
+// version, and compacts it if compact is true.
+func pruneStateStorage(home string, backend dbm.BackendType, version int64, compact bool) error {
+	db, err := dbm.NewDB(stateStorageDBName, backend, filepath.Join(home, "data"))
+	if err != nil {
+		return err
+	}
+	ss := storage.NewDatabase(db)
+	defer ss.Close()
+
+	earliest, err := ss.GetEarliestVersion()
+	if err != nil {
+		return err
+	}
+	latest, err := ss.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	if version <= earliest || latest == 0 {
+		fmt.Printf("no state storage versions to prune\n")
+	} else {
+		fmt.Printf("pruning the state storage versions from %v to %v\n", earliest, version-1)
+		if err := ss.Prune(version); err != nil {
+			return err
+		}
+		fmt.Printf("successfully pruned the state storage\n")
+	}
+
+	if compact {
+		return compactDB(db, stateStorageDBName)
+	}
+	return nil
+}
+
+// dbDir returns the directory of the database named name in the data
+// directory of home.
+func dbDir(home, name string) string {
+	return filepath.Join(home, "data", name+".db")
+}
+
+// dirsSize returns the total size of the files in dirs. The directories
+// which do not exist are skipped.
+func dirsSize(dirs []string) (int64, error) {
+	var size int64
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+
+	return size, nil
+}
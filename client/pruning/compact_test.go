@@ -0,0 +1,48 @@
+package pruning
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/storage"
+	storetypes "cosmossdk.io/store/types"
+)
+
+func TestPruneStateStorage(t *testing.T) {
+	home := t.TempDir()
+
+	db, err := dbm.NewDB(stateStorageDBName, dbm.GoLevelDBBackend, filepath.Join(home, "data"))
+	require.NoError(t, err)
+	ss := storage.NewDatabase(db)
+	for version := int64(1); version <= 10; version++ {
+		require.NoError(t, ss.ApplyChangeset(version, []*storetypes.StoreKVPair{
+			{StoreKey: "bank", Key: []byte("k"), Value: []byte(fmt.Sprintf("v%d", version))},
+		}))
+	}
+	require.NoError(t, ss.Close())
+
+	dirs := []string{dbDir(home, stateStorageDBName), dbDir(home, "missing")}
+	size, err := dirsSize(dirs)
+	require.NoError(t, err)
+	require.Positive(t, size)
+
+	require.NoError(t, pruneStateStorage(home, dbm.GoLevelDBBackend, 8, true))
+
+	db, err = dbm.NewDB(stateStorageDBName, dbm.GoLevelDBBackend, filepath.Join(home, "data"))
+	require.NoError(t, err)
+	ss = storage.NewDatabase(db)
+	defer ss.Close()
+
+	earliest, err := ss.GetEarliestVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 8, earliest)
+	_, err = ss.Get("bank", 7, []byte("k"))
+	require.Error(t, err)
+	value, err := ss.Get("bank", 8, []byte("k"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v8"), value)
+}
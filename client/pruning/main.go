@@ -17,7 +17,10 @@ import (
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 )
 
-const FlagAppDBBackend = "app-db-backend"
+const (
+	FlagAppDBBackend = "app-db-backend"
+	FlagCompact      = "compact"
+)
 
 // Cmd prunes the sdk root multi store history versions based on the pruning options
 // specified by command flags.
@@ -37,6 +40,10 @@ func Cmd(appCreator servertypes.AppCreator) *cobra.Command {
 		besides pruning options, database home directory and database backend type should also be specified via flags
 		'--home' and '--app-db-backend'.
 		valid app-db-backend type includes 'goleveldb', 'rocksdb', 'pebbledb'.
+
+		The state storage of the application, if enabled with '--state-storage', is pruned down to the same heights.
+		The databases are then compacted, when supported by their backend, and the disk space reclaimed is reported.
+		The node must be stopped.
 		`,
 		Example: "prune --home './' --app-db-backend 'goleveldb' --pruning 'custom' --pruning-keep-recent 100",
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -56,11 +63,27 @@ func Cmd(appCreator servertypes.AppCreator) *cobra.Command {
 			)
 
 			home := vp.GetString(flags.FlagHome)
-			db, err := openDB(home, server.GetAppDBBackend(vp))
+			backend := server.GetAppDBBackend(vp)
+			stateStorage := vp.GetBool(server.FlagStateStorage)
+			compact := vp.GetBool(FlagCompact)
+
+			dbDirs := []string{dbDir(home, "application")}
+			if stateStorage {
+				dbDirs = append(dbDirs, dbDir(home, stateStorageDBName))
+			}
+			sizeBefore, err := dirsSize(dbDirs)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(home, backend)
 			if err != nil {
 				return err
 			}
 
+			// the state storage is pruned on its own below, the application
+			// only prunes the IAVL stores.
+			vp.Set(server.FlagStateStorage, false)
 			logger := log.NewLogger(cmd.OutOrStdout())
 			app := appCreator(logger, db, nil, vp)
 			cms := app.CommitMultiStore()
@@ -83,19 +106,40 @@ func Cmd(appCreator servertypes.AppCreator) *cobra.Command {
 			}
 			if len(pruningHeights) == 0 {
 				fmt.Printf("no heights to prune\n")
-				return nil
+			} else {
+				fmt.Printf(
+					"pruning heights start from %v, end at %v\n",
+					pruningHeights[0],
+					pruningHeights[len(pruningHeights)-1],
+				)
+
+				if err := rootMultiStore.PruneStores(false, pruningHeights); err != nil {
+					return err
+				}
+				fmt.Printf("successfully pruned the application root multi stores\n")
+			}
+
+			if compact {
+				if err := compactDB(db, "application"); err != nil {
+					return err
+				}
+			}
+			if err := db.Close(); err != nil {
+				return err
+			}
+
+			if stateStorage {
+				if err := pruneStateStorage(home, backend, latestHeight-int64(pruningOptions.KeepRecent), compact); err != nil {
+					return err
+				}
 			}
-			fmt.Printf(
-				"pruning heights start from %v, end at %v\n",
-				pruningHeights[0],
-				pruningHeights[len(pruningHeights)-1],
-			)
 
-			err = rootMultiStore.PruneStores(false, pruningHeights)
+			sizeAfter, err := dirsSize(dbDirs)
 			if err != nil {
 				return err
 			}
-			fmt.Printf("successfully pruned the application root multi stores\n")
+			fmt.Printf("database size: %d bytes before, %d bytes after, %d bytes reclaimed\n",
+				sizeBefore, sizeAfter, sizeBefore-sizeAfter)
 			return nil
 		},
 	}
@@ -107,6 +151,8 @@ func Cmd(appCreator servertypes.AppCreator) *cobra.Command {
 	cmd.Flags().Uint64(server.FlagPruningInterval, 10,
 		`Height interval at which pruned heights are removed from disk (ignored if pruning is not 'custom'), 
 		this is not used by this command but kept for compatibility with the complete pruning options`)
+	cmd.Flags().Bool(server.FlagStateStorage, false, "Also prune the state storage of the application down to the recent heights")
+	cmd.Flags().Bool(FlagCompact, true, "Compact the databases after pruning to reclaim the disk space of the heights pruned")
 
 	return cmd
 }
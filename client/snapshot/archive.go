@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"cosmossdk.io/store/snapshots"
+	snapshottypes "cosmossdk.io/store/snapshots/types"
+)
+
+// The archive of a snapshot is a tar file holding the Protobuf-serialized
+// snapshot metadata, followed by the chunks in order, named by their index.
+const archiveMetadataName = "snapshot"
+
+// writeArchive writes the snapshot at height and format of store to w.
+func writeArchive(w io.Writer, store *snapshots.Store, height uint64, format uint32) error {
+	snapshot, chunks, err := store.Load(height, format)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("snapshot at height %d format %d does not exist", height, format)
+	}
+	defer snapshots.DrainChunks(chunks)
+
+	bz, err := snapshot.Marshal()
+	if err != nil {
+		return err
+	}
+
+	tarWriter := tar.NewWriter(w)
+	if err := writeArchiveEntry(tarWriter, archiveMetadataName, bytes.NewReader(bz), int64(len(bz))); err != nil {
+		return err
+	}
+
+	var index uint32
+	for chunk := range chunks {
+		// the chunks are buffered to know their size, they are at most 10MB
+		bz, err := io.ReadAll(chunk)
+		chunk.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read chunk %d: %w", index, err)
+		}
+
+		if err := writeArchiveEntry(tarWriter, strconv.FormatUint(uint64(index), 10), bytes.NewReader(bz), int64(len(bz))); err != nil {
+			return err
+		}
+		index++
+	}
+	if index != snapshot.Chunks {
+		return fmt.Errorf("snapshot has %d chunks, but %d were read", snapshot.Chunks, index)
+	}
+
+	return tarWriter.Close()
+}
+
+func writeArchiveEntry(tarWriter *tar.Writer, name string, r io.Reader, size int64) error {
+	if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: size}); err != nil {
+		return fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+	if _, err := io.Copy(tarWriter, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// readArchive saves the snapshot of the archive read from r into store. The
+// saved snapshot is deleted if its hash does not match the one of the archive.
+func readArchive(r io.Reader, store *snapshots.Store) (*snapshottypes.Snapshot, error) {
+	tarReader := tar.NewReader(r)
+
+	header, err := tarReader.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot metadata: %w", err)
+	}
+	if header.Name != archiveMetadataName {
+		return nil, fmt.Errorf("invalid archive, expected the snapshot metadata, got %s", header.Name)
+	}
+	bz, err := io.ReadAll(tarReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot metadata: %w", err)
+	}
+	var snapshot snapshottypes.Snapshot
+	if err := snapshot.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("invalid snapshot metadata: %w", err)
+	}
+
+	// the chunks are streamed to the store one at a time
+	chunks := make(chan io.ReadCloser)
+	go func() {
+		defer close(chunks)
+
+		for index := uint32(0); index < snapshot.Chunks; index++ {
+			pr, pw := io.Pipe()
+			chunks <- pr
+
+			header, err := tarReader.Next()
+			switch {
+			case errors.Is(err, io.EOF):
+				err = io.ErrUnexpectedEOF
+			case err == nil && header.Name != strconv.FormatUint(uint64(index), 10):
+				err = fmt.Errorf("invalid archive, expected chunk %d, got %s", index, header.Name)
+			case err == nil:
+				_, err = io.Copy(pw, tarReader)
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			pw.Close()
+		}
+	}()
+
+	saved, err := store.Save(snapshot.Height, snapshot.Format, chunks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	if saved.Chunks != snapshot.Chunks || !bytes.Equal(saved.Hash, snapshot.Hash) {
+		if err := store.Delete(saved.Height, saved.Format); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid archive, the hash of the saved snapshot %X does not match %X", saved.Hash, snapshot.Hash)
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,85 @@
+package snapshot
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/snapshots"
+)
+
+func newSnapshotStore(t *testing.T) *snapshots.Store {
+	t.Helper()
+
+	store, err := snapshots.NewStore(dbm.NewMemDB(), t.TempDir())
+	require.NoError(t, err)
+	return store
+}
+
+func makeChunks(chunks ...[]byte) <-chan io.ReadCloser {
+	ch := make(chan io.ReadCloser, len(chunks))
+	for _, chunk := range chunks {
+		ch <- io.NopCloser(bytes.NewReader(chunk))
+	}
+	close(ch)
+	return ch
+}
+
+func TestArchive(t *testing.T) {
+	source := newSnapshotStore(t)
+	snapshot, err := source.Save(3, 4, makeChunks([]byte{1, 2, 3}, []byte{4, 5}, []byte{6}))
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, source, 3, 4))
+	require.Error(t, writeArchive(io.Discard, source, 2, 4))
+
+	target := newSnapshotStore(t)
+	loaded, err := readArchive(bytes.NewReader(archive.Bytes()), target)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, loaded)
+
+	_, chunks, err := target.Load(3, 4)
+	require.NoError(t, err)
+	var bodies [][]byte
+	for chunk := range chunks {
+		body, err := io.ReadAll(chunk)
+		require.NoError(t, err)
+		bodies = append(bodies, body)
+	}
+	require.Equal(t, [][]byte{{1, 2, 3}, {4, 5}, {6}}, bodies)
+
+	// the snapshot already exists
+	_, err = readArchive(bytes.NewReader(archive.Bytes()), target)
+	require.Error(t, err)
+}
+
+func TestArchiveCorrupted(t *testing.T) {
+	source := newSnapshotStore(t)
+	_, err := source.Save(3, 4, makeChunks([]byte{1, 2, 3}, []byte{4, 5, 6}))
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, source, 3, 4))
+
+	// a chunk is modified, the snapshot hash does not match
+	bz := archive.Bytes()
+	i := bytes.Index(bz, []byte{4, 5, 6})
+	require.Positive(t, i)
+	bz[i] = 7
+
+	target := newSnapshotStore(t)
+	_, err = readArchive(bytes.NewReader(bz), target)
+	require.ErrorContains(t, err, "does not match")
+
+	snapshot, err := target.Get(3, 4)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+
+	// a truncated archive
+	_, err = readArchive(bytes.NewReader(archive.Bytes()[:1024]), target)
+	require.Error(t, err)
+}
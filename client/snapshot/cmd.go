@@ -0,0 +1,28 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+)
+
+// Cmd returns the snapshots group command, managing the local snapshots of a
+// node offline.
+func Cmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "Manage local snapshots",
+		Long: `Manage the local state sync snapshots of the node, which can be dumped to an archive file,
+loaded into the snapshot store of another node and restored without state sync.`,
+	}
+
+	cmd.AddCommand(
+		ListSnapshotsCmd(),
+		ExportSnapshotCmd(appCreator),
+		RestoreSnapshotCmd(appCreator),
+		DumpArchiveCmd(),
+		LoadArchiveCmd(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/server"
+)
+
+const flagOutput = "output"
+
+// DumpArchiveCmd returns a command writing a local snapshot to an archive file.
+func DumpArchiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump <height> <format>",
+		Short: "Dump a local snapshot to an archive file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := server.GetServerContextFromCmd(cmd)
+			height, format, err := parseSnapshotID(args)
+			if err != nil {
+				return err
+			}
+
+			output, err := cmd.Flags().GetString(flagOutput)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = fmt.Sprintf("%d-%d.tar", height, format)
+			}
+
+			snapshotStore, err := server.GetSnapshotStore(ctx.Viper)
+			if err != nil {
+				return err
+			}
+
+			fp, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			if err := writeArchive(fp, snapshotStore, height, format); err != nil {
+				fp.Close()
+				os.Remove(output)
+				return fmt.Errorf("failed to dump snapshot: %w", err)
+			}
+			if err := fp.Close(); err != nil {
+				return err
+			}
+
+			cmd.Printf("Snapshot dumped to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(flagOutput, "o", "", "Output file, <height>-<format>.tar by default")
+	return cmd
+}
@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+)
+
+const flagHeight = "height"
+
+// ExportSnapshotCmd returns a command taking a snapshot of the application
+// state into the local snapshot store.
+func ExportSnapshotCmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the application state to the local snapshot store",
+		Long: `Take a snapshot of the application state into the local snapshot store, at the latest
+height by default. The node must be stopped.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			ctx := server.GetServerContextFromCmd(cmd)
+			height, err := cmd.Flags().GetInt64(flagHeight)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(ctx.Config.RootDir, server.GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			app := appCreator(ctx.Logger, db, nil, ctx.Viper)
+			defer func() {
+				if closeErr := app.Close(); closeErr != nil && err == nil {
+					err = closeErr
+				}
+			}()
+			if height == 0 {
+				height = app.CommitMultiStore().LastCommitID().Version
+			}
+			if height <= 0 {
+				return fmt.Errorf("the application has no state to export")
+			}
+
+			cmd.Printf("Exporting snapshot at height %d\n", height)
+			snapshot, err := app.SnapshotManager().Create(uint64(height))
+			if err != nil {
+				return fmt.Errorf("failed to create snapshot: %w", err)
+			}
+
+			cmd.Printf("Snapshot created at height %d, format %d, chunks %d\n", snapshot.Height, snapshot.Format, snapshot.Chunks)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagHeight, 0, "Height to export, the latest height by default")
+	return cmd
+}
@@ -0,0 +1,43 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/server"
+)
+
+// LoadArchiveCmd returns a command saving the snapshot of an archive file into
+// the local snapshot store.
+func LoadArchiveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "load <archive-file>",
+		Short: "Load a snapshot archive file into the local snapshot store",
+		Long: `Load a snapshot archive file, written by the dump command, into the local snapshot store.
+The snapshot can then be restored with the restore command.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := server.GetServerContextFromCmd(cmd)
+			snapshotStore, err := server.GetSnapshotStore(ctx.Viper)
+			if err != nil {
+				return err
+			}
+
+			fp, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer fp.Close()
+
+			snapshot, err := readArchive(fp, snapshotStore)
+			if err != nil {
+				return fmt.Errorf("failed to load archive: %w", err)
+			}
+
+			cmd.Printf("Snapshot loaded at height %d, format %d, chunks %d\n", snapshot.Height, snapshot.Format, snapshot.Chunks)
+			return nil
+		},
+	}
+}
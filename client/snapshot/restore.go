@@ -0,0 +1,70 @@
+package snapshot
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+)
+
+// RestoreSnapshotCmd returns a command restoring the application state from a
+// local snapshot.
+func RestoreSnapshotCmd(appCreator servertypes.AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <height> <format>",
+		Short: "Restore the application state from a local snapshot",
+		Long: `Restore the application state from a snapshot of the local snapshot store, without state sync.
+The application state must be empty, e.g. in a freshly initialized home directory.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx := server.GetServerContextFromCmd(cmd)
+			height, format, err := parseSnapshotID(args)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(ctx.Config.RootDir, server.GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			app := appCreator(ctx.Logger, db, nil, ctx.Viper)
+			defer func() {
+				if closeErr := app.Close(); closeErr != nil && err == nil {
+					err = closeErr
+				}
+			}()
+			if err := app.SnapshotManager().RestoreLocalSnapshot(height, format); err != nil {
+				return fmt.Errorf("failed to restore snapshot: %w", err)
+			}
+
+			cmd.Printf("Restored application state at height %d\n", height)
+			return nil
+		},
+	}
+}
+
+// parseSnapshotID parses the height and format arguments of a snapshot.
+func parseSnapshotID(args []string) (uint64, uint32, error) {
+	height, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid height %q: %w", args[0], err)
+	}
+	format, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid format %q: %w", args[1], err)
+	}
+
+	return height, uint32(format), nil
+}
+
+func openDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
+	dataDir := filepath.Join(rootDir, "data")
+	return dbm.NewDB("application", backendType, dataDir)
+}
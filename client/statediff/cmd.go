@@ -0,0 +1,268 @@
+package statediff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"cosmossdk.io/log"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+const (
+	FlagOtherHome = "other-home"
+	FlagStores    = "stores"
+	FlagLimit     = "limit"
+)
+
+// simulationApp is an Application registering the StoreDecoders of its
+// modules in its SimulationManager.
+type simulationApp interface {
+	SimulationManager() *module.SimulationManager
+}
+
+// schemaApp is an Application exposing the collections schemas of its modules,
+// used to decode the values of the stores without a simulation store decoder.
+type schemaApp interface {
+	SchemaRegistry() (*runtime.SchemaRegistry, error)
+}
+
+// Cmd returns a command comparing the key/values of the stores of the
+// application state at two heights, or of two nodes.
+func Cmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff <height> [other-height]",
+		Short: "Print the keys which differ between the application states of two heights or of two nodes",
+		Long: `Compare the application state at height with the one at other-height, and print the keys added,
+removed and changed in each store, e.g. to find the cause of an app hash mismatch. The values are decoded by the
+store decoders of the simulation of the modules or else by the collections schemas of the modules, if any.
+
+With --other-home, the state at height of the node home is compared with the state of the node other-home, at
+other-height if set or else at the same height. Only the stores whose commit hashes differ are compared.
+
+The states compared must not be pruned, and the nodes must be stopped.`,
+		Example: `state-diff 100 101
+state-diff 100 --other-home ~/.simapp-backup --stores bank,staking`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := server.GetServerContextFromCmd(cmd)
+			heightA, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %q: %w", args[0], err)
+			}
+			heightB := heightA
+			if len(args) == 2 {
+				if heightB, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+					return fmt.Errorf("invalid height %q: %w", args[1], err)
+				}
+			}
+
+			otherHome, err := cmd.Flags().GetString(FlagOtherHome)
+			if err != nil {
+				return err
+			}
+			if otherHome == "" && heightA == heightB {
+				return errors.New("either another height or another home must be provided")
+			}
+			stores, err := cmd.Flags().GetStringSlice(FlagStores)
+			if err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetInt(FlagLimit)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(flags.FlagOutput)
+			if err != nil {
+				return err
+			}
+
+			backend := server.GetAppDBBackend(ctx.Viper)
+			db, err := openDB(ctx.Config.RootDir, backend)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			ctx.Viper.Set(server.FlagInterBlockCache, false)
+			app := appCreator(ctx.Logger, db, nil, ctx.Viper)
+			rmsA, ok := app.CommitMultiStore().(*rootmulti.Store)
+			if !ok {
+				return errors.New("currently only support the comparison of rootmulti.Store type")
+			}
+			decoders := make(simtypes.StoreDecoderRegistry)
+			if app, ok := app.(simulationApp); ok && app.SimulationManager() != nil {
+				for name, decoder := range app.SimulationManager().StoreDecoders {
+					decoders[name] = decoder
+				}
+			}
+			if app, ok := app.(schemaApp); ok {
+				registry, err := app.SchemaRegistry()
+				if err != nil {
+					return err
+				}
+				registry.RegisterStoreDecoders(decoders)
+			}
+
+			keys, err := iavlStoreKeys(rmsA, stores)
+			if err != nil {
+				return err
+			}
+
+			rmsB := rmsA
+			if otherHome != "" {
+				otherDB, err := openDB(otherHome, backend)
+				if err != nil {
+					return err
+				}
+				defer otherDB.Close()
+
+				if rmsB, err = loadStore(otherDB, keys, ctx.Logger); err != nil {
+					return fmt.Errorf("failed to load the application state of %s: %w", otherHome, err)
+				}
+			}
+
+			report, err := diffStates(keys, rmsA, heightA, rmsB, heightB, decoders, limit)
+			if err != nil {
+				return err
+			}
+
+			if output == flags.OutputFormatJSON {
+				bz, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bz))
+				return nil
+			}
+
+			return report.WriteText(cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().String(FlagOtherHome, "", "The home directory of the other node to compare the state with")
+	cmd.Flags().StringSlice(FlagStores, nil, "The names of the stores to compare, all the IAVL stores if empty")
+	cmd.Flags().Int(FlagLimit, 0, "The maximum number of keys printed per store, all of them if 0")
+	cmd.Flags().StringP(flags.FlagOutput, "o", "text", "Output format (text|json)")
+	return cmd
+}
+
+// iavlStoreKeys returns the keys of the IAVL stores of rms, restricted to the
+// stores named names if not empty.
+func iavlStoreKeys(rms *rootmulti.Store, names []string) ([]storetypes.StoreKey, error) {
+	keysByName := rms.StoreKeysByName()
+	if len(names) == 0 {
+		for name := range keysByName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	keys := make([]storetypes.StoreKey, 0, len(names))
+	for _, name := range names {
+		key, ok := keysByName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown store %s", name)
+		}
+		if rms.GetCommitKVStore(key).GetStoreType() == storetypes.StoreTypeIAVL {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// loadStore loads the latest version of the root multistore of db, with the
+// IAVL stores of keys mounted. The IAVL fast nodes are not upgraded, so that
+// db is left unchanged.
+func loadStore(db dbm.DB, keys []storetypes.StoreKey, logger log.Logger) (*rootmulti.Store, error) {
+	rms := rootmulti.NewStore(db, logger, metrics.NewNoOpMetrics())
+	rms.SetIAVLDisableFastNode(true)
+	for _, key := range keys {
+		rms.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+	}
+
+	return rms, rms.LoadLatestVersion()
+}
+
+// diffStates compares the stores of keys of rmsA at heightA and of rmsB at
+// heightB. The stores with the same commit hashes are not compared.
+func diffStates(
+	keys []storetypes.StoreKey,
+	rmsA *rootmulti.Store, heightA int64,
+	rmsB *rootmulti.Store, heightB int64,
+	decoders simtypes.StoreDecoderRegistry, limit int,
+) (*Report, error) {
+	infoA, err := rmsA.GetCommitInfo(heightA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the commit info of height %d: %w", heightA, err)
+	}
+	infoB, err := rmsB.GetCommitInfo(heightB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the commit info of height %d: %w", heightB, err)
+	}
+	cmsA, err := rmsA.CacheMultiStoreWithVersion(heightA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the state at height %d: %w", heightA, err)
+	}
+	cmsB, err := rmsB.CacheMultiStoreWithVersion(heightB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the state at height %d: %w", heightB, err)
+	}
+
+	report := &Report{
+		AppHashA: fmt.Sprintf("%X", infoA.Hash()),
+		AppHashB: fmt.Sprintf("%X", infoB.Hash()),
+	}
+	for _, key := range keys {
+		hashA, hashB := storeHash(infoA, key.Name()), storeHash(infoB, key.Name())
+		if hashA != "" && hashA == hashB {
+			report.Identical = append(report.Identical, key.Name())
+			continue
+		}
+
+		diff := diffStore(key.Name(), cmsA.GetKVStore(key), cmsB.GetKVStore(key), decoders, limit)
+		// the hashes of the IAVL trees also depend on the versions of their
+		// nodes, so the stores may have the same key/values
+		if diff.Added+diff.Removed+diff.Changed == 0 {
+			report.Identical = append(report.Identical, key.Name())
+			continue
+		}
+		diff.HashA, diff.HashB = hashA, hashB
+		report.Stores = append(report.Stores, diff)
+	}
+
+	return report, nil
+}
+
+// storeHash returns the commit hash of the store name in info, or an empty
+// string if the store was not committed.
+func storeHash(info *storetypes.CommitInfo, name string) string {
+	for _, storeInfo := range info.StoreInfos {
+		if storeInfo.Name == name {
+			return fmt.Sprintf("%X", storeInfo.CommitId.Hash)
+		}
+	}
+
+	return ""
+}
+
+func openDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
+	dataDir := filepath.Join(rootDir, "data")
+	return dbm.NewDB("application", backendType, dataDir)
+}
@@ -0,0 +1,168 @@
+package statediff
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+// The statuses of a key which differs between the two states.
+const (
+	StatusAdded   = "added"
+	StatusRemoved = "removed"
+	StatusChanged = "changed"
+)
+
+// Report is the difference between the key/values of the stores of two
+// states, A and B.
+type Report struct {
+	AppHashA string `json:"app_hash_a"`
+	AppHashB string `json:"app_hash_b"`
+	// Stores are the stores which differ.
+	Stores []StoreDiff `json:"stores"`
+	// Identical are the names of the stores with the same key/values.
+	Identical []string `json:"identical"`
+}
+
+// StoreDiff is the difference between the key/values of a store of the two
+// states.
+type StoreDiff struct {
+	Name    string `json:"name"`
+	HashA   string `json:"hash_a"`
+	HashB   string `json:"hash_b"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+	Changed int    `json:"changed"`
+	// Keys are the keys which differ, up to the limit of keys reported.
+	Keys []KeyDiff `json:"keys"`
+}
+
+// KeyDiff is a key of a store which differs between the two states, with its
+// values hex encoded. The value of a key absent from a state is empty.
+type KeyDiff struct {
+	Status string `json:"status"`
+	Key    string `json:"key"`
+	ValueA string `json:"value_a,omitempty"`
+	ValueB string `json:"value_b,omitempty"`
+	// Decoded are the values decoded by the StoreDecoder of the store, if any.
+	Decoded string `json:"decoded,omitempty"`
+}
+
+// diffStore compares the key/values of a and b, the store named name of the
+// two states, and reports up to limit keys which differ, or all of them if
+// limit is 0. The values are decoded by the decoder of the store in decoders,
+// if any.
+func diffStore(name string, a, b storetypes.KVStore, decoders simtypes.StoreDecoderRegistry, limit int) StoreDiff {
+	diff := StoreDiff{Name: name}
+
+	iterA := a.Iterator(nil, nil)
+	defer iterA.Close()
+	iterB := b.Iterator(nil, nil)
+	defer iterB.Close()
+
+	report := func(status string, key, valueA, valueB []byte) {
+		switch status {
+		case StatusAdded:
+			diff.Added++
+		case StatusRemoved:
+			diff.Removed++
+		case StatusChanged:
+			diff.Changed++
+		}
+		if limit > 0 && len(diff.Keys) >= limit {
+			return
+		}
+
+		diff.Keys = append(diff.Keys, KeyDiff{
+			Status:  status,
+			Key:     hex.EncodeToString(key),
+			ValueA:  hex.EncodeToString(valueA),
+			ValueB:  hex.EncodeToString(valueB),
+			Decoded: decode(decoders[name], key, valueA, valueB),
+		})
+	}
+
+	// both iterators are sorted by key, so they are merged
+	for iterA.Valid() || iterB.Valid() {
+		var cmp int
+		switch {
+		case !iterA.Valid():
+			cmp = 1
+		case !iterB.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(iterA.Key(), iterB.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			report(StatusRemoved, iterA.Key(), iterA.Value(), nil)
+			iterA.Next()
+		case cmp > 0:
+			report(StatusAdded, iterB.Key(), nil, iterB.Value())
+			iterB.Next()
+		default:
+			if !bytes.Equal(iterA.Value(), iterB.Value()) {
+				report(StatusChanged, iterA.Key(), iterA.Value(), iterB.Value())
+			}
+			iterA.Next()
+			iterB.Next()
+		}
+	}
+
+	return diff
+}
+
+// decode returns the values of key decoded by decoder, or an empty string if
+// there is no decoder or if it fails, e.g. on the keys it does not expect.
+func decode(decoder func(kvA, kvB kv.Pair) string, key, valueA, valueB []byte) (decoded string) {
+	if decoder == nil {
+		return ""
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			decoded = ""
+		}
+	}()
+
+	return decoder(kv.Pair{Key: key, Value: valueA}, kv.Pair{Key: key, Value: valueB})
+}
+
+// WriteText writes the report to w as text.
+func (r *Report) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "App hash A: %s\nApp hash B: %s\n", r.AppHashA, r.AppHashB)
+	fmt.Fprintf(bw, "%d stores differ, %d stores are identical\n", len(r.Stores), len(r.Identical))
+
+	for _, s := range r.Stores {
+		fmt.Fprintf(bw, "\nStore %s: %d added, %d removed, %d changed\n", s.Name, s.Added, s.Removed, s.Changed)
+		for _, k := range s.Keys {
+			fmt.Fprintf(bw, "  %-7s %s\n", k.Status, k.Key)
+			if k.Decoded != "" {
+				decoded := strings.ReplaceAll(strings.TrimRight(k.Decoded, "\n"), "\n", "\n    ")
+				fmt.Fprintf(bw, "    %s\n", decoded)
+				continue
+			}
+			if k.ValueA != "" {
+				fmt.Fprintf(bw, "    A: %s\n", k.ValueA)
+			}
+			if k.ValueB != "" {
+				fmt.Fprintf(bw, "    B: %s\n", k.ValueB)
+			}
+		}
+		if omitted := s.Added + s.Removed + s.Changed - len(s.Keys); omitted > 0 {
+			fmt.Fprintf(bw, "  ... %d more keys\n", omitted)
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,90 @@
+package statediff
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"cosmossdk.io/log"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func TestDiffStates(t *testing.T) {
+	bankKey, authKey := storetypes.NewKVStoreKey("bank"), storetypes.NewKVStoreKey("auth")
+	keys := []storetypes.StoreKey{authKey, bankKey}
+
+	db := dbm.NewMemDB()
+	rms, err := loadStore(db, keys, log.NewNopLogger())
+	require.NoError(t, err)
+
+	bank, auth := rms.GetKVStore(bankKey), rms.GetKVStore(authKey)
+	bank.Set([]byte("a"), []byte("1"))
+	bank.Set([]byte("b"), []byte("2"))
+	bank.Set([]byte("c"), []byte("3"))
+	auth.Set([]byte("x"), []byte("1"))
+	rms.Commit()
+
+	bank.Delete([]byte("a"))
+	bank.Set([]byte("b"), []byte("4"))
+	bank.Set([]byte("d"), []byte("5"))
+	rms.Commit()
+
+	decoders := simtypes.StoreDecoderRegistry{
+		"bank": func(kvA, kvB kv.Pair) string {
+			if bytes.Equal(kvA.Key, []byte("d")) {
+				panic("unexpected key")
+			}
+			return fmt.Sprintf("%s => %s", kvA.Value, kvB.Value)
+		},
+	}
+
+	report, err := diffStates(keys, rms, 1, rms, 2, decoders, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"auth"}, report.Identical)
+	require.Len(t, report.Stores, 1)
+
+	diff := report.Stores[0]
+	require.Equal(t, "bank", diff.Name)
+	require.NotEqual(t, diff.HashA, diff.HashB)
+	require.Equal(t, 1, diff.Added)
+	require.Equal(t, 1, diff.Removed)
+	require.Equal(t, 1, diff.Changed)
+	require.Equal(t, []KeyDiff{
+		{Status: StatusRemoved, Key: "61", ValueA: "31", Decoded: "1 => "},
+		{Status: StatusChanged, Key: "62", ValueA: "32", ValueB: "34", Decoded: "2 => 4"},
+		// the keys the decoder fails to decode are not decoded
+		{Status: StatusAdded, Key: "64", ValueB: "35"},
+	}, diff.Keys)
+
+	// the states of two nodes are compared
+	otherDB := dbm.NewMemDB()
+	other, err := loadStore(otherDB, keys, log.NewNopLogger())
+	require.NoError(t, err)
+	other.GetKVStore(bankKey).Set([]byte("c"), []byte("3"))
+	other.Commit()
+	other.GetKVStore(bankKey).Set([]byte("b"), []byte("4"))
+	other.Commit()
+
+	report, err = diffStates(keys, rms, 2, other, 2, nil, 1)
+	require.NoError(t, err)
+	require.Empty(t, report.Identical)
+	require.Len(t, report.Stores, 2)
+	require.Equal(t, "auth", report.Stores[0].Name)
+	require.Equal(t, 1, report.Stores[0].Removed)
+	require.Equal(t, 1, report.Stores[1].Removed)
+	require.Len(t, report.Stores[1].Keys, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	require.Contains(t, buf.String(), "Store bank: 0 added, 1 removed, 0 changed")
+	require.Contains(t, buf.String(), "  removed 64\n    A: 35\n")
+
+	_, err = diffStates(keys, rms, 3, other, 2, nil, 0)
+	require.Error(t, err)
+}
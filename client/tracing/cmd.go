@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	"github.com/cometbft/cometbft/node"
+	cmtstate "github.com/cometbft/cometbft/proto/tendermint/state"
+	sm "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/store/tracekv"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+)
+
+// tracedApplication is an Application whose accesses to the stores can be
+// traced, e.g. a BaseApp.
+type tracedApplication interface {
+	servertypes.Application
+	SetAccessTracer(tracer storetypes.AccessTracer)
+}
+
+// Cmd returns a command replaying a block of the local block store and
+// reporting the accesses of its transactions to the stores.
+func Cmd(appCreator servertypes.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace-block <height>",
+		Short: "Replay a block and report the accesses of its transactions to the stores",
+		Long: `Replay a block of the local block store on the application state of the previous height, and report
+the reads, writes and iterations made by each transaction and message of the block to each store, with their
+sizes in bytes and the gas charged for them, as well as the keys written by a transaction and accessed by another one.
+
+The application state of the previous height must not be pruned from the IAVL stores. The block is not
+committed and the state storage is not loaded, so the node state is left unchanged, but the node must be stopped.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := server.GetServerContextFromCmd(cmd)
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %q: %w", args[0], err)
+			}
+			output, err := cmd.Flags().GetString(flags.FlagOutput)
+			if err != nil {
+				return err
+			}
+
+			block, beginBlock, expected, err := loadBlock(ctx, height)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(ctx.Config.RootDir, server.GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			// the block replayed is not streamed
+			ctx.Viper.Set(baseapp.StreamingTomlKey, map[string]interface{}{})
+			ctx.Viper.Set(fmt.Sprintf("%s.%s.%s",
+				baseapp.StreamingTomlKey, baseapp.StreamingFileTomlKey, baseapp.StreamingFileDirTomlKey), "")
+			ctx.Viper.Set(flags.FlagChainID, block.ChainID)
+			ctx.Viper.Set(server.FlagInterBlockCache, false)
+			// the previous height is loaded from the IAVL stores, the state storage
+			// is not loaded to leave the versions after it untouched
+			ctx.Viper.Set(server.FlagStateStorage, false)
+			app, ok := appCreator(ctx.Logger, db, nil, ctx.Viper).(tracedApplication)
+			if !ok {
+				return errors.New("the application does not support the tracing of the accesses to the stores")
+			}
+			if err := app.CommitMultiStore().LoadVersion(height - 1); err != nil {
+				return fmt.Errorf("failed to load the application state at height %d: %w", height-1, err)
+			}
+
+			recorder := tracekv.NewAccessRecorder()
+			app.SetAccessTracer(recorder)
+
+			app.BeginBlock(beginBlock)
+			txs := make([]txResult, len(block.Txs))
+			for i, tx := range block.Txs {
+				txs[i] = txResult{
+					hash: fmt.Sprintf("%X", tmhash.Sum(tx)),
+					res:  app.DeliverTx(abci.RequestDeliverTx{Tx: tx}),
+				}
+				if expected != nil && !sameResult(txs[i].res, expected.DeliverTxs[i]) {
+					cmd.PrintErrf("WARNING: the replay of the tx %d differs from its execution by the node\n", i)
+				}
+			}
+			app.EndBlock(abci.RequestEndBlock{Height: height})
+
+			report := newReport(height, txs, recorder.Accesses())
+			if output == flags.OutputFormatJSON {
+				bz, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bz))
+				return nil
+			}
+
+			return report.WriteText(cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringP(flags.FlagOutput, "o", "text", "Output format (text|json)")
+	return cmd
+}
+
+// loadBlock loads the block of the given height from the block store of the
+// node, along with the BeginBlock request it was executed with and its ABCI
+// responses, if they were kept.
+func loadBlock(
+	ctx *server.Context, height int64,
+) (*cmttypes.Block, abci.RequestBeginBlock, *cmtstate.ABCIResponses, error) {
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: ctx.Config})
+	if err != nil {
+		return nil, abci.RequestBeginBlock{}, nil, err
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: ctx.Config})
+	if err != nil {
+		return nil, abci.RequestBeginBlock{}, nil, err
+	}
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	defer stateStore.Close()
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, abci.RequestBeginBlock{}, nil, err
+	}
+	if height <= state.InitialHeight {
+		return nil, abci.RequestBeginBlock{}, nil, fmt.Errorf("cannot replay the block %d, the initial height is %d", height, state.InitialHeight)
+	}
+
+	block := store.NewBlockStore(blockStoreDB).LoadBlock(height)
+	if block == nil {
+		return nil, abci.RequestBeginBlock{}, nil, fmt.Errorf("block %d not found in the block store", height)
+	}
+
+	lastValidators, err := stateStore.LoadValidators(height - 1)
+	if err != nil {
+		return nil, abci.RequestBeginBlock{}, nil, fmt.Errorf("failed to load the validators of height %d: %w", height-1, err)
+	}
+	if block.LastCommit.Size() != len(lastValidators.Validators) {
+		return nil, abci.RequestBeginBlock{}, nil, fmt.Errorf(
+			"the last commit of block %d has %d signatures for %d validators",
+			height, block.LastCommit.Size(), len(lastValidators.Validators))
+	}
+	votes := make([]abci.VoteInfo, len(lastValidators.Validators))
+	for i, val := range lastValidators.Validators {
+		votes[i] = abci.VoteInfo{
+			Validator:       cmttypes.TM2PB.Validator(val),
+			SignedLastBlock: block.LastCommit.Signatures[i].BlockIDFlag != cmttypes.BlockIDFlagAbsent,
+		}
+	}
+
+	req := abci.RequestBeginBlock{
+		Hash:                block.Hash(),
+		Header:              *block.Header.ToProto(),
+		LastCommitInfo:      abci.CommitInfo{Round: block.LastCommit.Round, Votes: votes},
+		ByzantineValidators: block.Evidence.Evidence.ToABCI(),
+	}
+
+	// the responses are compared to the ones of the replay when available
+	responses, err := stateStore.LoadABCIResponses(height)
+	if err != nil || len(responses.DeliverTxs) != len(block.Txs) {
+		responses = nil
+	}
+
+	return block, req, responses, nil
+}
+
+// sameResult returns true if the replay of a transaction returned the result
+// of its execution by the node.
+func sameResult(replayed abci.ResponseDeliverTx, executed *abci.ResponseDeliverTx) bool {
+	return executed != nil && replayed.Code == executed.Code && replayed.GasUsed == executed.GasUsed
+}
+
+func openDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
+	dataDir := filepath.Join(rootDir, "data")
+	return dbm.NewDB("application", backendType, dataDir)
+}
@@ -0,0 +1,281 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+// Report is the report of the accesses to the stores made by a block.
+type Report struct {
+	Height     int64           `json:"height"`
+	BeginBlock []StoreAccesses `json:"begin_block"`
+	Txs        []TxReport      `json:"txs"`
+	EndBlock   []StoreAccesses `json:"end_block"`
+	// Conflicts are the keys written by a transaction of the block and
+	// accessed by another one.
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// TxReport is the report of the accesses made by a transaction.
+type TxReport struct {
+	Index     int    `json:"index"`
+	Hash      string `json:"hash"`
+	Code      uint32 `json:"code"`
+	GasWanted int64  `json:"gas_wanted"`
+	GasUsed   int64  `json:"gas_used"`
+	// Stores are the accesses of the transaction per message and store.
+	Stores []StoreAccesses `json:"stores"`
+}
+
+// StoreAccesses sums up the accesses made to a store, by a message when
+// MsgIndex is set.
+type StoreAccesses struct {
+	MsgIndex *int   `json:"msg_index,omitempty"`
+	StoreKey string `json:"store_key"`
+	// Reads counts the Get and Has calls, and Iterated the keys reached by
+	// iterators.
+	Reads        int    `json:"reads"`
+	Writes       int    `json:"writes"`
+	Deletes      int    `json:"deletes"`
+	Iterated     int    `json:"iterated"`
+	BytesRead    int    `json:"bytes_read"`
+	BytesWritten int    `json:"bytes_written"`
+	Gas          uint64 `json:"gas"`
+}
+
+// Conflict is a key written by a transaction and accessed by another one, in
+// which case the transactions cannot be executed independently.
+type Conflict struct {
+	StoreKey string `json:"store_key"`
+	Key      string `json:"key"`
+	// Writers and Readers are the indexes of the transactions writing and
+	// only reading the key.
+	Writers []int `json:"writers"`
+	Readers []int `json:"readers"`
+}
+
+// txResult is a transaction replayed.
+type txResult struct {
+	hash string
+	res  abci.ResponseDeliverTx
+}
+
+// newReport returns the report of the accesses traced while executing the
+// block of the given height, made of the transactions txs.
+func newReport(height int64, txs []txResult, accesses []storetypes.Access) *Report {
+	report := &Report{Height: height}
+
+	txIndexes := make(map[string]int, len(txs))
+	txStores := make([]map[storeAccessesKey]*StoreAccesses, len(txs))
+	for i, tx := range txs {
+		txIndexes[tx.hash] = i
+		txStores[i] = make(map[storeAccessesKey]*StoreAccesses)
+	}
+	beginBlock := make(map[storeAccessesKey]*StoreAccesses)
+	endBlock := make(map[storeAccessesKey]*StoreAccesses)
+
+	// the transactions accessing each key, and whether they wrote it
+	type keyAccess struct{ storeKey, key string }
+	keyTxs := make(map[keyAccess]map[int]bool)
+	var keys []keyAccess
+
+	for _, access := range accesses {
+		var stores map[storeAccessesKey]*StoreAccesses
+		txIndex := -1
+		switch access.Context[baseapp.TraceContextPhaseKey] {
+		case baseapp.AccessPhaseBeginBlock:
+			stores = beginBlock
+		case baseapp.AccessPhaseEndBlock:
+			stores = endBlock
+		default:
+			hash, _ := access.Context[baseapp.TraceContextTxHashKey].(string)
+			i, ok := txIndexes[hash]
+			if !ok {
+				continue
+			}
+			stores, txIndex = txStores[i], i
+		}
+
+		msgIndex := -1
+		if i, ok := access.Context[baseapp.TraceContextMsgIndexKey].(int); ok {
+			msgIndex = i
+		}
+		addAccess(stores, storeAccessesKey{msgIndex: msgIndex, storeKey: access.StoreKey}, access)
+
+		if txIndex < 0 || access.Key == nil {
+			continue
+		}
+		k := keyAccess{storeKey: access.StoreKey, key: string(access.Key)}
+		if keyTxs[k] == nil {
+			keyTxs[k] = make(map[int]bool)
+			keys = append(keys, k)
+		}
+		write := access.Operation == storetypes.AccessWrite || access.Operation == storetypes.AccessDelete
+		keyTxs[k][txIndex] = keyTxs[k][txIndex] || write
+	}
+
+	report.BeginBlock = sortedStoreAccesses(beginBlock)
+	report.EndBlock = sortedStoreAccesses(endBlock)
+	for i, tx := range txs {
+		report.Txs = append(report.Txs, TxReport{
+			Index:     i,
+			Hash:      tx.hash,
+			Code:      tx.res.Code,
+			GasWanted: tx.res.GasWanted,
+			GasUsed:   tx.res.GasUsed,
+			Stores:    sortedStoreAccesses(txStores[i]),
+		})
+	}
+
+	for _, k := range keys {
+		txWrites := keyTxs[k]
+		if len(txWrites) < 2 {
+			continue
+		}
+
+		conflict := Conflict{StoreKey: k.storeKey, Key: hex.EncodeToString([]byte(k.key))}
+		for i := range txs {
+			write, ok := txWrites[i]
+			switch {
+			case !ok:
+			case write:
+				conflict.Writers = append(conflict.Writers, i)
+			default:
+				conflict.Readers = append(conflict.Readers, i)
+			}
+		}
+		if len(conflict.Writers) > 0 {
+			report.Conflicts = append(report.Conflicts, conflict)
+		}
+	}
+	sort.SliceStable(report.Conflicts, func(i, j int) bool {
+		return report.Conflicts[i].StoreKey < report.Conflicts[j].StoreKey
+	})
+
+	return report
+}
+
+type storeAccessesKey struct {
+	msgIndex int
+	storeKey string
+}
+
+func addAccess(stores map[storeAccessesKey]*StoreAccesses, key storeAccessesKey, access storetypes.Access) {
+	s, ok := stores[key]
+	if !ok {
+		s = &StoreAccesses{StoreKey: key.storeKey}
+		if key.msgIndex >= 0 {
+			msgIndex := key.msgIndex
+			s.MsgIndex = &msgIndex
+		}
+		stores[key] = s
+	}
+
+	size := len(access.Key) + access.ValueSize
+	switch access.Operation {
+	case storetypes.AccessRead, storetypes.AccessHas:
+		s.Reads++
+		s.BytesRead += size
+	case storetypes.AccessIterKey:
+		if access.Key != nil {
+			s.Iterated++
+		}
+		s.BytesRead += size
+	case storetypes.AccessWrite:
+		s.Writes++
+		s.BytesWritten += size
+	case storetypes.AccessDelete:
+		s.Deletes++
+		s.BytesWritten += size
+	}
+	s.Gas += access.Gas
+}
+
+// sortedStoreAccesses returns the accesses of stores, those made outside of
+// the messages first, then by message index and store key.
+func sortedStoreAccesses(stores map[storeAccessesKey]*StoreAccesses) []StoreAccesses {
+	keys := make([]storeAccessesKey, 0, len(stores))
+	for key := range stores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].msgIndex != keys[j].msgIndex {
+			return keys[i].msgIndex < keys[j].msgIndex
+		}
+		return keys[i].storeKey < keys[j].storeKey
+	})
+
+	accesses := make([]StoreAccesses, 0, len(keys))
+	for _, key := range keys {
+		accesses = append(accesses, *stores[key])
+	}
+
+	return accesses
+}
+
+// WriteText writes the report to w as text tables.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Block %d: %d txs\n", r.Height, len(r.Txs))
+	writeStoreAccesses(tw, "BeginBlock", r.BeginBlock)
+	for _, tx := range r.Txs {
+		title := fmt.Sprintf("Tx %d %s: code %d, gas used %d of %d", tx.Index, tx.Hash, tx.Code, tx.GasUsed, tx.GasWanted)
+		writeStoreAccesses(tw, title, tx.Stores)
+	}
+	writeStoreAccesses(tw, "EndBlock", r.EndBlock)
+
+	fmt.Fprintf(tw, "\nConflicts: %d\n", len(r.Conflicts))
+	if len(r.Conflicts) > 0 {
+		fmt.Fprintln(tw, "  STORE\tKEY\tWRITERS\tREADERS\t")
+		for _, c := range r.Conflicts {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t\n", c.StoreKey, c.Key, formatIndexes(c.Writers), formatIndexes(c.Readers))
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeStoreAccesses(w io.Writer, title string, accesses []StoreAccesses) {
+	fmt.Fprintf(w, "\n%s\n", title)
+	if len(accesses) == 0 {
+		fmt.Fprintln(w, "  no accesses")
+		return
+	}
+
+	fmt.Fprintln(w, "  MSG\tSTORE\tREADS\tWRITES\tDELETES\tITERATED\tBYTES READ\tBYTES WRITTEN\tGAS\t")
+	for _, a := range accesses {
+		msg := "-"
+		if a.MsgIndex != nil {
+			msg = strconv.Itoa(*a.MsgIndex)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			msg, a.StoreKey, a.Reads, a.Writes, a.Deletes, a.Iterated, a.BytesRead, a.BytesWritten, a.Gas)
+	}
+}
+
+func formatIndexes(indexes []int) string {
+	if len(indexes) == 0 {
+		return "-"
+	}
+
+	s := ""
+	for i, index := range indexes {
+		if i > 0 {
+			s += ","
+		}
+		s += strconv.Itoa(index)
+	}
+
+	return s
+}
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"bytes"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+func txAccess(hash string, msgIndex int, storeKey string, op storetypes.AccessOperation, key string, valueSize int) storetypes.Access {
+	tc := storetypes.TraceContext{baseapp.TraceContextTxHashKey: hash}
+	if msgIndex >= 0 {
+		tc[baseapp.TraceContextMsgIndexKey] = msgIndex
+	}
+
+	return storetypes.Access{
+		Context:   tc,
+		StoreKey:  storeKey,
+		Operation: op,
+		Key:       []byte(key),
+		ValueSize: valueSize,
+		Gas:       10,
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	txs := []txResult{
+		{hash: "A", res: abci.ResponseDeliverTx{GasWanted: 100, GasUsed: 50}},
+		{hash: "B", res: abci.ResponseDeliverTx{Code: 5, GasWanted: 100, GasUsed: 80}},
+	}
+	accesses := []storetypes.Access{
+		{
+			Context:   storetypes.TraceContext{baseapp.TraceContextPhaseKey: baseapp.AccessPhaseBeginBlock},
+			StoreKey:  "mint",
+			Operation: storetypes.AccessRead,
+			Key:       []byte("m"),
+			ValueSize: 3,
+			Gas:       7,
+		},
+		txAccess("A", -1, "acc", storetypes.AccessRead, "a", 10),
+		txAccess("A", 0, "bank", storetypes.AccessWrite, "b", 4),
+		txAccess("A", 0, "bank", storetypes.AccessIterKey, "c", 2),
+		txAccess("A", 0, "bank", storetypes.AccessIterKey, "", 0),
+		txAccess("A", 1, "bank", storetypes.AccessDelete, "d", 0),
+		txAccess("B", -1, "acc", storetypes.AccessRead, "a", 10),
+		txAccess("B", 0, "bank", storetypes.AccessHas, "b", 0),
+		txAccess("B", 0, "bank", storetypes.AccessWrite, "d", 1),
+		// the accesses of unknown transactions are ignored
+		txAccess("C", 0, "bank", storetypes.AccessWrite, "b", 1),
+	}
+	// an exhausted iterator has no key
+	accesses[4].Key = nil
+
+	report := newReport(3, txs, accesses)
+	require.Equal(t, int64(3), report.Height)
+	require.Equal(t, []StoreAccesses{{StoreKey: "mint", Reads: 1, BytesRead: 4, Gas: 7}}, report.BeginBlock)
+	require.Empty(t, report.EndBlock)
+
+	msg0, msg1 := 0, 1
+	require.Len(t, report.Txs, 2)
+	require.Equal(t, TxReport{
+		Index:     0,
+		Hash:      "A",
+		GasWanted: 100,
+		GasUsed:   50,
+		Stores: []StoreAccesses{
+			{StoreKey: "acc", Reads: 1, BytesRead: 11, Gas: 10},
+			{MsgIndex: &msg0, StoreKey: "bank", Writes: 1, Iterated: 1, BytesRead: 3, BytesWritten: 5, Gas: 30},
+			{MsgIndex: &msg1, StoreKey: "bank", Deletes: 1, BytesWritten: 1, Gas: 10},
+		},
+	}, report.Txs[0])
+	require.Equal(t, uint32(5), report.Txs[1].Code)
+
+	// the key a is only read, and the key c is only accessed by a transaction
+	require.Equal(t, []Conflict{
+		{StoreKey: "bank", Key: "62", Writers: []int{0}, Readers: []int{1}},
+		{StoreKey: "bank", Key: "64", Writers: []int{0, 1}},
+	}, report.Conflicts)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	require.Contains(t, buf.String(), "Tx 1 B: code 5, gas used 80 of 100")
+	require.Contains(t, buf.String(), "Conflicts: 2")
+}
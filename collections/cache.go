@@ -0,0 +1,88 @@
+package collections
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+)
+
+// valueCache is a bounded least recently used cache of the decoded values of
+// a collection, by their prefixed key bytes. Each entry retains the bytes the
+// value was decoded from: it is only returned when the store of the context
+// holds the very same bytes. This scopes the cache to the store branch being
+// read, the values cached from a branch which is discarded are never returned
+// for its parent, and the reads of the store, which consume gas, are kept.
+type valueCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type valueCacheEntry[V any] struct {
+	key        string
+	valueBytes []byte
+	value      V
+}
+
+func newValueCache[V any](size int) *valueCache[V] {
+	if size <= 0 {
+		panic("value cache size must be positive")
+	}
+	return &valueCache[V]{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+// get returns the cached value of key if it was decoded from valueBytes.
+func (c *valueCache[V]) get(key, valueBytes []byte) (v V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[string(key)]
+	if !ok {
+		return v, false
+	}
+	entry := elem.Value.(*valueCacheEntry[V])
+	if !bytes.Equal(entry.valueBytes, valueBytes) {
+		return v, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// add caches the value of key decoded from valueBytes, evicting the least
+// recently used entry if the cache is full.
+func (c *valueCache[V]) add(key, valueBytes []byte, value V) {
+	valueBytes = bytes.Clone(valueBytes)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[string(key)]; ok {
+		entry := elem.Value.(*valueCacheEntry[V])
+		entry.valueBytes, entry.value = valueBytes, value
+		c.lru.MoveToFront(elem)
+		return
+	}
+	if c.lru.Len() >= c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*valueCacheEntry[V]).key)
+	}
+	entry := &valueCacheEntry[V]{key: string(key), valueBytes: valueBytes, value: value}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+}
+
+// remove invalidates the cached value of key, if any.
+func (c *valueCache[V]) remove(key []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[string(key)]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, string(key))
+	}
+}
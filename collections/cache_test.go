@@ -0,0 +1,211 @@
+package collections
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections/codec"
+	"cosmossdk.io/core/store"
+)
+
+// branchStore is a branch of a store which writes its changes to its parent
+// store only when written, like the cache multistore of sdk.Context.
+// Only Get and Set are branched.
+type branchStore struct {
+	store.KVStore
+	writes map[string][]byte
+}
+
+func newBranchStore(parent store.KVStore) *branchStore {
+	return &branchStore{KVStore: parent, writes: map[string][]byte{}}
+}
+
+func (b *branchStore) Get(key []byte) ([]byte, error) {
+	if value, ok := b.writes[string(key)]; ok {
+		return value, nil
+	}
+	return b.KVStore.Get(key)
+}
+
+func (b *branchStore) Set(key, value []byte) error {
+	b.writes[string(key)] = value
+	return nil
+}
+
+func (b *branchStore) write() {
+	for key, value := range b.writes {
+		_ = b.KVStore.Set([]byte(key), value)
+	}
+}
+
+type contextStoreKey struct{}
+
+// branchedDeps returns a store service which opens the store held by the context, if any.
+func branchedDeps() (store.KVStoreService, context.Context) {
+	sk, ctx := deps()
+	return storeServiceFunc(func(ctx context.Context) store.KVStore {
+		if branch, ok := ctx.Value(contextStoreKey{}).(*branchStore); ok {
+			return branch
+		}
+		return sk.OpenKVStore(ctx)
+	}), ctx
+}
+
+type storeServiceFunc func(context.Context) store.KVStore
+
+func (f storeServiceFunc) OpenKVStore(ctx context.Context) store.KVStore { return f(ctx) }
+
+// countingValueCodec counts the values it decodes.
+type countingValueCodec struct {
+	codec.ValueCodec[uint64]
+	decoded *int
+}
+
+func (c countingValueCodec) Decode(b []byte) (uint64, error) {
+	*c.decoded++
+	return c.ValueCodec.Decode(b)
+}
+
+func TestMapValueCache(t *testing.T) {
+	sk, ctx := branchedDeps()
+	decoded := 0
+	m := NewMap(NewSchemaBuilder(sk), NewPrefix(0), "m", StringKey, codec.ValueCodec[uint64](countingValueCodec{Uint64Value, &decoded})).
+		WithValueCache(2)
+
+	require.NoError(t, m.Set(ctx, "a", 1))
+	for i := 0; i < 3; i++ {
+		v, err := m.Get(ctx, "a")
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), v)
+	}
+	require.Equal(t, 1, decoded)
+
+	// set invalidates the cached value
+	require.NoError(t, m.Set(ctx, "a", 2))
+	v, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), v)
+	require.Equal(t, 2, decoded)
+
+	// remove invalidates the cached value
+	require.NoError(t, m.Remove(ctx, "a"))
+	_, err = m.Get(ctx, "a")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// the least recently used values are evicted
+	require.NoError(t, m.Set(ctx, "a", 1))
+	require.NoError(t, m.Set(ctx, "b", 2))
+	require.NoError(t, m.Set(ctx, "c", 3))
+	decoded = 0
+	for _, key := range []string{"a", "b", "c", "b", "c", "a"} {
+		_, err = m.Get(ctx, key)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, decoded)
+
+	t.Run("branches", func(t *testing.T) {
+		branch := newBranchStore(sk.OpenKVStore(ctx))
+		branchCtx := context.WithValue(ctx, contextStoreKey{}, branch)
+		require.NoError(t, m.Set(branchCtx, "a", 10))
+		v, err := m.Get(branchCtx, "a")
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), v)
+
+		// the value cached from the branch is not returned for its parent
+		v, err = m.Get(ctx, "a")
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), v)
+
+		// and the one cached from the parent is not returned for the branch
+		v, err = m.Get(branchCtx, "a")
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), v)
+
+		branch.write()
+		v, err = m.Get(ctx, "a")
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), v)
+	})
+}
+
+func TestItemValueCache(t *testing.T) {
+	sk, ctx := deps()
+	decoded := 0
+	item := NewItem(NewSchemaBuilder(sk), NewPrefix(0), "item", codec.ValueCodec[uint64](countingValueCodec{Uint64Value, &decoded})).
+		WithValueCache()
+
+	require.NoError(t, item.Set(ctx, 1))
+	for i := 0; i < 3; i++ {
+		v, err := item.Get(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), v)
+	}
+	require.Equal(t, 1, decoded)
+}
+
+// benchParams is a value whose decoding costs as much as the one of typical parameters.
+type benchParams struct {
+	MaxValidators     uint32   `json:"max_validators"`
+	BondDenom         string   `json:"bond_denom"`
+	UnbondingTime     int64    `json:"unbonding_time"`
+	MinCommissionRate string   `json:"min_commission_rate"`
+	SendEnabled       []string `json:"send_enabled"`
+}
+
+type benchParamsCodec struct{}
+
+func (benchParamsCodec) Encode(value benchParams) ([]byte, error) { return json.Marshal(value) }
+
+func (benchParamsCodec) Decode(b []byte) (benchParams, error) {
+	var value benchParams
+	err := json.Unmarshal(b, &value)
+	return value, err
+}
+
+func (c benchParamsCodec) EncodeJSON(value benchParams) ([]byte, error) { return c.Encode(value) }
+
+func (c benchParamsCodec) DecodeJSON(b []byte) (benchParams, error) { return c.Decode(b) }
+
+func (benchParamsCodec) Stringify(value benchParams) string { return value.BondDenom }
+
+func (benchParamsCodec) ValueType() string { return "benchParams" }
+
+func BenchmarkItemGet(b *testing.B) {
+	params := benchParams{
+		MaxValidators:     100,
+		BondDenom:         "stake",
+		UnbondingTime:     1814400,
+		MinCommissionRate: "0.050000000000000000",
+		SendEnabled:       []string{"stake", "atom", "osmo"},
+	}
+
+	b.Run("decoding", func(b *testing.B) {
+		sk, ctx := deps()
+		item := NewItem(NewSchemaBuilder(sk), NewPrefix(0), "params", codec.ValueCodec[benchParams](benchParamsCodec{}))
+		require.NoError(b, item.Set(ctx, params))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := item.Get(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		sk, ctx := deps()
+		item := NewItem(NewSchemaBuilder(sk), NewPrefix(0), "params", codec.ValueCodec[benchParams](benchParamsCodec{})).
+			WithValueCache()
+		require.NoError(b, item.Set(ctx, params))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := item.Get(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
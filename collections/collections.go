@@ -72,9 +72,8 @@ var (
 	BytesValue = codec.KeyToValueCodec(BytesKey)
 )
 
-// collection is the interface that all collections support. It will eventually
-// include methods for importing/exporting genesis data and schema
-// reflection for clients.
+// collection is the interface that all collections support. It includes
+// methods for importing/exporting genesis data and schema reflection for clients.
 type collection interface {
 	// getName is the unique name of the collection within a schema. It must
 	// match format specified by NameRegex.
@@ -84,6 +83,7 @@ type collection interface {
 	getPrefix() []byte
 
 	genesisHandler
+	reflectionHandler
 }
 
 // Prefix defines a segregation bytes namespace for specific collections objects.
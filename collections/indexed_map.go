@@ -2,6 +2,7 @@ package collections
 
 import (
 	"context"
+	"errors"
 
 	"cosmossdk.io/collections/codec"
 )
@@ -92,6 +93,35 @@ func (m *IndexedMap[PrimaryKey, Value, Idx]) Remove(ctx context.Context, pk Prim
 	return m.m.Remove(ctx, pk)
 }
 
+// Clear removes all the primary keys within the provided range, and their values, from the map,
+// along with all the references of the indexes to them.
+// A nil ranger equals to clearing the whole IndexedMap. See Map.Clear.
+func (m *IndexedMap[PrimaryKey, Value, Idx]) Clear(ctx context.Context, ranger Ranger[PrimaryKey]) error {
+	iter, err := m.m.Iterate(ctx, ranger)
+	if errors.Is(err, ErrInvalidIterator) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	pks, err := iter.Keys()
+	if err != nil {
+		return err
+	}
+	for _, pk := range pks {
+		err = m.Remove(ctx, pk)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count applies the same semantics as Map.Count.
+func (m *IndexedMap[PrimaryKey, Value, Idx]) Count(ctx context.Context, ranger Ranger[PrimaryKey]) (uint64, error) {
+	return m.m.Count(ctx, ranger)
+}
+
 // Walk applies the same semantics as Map.Walk.
 func (m *IndexedMap[PrimaryKey, Value, Idx]) Walk(ctx context.Context, ranger Ranger[PrimaryKey], walkFunc func(key PrimaryKey, value Value) bool) error {
 	return m.m.Walk(ctx, ranger, walkFunc)
@@ -103,3 +103,35 @@ func TestIndexedMap(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, company{"milan", 4}, v)
 }
+
+func TestIndexedMap_ClearCount(t *testing.T) {
+	sk, ctx := colltest.MockStore()
+	schema := collections.NewSchemaBuilder(sk)
+
+	im := newTestIndexedMap(schema)
+	require.NoError(t, im.Set(ctx, "1", company{City: "milan", Vat: 0}))
+	require.NoError(t, im.Set(ctx, "2", company{City: "milan", Vat: 1}))
+	require.NoError(t, im.Set(ctx, "3", company{City: "rome", Vat: 2}))
+
+	count, err := im.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), count)
+
+	// clearing removes the references of the indexes
+	require.NoError(t, im.Clear(ctx, new(collections.Range[string]).EndInclusive("2")))
+	count, err = im.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	_, err = im.Indexes.Vat.MatchExact(ctx, 1)
+	require.ErrorIs(t, err, collections.ErrNotFound)
+	_, err = im.Indexes.City.MatchExact(ctx, "milan")
+	require.ErrorIs(t, err, collections.ErrInvalidIterator)
+	pk, err := im.Indexes.Vat.MatchExact(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, "3", pk)
+
+	require.NoError(t, im.Clear(ctx, nil))
+	_, err = im.Indexes.Vat.MatchExact(ctx, 2)
+	require.ErrorIs(t, err, collections.ErrNotFound)
+}
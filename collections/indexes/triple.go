@@ -0,0 +1,172 @@
+package indexes
+
+import (
+	"context"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/collections/codec"
+)
+
+// tripleKeyCodec is an interface to cast a collections.KeyCodec of a Triple
+// to the triple codec, exposing the codecs of the parts of the key.
+// See the pairKeyCodec interface for the rationale.
+type tripleKeyCodec[K1, K2, K3 any] interface {
+	KeyCodec1() codec.KeyCodec[K1]
+	KeyCodec2() codec.KeyCodec[K2]
+	KeyCodec3() codec.KeyCodec[K3]
+}
+
+// TripleByK2 is an index that is used with collections.Triple keys. It indexes objects by the second part
+// of their key: when the value is being indexed by collections.IndexedMap then TripleByK2 references the
+// primary key Join3(K1, K2, K3) as Join3(K2, K1, K3).
+// Example: redelegations keyed by (delegator, source validator, destination validator) indexed by source validator.
+type TripleByK2[K1, K2, K3, Value any] struct {
+	*tripleIndex[K1, K2, K3, K2, K1, K3, Value]
+}
+
+// NewTripleByK2 instantiates a new TripleByK2 index.
+// NOTE: when using this function you will need to type hint: doing NewTripleByK2[Value]()
+// Example: if the value of the indexed map is string, you need to do NewTripleByK2[string](...)
+func NewTripleByK2[Value, K1, K2, K3 any](
+	sb *collections.SchemaBuilder,
+	prefix collections.Prefix,
+	name string,
+	tripleCodec codec.KeyCodec[collections.Triple[K1, K2, K3]],
+) *TripleByK2[K1, K2, K3, Value] {
+	tkc := tripleCodec.(tripleKeyCodec[K1, K2, K3])
+	return &TripleByK2[K1, K2, K3, Value]{
+		tripleIndex: &tripleIndex[K1, K2, K3, K2, K1, K3, Value]{
+			refKeys: collections.NewKeySet(sb, prefix, name, collections.TripleKeyCodec(tkc.KeyCodec2(), tkc.KeyCodec1(), tkc.KeyCodec3())),
+			toRefKey: func(pk collections.Triple[K1, K2, K3]) collections.Triple[K2, K1, K3] {
+				return collections.Join3(pk.K2(), pk.K1(), pk.K3())
+			},
+			toPrimaryKey: func(refKey collections.Triple[K2, K1, K3]) collections.Triple[K1, K2, K3] {
+				return collections.Join3(refKey.K2(), refKey.K1(), refKey.K3())
+			},
+		},
+	}
+}
+
+// TripleByK3 is an index that is used with collections.Triple keys. It indexes objects by the third part
+// of their key: when the value is being indexed by collections.IndexedMap then TripleByK3 references the
+// primary key Join3(K1, K2, K3) as Join3(K3, K1, K2).
+// Example: redelegations keyed by (delegator, source validator, destination validator) indexed by destination validator.
+type TripleByK3[K1, K2, K3, Value any] struct {
+	*tripleIndex[K1, K2, K3, K3, K1, K2, Value]
+}
+
+// NewTripleByK3 instantiates a new TripleByK3 index.
+// NOTE: when using this function you will need to type hint: doing NewTripleByK3[Value]()
+// Example: if the value of the indexed map is string, you need to do NewTripleByK3[string](...)
+func NewTripleByK3[Value, K1, K2, K3 any](
+	sb *collections.SchemaBuilder,
+	prefix collections.Prefix,
+	name string,
+	tripleCodec codec.KeyCodec[collections.Triple[K1, K2, K3]],
+) *TripleByK3[K1, K2, K3, Value] {
+	tkc := tripleCodec.(tripleKeyCodec[K1, K2, K3])
+	return &TripleByK3[K1, K2, K3, Value]{
+		tripleIndex: &tripleIndex[K1, K2, K3, K3, K1, K2, Value]{
+			refKeys: collections.NewKeySet(sb, prefix, name, collections.TripleKeyCodec(tkc.KeyCodec3(), tkc.KeyCodec1(), tkc.KeyCodec2())),
+			toRefKey: func(pk collections.Triple[K1, K2, K3]) collections.Triple[K3, K1, K2] {
+				return collections.Join3(pk.K3(), pk.K1(), pk.K2())
+			},
+			toPrimaryKey: func(refKey collections.Triple[K3, K1, K2]) collections.Triple[K1, K2, K3] {
+				return collections.Join3(refKey.K2(), refKey.K3(), refKey.K1())
+			},
+		},
+	}
+}
+
+// tripleIndex implements the indexes of collections.Triple keys referencing the primary
+// key Join3(K1, K2, K3) as the key Join3(R1, R2, R3), made of the same parts in another order.
+type tripleIndex[K1, K2, K3, R1, R2, R3, Value any] struct {
+	refKeys      collections.KeySet[collections.Triple[R1, R2, R3]]
+	toRefKey     func(pk collections.Triple[K1, K2, K3]) collections.Triple[R1, R2, R3]
+	toPrimaryKey func(refKey collections.Triple[R1, R2, R3]) collections.Triple[K1, K2, K3]
+}
+
+// Iterate exposes the raw iterator API.
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) Iterate(
+	ctx context.Context, ranger collections.Ranger[collections.Triple[R1, R2, R3]],
+) (TripleIterator[R1, R2, R3, K1, K2, K3], error) {
+	iter, err := i.refKeys.Iterate(ctx, ranger)
+	return TripleIterator[R1, R2, R3, K1, K2, K3]{KeySetIterator: iter, toPrimaryKey: i.toPrimaryKey}, err
+}
+
+// MatchExact returns an iterator containing only the primary keys whose indexing part is the one provided.
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) MatchExact(ctx context.Context, key R1) (TripleIterator[R1, R2, R3, K1, K2, K3], error) {
+	return i.Iterate(ctx, collections.NewPrefixedTripleRange[R1, R2, R3](key))
+}
+
+// MatchExactPair returns an iterator containing only the primary keys whose indexing part is key and whose
+// first remaining part, in the order of the reference key, is next.
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) MatchExactPair(ctx context.Context, key R1, next R2) (TripleIterator[R1, R2, R3, K1, K2, K3], error) {
+	return i.Iterate(ctx, collections.NewSuperPrefixedTripleRange[R1, R2, R3](key, next))
+}
+
+// Reference implements collections.Index
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) Reference(ctx context.Context, pk collections.Triple[K1, K2, K3], _ Value, _ func() (Value, error)) error {
+	return i.refKeys.Set(ctx, i.toRefKey(pk))
+}
+
+// Unreference implements collections.Index
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) Unreference(ctx context.Context, pk collections.Triple[K1, K2, K3], _ func() (Value, error)) error {
+	return i.refKeys.Remove(ctx, i.toRefKey(pk))
+}
+
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) Walk(
+	ctx context.Context,
+	ranger collections.Ranger[collections.Triple[R1, R2, R3]],
+	walkFunc func(indexingKey R1, indexedKey collections.Triple[K1, K2, K3]) bool,
+) error {
+	return i.refKeys.Walk(ctx, ranger, func(key collections.Triple[R1, R2, R3]) bool {
+		return walkFunc(key.K1(), i.toPrimaryKey(key))
+	})
+}
+
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) IterateRaw(
+	ctx context.Context, start, end []byte, order collections.Order,
+) (
+	iter collections.Iterator[collections.Triple[R1, R2, R3], collections.NoValue], err error,
+) {
+	return i.refKeys.IterateRaw(ctx, start, end, order)
+}
+
+func (i *tripleIndex[K1, K2, K3, R1, R2, R3, Value]) KeyCodec() codec.KeyCodec[collections.Triple[R1, R2, R3]] {
+	return i.refKeys.KeyCodec()
+}
+
+// TripleIterator is a helper type around a collections.KeySetIterator when used to work
+// with TripleByK2 and TripleByK3 indexes iterations.
+type TripleIterator[R1, R2, R3, K1, K2, K3 any] struct {
+	collections.KeySetIterator[collections.Triple[R1, R2, R3]]
+	toPrimaryKey func(refKey collections.Triple[R1, R2, R3]) collections.Triple[K1, K2, K3]
+}
+
+// PrimaryKey returns the iterator's current primary key.
+func (i TripleIterator[R1, R2, R3, K1, K2, K3]) PrimaryKey() (collections.Triple[K1, K2, K3], error) {
+	fullKey, err := i.FullKey()
+	if err != nil {
+		return collections.Triple[K1, K2, K3]{}, err
+	}
+	return i.toPrimaryKey(fullKey), nil
+}
+
+// PrimaryKeys fully consumes the iterator and returns the list of primary keys.
+func (i TripleIterator[R1, R2, R3, K1, K2, K3]) PrimaryKeys() ([]collections.Triple[K1, K2, K3], error) {
+	fullKeys, err := i.Keys()
+	if err != nil {
+		return nil, err
+	}
+	pks := make([]collections.Triple[K1, K2, K3], len(fullKeys))
+	for j, fullKey := range fullKeys {
+		pks[j] = i.toPrimaryKey(fullKey)
+	}
+	return pks, nil
+}
+
+// FullKey returns the current full reference key.
+func (i TripleIterator[R1, R2, R3, K1, K2, K3]) FullKey() (collections.Triple[R1, R2, R3], error) {
+	return i.Key()
+}
@@ -0,0 +1,85 @@
+package indexes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections"
+)
+
+type (
+	Delegator = string
+	Validator = string
+)
+
+// our redelegation indexes, allow us to efficiently iterate the redelegations keyed by
+// collections.Triple[Delegator, Validator, Validator] from a source or to a destination validator.
+type redelegationIndexes struct {
+	BySrc *TripleByK2[Delegator, Validator, Validator, Amount]
+	ByDst *TripleByK3[Delegator, Validator, Validator, Amount]
+}
+
+func (r redelegationIndexes) IndexesList() []collections.Index[collections.Triple[Delegator, Validator, Validator], Amount] {
+	return []collections.Index[collections.Triple[Delegator, Validator, Validator], Amount]{r.BySrc, r.ByDst}
+}
+
+func TestTripleIndexes(t *testing.T) {
+	sk, ctx := deps()
+	sb := collections.NewSchemaBuilder(sk)
+	keyCodec := collections.TripleKeyCodec(collections.StringKey, collections.StringKey, collections.StringKey)
+
+	indexedMap := collections.NewIndexedMap(
+		sb,
+		collections.NewPrefix("redelegations"), "redelegations",
+		keyCodec,
+		collections.Uint64Value,
+		redelegationIndexes{
+			BySrc: NewTripleByK2[Amount](sb, collections.NewPrefix("by_src"), "by_src", keyCodec),
+			ByDst: NewTripleByK3[Amount](sb, collections.NewPrefix("by_dst"), "by_dst", keyCodec),
+		},
+	)
+
+	require.NoError(t, indexedMap.Set(ctx, collections.Join3("delegator1", "val1", "val2"), 100))
+	require.NoError(t, indexedMap.Set(ctx, collections.Join3("delegator1", "val1", "val3"), 200))
+	require.NoError(t, indexedMap.Set(ctx, collections.Join3("delegator2", "val1", "val2"), 300))
+	require.NoError(t, indexedMap.Set(ctx, collections.Join3("delegator2", "val3", "val2"), 400))
+
+	iter, err := indexedMap.Indexes.BySrc.MatchExact(ctx, "val1")
+	require.NoError(t, err)
+	pks, err := iter.PrimaryKeys()
+	require.NoError(t, err)
+	require.Equal(t, []collections.Triple[Delegator, Validator, Validator]{
+		collections.Join3("delegator1", "val1", "val2"),
+		collections.Join3("delegator1", "val1", "val3"),
+		collections.Join3("delegator2", "val1", "val2"),
+	}, pks)
+
+	iter, err = indexedMap.Indexes.ByDst.MatchExactPair(ctx, "val2", "delegator2")
+	require.NoError(t, err)
+	values, err := CollectValues(ctx, indexedMap, iter)
+	require.NoError(t, err)
+	require.Equal(t, []Amount{300, 400}, values)
+
+	// removing the redelegation removes its references
+	require.NoError(t, indexedMap.Remove(ctx, collections.Join3("delegator2", "val1", "val2")))
+	iter, err = indexedMap.Indexes.ByDst.MatchExact(ctx, "val2")
+	require.NoError(t, err)
+	fullKey, err := iter.FullKey()
+	require.NoError(t, err)
+	require.Equal(t, collections.Join3("val2", "delegator1", "val1"), fullKey)
+	pks, err = iter.PrimaryKeys()
+	require.NoError(t, err)
+	require.Equal(t, []collections.Triple[Delegator, Validator, Validator]{
+		collections.Join3("delegator1", "val1", "val2"),
+		collections.Join3("delegator2", "val3", "val2"),
+	}, pks)
+
+	var walked []collections.Triple[Delegator, Validator, Validator]
+	require.NoError(t, indexedMap.Indexes.BySrc.Walk(ctx, collections.NewPrefixedTripleRange[Validator, Delegator, Validator]("val3"), func(src Validator, pk collections.Triple[Delegator, Validator, Validator]) bool {
+		require.Equal(t, "val3", src)
+		walked = append(walked, pk)
+		return false
+	}))
+	require.Equal(t, []collections.Triple[Delegator, Validator, Validator]{collections.Join3("delegator2", "val3", "val2")}, walked)
+}
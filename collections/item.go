@@ -25,6 +25,12 @@ func NewItem[V any](
 	return item
 }
 
+// WithValueCache returns an Item, sharing the storage of i, which caches its value decoded
+// by Get. See Map.WithValueCache.
+func (i Item[V]) WithValueCache() Item[V] {
+	return (Item[V])((Map[noKey, V])(i).WithValueCache(1))
+}
+
 // Get gets the item, if it is not set it returns an ErrNotFound error.
 // If value decoding fails then an ErrEncoding is returned.
 func (i Item[V]) Get(ctx context.Context) (V, error) {
@@ -51,6 +51,18 @@ func (k KeySet[K]) IterateRaw(ctx context.Context, start, end []byte, order Orde
 	return (Map[K, NoValue])(k).IterateRaw(ctx, start, end, order)
 }
 
+// Clear removes all the keys within the provided range from the set.
+// A nil ranger equals to clearing the whole KeySet. See Map.Clear.
+func (k KeySet[K]) Clear(ctx context.Context, ranger Ranger[K]) error {
+	return (Map[K, NoValue])(k).Clear(ctx, ranger)
+}
+
+// Count returns the number of keys of the set within the provided range.
+// A nil ranger equals to counting all the keys of the KeySet.
+func (k KeySet[K]) Count(ctx context.Context, ranger Ranger[K]) (uint64, error) {
+	return (Map[K, NoValue])(k).Count(ctx, ranger)
+}
+
 // Walk provides the same functionality as Map.Walk, but callbacks the walk
 // function only with the key.
 func (k KeySet[K]) Walk(ctx context.Context, ranger Ranger[K], walkFunc func(key K) bool) error {
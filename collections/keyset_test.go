@@ -50,6 +50,17 @@ func TestKeySet(t *testing.T) {
 
 	// validity
 	require.False(t, iter.Valid())
+
+	// count
+	count, err := ks.Count(ctx, new(Range[string]).Prefix("C"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+
+	// clear
+	require.NoError(t, ks.Clear(ctx, new(Range[string]).StartInclusive("C")))
+	count, err = ks.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
 }
 
 func Test_noValue(t *testing.T) {
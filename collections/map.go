@@ -1,7 +1,9 @@
 package collections
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 
 	"cosmossdk.io/collections/codec"
@@ -20,6 +22,9 @@ type Map[K, V any] struct {
 	sa     func(context.Context) store.KVStore
 	prefix []byte
 	name   string
+
+	// cache of the decoded values, nil unless enabled with WithValueCache
+	cache *valueCache[V]
 }
 
 // NewMap returns a Map given a StoreKey, a Prefix, human-readable name and the relative value and key encoders.
@@ -43,6 +48,20 @@ func NewMap[K, V any](
 	return m
 }
 
+// WithValueCache returns a Map, sharing the storage of m, which caches in memory the values
+// decoded by Get, up to size values, so that repeatedly reading the same keys does not
+// pay the cost of decoding their values, e.g. for parameters or supplies.
+// The values are still read from the store, and a cached value is only returned if the
+// store of the context holds the bytes it was decoded from, so the cache is consistent
+// with the branches of the store, e.g. the cache multistore of sdk.Context, and does not
+// change the gas consumed. Set and Remove invalidate the cached value of their key.
+// NOTE: the cached values are shared by the callers of Get, they must not be mutated,
+// which matters for values holding pointers, slices or maps.
+func (m Map[K, V]) WithValueCache(size int) Map[K, V] {
+	m.cache = newValueCache[V](size)
+	return m
+}
+
 func (m Map[K, V]) getName() string {
 	return m.name
 }
@@ -66,6 +85,9 @@ func (m Map[K, V]) Set(ctx context.Context, key K, value V) error {
 
 	kvStore := m.sa(ctx)
 	kvStore.Set(bytesKey, valueBytes)
+	if m.cache != nil {
+		m.cache.remove(bytesKey)
+	}
 	return nil
 }
 
@@ -87,10 +109,19 @@ func (m Map[K, V]) Get(ctx context.Context, key K) (v V, err error) {
 		return v, fmt.Errorf("%w: key '%s' of type %s", ErrNotFound, m.kc.Stringify(key), m.vc.ValueType())
 	}
 
+	if m.cache != nil {
+		if v, ok := m.cache.get(bytesKey, valueBytes); ok {
+			return v, nil
+		}
+	}
+
 	v, err = m.vc.Decode(valueBytes)
 	if err != nil {
 		return v, fmt.Errorf("%w: value decode: %s", ErrEncoding, err) // TODO: use multi err wrapping in go1.20: https://github.com/golang/go/issues/53435
 	}
+	if m.cache != nil {
+		m.cache.add(bytesKey, valueBytes, v)
+	}
 	return v, nil
 }
 
@@ -114,9 +145,71 @@ func (m Map[K, V]) Remove(ctx context.Context, key K) error {
 		return err
 	}
 	kvStore := m.sa(ctx)
+	if m.cache != nil {
+		m.cache.remove(bytesKey)
+	}
 	return kvStore.Delete(bytesKey)
 }
 
+// Clear removes all the keys within the provided range, and their values, from the storage.
+// A nil ranger equals to clearing the whole Map.
+// The keys are collected before being removed, since a store must not be written while being
+// iterated, so clearing a large range holds all its keys in memory.
+func (m Map[K, V]) Clear(ctx context.Context, ranger Ranger[K]) error {
+	keys, err := m.rawKeys(ctx, ranger)
+	if err != nil {
+		return err
+	}
+	kvStore := m.sa(ctx)
+	for _, key := range keys {
+		if m.cache != nil {
+			m.cache.remove(key)
+		}
+		err = kvStore.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count returns the number of keys within the provided range, without decoding them.
+// A nil ranger equals to counting all the keys of the Map.
+func (m Map[K, V]) Count(ctx context.Context, ranger Ranger[K]) (uint64, error) {
+	iter, err := m.Iterate(ctx, ranger)
+	if errors.Is(err, ErrInvalidIterator) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	count := uint64(0)
+	for ; iter.Valid(); iter.Next() {
+		count++
+	}
+	return count, nil
+}
+
+// rawKeys returns the prefixed bytes of the keys within the provided range.
+func (m Map[K, V]) rawKeys(ctx context.Context, ranger Ranger[K]) ([][]byte, error) {
+	iter, err := m.Iterate(ctx, ranger)
+	if errors.Is(err, ErrInvalidIterator) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, bytes.Clone(iter.iter.Key()))
+	}
+	return keys, nil
+}
+
 // Iterate provides an Iterator over K and V. It accepts a Ranger interface.
 // A nil ranger equals to iterate over all the keys in ascending order.
 func (m Map[K, V]) Iterate(ctx context.Context, ranger Ranger[K]) (Iterator[K, V], error) {
@@ -71,6 +71,54 @@ func TestMap_IterateRaw(t *testing.T) {
 	require.Equal(t, []uint64{2, 1, 0}, keys)
 }
 
+func TestMap_ClearCount(t *testing.T) {
+	sk, ctx := deps()
+	// safety check to ensure prefix boundaries are not crossed
+	require.NoError(t, sk.OpenKVStore(ctx).Set([]byte{0x0, 0x0}, []byte("before prefix")))
+	require.NoError(t, sk.OpenKVStore(ctx).Set([]byte{0x2, 0x0}, []byte("after prefix")))
+
+	m := NewMap(NewSchemaBuilder(sk), NewPrefix(1), "m", PairKeyCodec(StringKey, Uint64Key), Uint64Value)
+	for _, key := range []Pair[string, uint64]{Join("a", uint64(0)), Join("a", uint64(1)), Join("b", uint64(0)), Join("c", uint64(0))} {
+		require.NoError(t, m.Set(ctx, key, 1))
+	}
+
+	// count
+	count, err := m.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), count)
+	count, err = m.Count(ctx, NewPrefixedPairRange[string, uint64]("a"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), count)
+	count, err = m.Count(ctx, NewPrefixedPairRange[string, uint64]("d"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), count)
+
+	// clear a range
+	require.NoError(t, m.Clear(ctx, NewPrefixedPairRange[string, uint64]("a")))
+	iter, err := m.Iterate(ctx, nil)
+	require.NoError(t, err)
+	keys, err := iter.Keys()
+	require.NoError(t, err)
+	require.Equal(t, []Pair[string, uint64]{Join("b", uint64(0)), Join("c", uint64(0))}, keys)
+
+	// clearing an empty range is a no-op
+	require.NoError(t, m.Clear(ctx, NewPrefixedPairRange[string, uint64]("a")))
+
+	// clear all
+	require.NoError(t, m.Clear(ctx, nil))
+	count, err = m.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), count)
+
+	// the keys outside of the prefix are kept
+	v, err := sk.OpenKVStore(ctx).Get([]byte{0x0, 0x0})
+	require.NoError(t, err)
+	require.Equal(t, []byte("before prefix"), v)
+	v, err = sk.OpenKVStore(ctx).Get([]byte{0x2, 0x0})
+	require.NoError(t, err)
+	require.Equal(t, []byte("after prefix"), v)
+}
+
 func Test_encodeKey(t *testing.T) {
 	prefix := "prefix"
 	number := []byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0}
@@ -0,0 +1,115 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// reflectionHandler defines the methods a collection needs to describe itself
+// and to decode its raw key and value bytes without knowing its types.
+type reflectionHandler interface {
+	keyType() string
+	valueType() string
+	decodeKeyJSON(key []byte) (json.RawMessage, error)
+	decodeValueJSON(value []byte) (json.RawMessage, error)
+}
+
+// CollectionInfo describes a collection of a Schema.
+type CollectionInfo struct {
+	// Name is the unique name of the collection within the schema.
+	Name string
+	// Prefix is the unique prefix of the keys of the collection within the schema.
+	Prefix []byte
+	// KeyType is the type of the keys of the collection, as reported by its KeyCodec.
+	KeyType string
+	// ValueType is the type of the values of the collection, as reported by its ValueCodec.
+	ValueType string
+}
+
+// DecodedKV is a raw key value pair of a Schema's store decoded by the codecs of
+// the collection it belongs to.
+type DecodedKV struct {
+	// Collection is the name of the collection the pair belongs to.
+	Collection string `json:"collection"`
+	// Key is the JSON encoded key, as produced by the collection's KeyCodec.
+	Key json.RawMessage `json:"key"`
+	// Value is the JSON encoded value, as produced by the collection's ValueCodec.
+	// It is nil if no value was provided.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Collections returns the description of the collections of the schema, ordered by name.
+func (s Schema) Collections() []CollectionInfo {
+	infos := make([]CollectionInfo, 0, len(s.collectionsOrdered))
+	for _, name := range s.collectionsOrdered {
+		coll := s.collectionsByName[name]
+		infos = append(infos, CollectionInfo{
+			Name:      name,
+			Prefix:    coll.getPrefix(),
+			KeyType:   coll.keyType(),
+			ValueType: coll.valueType(),
+		})
+	}
+	return infos
+}
+
+// DecodeKV decodes a raw key value pair of the schema's store into the name of the collection
+// the key belongs to and the JSON representation of its key and value. A nil value is not decoded,
+// which allows decoding the keys of deleted pairs. If the key does not match the prefix of any
+// collection of the schema, ErrNotFound is returned.
+func (s Schema) DecodeKV(key, value []byte) (DecodedKV, error) {
+	coll, err := s.collectionByKey(key)
+	if err != nil {
+		return DecodedKV{}, err
+	}
+
+	name := coll.getName()
+	keyJSON, err := coll.decodeKeyJSON(key[len(coll.getPrefix()):])
+	if err != nil {
+		return DecodedKV{}, fmt.Errorf("collection %s: %w", name, err)
+	}
+
+	decoded := DecodedKV{Collection: name, Key: keyJSON}
+	if value == nil {
+		return decoded, nil
+	}
+	decoded.Value, err = coll.decodeValueJSON(value)
+	if err != nil {
+		return DecodedKV{}, fmt.Errorf("collection %s: %w", name, err)
+	}
+	return decoded, nil
+}
+
+// collectionByKey returns the collection whose prefix the provided key starts with.
+// Since the schema builder refuses overlapping prefixes there is at most one.
+func (s Schema) collectionByKey(key []byte) (collection, error) {
+	for i := 0; i <= len(key); i++ {
+		if coll, ok := s.collectionsByPrefix[string(key[:i])]; ok {
+			return coll, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no collection with a prefix of key %X", ErrNotFound, key)
+}
+
+func (m Map[K, V]) keyType() string { return m.kc.KeyType() }
+
+func (m Map[K, V]) valueType() string { return m.vc.ValueType() }
+
+func (m Map[K, V]) decodeKeyJSON(key []byte) (json.RawMessage, error) {
+	read, k, err := m.kc.Decode(key)
+	if err != nil {
+		return nil, err
+	}
+	if read != len(key) {
+		return nil, fmt.Errorf("%w: key decoding read %d bytes of %d", ErrEncoding, read, len(key))
+	}
+	return m.kc.EncodeJSON(k)
+}
+
+func (m Map[K, V]) decodeValueJSON(value []byte) (json.RawMessage, error) {
+	v, err := m.vc.Decode(value)
+	if err != nil {
+		return nil, err
+	}
+	return m.vc.EncodeJSON(v)
+}
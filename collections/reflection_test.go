@@ -0,0 +1,59 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaReflection(t *testing.T) {
+	sk, ctx := deps()
+	sb := NewSchemaBuilder(sk)
+	m := NewMap(sb, NewPrefix(1), "balances", PairKeyCodec(StringKey, StringKey), Uint64Value)
+	ks := NewKeySet(sb, NewPrefix(2), "accounts", StringKey)
+	item := NewItem(sb, NewPrefix(3), "params", StringValue)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+
+	require.Equal(t, []CollectionInfo{
+		{Name: "accounts", Prefix: []byte{2}, KeyType: "string", ValueType: "no_value"},
+		{Name: "balances", Prefix: []byte{1}, KeyType: "Pair[string, string]", ValueType: "uint64"},
+		{Name: "params", Prefix: []byte{3}, KeyType: "no_key", ValueType: "string"},
+	}, schema.Collections())
+
+	require.NoError(t, m.Set(ctx, Join("alice", "atom"), 100))
+	require.NoError(t, ks.Set(ctx, "alice"))
+	require.NoError(t, item.Set(ctx, "hello"))
+
+	// decode every raw pair of the store
+	iter, err := sk.OpenKVStore(ctx).Iterator(nil, nil)
+	require.NoError(t, err)
+	defer iter.Close()
+	var decoded []DecodedKV
+	for ; iter.Valid(); iter.Next() {
+		kv, err := schema.DecodeKV(iter.Key(), iter.Value())
+		require.NoError(t, err)
+		decoded = append(decoded, kv)
+	}
+	require.Equal(t, []DecodedKV{
+		{Collection: "balances", Key: []byte(`["alice","atom"]`), Value: []byte(`"100"`)},
+		{Collection: "accounts", Key: []byte(`"alice"`)},
+		{Collection: "params", Key: []byte(`"item"`), Value: []byte(`"hello"`)},
+	}, decoded)
+
+	t.Run("nil value", func(t *testing.T) {
+		kv, err := schema.DecodeKV(append([]byte{1}, []byte("bob\x00osmo")...), nil)
+		require.NoError(t, err)
+		require.Equal(t, DecodedKV{Collection: "balances", Key: []byte(`["bob","osmo"]`)}, kv)
+	})
+
+	t.Run("unknown prefix", func(t *testing.T) {
+		_, err := schema.DecodeKV([]byte{4, 1}, nil)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("trailing key bytes", func(t *testing.T) {
+		_, err := schema.DecodeKV([]byte{3, 1}, []byte("hello"))
+		require.ErrorIs(t, err, ErrEncoding)
+	})
+}
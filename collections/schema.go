@@ -122,9 +122,8 @@ var nameRegex = regexp.MustCompile("^" + NameRegex + "$")
 
 // Schema specifies a group of collections stored within the storage specified
 // by a single store key. All the collections within the schema must have a
-// unique binary prefix and human-readable name. Schema will eventually include
-// methods for importing/exporting genesis data and for schema reflection for
-// clients.
+// unique binary prefix and human-readable name. Schema includes methods for
+// importing/exporting genesis data and for schema reflection for clients.
 type Schema struct {
 	storeAccessor       func(context.Context) store.KVStore
 	collectionsOrdered  []string
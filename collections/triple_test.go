@@ -0,0 +1,104 @@
+package collections
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriple(t *testing.T) {
+	keyCodec := TripleKeyCodec(StringKey, StringKey, StringKey)
+
+	t.Run("encoding", func(t *testing.T) {
+		key := Join3("a", "b", "c")
+		buffer := make([]byte, keyCodec.Size(key))
+		written, err := keyCodec.Encode(buffer, key)
+		require.NoError(t, err)
+		require.Equal(t, len(buffer), written)
+		read, decoded, err := keyCodec.Decode(buffer)
+		require.NoError(t, err)
+		require.Equal(t, len(buffer), read)
+		require.Equal(t, key, decoded)
+
+		// non terminal encoding, used when the triple is part of another key
+		pairCodec := PairKeyCodec(keyCodec, StringKey)
+		pair := Join(key, "d")
+		buffer = make([]byte, pairCodec.Size(pair))
+		_, err = pairCodec.Encode(buffer, pair)
+		require.NoError(t, err)
+		_, decodedPair, err := pairCodec.Decode(buffer)
+		require.NoError(t, err)
+		require.Equal(t, pair, decodedPair)
+	})
+
+	t.Run("stringify", func(t *testing.T) {
+		s := keyCodec.Stringify(Join3("a", "b", "c"))
+		require.Equal(t, `("a", "b", "c")`, s)
+		s = keyCodec.Stringify(TripleSuperPrefix[string, string, string]("a", "b"))
+		require.Equal(t, `("a", "b", <nil>)`, s)
+		s = keyCodec.Stringify(TriplePrefix[string, string, string]("a"))
+		require.Equal(t, `("a", <nil>, <nil>)`, s)
+		s = keyCodec.Stringify(Triple[string, string, string]{})
+		require.Equal(t, `(<nil>, <nil>, <nil>)`, s)
+		require.Equal(t, "Triple[string, string, string]", keyCodec.KeyType())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := keyCodec.EncodeJSON(Join3("k1", "k2", "k3"))
+		require.NoError(t, err)
+		require.Equal(t, []byte(`["k1","k2","k3"]`), b)
+		key, err := keyCodec.DecodeJSON(b)
+		require.NoError(t, err)
+		require.Equal(t, Join3("k1", "k2", "k3"), key)
+	})
+}
+
+func TestTripleRange(t *testing.T) {
+	sk, ctx := deps()
+	schema := NewSchemaBuilder(sk)
+	m := NewKeySet(schema, NewPrefix(0), "triple", TripleKeyCodec(StringKey, StringKey, Uint64Key))
+
+	keys := []Triple[string, string, uint64]{
+		Join3("A", "A", uint64(0)),
+		Join3("A", "B", uint64(0)),
+		Join3("A", "B", uint64(1)),
+		Join3("AA", "A", uint64(0)),
+		Join3("B", "A", uint64(0)),
+	}
+	for _, key := range keys {
+		require.NoError(t, m.Set(ctx, key))
+	}
+
+	iter, err := m.Iterate(ctx, NewPrefixedTripleRange[string, string, uint64]("A"))
+	require.NoError(t, err)
+	got, err := iter.Keys()
+	require.NoError(t, err)
+	require.Equal(t, keys[:3], got)
+
+	iter, err = m.Iterate(ctx, NewSuperPrefixedTripleRange[string, string, uint64]("A", "B"))
+	require.NoError(t, err)
+	got, err = iter.Keys()
+	require.NoError(t, err)
+	require.Equal(t, keys[1:3], got)
+}
+
+func TestTripleGenesis(t *testing.T) {
+	sk, ctx := deps()
+	schema := NewSchemaBuilder(sk)
+	m := NewMap(schema, NewPrefix(0), "triple", TripleKeyCodec(StringKey, Uint64Key, StringKey), Uint64Value)
+	require.NoError(t, m.Set(ctx, Join3("a", uint64(1), "b"), 1))
+	require.NoError(t, m.Set(ctx, Join3("a", uint64(2), "c"), 2))
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, m.exportGenesis(ctx, buf))
+	require.Equal(t, `[{"key":["a","1","b"],"value":"1"},{"key":["a","2","c"],"value":"2"}]`, buf.String())
+	require.NoError(t, m.validateGenesis(bytes.NewReader(buf.Bytes())))
+
+	sk, ctx = deps()
+	imported := NewMap(NewSchemaBuilder(sk), NewPrefix(0), "triple", TripleKeyCodec(StringKey, Uint64Key, StringKey), Uint64Value)
+	require.NoError(t, imported.importGenesis(ctx, bytes.NewReader(buf.Bytes())))
+	v, err := imported.Get(ctx, Join3("a", uint64(2), "c"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), v)
+}
@@ -160,6 +160,12 @@ require (
 	nhooyr.io/websocket v1.8.6 // indirect
 )
 
+// TODO: remove after the release of the store module
+replace cosmossdk.io/store => ./store
+
+// TODO: remove after the release of the collections module
+replace cosmossdk.io/collections => ./collections
+
 // Below are the long-lived replace of the Cosmos SDK
 replace (
 	// use cosmos fork of keyring
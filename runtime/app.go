@@ -50,6 +50,7 @@ type App struct {
 	msgServiceRouter  *baseapp.MsgServiceRouter
 	appConfig         *appv1alpha1.Config
 	logger            log.Logger
+	schemaRegistry    *SchemaRegistry
 	// initChainer is the init chainer function defined by the app config.
 	// this is only required if the chain wants to add special InitChainer logic.
 	initChainer sdk.InitChainer
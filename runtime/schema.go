@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"fmt"
+	"sort"
+
+	"cosmossdk.io/collections"
+
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+// SchemaRegistry holds the collections.Schema of the modules of an app, by the
+// name of the store key of the store they describe. It allows to decode any raw
+// key value pair of the app state without module specific decoders.
+type SchemaRegistry struct {
+	schemas map[string]collections.Schema
+}
+
+// NewSchemaRegistry returns an empty SchemaRegistry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: map[string]collections.Schema{}}
+}
+
+// Register registers the schema describing the store of the provided store key name.
+func (r *SchemaRegistry) Register(storeKey string, schema collections.Schema) error {
+	if _, ok := r.schemas[storeKey]; ok {
+		return fmt.Errorf("a schema is already registered for store key %q", storeKey)
+	}
+	r.schemas[storeKey] = schema
+	return nil
+}
+
+// Schema returns the schema registered for the provided store key name, if any.
+func (r *SchemaRegistry) Schema(storeKey string) (collections.Schema, bool) {
+	schema, ok := r.schemas[storeKey]
+	return schema, ok
+}
+
+// StoreKeys returns the sorted names of the store keys having a registered schema.
+func (r *SchemaRegistry) StoreKeys() []string {
+	storeKeys := make([]string, 0, len(r.schemas))
+	for storeKey := range r.schemas {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	sort.Strings(storeKeys)
+	return storeKeys
+}
+
+// DecodeKV decodes the raw key value pair of the store of the provided store key name
+// with the schema registered for it, see collections.Schema.DecodeKV.
+func (r *SchemaRegistry) DecodeKV(storeKey string, key, value []byte) (collections.DecodedKV, error) {
+	schema, ok := r.schemas[storeKey]
+	if !ok {
+		return collections.DecodedKV{}, fmt.Errorf("no schema registered for store key %q", storeKey)
+	}
+	return schema.DecodeKV(key, value)
+}
+
+// RegisterStoreDecoders registers in decoders a store decoder based on the registered
+// schemas for each of the stores without a decoder, e.g. for the simulations or the
+// state-diff command. Like the modules store decoders, they panic on the keys they
+// cannot decode.
+func (r *SchemaRegistry) RegisterStoreDecoders(decoders simtypes.StoreDecoderRegistry) {
+	for storeKey, schema := range r.schemas {
+		if _, ok := decoders[storeKey]; ok {
+			continue
+		}
+		decoders[storeKey] = newSchemaStoreDecoder(schema)
+	}
+}
+
+func newSchemaStoreDecoder(schema collections.Schema) func(kvA, kvB kv.Pair) string {
+	return func(kvA, kvB kv.Pair) string {
+		decodedA, err := schema.DecodeKV(kvA.Key, kvA.Value)
+		if err != nil {
+			panic(err)
+		}
+		decodedB, err := schema.DecodeKV(kvB.Key, kvB.Value)
+		if err != nil {
+			panic(err)
+		}
+		return fmt.Sprintf("%s %s\n%s\n%s", decodedA.Collection, decodedA.Key, decodedA.Value, decodedB.Value)
+	}
+}
+
+// SchemaRegistry returns the registry of the collections schemas of the app modules
+// implementing module.HasCollectionsSchema, by the name of their store key. It is built
+// from the modules registered when it is first called. An error is returned if several
+// modules have a schema for the same store key.
+func (a *App) SchemaRegistry() (*SchemaRegistry, error) {
+	if a.schemaRegistry != nil {
+		return a.schemaRegistry, nil
+	}
+
+	registry := NewSchemaRegistry()
+	for _, name := range a.ModuleManager.ModuleNames() {
+		mod, ok := a.ModuleManager.Modules[name].(module.HasCollectionsSchema)
+		if !ok {
+			continue
+		}
+
+		schema := mod.CollectionsSchema()
+		if len(schema.Collections()) == 0 {
+			continue
+		}
+
+		storeKey := name
+		if override := storeKeyOverride(a.config, name); override != nil {
+			storeKey = override.KvStoreKey
+		}
+		if err := registry.Register(storeKey, schema); err != nil {
+			return nil, fmt.Errorf("failed to register the collections schema of module %s: %w", name, err)
+		}
+	}
+
+	a.schemaRegistry = registry
+	return registry, nil
+}
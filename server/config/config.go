@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -75,6 +76,19 @@ type BaseConfig struct {
 	// InterBlockCache enables inter-block caching.
 	InterBlockCache bool `mapstructure:"inter-block-cache"`
 
+	// InterBlockCacheSize sets the number of entries of the inter-block cache of
+	// each store.
+	InterBlockCacheSize uint `mapstructure:"inter-block-cache-size"`
+
+	// InterBlockCacheStoreSizes overrides the number of entries of the
+	// inter-block cache of some stores, in the form {storeKey}={size}.
+	InterBlockCacheStoreSizes []string `mapstructure:"inter-block-cache-store-sizes"`
+
+	// InterBlockCacheWarmUp defines the prefixes of stores whose entries are
+	// loaded into the inter-block cache at startup, in the form
+	// {storeKey}/{hexPrefix}.
+	InterBlockCacheWarmUp []string `mapstructure:"inter-block-cache-warm-up"`
+
 	// IndexEvents defines the set of events in the form {eventType}.{attributeKey},
 	// which informs CometBFT what to index. If empty, all events will be indexed.
 	IndexEvents []string `mapstructure:"index-events"`
@@ -88,6 +102,10 @@ type BaseConfig struct {
 	// IAVLLazyLoading enable/disable the lazy loading of iavl store.
 	IAVLLazyLoading bool `mapstructure:"iavl-lazy-loading"`
 
+	// StateStorage enables the state storage, which serves the historical
+	// queries while the IAVL trees only retain the recent versions.
+	StateStorage bool `mapstructure:"state-storage"`
+
 	// AppDBBackend defines the type of Database to use for the application and snapshots databases.
 	// An empty string indicates that the CometBFT config's DBBackend value should be used.
 	AppDBBackend string `mapstructure:"app-db-backend"`
@@ -139,6 +157,10 @@ type GRPCConfig struct {
 	// MaxSendMsgSize defines the max message size in bytes the server can send.
 	// The default value is math.MaxInt32.
 	MaxSendMsgSize int `mapstructure:"max-send-msg-size"`
+
+	// AdminEnable defines if the node Admin service, which changes the settings
+	// of the running node, should be registered on the gRPC server.
+	AdminEnable bool `mapstructure:"admin-enable"`
 }
 
 // GRPCWebConfig defines configuration for the gRPC-web server.
@@ -166,19 +188,61 @@ type MempoolConfig struct {
 	// unbounded in how many txs it may contain, and a positive value indicates
 	// the maximum amount of txs it may contain.
 	MaxTxs int
+
+	// TTLBlocks defines the number of blocks after which a tx is removed from the
+	// mempool. Zero disables the limit.
+	TTLBlocks int64 `mapstructure:"ttl-blocks"`
+
+	// TTLDuration defines the duration, measured in block time, after which a tx
+	// is removed from the mempool. Zero disables the limit.
+	TTLDuration time.Duration `mapstructure:"ttl-duration"`
+
+	// Recheck defines whether the txs remaining in the mempool are re-validated
+	// by running the AnteHandler after every Commit, evicting the invalid ones.
+	Recheck bool `mapstructure:"recheck"`
+}
+
+// MemIAVLConfig defines the configuration of the memiavl stores, which replace
+// the IAVL stores if enabled.
+type MemIAVLConfig struct {
+	// Enable loads the IAVL stores as memiavl stores, migrating them the first
+	// time.
+	Enable bool `mapstructure:"enable"`
+
+	// SnapshotInterval defines the block interval at which the memiavl stores
+	// write a snapshot of their tree.
+	SnapshotInterval uint32 `mapstructure:"snapshot-interval"`
+
+	// SnapshotKeepRecent defines the number of snapshots to keep besides the
+	// latest one, to which the stores can be rolled back.
+	SnapshotKeepRecent uint32 `mapstructure:"snapshot-keep-recent"`
+
+	// KeepRecent defines the number of versions before the latest one kept in
+	// memory to serve queries.
+	KeepRecent uint32 `mapstructure:"keep-recent"`
 }
 
 // State Streaming configuration
 type (
 	// StreamingConfig defines application configuration for external streaming services
 	StreamingConfig struct {
-		ABCI ABCIListenerConfig `mapstructure:"abci"`
+		ABCI ABCIListenerConfig  `mapstructure:"abci"`
+		File FileStreamingConfig `mapstructure:"file"`
 	}
 	// ABCIListenerConfig defines application configuration for ABCIListener streaming service
 	ABCIListenerConfig struct {
 		Keys          []string `mapstructure:"keys"`
 		Plugin        string   `mapstructure:"plugin"`
 		StopNodeOnErr bool     `mapstructure:"stop-node-on-err"`
+		BufferSize    int      `mapstructure:"buffer-size"`
+	}
+	// FileStreamingConfig defines application configuration for the file streaming service
+	FileStreamingConfig struct {
+		Dir         string   `mapstructure:"dir"`
+		Keys        []string `mapstructure:"keys"`
+		MaxFileSize int64    `mapstructure:"max-file-size"`
+		Fsync       bool     `mapstructure:"fsync"`
+		BufferSize  int      `mapstructure:"buffer-size"`
 	}
 )
 
@@ -192,6 +256,7 @@ type Config struct {
 	GRPC      GRPCConfig       `mapstructure:"grpc"`
 	GRPCWeb   GRPCWebConfig    `mapstructure:"grpc-web"`
 	StateSync StateSyncConfig  `mapstructure:"state-sync"`
+	MemIAVL   MemIAVLConfig    `mapstructure:"memiavl"`
 	Streaming StreamingConfig  `mapstructure:"streaming"`
 	Mempool   MempoolConfig    `mapstructure:"mempool"`
 }
@@ -229,6 +294,7 @@ func DefaultConfig() *Config {
 		BaseConfig: BaseConfig{
 			MinGasPrices:        defaultMinGasPrices,
 			InterBlockCache:     true,
+			InterBlockCacheSize: 1000,
 			Pruning:             pruningtypes.PruningOptionDefault,
 			PruningKeepRecent:   "0",
 			PruningInterval:     "0",
@@ -237,6 +303,7 @@ func DefaultConfig() *Config {
 			IAVLCacheSize:       781250,
 			IAVLDisableFastNode: false,
 			IAVLLazyLoading:     false,
+			StateStorage:        false,
 			AppDBBackend:        "",
 		},
 		Telemetry: telemetry.Config{
@@ -264,11 +331,23 @@ func DefaultConfig() *Config {
 			SnapshotInterval:   0,
 			SnapshotKeepRecent: 2,
 		},
+		MemIAVL: MemIAVLConfig{
+			Enable:             false,
+			SnapshotInterval:   1000,
+			SnapshotKeepRecent: 1,
+			KeepRecent:         10,
+		},
 		Streaming: StreamingConfig{
 			ABCI: ABCIListenerConfig{
 				Keys:          []string{},
 				StopNodeOnErr: true,
 			},
+			File: FileStreamingConfig{
+				Keys:        []string{},
+				MaxFileSize: 128 << 20,
+				Fsync:       true,
+				BufferSize:  100,
+			},
 		},
 		Mempool: MempoolConfig{
 			MaxTxs: 5_000,
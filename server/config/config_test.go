@@ -43,6 +43,14 @@ func TestStreamingConfig(t *testing.T) {
 				Keys:          []string{"one", "two"},
 				Plugin:        "plugin-A",
 				StopNodeOnErr: false,
+				BufferSize:    10,
+			},
+			File: FileStreamingConfig{
+				Dir:         "data/streaming",
+				Keys:        []string{"*"},
+				MaxFileSize: 1024,
+				Fsync:       true,
+				BufferSize:  20,
 			},
 		},
 	}
@@ -60,6 +68,12 @@ func TestStreamingConfig(t *testing.T) {
 		`keys = ["one", "two", ]`,
 		`plugin = "plugin-A"`,
 		`stop-node-on-err = false`,
+		`buffer-size = 10`,
+		`dir = "data/streaming"`,
+		`keys = ["*", ]`,
+		`max-file-size = 1024`,
+		`fsync = true`,
+		`buffer-size = 20`,
 	}
 
 	for _, line := range expectedLines {
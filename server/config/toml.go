@@ -63,6 +63,25 @@ min-retain-blocks = {{ .BaseConfig.MinRetainBlocks }}
 # InterBlockCache enables inter-block caching.
 inter-block-cache = {{ .BaseConfig.InterBlockCache }}
 
+# InterBlockCacheSize sets the number of entries of the inter-block cache of
+# each store.
+inter-block-cache-size = {{ .BaseConfig.InterBlockCacheSize }}
+
+# InterBlockCacheStoreSizes overrides the number of entries of the inter-block
+# cache of some stores, in the form {storeKey}={size}.
+#
+# Example:
+# ["bank=10000", "acc=10000"]
+inter-block-cache-store-sizes = [{{ range .BaseConfig.InterBlockCacheStoreSizes }}{{ printf "%q, " . }}{{end}}]
+
+# InterBlockCacheWarmUp defines the prefixes of stores whose entries are loaded
+# into the inter-block cache at startup, until it is full, in the form
+# {storeKey}/{hexPrefix}.
+#
+# Example (bank balances and auth accounts):
+# ["bank/02", "acc/01"]
+inter-block-cache-warm-up = [{{ range .BaseConfig.InterBlockCacheWarmUp }}{{ printf "%q, " . }}{{end}}]
+
 # IndexEvents defines the set of events in the form {eventType}.{attributeKey},
 # which informs CometBFT what to index. If empty, all events will be indexed.
 #
@@ -81,6 +100,13 @@ iavl-disable-fastnode = {{ .BaseConfig.IAVLDisableFastNode }}
 # Default is false.
 iavl-lazy-loading = {{ .BaseConfig.IAVLLazyLoading }}
 
+# StateStorage enables the state storage, a flat versioned database storing the
+# key/values of every height and serving the queries without proof. The IAVL
+# trees then only retain the recent heights needed for the proofs, and the
+# pruning options above apply to the state storage.
+# Default is false.
+state-storage = {{ .BaseConfig.StateStorage }}
+
 # AppDBBackend defines the database backend type to use for the application and snapshots DBs.
 # An empty string indicates that a fallback will be used.
 # First fallback is the deprecated compile-time types.DBBackend value.
@@ -172,6 +198,11 @@ max-recv-msg-size = "{{ .GRPC.MaxRecvMsgSize }}"
 # The default value is math.MaxInt32.
 max-send-msg-size = "{{ .GRPC.MaxSendMsgSize }}"
 
+# AdminEnable defines if the node Admin service, which changes settings of the
+# running node such as its pruning options, is served by the gRPC server.
+# It must only be enabled when the gRPC server address is not publicly exposed.
+admin-enable = {{ .GRPC.AdminEnable }}
+
 ###############################################################################
 ###                        gRPC Web Configuration                           ###
 ###############################################################################
@@ -198,6 +229,31 @@ snapshot-interval = {{ .StateSync.SnapshotInterval }}
 # snapshot-keep-recent specifies the number of recent snapshots to keep and serve (0 to keep all).
 snapshot-keep-recent = {{ .StateSync.SnapshotKeepRecent }}
 
+###############################################################################
+###                          memiavl Configuration                          ###
+###############################################################################
+
+# memiavl replaces the IAVL stores by memiavl stores, which hold their tree in memory and
+# persist it in data/memiavl.db as snapshots, read from memory maps, and a write-ahead log.
+# They produce the same hashes as the IAVL stores, which are migrated when first loaded.
+# The IAVL pruning options do not apply to them.
+[memiavl]
+
+# enable defines if the IAVL stores are loaded as memiavl stores.
+# NOTE: the IAVL stores are not updated anymore once migrated.
+enable = {{ .MemIAVL.Enable }}
+
+# snapshot-interval specifies the block interval at which a snapshot of the stores is written.
+snapshot-interval = {{ .MemIAVL.SnapshotInterval }}
+
+# snapshot-keep-recent specifies the number of snapshots to keep besides the latest one, to
+# which the stores can be rolled back.
+snapshot-keep-recent = {{ .MemIAVL.SnapshotKeepRecent }}
+
+# keep-recent specifies the number of versions before the latest one kept in memory to serve
+# queries.
+keep-recent = {{ .MemIAVL.KeepRecent }}
+
 ###############################################################################
 ###                              State Streaming                            ###
 ###############################################################################
@@ -224,6 +280,34 @@ plugin = "{{ .Streaming.ABCI.Plugin }}"
 # stop-node-on-err specifies whether to stop the node on message delivery error.
 stop-node-on-err = {{ .Streaming.ABCI.StopNodeOnErr }}
 
+# buffer-size is the number of blocks buffered for the plugin, which is then
+# called asynchronously so that a slow plugin does not stall the commits.
+# The plugin is called synchronously if it is 0.
+buffer-size = {{ .Streaming.ABCI.BufferSize }}
+
+# streaming.file specifies the configuration for the file streaming service,
+# writing the ABCI messages and the state changes of every block to files.
+[streaming.file]
+
+# The directory of the files, relative to the node home if it is not absolute.
+# Streaming to files is only enabled if this is set.
+dir = "{{ .Streaming.File.Dir }}"
+
+# List of kv store keys whose state changes are written, ["*"] for all of them.
+keys = [{{ range .Streaming.File.Keys }}{{ printf "%q, " . }}{{end}}]
+
+# max-file-size is the size in bytes after which a new file is started.
+max-file-size = {{ .Streaming.File.MaxFileSize }}
+
+# fsync specifies whether to sync the files to disk after every block.
+fsync = {{ .Streaming.File.Fsync }}
+
+# buffer-size is the number of blocks buffered before they are written, so that
+# a slow disk does not stall the commits. The blocks are written on commit if it
+# is 0. The blocks of the buffer are lost if the node stops, which is detected
+# by the readers of the files from the sequence of the blocks.
+buffer-size = {{ .Streaming.File.BufferSize }}
+
 ###############################################################################
 ###                         Mempool                                         ###
 ###############################################################################
@@ -236,6 +320,16 @@ stop-node-on-err = {{ .Streaming.ABCI.StopNodeOnErr }}
 # Note, this configuration only applies to SDK built-in app-side mempool
 # implementations.
 max-txs = "{{ .Mempool.MaxTxs }}"
+
+# ttl-blocks defines the number of blocks after which a transaction is removed from the mempool.
+# ttl-duration defines the duration, measured in block time, after which a transaction is removed from the mempool.
+# Setting either to 0 disables the corresponding limit.
+ttl-blocks = {{ .Mempool.TTLBlocks }}
+ttl-duration = "{{ .Mempool.TTLDuration }}"
+
+# recheck defines whether the transactions remaining in the mempool are re-validated by running the
+# AnteHandler after every Commit, evicting the transactions which became invalid.
+recheck = {{ .Mempool.Recheck }}
 `
 
 var configTemplate *template.Template
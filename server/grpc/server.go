@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 
@@ -10,6 +11,7 @@ import (
 	"cosmossdk.io/log"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/server/config"
 	"github.com/cosmos/cosmos-sdk/server/grpc/gogoreflection"
@@ -40,6 +42,16 @@ func NewGRPCServer(clientCtx client.Context, app types.Application, cfg config.G
 
 	app.RegisterGRPCServer(grpcSrv)
 
+	// The Admin service is only registered on the gRPC server, so that it is
+	// not reachable through the ABCI queries.
+	if cfg.AdminEnable {
+		store, ok := app.CommitMultiStore().(node.PruningUpdater)
+		if !ok {
+			return nil, errors.New("the store of the application does not support the Admin service")
+		}
+		node.RegisterAdminService(grpcSrv, store)
+	}
+
 	// Reflection allows consumers to build dynamic clients that can write to any
 	// Cosmos SDK application without relying on application packages at compile
 	// time.
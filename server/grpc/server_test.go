@@ -3,18 +3,26 @@ package grpc_test
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/jhump/protoreflect/grpcreflect"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"cosmossdk.io/collections"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
 	reflectionv1 "github.com/cosmos/cosmos-sdk/client/grpc/reflection"
+	"github.com/cosmos/cosmos-sdk/client/proof"
 	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/codec"
 	reflectionv2 "github.com/cosmos/cosmos-sdk/server/grpc/reflection/v2alpha1"
@@ -105,6 +113,52 @@ func (s *IntegrationTestSuite) TestGRPCServer_BankBalance() {
 	s.Require().Equal([]string{"1"}, blockHeight)
 }
 
+func (s *IntegrationTestSuite) TestGRPCServer_QueryProofs() {
+	val0 := s.network.Validators[0]
+	denom := fmt.Sprintf("%stoken", val0.Moniker)
+	req := &banktypes.QueryBalanceRequest{Address: val0.Address.String(), Denom: denom}
+
+	keyCodec := collections.PairKeyCodec(sdk.AccAddressKey, collections.StringKey)
+	balanceKey := collections.Join(val0.Address, denom)
+	key := make([]byte, keyCodec.Size(balanceKey))
+	_, err := keyCodec.Encode(key, balanceKey)
+	s.Require().NoError(err)
+	key = append(banktypes.BalancesPrefix.Bytes(), key...)
+
+	// the proofs are returned by the gRPC server, and through ABCI
+	for _, conn := range []gogogrpc.ClientConn{s.conn, val0.ClientCtx} {
+		var header, trailer metadata.MD
+		bankRes, err := banktypes.NewQueryClient(conn).Balance(
+			proof.WithProofs(context.Background()), req, grpc.Header(&header), grpc.Trailer(&trailer))
+		s.Require().NoError(err)
+
+		proofs, err := proof.FromTrailer(trailer)
+		s.Require().NoError(err)
+		s.Require().Equal([]string{strconv.FormatInt(proofs.Height, 10)}, header.Get(grpctypes.GRPCBlockHeightHeader))
+
+		// the app hash of the state queried is committed by the next block
+		nextHeight := proofs.Height + 1
+		_, err = s.network.WaitForHeight(nextHeight)
+		s.Require().NoError(err)
+		block, err := val0.RPCClient.Block(context.Background(), &nextHeight)
+		s.Require().NoError(err)
+		s.Require().NoError(proof.Verify(proofs, block.Block.AppHash))
+
+		value, found := proofs.Value(banktypes.StoreKey, key)
+		s.Require().True(found)
+		amount, err := banktypes.NewBalanceCompatValueCodec().Decode(value)
+		s.Require().NoError(err)
+		s.Require().Equal(bankRes.Balance.Amount, amount)
+	}
+}
+
+func (s *IntegrationTestSuite) TestGRPCServer_AdminDisabled() {
+	// the Admin service is not served unless enabled in app.toml
+	adminClient := node.NewAdminClient(s.conn)
+	_, err := adminClient.UpdatePruning(context.Background(), &node.UpdatePruningRequest{Pruning: "everything"})
+	s.Require().Equal(codes.Unimplemented, status.Code(err))
+}
+
 func (s *IntegrationTestSuite) TestGRPCServer_Reflection() {
 	// Test server reflection
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
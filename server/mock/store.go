@@ -146,6 +146,14 @@ func (ms multiStore) SetLazyLoading(bool) {
 	panic("not implemented")
 }
 
+func (ms multiStore) SetStateStorage(dbm.DB) {
+	panic("not implemented")
+}
+
+func (ms multiStore) SetMemIAVL(storetypes.MemIAVLOptions) {
+	panic("not implemented")
+}
+
 func (ms multiStore) SetInitialVersion(version int64) error {
 	panic("not implemented")
 }
@@ -172,6 +180,10 @@ func (ms multiStore) WorkingHash() []byte {
 	panic("not implemented")
 }
 
+func (ms multiStore) Close() error {
+	return nil
+}
+
 var _ storetypes.KVStore = kvStore{}
 
 type kvStore struct {
@@ -60,11 +60,23 @@ const (
 	FlagIAVLCacheSize       = "iavl-cache-size"
 	FlagDisableIAVLFastNode = "iavl-disable-fastnode"
 	FlagIAVLLazyLoading     = "iavl-lazy-loading"
+	FlagStateStorage        = "state-storage"
+
+	// inter-block cache-related flags
+	FlagInterBlockCacheSize       = "inter-block-cache-size"
+	FlagInterBlockCacheStoreSizes = "inter-block-cache-store-sizes"
+	FlagInterBlockCacheWarmUp     = "inter-block-cache-warm-up"
 
 	// state sync-related flags
 	FlagStateSyncSnapshotInterval   = "state-sync.snapshot-interval"
 	FlagStateSyncSnapshotKeepRecent = "state-sync.snapshot-keep-recent"
 
+	// memiavl-related flags
+	FlagMemIAVLEnable             = "memiavl.enable"
+	FlagMemIAVLSnapshotInterval   = "memiavl.snapshot-interval"
+	FlagMemIAVLSnapshotKeepRecent = "memiavl.snapshot-keep-recent"
+	FlagMemIAVLKeepRecent         = "memiavl.keep-recent"
+
 	// api-related flags
 	FlagAPIEnable             = "api.enable"
 	FlagAPISwagger            = "api.swagger"
@@ -82,7 +94,10 @@ const (
 	flagGRPCWebEnable = "grpc-web.enable"
 
 	// mempool flags
-	FlagMempoolMaxTxs = "mempool.max-txs"
+	FlagMempoolMaxTxs      = "mempool.max-txs"
+	FlagMempoolTTLBlocks   = "mempool.ttl-blocks"
+	FlagMempoolTTLDuration = "mempool.ttl-duration"
+	FlagMempoolRecheck     = "mempool.recheck"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -162,6 +177,9 @@ is performed. Note, when enabled, gRPC will also be automatically enabled.
 	cmd.Flags().Uint64(FlagHaltHeight, 0, "Block height at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Uint64(FlagHaltTime, 0, "Minimum block time (in Unix seconds) at which to gracefully halt the chain and shutdown the node")
 	cmd.Flags().Bool(FlagInterBlockCache, true, "Enable inter-block caching")
+	cmd.Flags().Uint(FlagInterBlockCacheSize, 1000, "Number of entries of the inter-block cache of each store")
+	cmd.Flags().StringSlice(FlagInterBlockCacheStoreSizes, []string{}, "Number of entries of the inter-block cache of some stores (e.g. bank=10000)")
+	cmd.Flags().StringSlice(FlagInterBlockCacheWarmUp, []string{}, "Hex-encoded prefixes of stores loaded into the inter-block cache at startup (e.g. bank/02,acc/01)")
 	cmd.Flags().String(flagCPUProfile, "", "Enable CPU profiling and write to the provided file")
 	cmd.Flags().Bool(FlagTrace, false, "Provide full stack traces for errors in ABCI Log")
 	cmd.Flags().String(FlagPruning, pruningtypes.PruningOptionDefault, "Pruning strategy (default|nothing|everything|custom)")
@@ -183,8 +201,16 @@ is performed. Note, when enabled, gRPC will also be automatically enabled.
 	cmd.Flags().Bool(flagGRPCWebEnable, true, "Define if the gRPC-Web server should be enabled. (Note: gRPC must also be enabled)")
 	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval")
 	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "State sync snapshot to keep")
+
+	cmd.Flags().Bool(FlagMemIAVLEnable, false, "Load the IAVL stores as memiavl stores, held in memory and persisted as snapshots and a write-ahead log")
+	cmd.Flags().Uint32(FlagMemIAVLSnapshotInterval, 1000, "Block interval at which the memiavl stores write a snapshot")
+	cmd.Flags().Uint32(FlagMemIAVLSnapshotKeepRecent, 1, "Number of memiavl snapshots to keep besides the latest one")
+	cmd.Flags().Uint32(FlagMemIAVLKeepRecent, 10, "Number of versions before the latest one the memiavl stores keep in memory for queries")
 	cmd.Flags().Bool(FlagDisableIAVLFastNode, false, "Disable fast node for IAVL tree")
 	cmd.Flags().Int(FlagMempoolMaxTxs, mempool.DefaultMaxTx, "Sets MaxTx value for the app-side mempool")
+	cmd.Flags().Int64(FlagMempoolTTLBlocks, 0, "Number of blocks after which a tx is removed from the app-side mempool (0 disables)")
+	cmd.Flags().Duration(FlagMempoolTTLDuration, 0, "Block time duration after which a tx is removed from the app-side mempool (0 disables)")
+	cmd.Flags().Bool(FlagMempoolRecheck, false, "Re-validate the app-side mempool txs with the AnteHandler after every Commit")
 
 	// support old flags name for backwards compatibility
 	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -255,7 +281,12 @@ func startStandAlone(svrCtx *Context, appCreator types.AppCreator) error {
 		return svr.Stop()
 	})
 
-	return g.Wait()
+	err = g.Wait()
+	if closeErr := app.Close(); closeErr != nil {
+		svrCtx.Logger.Error("failed to close the application", "err", closeErr)
+	}
+
+	return err
 }
 
 func startInProcess(svrCtx *Context, clientCtx client.Context, appCreator types.AppCreator) error {
@@ -459,6 +490,10 @@ func startInProcess(svrCtx *Context, clientCtx client.Context, appCreator types.
 			_ = tmNode.Stop()
 		}
 
+		if err := app.Close(); err != nil {
+			svrCtx.Logger.Error("failed to close the application", "err", err)
+		}
+
 		if traceWriterCleanup != nil {
 			traceWriterCleanup()
 		}
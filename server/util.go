@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -24,7 +25,8 @@ import (
 	"github.com/spf13/viper"
 
 	"cosmossdk.io/log"
-	"cosmossdk.io/store"
+	"cosmossdk.io/store/cache"
+	storemetrics "cosmossdk.io/store/metrics"
 	"cosmossdk.io/store/snapshots"
 	snapshottypes "cosmossdk.io/store/snapshots/types"
 	storetypes "cosmossdk.io/store/types"
@@ -334,6 +336,86 @@ func AddCommands(rootCmd *cobra.Command, defaultNodeHome string, appCreator type
 	)
 }
 
+// GetSnapshotStore opens the snapshot store of the node home directory given by
+// the home flag of appOpts.
+func GetSnapshotStore(appOpts types.AppOptions) (*snapshots.Store, error) {
+	homeDir := cast.ToString(appOpts.Get(flags.FlagHome))
+	snapshotDir := filepath.Join(homeDir, "data", "snapshots")
+	if err := os.MkdirAll(snapshotDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create snapshots directory: %w", err)
+	}
+
+	snapshotDB, err := dbm.NewDB("metadata", GetAppDBBackend(appOpts), snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots.NewStore(snapshotDB, snapshotDir)
+}
+
+// GetInterBlockCacheFromFlags returns the inter-block cache of the stores
+// configured by the inter-block-cache flags of appOpts, or nil if it is
+// disabled. The hits, misses and evictions of the caches are emitted as metrics
+// when telemetry is enabled.
+func GetInterBlockCacheFromFlags(appOpts types.AppOptions) (storetypes.MultiStorePersistentCache, error) {
+	if !cast.ToBool(appOpts.Get(FlagInterBlockCache)) {
+		return nil, nil
+	}
+
+	size := cast.ToUint(appOpts.Get(FlagInterBlockCacheSize))
+	if size == 0 {
+		size = cache.DefaultCommitKVStoreCacheSize
+	}
+	manager := cache.NewCommitKVStoreCacheManager(size)
+
+	for _, storeSize := range cast.ToStringSlice(appOpts.Get(FlagInterBlockCacheStoreSizes)) {
+		name, value, ok := strings.Cut(storeSize, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid inter-block cache store size %q, expected {storeKey}={size}", storeSize)
+		}
+		size, err := cast.ToUintE(value)
+		if err != nil || size == 0 {
+			return nil, fmt.Errorf("invalid inter-block cache size of store %s: %q", name, value)
+		}
+		manager.SetStoreCacheSize(name, size)
+	}
+
+	for _, warmUp := range cast.ToStringSlice(appOpts.Get(FlagInterBlockCacheWarmUp)) {
+		name, hexPrefix, ok := strings.Cut(warmUp, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid inter-block cache warm-up prefix %q, expected {storeKey}/{hexPrefix}", warmUp)
+		}
+		prefix, err := hex.DecodeString(hexPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inter-block cache warm-up prefix of store %s: %w", name, err)
+		}
+		manager.SetWarmUpPrefixes(name, prefix)
+	}
+
+	if cast.ToBool(appOpts.Get("telemetry.enabled")) {
+		manager.SetMetrics(storemetrics.NewMetrics(getTelemetryGlobalLabels(appOpts)))
+	}
+
+	return manager, nil
+}
+
+// getTelemetryGlobalLabels returns the global labels of the telemetry
+// configuration of appOpts.
+func getTelemetryGlobalLabels(appOpts types.AppOptions) [][]string {
+	if labels, ok := appOpts.Get("telemetry.global-labels").([][]string); ok {
+		return labels
+	}
+
+	var labels [][]string
+	for _, label := range cast.ToSlice(appOpts.Get("telemetry.global-labels")) {
+		if label := cast.ToStringSlice(label); len(label) == 2 {
+			labels = append(labels, label)
+		}
+	}
+
+	return labels
+}
+
 // https://stackoverflow.com/questions/23558425/how-do-i-get-the-local-ip-address-in-go
 // TODO there must be a better way to get external IP
 func ExternalIP() (string, error) {
@@ -445,10 +527,9 @@ func openTraceWriter(traceWriterFile string) (w io.WriteCloser, err error) {
 
 // DefaultBaseappOptions returns the default baseapp options provided by the Cosmos SDK
 func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
-	var cache storetypes.MultiStorePersistentCache
-
-	if cast.ToBool(appOpts.Get(FlagInterBlockCache)) {
-		cache = store.NewCommitKVStoreCacheManager()
+	interBlockCache, err := GetInterBlockCacheFromFlags(appOpts)
+	if err != nil {
+		panic(err)
 	}
 
 	pruningOpts, err := GetPruningOptionsFromFlags(appOpts)
@@ -468,16 +549,7 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 		chainID = appGenesis.ChainID
 	}
 
-	snapshotDir := filepath.Join(homeDir, "data", "snapshots")
-	if err = os.MkdirAll(snapshotDir, os.ModePerm); err != nil {
-		panic(fmt.Errorf("failed to create snapshots directory: %w", err))
-	}
-
-	snapshotDB, err := dbm.NewDB("metadata", GetAppDBBackend(appOpts), snapshotDir)
-	if err != nil {
-		panic(err)
-	}
-	snapshotStore, err := snapshots.NewStore(snapshotDB, snapshotDir)
+	snapshotStore, err := GetSnapshotStore(appOpts)
 	if err != nil {
 		panic(err)
 	}
@@ -487,13 +559,44 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 		cast.ToUint32(appOpts.Get(FlagStateSyncSnapshotKeepRecent)),
 	)
 
+	mempoolTTL := mempool.TxTTL{
+		Blocks:   cast.ToInt64(appOpts.Get(FlagMempoolTTLBlocks)),
+		Duration: cast.ToDuration(appOpts.Get(FlagMempoolTTLDuration)),
+	}
+
+	var mempoolRecheck func(*baseapp.BaseApp)
+	if cast.ToBool(appOpts.Get(FlagMempoolRecheck)) {
+		mempoolRecheck = func(app *baseapp.BaseApp) { app.SetMempoolRecheck(app.AnteRecheckHandler()) }
+	} else {
+		mempoolRecheck = baseapp.SetMempoolRecheck(nil)
+	}
+
+	stateStorage := func(*baseapp.BaseApp) {}
+	if cast.ToBool(appOpts.Get(FlagStateStorage)) {
+		stateStorageDB, err := dbm.NewDB("state_storage", GetAppDBBackend(appOpts), filepath.Join(homeDir, "data"))
+		if err != nil {
+			panic(err)
+		}
+		stateStorage = baseapp.SetStateStorage(stateStorageDB)
+	}
+
+	memIAVL := func(*baseapp.BaseApp) {}
+	if cast.ToBool(appOpts.Get(FlagMemIAVLEnable)) {
+		memIAVL = baseapp.SetMemIAVL(storetypes.MemIAVLOptions{
+			Dir:                filepath.Join(homeDir, "data", "memiavl.db"),
+			SnapshotInterval:   cast.ToUint32(appOpts.Get(FlagMemIAVLSnapshotInterval)),
+			SnapshotKeepRecent: cast.ToUint32(appOpts.Get(FlagMemIAVLSnapshotKeepRecent)),
+			KeepRecent:         cast.ToUint32(appOpts.Get(FlagMemIAVLKeepRecent)),
+		})
+	}
+
 	return []func(*baseapp.BaseApp){
 		baseapp.SetPruning(pruningOpts),
 		baseapp.SetMinGasPrices(cast.ToString(appOpts.Get(FlagMinGasPrices))),
 		baseapp.SetHaltHeight(cast.ToUint64(appOpts.Get(FlagHaltHeight))),
 		baseapp.SetHaltTime(cast.ToUint64(appOpts.Get(FlagHaltTime))),
 		baseapp.SetMinRetainBlocks(cast.ToUint64(appOpts.Get(FlagMinRetainBlocks))),
-		baseapp.SetInterBlockCache(cache),
+		baseapp.SetInterBlockCache(interBlockCache),
 		baseapp.SetTrace(cast.ToBool(appOpts.Get(FlagTrace))),
 		baseapp.SetIndexEvents(cast.ToStringSlice(appOpts.Get(FlagIndexEvents))),
 		baseapp.SetSnapshot(snapshotStore, snapshotOptions),
@@ -502,9 +605,13 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 		baseapp.SetMempool(
 			mempool.NewSenderNonceMempool(
 				mempool.SenderNonceMaxTxOpt(cast.ToInt(appOpts.Get(FlagMempoolMaxTxs))),
+				mempool.SenderNonceTTLOpt(mempoolTTL),
 			),
 		),
+		mempoolRecheck,
 		baseapp.SetIAVLLazyLoading(cast.ToBool(appOpts.Get(FlagIAVLLazyLoading))),
+		stateStorage,
+		memIAVL,
 		baseapp.SetChainID(chainID),
 	}
 }
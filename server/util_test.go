@@ -14,6 +14,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
+	"cosmossdk.io/store/cache"
+	"cosmossdk.io/store/mem"
+	storetypes "cosmossdk.io/store/types"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/server"
@@ -452,3 +456,37 @@ func (m mapGetter) Get(key string) interface{} {
 }
 
 var _ servertypes.AppOptions = mapGetter{}
+
+func TestGetInterBlockCacheFromFlags(t *testing.T) {
+	interBlockCache, err := server.GetInterBlockCacheFromFlags(mapGetter{server.FlagInterBlockCache: false})
+	require.NoError(t, err)
+	require.Nil(t, interBlockCache)
+
+	for _, appOpts := range []mapGetter{
+		{server.FlagInterBlockCache: true, server.FlagInterBlockCacheStoreSizes: []string{"bank"}},
+		{server.FlagInterBlockCache: true, server.FlagInterBlockCacheStoreSizes: []string{"bank=0"}},
+		{server.FlagInterBlockCache: true, server.FlagInterBlockCacheWarmUp: []string{"bank"}},
+		{server.FlagInterBlockCache: true, server.FlagInterBlockCacheWarmUp: []string{"bank/0x02"}},
+	} {
+		_, err := server.GetInterBlockCacheFromFlags(appOpts)
+		require.Error(t, err)
+	}
+
+	interBlockCache, err = server.GetInterBlockCacheFromFlags(mapGetter{
+		server.FlagInterBlockCache:           true,
+		server.FlagInterBlockCacheSize:       uint(10),
+		server.FlagInterBlockCacheStoreSizes: []string{"bank=1"},
+		server.FlagInterBlockCacheWarmUp:     []string{"bank/02"},
+	})
+	require.NoError(t, err)
+
+	bankStore := mem.NewStore()
+	bankStore.Set([]byte{0x02, 0x01}, []byte("balance"))
+	bankStore.Set([]byte{0x02, 0x02}, []byte("balance"))
+	kvStore := interBlockCache.GetStoreCache(storetypes.NewKVStoreKey("bank"), bankStore)
+
+	// only the first balance is loaded in the cache of a single entry
+	require.Equal(t, []byte("balance"), kvStore.Get([]byte{0x02, 0x01}))
+	require.Equal(t, []byte("balance"), kvStore.Get([]byte{0x02, 0x02}))
+	require.Equal(t, cache.CacheStats{Hits: 1, Misses: 1, Evictions: 1}, kvStore.(*cache.CommitKVStoreCache).Stats())
+}
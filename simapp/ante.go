@@ -0,0 +1,86 @@
+package simapp
+
+import (
+	"errors"
+
+	circuitante "cosmossdk.io/x/circuit/ante"
+	feemarketante "cosmossdk.io/x/feemarket/ante"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+)
+
+// HandlerOptions are the options required for constructing a default SDK AnteHandler.
+type HandlerOptions struct {
+	ante.HandlerOptions
+	CircuitKeeper   circuitante.CircuitBreaker
+	FeeMarketKeeper feemarketante.FeeMarketKeeper
+}
+
+// NewAnteHandler returns an AnteHandler that checks and increments sequence
+// numbers, checks signatures & account numbers, and deducts fees from the first
+// signer.
+func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
+	if options.AccountKeeper == nil {
+		return nil, errors.New("account keeper is required for ante builder")
+	}
+
+	if options.BankKeeper == nil {
+		return nil, errors.New("bank keeper is required for ante builder")
+	}
+
+	if options.SignModeHandler == nil {
+		return nil, errors.New("sign mode handler is required for ante builder")
+	}
+
+	if options.CircuitKeeper == nil {
+		return nil, errors.New("circuit keeper is required for ante builder")
+	}
+
+	if options.FeeMarketKeeper == nil {
+		return nil, errors.New("fee market keeper is required for ante builder")
+	}
+
+	anteDecorators := []sdk.AnteDecorator{
+		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
+		circuitante.NewCircuitBreakerDecorator(options.CircuitKeeper),
+		ante.NewExtensionOptionsDecorator(options.ExtensionOptionChecker),
+		ante.NewValidateBasicDecorator(),
+		ante.NewTxTimeoutHeightDecorator(),
+		ante.NewUnorderedTxDecorator(ante.DefaultMaxUnorderedTxTimeoutHeight, ante.DefaultMaxUnorderedTxTimeoutDuration, options.UnorderedTxKeeper),
+		ante.NewValidateMemoDecorator(options.AccountKeeper),
+		ante.NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
+		ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, options.TxFeeChecker),
+		feemarketante.NewBaseFeeDecorator(options.FeeMarketKeeper), // BaseFeeDecorator must be called after DeductFeeDecorator
+		ante.NewSetPubKeyDecorator(options.AccountKeeper),          // SetPubKeyDecorator must be called before all signature verification decorators
+		ante.NewValidateSigCountDecorator(options.AccountKeeper),
+		ante.NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer),
+		ante.NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler),
+		ante.NewIncrementSequenceDecorator(options.AccountKeeper),
+	}
+
+	return sdk.ChainAnteDecorators(anteDecorators...), nil
+}
+
+func (app *SimApp) setAnteHandler(txConfig client.TxConfig) {
+	anteHandler, err := NewAnteHandler(
+		HandlerOptions{
+			ante.HandlerOptions{
+				AccountKeeper:     app.AccountKeeper,
+				BankKeeper:        app.BankKeeper,
+				SignModeHandler:   txConfig.SignModeHandler(),
+				FeegrantKeeper:    app.FeeGrantKeeper,
+				SigGasConsumer:    ante.DefaultSigVerificationGasConsumer,
+				UnorderedTxKeeper: app.AccountKeeper,
+			},
+			&app.CircuitKeeper,
+			app.FeeMarketKeeper,
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	app.SetAnteHandler(anteHandler)
+}
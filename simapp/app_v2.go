@@ -12,6 +12,8 @@ import (
 
 	"cosmossdk.io/client/v2/autocli"
 	"cosmossdk.io/depinject"
+	"cosmossdk.io/x/circuit"
+	circuitkeeper "cosmossdk.io/x/circuit/keeper"
 	"cosmossdk.io/x/evidence"
 	evidencekeeper "cosmossdk.io/x/evidence/keeper"
 	nftkeeper "cosmossdk.io/x/nft/keeper"
@@ -20,6 +22,8 @@ import (
 	storetypes "cosmossdk.io/store/types"
 	feegrantkeeper "cosmossdk.io/x/feegrant/keeper"
 	feegrantmodule "cosmossdk.io/x/feegrant/module"
+	"cosmossdk.io/x/feemarket"
+	feemarketkeeper "cosmossdk.io/x/feemarket/keeper"
 	"cosmossdk.io/x/upgrade"
 	upgradekeeper "cosmossdk.io/x/upgrade/keeper"
 
@@ -99,6 +103,8 @@ var (
 		vesting.AppModuleBasic{},
 		nftmodule.AppModuleBasic{},
 		consensus.AppModuleBasic{},
+		circuit.AppModuleBasic{},
+		feemarket.AppModuleBasic{},
 	)
 )
 
@@ -135,6 +141,8 @@ type SimApp struct {
 	GroupKeeper           groupkeeper.Keeper
 	NFTKeeper             nftkeeper.Keeper
 	ConsensusParamsKeeper consensuskeeper.Keeper
+	CircuitKeeper         circuitkeeper.Keeper
+	FeeMarketKeeper       feemarketkeeper.Keeper
 
 	// simulation manager
 	sm *module.SimulationManager
@@ -219,6 +227,8 @@ func NewSimApp(
 		&app.GroupKeeper,
 		&app.NFTKeeper,
 		&app.ConsensusParamsKeeper,
+		&app.CircuitKeeper,
+		&app.FeeMarketKeeper,
 	); err != nil {
 		panic(err)
 	}
@@ -251,6 +261,10 @@ func NewSimApp(
 
 	app.App = appBuilder.Build(db, traceStore, baseAppOptions...)
 
+	// the circuit breaker is checked by the msg service router for every msg,
+	// including the ones nested in authz or group executions.
+	app.SetCircuitBreaker(&app.CircuitKeeper)
+
 	// register streaming services
 	if err := app.RegisterStreamingServices(appOpts, app.kvStoreKeys()); err != nil {
 		panic(err)
@@ -276,6 +290,12 @@ func NewSimApp(
 	app.sm = module.NewSimulationManagerFromAppModules(app.ModuleManager.Modules, overrideModules)
 
 	app.sm.RegisterStoreDecoders()
+	// decode the stores of the modules without store decoders with their collections schema
+	schemaRegistry, err := app.SchemaRegistry()
+	if err != nil {
+		panic(err)
+	}
+	schemaRegistry.RegisterStoreDecoders(app.sm.StoreDecoders)
 
 	// A custom InitChainer can be set if extra pre-init-genesis logic is required.
 	// By default, when using app wiring enabled module, this is not required.
@@ -288,6 +308,9 @@ func NewSimApp(
 	// 	return app.App.InitChainer(ctx, req)
 	// })
 
+	// set the ante handler including the circuit breaker decorator
+	app.setAnteHandler(app.txConfig)
+
 	if err := app.Load(loadLatest); err != nil {
 		panic(err)
 	}
@@ -2,8 +2,12 @@ package cache
 
 import (
 	"fmt"
+	"sync/atomic"
+
+	gometrics "github.com/armon/go-metrics"
 
 	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/metrics"
 	"cosmossdk.io/store/types"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -16,6 +20,10 @@ var (
 	// DefaultCommitKVStoreCacheSize defines the persistent ARC cache size for a
 	// CommitKVStoreCache.
 	DefaultCommitKVStoreCacheSize uint = 1000
+
+	hitKeys      = []string{"store", "cache", "hit"}
+	missKeys     = []string{"store", "cache", "miss"}
+	evictionKeys = []string{"store", "cache", "eviction"}
 )
 
 type (
@@ -25,9 +33,27 @@ type (
 	// and cached. Deletes and writes always happen to both the cache and the
 	// CommitKVStore in a write-through manner. Caching performed in the
 	// CommitKVStore and below is completely irrelevant to this layer.
+	//
+	// The hits, misses and evictions of the cache are counted, see Stats, and
+	// emitted as metrics labeled with the name of the store.
 	CommitKVStoreCache struct {
 		types.CommitKVStore
 		cache *lru.ARCCache
+		size  uint
+
+		metrics   metrics.StoreMetrics
+		labels    []gometrics.Label
+		hits      atomic.Uint64
+		misses    atomic.Uint64
+		evictions atomic.Uint64
+	}
+
+	// CacheStats defines the counters of the reads and evictions of a
+	// CommitKVStoreCache.
+	CacheStats struct {
+		Hits      uint64
+		Misses    uint64
+		Evictions uint64
 	}
 
 	// CommitKVStoreCacheManager maintains a mapping from a StoreKey to a
@@ -35,8 +61,11 @@ type (
 	// in an inter-block (persistent) manner and typically provided by a
 	// CommitMultiStore.
 	CommitKVStoreCacheManager struct {
-		cacheSize uint
-		caches    map[string]types.CommitKVStore
+		cacheSize  uint
+		storeSizes map[string]uint
+		warmUp     map[string][][]byte
+		metrics    metrics.StoreMetrics
+		caches     map[string]types.CommitKVStore
 	}
 )
 
@@ -49,25 +78,62 @@ func NewCommitKVStoreCache(store types.CommitKVStore, size uint) *CommitKVStoreC
 	return &CommitKVStoreCache{
 		CommitKVStore: store,
 		cache:         cache,
+		size:          size,
+		metrics:       metrics.NewNoOpMetrics(),
 	}
 }
 
 func NewCommitKVStoreCacheManager(size uint) *CommitKVStoreCacheManager {
 	return &CommitKVStoreCacheManager{
-		cacheSize: size,
-		caches:    make(map[string]types.CommitKVStore),
+		cacheSize:  size,
+		storeSizes: make(map[string]uint),
+		warmUp:     make(map[string][][]byte),
+		metrics:    metrics.NewNoOpMetrics(),
+		caches:     make(map[string]types.CommitKVStore),
 	}
 }
 
+// SetStoreCacheSize sets the size of the cache of the store named name,
+// overriding the size of the manager. It must be called before the cache of
+// the store is created.
+func (cmgr *CommitKVStoreCacheManager) SetStoreCacheSize(name string, size uint) {
+	cmgr.storeSizes[name] = size
+}
+
+// SetWarmUpPrefixes sets the prefixes of the store named name whose entries are
+// loaded into its cache when it is created, e.g. when the node starts, until
+// the cache is full.
+func (cmgr *CommitKVStoreCacheManager) SetWarmUpPrefixes(name string, prefixes ...[]byte) {
+	cmgr.warmUp[name] = append(cmgr.warmUp[name], prefixes...)
+}
+
+// SetMetrics sets the metrics gatherer to which the hits, misses and evictions
+// of the caches created are emitted.
+func (cmgr *CommitKVStoreCacheManager) SetMetrics(metrics metrics.StoreMetrics) {
+	cmgr.metrics = metrics
+}
+
 // GetStoreCache returns a Cache from the CommitStoreCacheManager for a given
-// StoreKey. If no Cache exists for the StoreKey, then one is created and set.
-// The returned Cache is meant to be used in a persistent manner.
+// StoreKey. If no Cache exists for the StoreKey, or if it wraps another store
+// than the one given, e.g. after the stores are loaded again, then one is
+// created and set. The returned Cache is meant to be used in a persistent
+// manner.
 func (cmgr *CommitKVStoreCacheManager) GetStoreCache(key types.StoreKey, store types.CommitKVStore) types.CommitKVStore {
-	if cmgr.caches[key.Name()] == nil {
-		cmgr.caches[key.Name()] = NewCommitKVStoreCache(store, cmgr.cacheSize)
+	name := key.Name()
+	if ckv, ok := cmgr.caches[name]; !ok || ckv.(*CommitKVStoreCache).CommitKVStore != store {
+		size, ok := cmgr.storeSizes[name]
+		if !ok {
+			size = cmgr.cacheSize
+		}
+
+		cache := NewCommitKVStoreCache(store, size)
+		cache.metrics = cmgr.metrics
+		cache.labels = []gometrics.Label{{Name: "store_key", Value: name}}
+		cache.WarmUp(cmgr.warmUp[name]...)
+		cmgr.caches[name] = cache
 	}
 
-	return cmgr.caches[key.Name()]
+	return cmgr.caches[name]
 }
 
 // Unwrap returns the underlying CommitKVStore for a given StoreKey.
@@ -79,6 +145,16 @@ func (cmgr *CommitKVStoreCacheManager) Unwrap(key types.StoreKey) types.CommitKV
 	return nil
 }
 
+// Stats returns the counters of the caches, by store name.
+func (cmgr *CommitKVStoreCacheManager) Stats() map[string]CacheStats {
+	stats := make(map[string]CacheStats, len(cmgr.caches))
+	for name, ckv := range cmgr.caches {
+		stats[name] = ckv.(*CommitKVStoreCache).Stats()
+	}
+
+	return stats
+}
+
 // Reset resets in the internal caches.
 func (cmgr *CommitKVStoreCacheManager) Reset() {
 	// Clear the map.
@@ -104,12 +180,16 @@ func (ckv *CommitKVStoreCache) Get(key []byte) []byte {
 	valueI, ok := ckv.cache.Get(keyStr)
 	if ok {
 		// cache hit
+		ckv.hits.Add(1)
+		ckv.metrics.IncrCounterWithLabels(hitKeys, 1, ckv.labels)
 		return valueI.([]byte)
 	}
 
 	// cache miss; write to cache
+	ckv.misses.Add(1)
+	ckv.metrics.IncrCounterWithLabels(missKeys, 1, ckv.labels)
 	value := ckv.CommitKVStore.Get(key)
-	ckv.cache.Add(keyStr, value)
+	ckv.add(keyStr, value)
 
 	return value
 }
@@ -120,7 +200,7 @@ func (ckv *CommitKVStoreCache) Set(key, value []byte) {
 	types.AssertValidKey(key)
 	types.AssertValidValue(value)
 
-	ckv.cache.Add(string(key), value)
+	ckv.add(string(key), value)
 	ckv.CommitKVStore.Set(key, value)
 }
 
@@ -130,3 +210,40 @@ func (ckv *CommitKVStoreCache) Delete(key []byte) {
 	ckv.cache.Remove(string(key))
 	ckv.CommitKVStore.Delete(key)
 }
+
+// WarmUp loads the entries of the underlying CommitKVStore under the prefixes
+// into the cache, until it is full, and returns the number of entries loaded.
+func (ckv *CommitKVStoreCache) WarmUp(prefixes ...[]byte) int {
+	loaded := 0
+	for _, prefix := range prefixes {
+		it := types.KVStorePrefixIterator(ckv.CommitKVStore, prefix)
+		for ; it.Valid() && uint(ckv.cache.Len()) < ckv.size; it.Next() {
+			ckv.cache.Add(string(it.Key()), it.Value())
+			loaded++
+		}
+		it.Close()
+	}
+
+	return loaded
+}
+
+// Stats returns the counters of the cache. They are updated concurrently with
+// the reads and writes, so that they may be off by a few reads when the cache is
+// used by several goroutines.
+func (ckv *CommitKVStoreCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:      ckv.hits.Load(),
+		Misses:    ckv.misses.Load(),
+		Evictions: ckv.evictions.Load(),
+	}
+}
+
+// add adds a key/value pair to the cache, counting the eviction of another
+// entry when the cache is full.
+func (ckv *CommitKVStoreCache) add(key string, value []byte) {
+	if uint(ckv.cache.Len()) >= ckv.size && !ckv.cache.Contains(key) {
+		ckv.evictions.Add(1)
+		ckv.metrics.IncrCounterWithLabels(evictionKeys, 1, ckv.labels)
+	}
+	ckv.cache.Add(key, value)
+}
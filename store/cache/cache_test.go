@@ -102,3 +102,63 @@ func TestCacheWrap(t *testing.T) {
 	cacheWrapper := mngr.GetStoreCache(sKey, store).CacheWrap()
 	require.IsType(t, &cachekv.Store{}, cacheWrapper)
 }
+
+func TestStoreCacheSizeAndStats(t *testing.T) {
+	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize)
+	mngr.SetStoreCacheSize("small", 2)
+
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 100, false)
+	require.NoError(t, err)
+	store := iavlstore.UnsafeNewStore(tree)
+	kvStore := mngr.GetStoreCache(types.NewKVStoreKey("small"), store)
+
+	for i := 0; i < 4; i++ {
+		kvStore.Set([]byte(fmt.Sprintf("key_%d", i)), []byte("value"))
+	}
+	kvStore.Get([]byte("key_3"))
+	kvStore.Get([]byte("key_0"))
+	kvStore.Get([]byte("key_0"))
+
+	expected := cache.CacheStats{Hits: 2, Misses: 1, Evictions: 3}
+	require.Equal(t, expected, kvStore.(*cache.CommitKVStoreCache).Stats())
+	require.Equal(t, map[string]cache.CacheStats{"small": expected}, mngr.Stats())
+}
+
+func TestStoreCacheWarmUp(t *testing.T) {
+	mngr := cache.NewCommitKVStoreCacheManager(3)
+	mngr.SetWarmUpPrefixes("test", []byte("a"), []byte("b"))
+
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 100, false)
+	require.NoError(t, err)
+	store := iavlstore.UnsafeNewStore(tree)
+	for _, key := range []string{"a1", "a2", "b1", "b2", "c1"} {
+		store.Set([]byte(key), []byte(key))
+	}
+	kvStore := mngr.GetStoreCache(types.NewKVStoreKey("test"), store)
+
+	// the cache is full after the first entries of the prefixes are loaded
+	for _, key := range []string{"a1", "a2", "b1", "b2", "c1"} {
+		require.Equal(t, []byte(key), kvStore.Get([]byte(key)))
+	}
+	require.Equal(t, cache.CacheStats{Hits: 3, Misses: 2, Evictions: 2}, kvStore.(*cache.CommitKVStoreCache).Stats())
+}
+
+func TestGetStoreCacheOfReloadedStore(t *testing.T) {
+	mngr := cache.NewCommitKVStoreCacheManager(cache.DefaultCommitKVStoreCacheSize)
+	sKey := types.NewKVStoreKey("test")
+
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 100, false)
+	require.NoError(t, err)
+	store := iavlstore.UnsafeNewStore(tree)
+	kvStore := mngr.GetStoreCache(sKey, store)
+	kvStore.Set([]byte("key"), []byte("value"))
+
+	// the cache of a store loaded again does not return the values of the
+	// previous store
+	tree, err = iavl.NewMutableTree(dbm.NewMemDB(), 100, false)
+	require.NoError(t, err)
+	reloaded := iavlstore.UnsafeNewStore(tree)
+	kvStore = mngr.GetStoreCache(sKey, reloaded)
+	require.Equal(t, reloaded, mngr.Unwrap(sKey))
+	require.Nil(t, kvStore.Get([]byte("key")))
+}
@@ -30,6 +30,10 @@ type Store struct {
 	unsortedCache map[string]struct{}
 	sortedCache   internal.BTree // always ascending sorted
 	parent        types.KVStore
+
+	// access is the set of keys accessed through the store, it is nil unless
+	// the store was created with NewStoreWithAccessTracking.
+	access *types.AccessSet
 }
 
 var _ types.CacheKVStore = (*Store)(nil)
@@ -44,6 +48,23 @@ func NewStore(parent types.KVStore) *Store {
 	}
 }
 
+// NewStoreWithAccessTracking creates a new Store object which records the keys
+// read from its parent and the keys written to it, see AccessSet.
+func NewStoreWithAccessTracking(parent types.KVStore) *Store {
+	store := NewStore(parent)
+	store.access = types.NewAccessSet()
+	return store
+}
+
+// AccessSet returns the keys accessed through the store, or nil if it does
+// not track accesses.
+func (store *Store) AccessSet() *types.AccessSet {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+
+	return store.access
+}
+
 // GetStoreType implements Store.
 func (store *Store) GetStoreType() types.StoreType {
 	return store.parent.GetStoreType()
@@ -60,6 +81,9 @@ func (store *Store) Get(key []byte) (value []byte) {
 	if !ok {
 		value = store.parent.Get(key)
 		store.setCacheValue(key, value, false)
+		if store.access != nil {
+			store.access.Reads[string(key)] = struct{}{}
+		}
 	} else {
 		value = cacheValue.value
 	}
@@ -170,6 +194,13 @@ func (store *Store) iterator(start, end []byte, ascending bool) types.Iterator {
 	store.mtx.Lock()
 	defer store.mtx.Unlock()
 
+	if store.access != nil {
+		store.access.Ranges = append(store.access.Ranges, types.KeyRange{
+			Start: bytes.Clone(start),
+			End:   bytes.Clone(end),
+		})
+	}
+
 	store.dirtyItems(start, end)
 	isoSortedCache := store.sortedCache.Copy()
 
@@ -386,5 +417,8 @@ func (store *Store) setCacheValue(key, value []byte, dirty bool) {
 	}
 	if dirty {
 		store.unsortedCache[keyStr] = struct{}{}
+		if store.access != nil {
+			store.access.Writes[string(key)] = struct{}{}
+		}
 	}
 }
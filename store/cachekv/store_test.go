@@ -447,6 +447,36 @@ func TestIteratorDeadlock(t *testing.T) {
 	defer it2.Close()
 }
 
+func TestAccessTracking(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	mem.Set(keyFmt(1), valFmt(1))
+	mem.Set(keyFmt(2), valFmt(2))
+
+	require.Nil(t, cachekv.NewStore(mem).AccessSet())
+
+	store := cachekv.NewStoreWithAccessTracking(mem)
+	require.Equal(t, valFmt(1), store.Get(keyFmt(1)))
+	require.Nil(t, store.Get(keyFmt(3)))
+
+	// keys written through the store are not read from the parent
+	store.Set(keyFmt(4), valFmt(4))
+	require.Equal(t, valFmt(4), store.Get(keyFmt(4)))
+	store.Delete(keyFmt(2))
+
+	it := store.Iterator(keyFmt(5), nil)
+	it.Close()
+
+	set := store.AccessSet()
+	require.Equal(t, map[string]struct{}{string(keyFmt(1)): {}, string(keyFmt(3)): {}}, set.Reads)
+	require.Equal(t, map[string]struct{}{string(keyFmt(2)): {}, string(keyFmt(4)): {}}, set.Writes)
+	require.Equal(t, []types.KeyRange{{Start: keyFmt(5)}}, set.Ranges)
+
+	// the set is kept once the store is written
+	store.Write()
+	require.Equal(t, valFmt(4), mem.Get(keyFmt(4)))
+	require.Len(t, store.AccessSet().Writes, 2)
+}
+
 //-------------------------------------------------------------------------------------------
 // do some random ops
 
@@ -32,7 +32,11 @@ type Store struct {
 	traceContext types.TraceContext
 }
 
-var _ types.CacheMultiStore = Store{}
+var (
+	_ types.CacheMultiStore          = Store{}
+	_ types.AccessTrackingMultiStore = Store{}
+	_ types.AccessTrackingBrancher   = Store{}
+)
 
 // NewFromKVStore creates a new Store object from a mapping of store keys to
 // CacheWrapper objects and a KVStore as the database. Each CacheWrapper store
@@ -40,6 +44,14 @@ var _ types.CacheMultiStore = Store{}
 func NewFromKVStore(
 	store types.KVStore, stores map[types.StoreKey]types.CacheWrapper,
 	keys map[string]types.StoreKey, traceWriter io.Writer, traceContext types.TraceContext,
+) Store {
+	return newFromKVStore(store, stores, keys, traceWriter, traceContext, cachekv.NewStore)
+}
+
+func newFromKVStore(
+	store types.KVStore, stores map[types.StoreKey]types.CacheWrapper,
+	keys map[string]types.StoreKey, traceWriter io.Writer, traceContext types.TraceContext,
+	newCacheKVStore func(types.KVStore) *cachekv.Store,
 ) Store {
 	cms := Store{
 		db:           cachekv.NewStore(store),
@@ -57,7 +69,7 @@ func NewFromKVStore(
 
 			store = tracekv.NewStore(store.(types.KVStore), cms.traceWriter, tctx)
 		}
-		cms.stores[key] = cachekv.NewStore(store.(types.KVStore))
+		cms.stores[key] = newCacheKVStore(store.(types.KVStore))
 	}
 
 	return cms
@@ -81,6 +93,32 @@ func newCacheMultiStoreFromCMS(cms Store) Store {
 	return NewFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext)
 }
 
+// CacheMultiStoreWithAccessTracking branches the multi store like
+// CacheMultiStore, recording the keys accessed through each substore of the
+// branch. Accesses to the underlying database are not recorded.
+func (cms Store) CacheMultiStoreWithAccessTracking() types.AccessTrackingMultiStore {
+	stores := make(map[types.StoreKey]types.CacheWrapper)
+	for k, v := range cms.stores {
+		stores[k] = v
+	}
+
+	return newFromKVStore(cms.db, stores, nil, cms.traceWriter, cms.traceContext, cachekv.NewStoreWithAccessTracking)
+}
+
+// AccessSets returns the AccessSet of each substore which tracks accesses.
+func (cms Store) AccessSets() map[types.StoreKey]*types.AccessSet {
+	sets := make(map[types.StoreKey]*types.AccessSet)
+	for key, store := range cms.stores {
+		if store, ok := store.(*cachekv.Store); ok {
+			if set := store.AccessSet(); set != nil {
+				sets[key] = set
+			}
+		}
+	}
+
+	return sets
+}
+
 // SetTracer sets the tracer for the MultiStore that the underlying
 // stores will utilize to trace operations. A MultiStore is returned.
 func (cms Store) SetTracer(w io.Writer) types.MultiStore {
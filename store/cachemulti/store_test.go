@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"testing"
 
+	dbm "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/require"
 
+	"cosmossdk.io/store/dbadapter"
 	"cosmossdk.io/store/types"
 )
 
@@ -22,3 +24,36 @@ func TestStoreGetKVStore(t *testing.T) {
 	require.PanicsWithValue(errMsg,
 		func() { s.GetKVStore(key) })
 }
+
+func TestCacheMultiStoreWithAccessTracking(t *testing.T) {
+	key1, key2 := types.NewKVStoreKey("store1"), types.NewKVStoreKey("store2")
+	store1 := dbadapter.Store{DB: dbm.NewMemDB()}
+	store2 := dbadapter.Store{DB: dbm.NewMemDB()}
+	cms := NewStore(dbm.NewMemDB(), map[types.StoreKey]types.CacheWrapper{key1: store1, key2: store2}, nil, nil, nil)
+
+	require.Empty(t, cms.AccessSets())
+
+	branch := cms.CacheMultiStoreWithAccessTracking()
+	branch.GetKVStore(key1).Get([]byte("a"))
+	branch.GetKVStore(key2).Set([]byte("b"), []byte("b"))
+
+	sets := branch.AccessSets()
+	require.Len(t, sets, 2)
+	require.Equal(t, map[string]struct{}{"a": {}}, sets[key1].Reads)
+	require.Empty(t, sets[key1].Writes)
+	require.Empty(t, sets[key2].Reads)
+	require.Equal(t, map[string]struct{}{"b": {}}, sets[key2].Writes)
+
+	// writes of a nested branch are recorded once it is written
+	nested := branch.CacheMultiStore()
+	nested.GetKVStore(key1).Set([]byte("c"), []byte("c"))
+	require.Empty(t, sets[key1].Writes)
+	nested.Write()
+	require.Equal(t, map[string]struct{}{"c": {}}, sets[key1].Writes)
+
+	branch.Write()
+	cms.Write()
+	require.Nil(t, store1.Get([]byte("a")))
+	require.Equal(t, []byte("c"), store1.Get([]byte("c")))
+	require.Equal(t, []byte("b"), store2.Get([]byte("b")))
+}
@@ -14,6 +14,9 @@ type Store struct {
 	gasMeter  types.GasMeter
 	gasConfig types.GasConfig
 	parent    types.KVStore
+
+	storeKey string
+	tracer   types.AccessTracer
 }
 
 // NewStore returns a reference to a new GasKVStore.
@@ -26,6 +29,17 @@ func NewStore(parent types.KVStore, gasMeter types.GasMeter, gasConfig types.Gas
 	return kvs
 }
 
+// NewStoreWithTracer returns a reference to a new GasKVStore tracing the
+// accesses to the store named storeKey, and the gas charged for them, to
+// tracer. The accesses are not traced if tracer is nil.
+func NewStoreWithTracer(
+	parent types.KVStore, gasMeter types.GasMeter, gasConfig types.GasConfig, storeKey string, tracer types.AccessTracer,
+) *Store {
+	kvs := NewStore(parent, gasMeter, gasConfig)
+	kvs.storeKey, kvs.tracer = storeKey, tracer
+	return kvs
+}
+
 // Implements Store.
 func (gs *Store) GetStoreType() types.StoreType {
 	return gs.parent.GetStoreType()
@@ -40,6 +54,8 @@ func (gs *Store) Get(key []byte) (value []byte) {
 	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostPerByte*types.Gas(len(key)), types.GasReadPerByteDesc)
 	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostPerByte*types.Gas(len(value)), types.GasReadPerByteDesc)
 
+	gs.trace(types.AccessRead, key, len(value),
+		gs.gasConfig.ReadCostFlat+gs.gasConfig.ReadCostPerByte*types.Gas(len(key)+len(value)))
 	return value
 }
 
@@ -52,12 +68,17 @@ func (gs *Store) Set(key, value []byte) {
 	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostPerByte*types.Gas(len(key)), types.GasWritePerByteDesc)
 	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostPerByte*types.Gas(len(value)), types.GasWritePerByteDesc)
 	gs.parent.Set(key, value)
+
+	gs.trace(types.AccessWrite, key, len(value),
+		gs.gasConfig.WriteCostFlat+gs.gasConfig.WriteCostPerByte*types.Gas(len(key)+len(value)))
 }
 
 // Implements KVStore.
 func (gs *Store) Has(key []byte) bool {
 	gs.gasMeter.ConsumeGas(gs.gasConfig.HasCost, types.GasHasDesc)
-	return gs.parent.Has(key)
+	has := gs.parent.Has(key)
+	gs.trace(types.AccessHas, key, 0, gs.gasConfig.HasCost)
+	return has
 }
 
 // Implements KVStore.
@@ -65,6 +86,7 @@ func (gs *Store) Delete(key []byte) {
 	// charge gas to prevent certain attack vectors even though space is being freed
 	gs.gasMeter.ConsumeGas(gs.gasConfig.DeleteCost, types.GasDeleteDesc)
 	gs.parent.Delete(key)
+	gs.trace(types.AccessDelete, key, 0, gs.gasConfig.DeleteCost)
 }
 
 // Iterator implements the KVStore interface. It returns an iterator which
@@ -100,16 +122,35 @@ func (gs *Store) iterator(start, end []byte, ascending bool) types.Iterator {
 		parent = gs.parent.ReverseIterator(start, end)
 	}
 
-	gi := newGasIterator(gs.gasMeter, gs.gasConfig, parent)
-	gi.(*gasIterator).consumeSeekGas()
+	gi := newGasIterator(gs.gasMeter, gs.gasConfig, parent).(*gasIterator)
+	gi.store = gs
+	gi.consumeSeekGas()
 
 	return gi
 }
 
+// trace traces an access to the tracer of the store, if any.
+func (gs *Store) trace(op types.AccessOperation, key []byte, valueSize int, gas types.Gas) {
+	if gs.tracer == nil {
+		return
+	}
+
+	gs.tracer.TraceAccess(types.Access{
+		StoreKey:  gs.storeKey,
+		Operation: op,
+		Key:       key,
+		ValueSize: valueSize,
+		Gas:       gas,
+	})
+}
+
 type gasIterator struct {
 	gasMeter  types.GasMeter
 	gasConfig types.GasConfig
 	parent    types.Iterator
+
+	// store is the store iterated, tracing the keys reached
+	store *Store
 }
 
 func newGasIterator(gasMeter types.GasMeter, gasConfig types.GasConfig, parent types.Iterator) types.Iterator {
@@ -165,12 +206,18 @@ func (gi *gasIterator) Error() error {
 // consumeSeekGas consumes on each iteration step a flat gas cost and a variable gas cost
 // based on the current value's length.
 func (gi *gasIterator) consumeSeekGas() {
+	var key, value []byte
 	if gi.Valid() {
-		key := gi.Key()
-		value := gi.Value()
+		key = gi.Key()
+		value = gi.Value()
 
 		gi.gasMeter.ConsumeGas(gi.gasConfig.ReadCostPerByte*types.Gas(len(key)), types.GasValuePerByteDesc)
 		gi.gasMeter.ConsumeGas(gi.gasConfig.ReadCostPerByte*types.Gas(len(value)), types.GasValuePerByteDesc)
 	}
 	gi.gasMeter.ConsumeGas(gi.gasConfig.IterNextCostFlat, types.GasIterNextCostFlatDesc)
+
+	if gi.store != nil {
+		gi.store.trace(types.AccessIterKey, key, len(value),
+			gi.gasConfig.IterNextCostFlat+gi.gasConfig.ReadCostPerByte*types.Gas(len(key)+len(value)))
+	}
 }
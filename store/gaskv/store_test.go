@@ -9,6 +9,7 @@ import (
 
 	"cosmossdk.io/store/dbadapter"
 	"cosmossdk.io/store/gaskv"
+	"cosmossdk.io/store/tracekv"
 	"cosmossdk.io/store/types"
 )
 
@@ -118,3 +119,43 @@ func TestGasKVStoreOutOfGasIterator(t *testing.T) {
 	iterator.Next()
 	require.Panics(t, func() { iterator.Value() }, "Expected out-of-gas")
 }
+
+func TestGasKVStoreTracer(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	meter := types.NewGasMeter(100000)
+	recorder := tracekv.NewAccessRecorder()
+	tracer := types.AccessTracerWithContext(recorder, types.TraceContext{"txHash": "AB"})
+	st := gaskv.NewStoreWithTracer(mem, meter, types.KVGasConfig(), "bank", tracer)
+
+	st.Set(keyFmt(1), valFmt(1))
+	st.Get(keyFmt(1))
+	st.Has(keyFmt(2))
+	iterator := st.Iterator(nil, nil)
+	iterator.Next()
+	require.NoError(t, iterator.Close())
+	st.Delete(keyFmt(1))
+
+	accesses := recorder.Accesses()
+	var (
+		ops []types.AccessOperation
+		gas types.Gas
+	)
+	for _, access := range accesses {
+		require.Equal(t, "bank", access.StoreKey)
+		require.Equal(t, types.TraceContext{"txHash": "AB"}, access.Context)
+		ops = append(ops, access.Operation)
+		gas += access.Gas
+	}
+	require.Equal(t, []types.AccessOperation{
+		types.AccessWrite, types.AccessRead, types.AccessHas, types.AccessIterKey, types.AccessIterKey, types.AccessDelete,
+	}, ops)
+	// the gas traced is the gas charged
+	require.Equal(t, meter.GasConsumed(), gas)
+
+	require.Equal(t, keyFmt(1), accesses[0].Key)
+	require.Equal(t, len(valFmt(1)), accesses[0].ValueSize)
+	// the iterator is charged for the current key when it is opened and when
+	// it moves to the next key
+	require.Equal(t, keyFmt(1), accesses[3].Key)
+	require.Equal(t, keyFmt(1), accesses[4].Key)
+}
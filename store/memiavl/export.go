@@ -0,0 +1,100 @@
+package memiavl
+
+import (
+	"errors"
+
+	"github.com/cosmos/iavl"
+)
+
+// Exporter exports the nodes of a tree in post-order, as iavl.Exporter, so
+// that they can be imported into an iavl or memiavl store.
+type Exporter struct {
+	// stack holds the nodes left to export, the next one last, with the
+	// branches whose children are already on the stack marked as expanded.
+	stack []exportedNode
+}
+
+type exportedNode struct {
+	node     node
+	expanded bool
+}
+
+func newExporter(t *tree) *Exporter {
+	e := &Exporter{}
+	if t.root != nil {
+		e.stack = append(e.stack, exportedNode{node: t.root})
+	}
+	return e
+}
+
+// Next returns the next node, or iavl.ErrorExportDone once all the nodes are
+// exported.
+func (e *Exporter) Next() (*iavl.ExportNode, error) {
+	for len(e.stack) > 0 {
+		top := len(e.stack) - 1
+		n := e.stack[top].node
+		if n.Height() > 0 && !e.stack[top].expanded {
+			e.stack[top].expanded = true
+			e.stack = append(e.stack, exportedNode{node: n.Right()}, exportedNode{node: n.Left()})
+			continue
+		}
+
+		e.stack = e.stack[:top]
+		return &iavl.ExportNode{
+			Key:     retainKey(n),
+			Value:   retainValue(n),
+			Version: n.Version(),
+			Height:  n.Height(),
+		}, nil
+	}
+
+	return nil, iavl.ErrorExportDone
+}
+
+// Close releases the exporter.
+func (e *Exporter) Close() {
+	e.stack = nil
+}
+
+// Importer imports the nodes of a tree, exported in post-order by an iavl or
+// memiavl store, into a memiavl store. The nodes are written to a snapshot,
+// which becomes the state of the store once committed.
+type Importer struct {
+	store  *Store
+	writer *snapshotWriter
+}
+
+// Add imports the next node.
+func (i *Importer) Add(node *iavl.ExportNode) error {
+	if i.writer == nil {
+		return iavl.ErrNoImport
+	}
+	if node == nil {
+		return errors.New("node cannot be nil")
+	}
+
+	return i.writer.add(node)
+}
+
+// Commit completes the import, loading the store at the version imported.
+func (i *Importer) Commit() error {
+	if i.writer == nil {
+		return iavl.ErrNoImport
+	}
+
+	writer := i.writer
+	i.writer = nil
+	if _, err := writer.commit(); err != nil {
+		return err
+	}
+
+	return i.store.load(writer.version)
+}
+
+// Close releases the importer, discarding the import if it is not committed.
+func (i *Importer) Close() {
+	if i.writer != nil {
+		i.writer.abort()
+		i.writer = nil
+	}
+}
@@ -0,0 +1,113 @@
+package memiavl
+
+import (
+	"bytes"
+
+	"cosmossdk.io/store/types"
+)
+
+var _ types.Iterator = (*iterator)(nil)
+
+// iterator iterates over the leaves of a tree within a domain.
+type iterator struct {
+	start, end []byte
+	ascending  bool
+
+	// stack holds the subtrees left to visit, the next one last.
+	stack []node
+	key   []byte
+	value []byte
+	valid bool
+}
+
+func newIterator(root node, start, end []byte, ascending bool) *iterator {
+	it := &iterator{
+		start:     start,
+		end:       end,
+		ascending: ascending,
+	}
+	if root != nil {
+		it.stack = append(it.stack, root)
+	}
+
+	it.Next()
+	return it
+}
+
+// Domain implements types.Iterator.
+func (it *iterator) Domain() ([]byte, []byte) {
+	return it.start, it.end
+}
+
+// Valid implements types.Iterator.
+func (it *iterator) Valid() bool {
+	return it.valid
+}
+
+// Next implements types.Iterator.
+func (it *iterator) Next() {
+	for len(it.stack) > 0 {
+		n := it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+
+		key := n.Key()
+		if n.Height() == 0 {
+			if (it.start == nil || bytes.Compare(key, it.start) >= 0) &&
+				(it.end == nil || bytes.Compare(key, it.end) < 0) {
+				it.key, it.value, it.valid = retainKey(n), retainValue(n), true
+				return
+			}
+			continue
+		}
+
+		// the keys of the left subtree are lower than the key of the branch,
+		// the ones of the right subtree are greater or equal
+		visitLeft := it.start == nil || bytes.Compare(it.start, key) < 0
+		visitRight := it.end == nil || bytes.Compare(key, it.end) < 0
+		if it.ascending {
+			if visitRight {
+				it.stack = append(it.stack, n.Right())
+			}
+			if visitLeft {
+				it.stack = append(it.stack, n.Left())
+			}
+		} else {
+			if visitLeft {
+				it.stack = append(it.stack, n.Left())
+			}
+			if visitRight {
+				it.stack = append(it.stack, n.Right())
+			}
+		}
+	}
+
+	it.key, it.value, it.valid = nil, nil, false
+}
+
+// Key implements types.Iterator.
+func (it *iterator) Key() []byte {
+	if !it.valid {
+		panic("iterator is invalid")
+	}
+	return it.key
+}
+
+// Value implements types.Iterator.
+func (it *iterator) Value() []byte {
+	if !it.valid {
+		panic("iterator is invalid")
+	}
+	return it.value
+}
+
+// Error implements types.Iterator.
+func (it *iterator) Error() error {
+	return nil
+}
+
+// Close implements types.Iterator.
+func (it *iterator) Close() error {
+	it.stack = nil
+	it.valid = false
+	return nil
+}
@@ -0,0 +1,24 @@
+//go:build !unix
+
+package memiavl
+
+import (
+	"os"
+)
+
+// mmap reads the file at path in memory, the platform not supporting memory
+// maps.
+func mmap(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// munmap releases a memory map returned by mmap.
+func munmap([]byte) error {
+	return nil
+}
+
+// syncDir is a no-op, the platform not supporting the synchronization of the
+// directories.
+func syncDir(string) error {
+	return nil
+}
@@ -0,0 +1,151 @@
+package memiavl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+)
+
+// emptyHash is the hash of an empty tree, as in iavl.
+var emptyHash = sha256.New().Sum(nil)
+
+// node is a node of a tree, either held in memory or read from a snapshot.
+// The nodes of the committed versions are immutable.
+//
+// The keys and values of the nodes read from a snapshot point to its memory
+// map, they must be copied to be retained, see retainKey and retainValue.
+type node interface {
+	Height() int8
+	Size() int64
+	Version() int64
+	Key() []byte
+	Value() []byte
+	Left() node
+	Right() node
+	Hash() []byte
+}
+
+// memNode is a node held in memory.
+type memNode struct {
+	height  int8
+	size    int64
+	version int64
+	key     []byte
+	value   []byte
+	left    node
+	right   node
+	hash    []byte
+}
+
+var _ node = (*memNode)(nil)
+
+func newLeaf(key, value []byte, version int64) *memNode {
+	return &memNode{
+		size:    1,
+		version: version,
+		key:     key,
+		value:   value,
+	}
+}
+
+func (n *memNode) Height() int8 { return n.height }
+
+func (n *memNode) Size() int64 { return n.size }
+
+func (n *memNode) Version() int64 { return n.version }
+
+func (n *memNode) Key() []byte { return n.key }
+
+func (n *memNode) Value() []byte { return n.value }
+
+func (n *memNode) Left() node { return n.left }
+
+func (n *memNode) Right() node { return n.right }
+
+// Hash returns the hash of the node, computing the hashes of its descendants
+// which are not known yet.
+func (n *memNode) Hash() []byte {
+	if n.hash != nil {
+		return n.hash
+	}
+
+	if n.height == 0 {
+		n.hash = hashLeaf(n.version, n.key, n.value)
+	} else {
+		n.hash = hashBranch(n.height, n.size, n.version, n.left.Hash(), n.right.Hash())
+	}
+
+	return n.hash
+}
+
+// updateHeightSize sets the height and the size of a branch from the ones of
+// its children.
+func (n *memNode) updateHeightSize() {
+	n.height = n.left.Height() + 1
+	if height := n.right.Height() + 1; height > n.height {
+		n.height = height
+	}
+	n.size = n.left.Size() + n.right.Size()
+}
+
+// balanceOf returns the difference between the heights of the children of a
+// branch.
+func balanceOf(n node) int {
+	return int(n.Left().Height()) - int(n.Right().Height())
+}
+
+// retainKey returns the key of n, copied if it points to a snapshot.
+func retainKey(n node) []byte {
+	if n, ok := n.(*memNode); ok {
+		return n.key
+	}
+	return bytes.Clone(n.Key())
+}
+
+// retainValue returns the value of n, copied if it points to a snapshot.
+func retainValue(n node) []byte {
+	if n, ok := n.(*memNode); ok {
+		return n.value
+	}
+	return bytes.Clone(n.Value())
+}
+
+// hashLeaf returns the hash of a leaf, as computed by iavl.
+func hashLeaf(version int64, key, value []byte) []byte {
+	valueHash := sha256.Sum256(value)
+
+	h := sha256.New()
+	writeVarint(h, 0)
+	writeVarint(h, 1)
+	writeVarint(h, version)
+	writeBytes(h, key)
+	writeBytes(h, valueHash[:])
+
+	return h.Sum(nil)
+}
+
+// hashBranch returns the hash of a branch, as computed by iavl.
+func hashBranch(height int8, size, version int64, leftHash, rightHash []byte) []byte {
+	h := sha256.New()
+	writeVarint(h, int64(height))
+	writeVarint(h, size)
+	writeVarint(h, version)
+	writeBytes(h, leftHash)
+	writeBytes(h, rightHash)
+
+	return h.Sum(nil)
+}
+
+func writeVarint(h hash.Hash, i int64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], i)
+	h.Write(buf[:n])
+}
+
+func writeBytes(h hash.Hash, bz []byte) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(bz)))
+	h.Write(buf[:n])
+	h.Write(bz)
+}
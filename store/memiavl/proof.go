@@ -0,0 +1,115 @@
+package memiavl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	ics23 "github.com/confio/ics23/go"
+)
+
+// getMembershipProof returns the ICS23 proof that key exists in the tree, as
+// created by iavl.
+func (t *tree) getMembershipProof(key []byte) (*ics23.CommitmentProof, error) {
+	exist, err := t.createExistenceProof(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ics23.CommitmentProof{
+		Proof: &ics23.CommitmentProof_Exist{Exist: exist},
+	}, nil
+}
+
+// getNonMembershipProof returns the ICS23 proof that key does not exist in
+// the tree, as created by iavl.
+func (t *tree) getNonMembershipProof(key []byte) (*ics23.CommitmentProof, error) {
+	index, value := t.getWithIndex(key)
+	if value != nil {
+		return nil, fmt.Errorf("cannot create NonExistanceProof when Key in State")
+	}
+
+	nonexist := &ics23.NonExistenceProof{Key: key}
+
+	if left := t.getByIndex(index - 1); left != nil {
+		var err error
+		if nonexist.Left, err = t.createExistenceProof(left.Key()); err != nil {
+			return nil, err
+		}
+	}
+	if right := t.getByIndex(index); right != nil {
+		var err error
+		if nonexist.Right, err = t.createExistenceProof(right.Key()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ics23.CommitmentProof{
+		Proof: &ics23.CommitmentProof_Nonexist{Nonexist: nonexist},
+	}, nil
+}
+
+// createExistenceProof returns the path from the leaf of key to the root.
+func (t *tree) createExistenceProof(key []byte) (*ics23.ExistenceProof, error) {
+	if t.root == nil {
+		return nil, fmt.Errorf("cannot generate the proof with nil root")
+	}
+
+	var path []node
+	n := t.root
+	for n.Height() > 0 {
+		path = append(path, n)
+		if bytes.Compare(key, n.Key()) < 0 {
+			n = n.Left()
+		} else {
+			n = n.Right()
+		}
+	}
+	if !bytes.Equal(key, n.Key()) {
+		return nil, fmt.Errorf("key %X does not exist", key)
+	}
+
+	// lengthByte is the length prefix of the hashes of the children
+	const lengthByte byte = 0x20
+
+	steps := make([]*ics23.InnerOp, 0, len(path))
+	for i := len(path) - 1; i >= 0; i-- {
+		branch := path[i]
+		prefix := appendVarints(nil, int64(branch.Height()), branch.Size(), branch.Version())
+
+		var suffix []byte
+		if bytes.Compare(key, branch.Key()) < 0 {
+			prefix = append(prefix, lengthByte)
+			suffix = append([]byte{lengthByte}, branch.Right().Hash()...)
+		} else {
+			prefix = append(prefix, lengthByte)
+			prefix = append(prefix, branch.Left().Hash()...)
+			prefix = append(prefix, lengthByte)
+		}
+
+		steps = append(steps, &ics23.InnerOp{
+			Hash:   ics23.HashOp_SHA256,
+			Prefix: prefix,
+			Suffix: suffix,
+		})
+	}
+
+	return &ics23.ExistenceProof{
+		Key:   retainKey(n),
+		Value: retainValue(n),
+		Leaf: &ics23.LeafOp{
+			Hash:         ics23.HashOp_SHA256,
+			PrehashValue: ics23.HashOp_SHA256,
+			Length:       ics23.LengthOp_VAR_PROTO,
+			Prefix:       appendVarints(nil, 0, 1, n.Version()),
+		},
+		Path: steps,
+	}, nil
+}
+
+func appendVarints(bz []byte, values ...int64) []byte {
+	for _, value := range values {
+		bz = binary.AppendVarint(bz, value)
+	}
+	return bz
+}
@@ -0,0 +1,427 @@
+package memiavl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/iavl"
+)
+
+const (
+	snapshotPrefix = "snapshot-"
+	tmpSuffix      = ".tmp"
+	nodesFile      = "nodes"
+	kvsFile        = "kvs"
+
+	// nodeSize is the size of the record of a node in the nodes file of a
+	// snapshot.
+	nodeSize = 64
+)
+
+// snapshot is the tree of a version written to disk and read from memory
+// maps.
+//
+// The nodes file of a snapshot holds its nodes in post-order, as exported by
+// iavl, as records of nodeSize bytes:
+//
+//	height (1 byte), padding (7 bytes), version (8 bytes), size (8 bytes),
+//	offset (8 bytes), hash (32 bytes)
+//
+// with the integers in little-endian. The kvs file holds the keys and values
+// of the leaves, each prefixed with its length as a uvarint. The offset of a
+// leaf is the one of its key and value in the kvs file, the offset of a
+// branch the one of the leftmost leaf of its right subtree, whose key is the
+// key of the branch. The right child of a branch is the node before it and
+// its left child the one before the right subtree.
+type snapshot struct {
+	version int64
+	nodes   []byte
+	kvs     []byte
+}
+
+// openSnapshot opens the snapshot of version in dir. Its memory maps are
+// released once no tree uses it anymore.
+func openSnapshot(dir string, version int64) (*snapshot, error) {
+	path := filepath.Join(dir, snapshotName(version))
+
+	nodes, err := mmap(filepath.Join(path, nodesFile))
+	if err != nil {
+		return nil, err
+	}
+	kvs, err := mmap(filepath.Join(path, kvsFile))
+	if err != nil {
+		_ = munmap(nodes)
+		return nil, err
+	}
+
+	if len(nodes)%nodeSize != 0 {
+		_ = munmap(nodes)
+		_ = munmap(kvs)
+		return nil, fmt.Errorf("invalid snapshot %d: truncated nodes file", version)
+	}
+
+	s := &snapshot{version: version, nodes: nodes, kvs: kvs}
+	runtime.SetFinalizer(s, (*snapshot).close)
+
+	return s, nil
+}
+
+func (s *snapshot) close() {
+	_ = munmap(s.nodes)
+	_ = munmap(s.kvs)
+}
+
+// tree returns the tree of the snapshot.
+func (s *snapshot) tree() *tree {
+	t := &tree{version: s.version}
+	if count := len(s.nodes) / nodeSize; count > 0 {
+		t.root = persistedNode{snapshot: s, index: uint32(count - 1)}
+	}
+	return t
+}
+
+// snapshotName returns the name of the directory of the snapshot of version.
+func snapshotName(version int64) string {
+	return fmt.Sprintf("%s%020d", snapshotPrefix, version)
+}
+
+// listSnapshots returns the versions of the snapshots in dir, in ascending
+// order.
+func listSnapshots(dir string) ([]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || strings.HasSuffix(name, tmpSuffix) {
+			continue
+		}
+		version, err := strconv.ParseInt(strings.TrimPrefix(name, snapshotPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions, nil
+}
+
+// persistedNode is a node read from a snapshot.
+type persistedNode struct {
+	snapshot *snapshot
+	index    uint32
+}
+
+var _ node = persistedNode{}
+
+func (n persistedNode) record() []byte {
+	offset := int(n.index) * nodeSize
+	return n.snapshot.nodes[offset : offset+nodeSize]
+}
+
+func (n persistedNode) Height() int8 {
+	return int8(n.record()[0])
+}
+
+func (n persistedNode) Size() int64 {
+	return int64(binary.LittleEndian.Uint64(n.record()[16:]))
+}
+
+func (n persistedNode) Version() int64 {
+	return int64(binary.LittleEndian.Uint64(n.record()[8:]))
+}
+
+// Key returns the key of the node, pointing to the memory map of the
+// snapshot.
+func (n persistedNode) Key() []byte {
+	key, _ := n.snapshot.keyValue(binary.LittleEndian.Uint64(n.record()[24:]))
+	return key
+}
+
+// Value returns the value of the node, pointing to the memory map of the
+// snapshot.
+func (n persistedNode) Value() []byte {
+	if n.Height() > 0 {
+		return nil
+	}
+	_, value := n.snapshot.keyValue(binary.LittleEndian.Uint64(n.record()[24:]))
+	return value
+}
+
+func (n persistedNode) Left() node {
+	if n.Height() == 0 {
+		return nil
+	}
+	right := n.index - 1
+	return persistedNode{snapshot: n.snapshot, index: right - uint32(2*persistedNode{snapshot: n.snapshot, index: right}.Size()-1)}
+}
+
+func (n persistedNode) Right() node {
+	if n.Height() == 0 {
+		return nil
+	}
+	return persistedNode{snapshot: n.snapshot, index: n.index - 1}
+}
+
+// Hash returns a copy of the hash of the node.
+func (n persistedNode) Hash() []byte {
+	return bytes.Clone(n.record()[32:])
+}
+
+// keyValue returns the key and value at offset in the kvs file.
+func (s *snapshot) keyValue(offset uint64) ([]byte, []byte) {
+	data := s.kvs[offset:]
+
+	keyLen, n := binary.Uvarint(data)
+	key := data[n : n+int(keyLen)]
+	data = data[n+int(keyLen):]
+
+	valueLen, n := binary.Uvarint(data)
+	value := data[n : n+int(valueLen)]
+
+	return key, value
+}
+
+// snapshotWriter writes the nodes of a tree, given in post-order as exported
+// by iavl, to a new snapshot.
+type snapshotWriter struct {
+	dir     string
+	version int64
+
+	nodesFile, kvsFile *os.File
+	nodes, kvs         *bufio.Writer
+	kvsOffset          uint64
+	count              uint32
+
+	// stack holds the subtrees written whose parent is not yet.
+	stack []writtenNode
+}
+
+// writtenNode is the root of a subtree written to a snapshot.
+type writtenNode struct {
+	height int8
+	size   int64
+	hash   []byte
+
+	// offset and key are the ones of the leftmost leaf of the subtree.
+	offset uint64
+	key    []byte
+}
+
+// newSnapshotWriter returns a writer of the snapshot of version in dir, which
+// is written to a temporary directory until committed.
+func newSnapshotWriter(dir string, version int64) (*snapshotWriter, error) {
+	tmp := filepath.Join(dir, snapshotName(version)+tmpSuffix)
+	if err := os.RemoveAll(tmp); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(tmp, 0o755); err != nil {
+		return nil, err
+	}
+
+	w := &snapshotWriter{dir: dir, version: version}
+
+	var err error
+	if w.nodesFile, err = os.Create(filepath.Join(tmp, nodesFile)); err != nil {
+		w.abort()
+		return nil, err
+	}
+	if w.kvsFile, err = os.Create(filepath.Join(tmp, kvsFile)); err != nil {
+		w.abort()
+		return nil, err
+	}
+	w.nodes = bufio.NewWriter(w.nodesFile)
+	w.kvs = bufio.NewWriter(w.kvsFile)
+
+	return w, nil
+}
+
+// add writes the next node of the tree.
+func (w *snapshotWriter) add(node *iavl.ExportNode) error {
+	if w.count == math.MaxUint32 {
+		return errors.New("too many nodes")
+	}
+	if node.Version > w.version {
+		return fmt.Errorf("node version %d can't be greater than the snapshot version %d", node.Version, w.version)
+	}
+
+	var written writtenNode
+	if node.Height == 0 {
+		if node.Key == nil || node.Value == nil {
+			return errors.New("leaf without key or value")
+		}
+
+		written = writtenNode{
+			size:   1,
+			hash:   hashLeaf(node.Version, node.Key, node.Value),
+			offset: w.kvsOffset,
+			key:    bytes.Clone(node.Key),
+		}
+		if err := w.writeKeyValue(node.Key, node.Value); err != nil {
+			return err
+		}
+		if err := w.writeNode(0, node.Version, 1, written.offset, written.hash); err != nil {
+			return err
+		}
+	} else {
+		count := len(w.stack)
+		if count < 2 || w.stack[count-2].height >= node.Height || w.stack[count-1].height >= node.Height {
+			return fmt.Errorf("branch of height %d without two children", node.Height)
+		}
+		left, right := w.stack[count-2], w.stack[count-1]
+		if !bytes.Equal(node.Key, right.key) {
+			return fmt.Errorf("branch key %X is not the lowest key of its right subtree", node.Key)
+		}
+		w.stack = w.stack[:count-2]
+
+		written = writtenNode{
+			height: node.Height,
+			size:   left.size + right.size,
+			offset: left.offset,
+			key:    left.key,
+		}
+		written.hash = hashBranch(node.Height, written.size, node.Version, left.hash, right.hash)
+		if err := w.writeNode(node.Height, node.Version, written.size, right.offset, written.hash); err != nil {
+			return err
+		}
+	}
+
+	w.stack = append(w.stack, written)
+	w.count++
+
+	return nil
+}
+
+func (w *snapshotWriter) writeKeyValue(key, value []byte) error {
+	var buf [binary.MaxVarintLen64]byte
+	for _, bz := range [][]byte{key, value} {
+		n := binary.PutUvarint(buf[:], uint64(len(bz)))
+		if _, err := w.kvs.Write(buf[:n]); err != nil {
+			return err
+		}
+		if _, err := w.kvs.Write(bz); err != nil {
+			return err
+		}
+		w.kvsOffset += uint64(n + len(bz))
+	}
+	return nil
+}
+
+func (w *snapshotWriter) writeNode(height int8, version, size int64, offset uint64, hash []byte) error {
+	var record [nodeSize]byte
+	record[0] = byte(height)
+	binary.LittleEndian.PutUint64(record[8:], uint64(version))
+	binary.LittleEndian.PutUint64(record[16:], uint64(size))
+	binary.LittleEndian.PutUint64(record[24:], offset)
+	copy(record[32:], hash)
+
+	_, err := w.nodes.Write(record[:])
+	return err
+}
+
+// commit completes the snapshot and returns the hash of its tree.
+func (w *snapshotWriter) commit() ([]byte, error) {
+	if len(w.stack) > 1 {
+		w.abort()
+		return nil, fmt.Errorf("incomplete tree: %d subtrees without parent", len(w.stack))
+	}
+
+	for _, f := range []struct {
+		file   *os.File
+		writer *bufio.Writer
+	}{{w.nodesFile, w.nodes}, {w.kvsFile, w.kvs}} {
+		if err := f.writer.Flush(); err != nil {
+			w.abort()
+			return nil, err
+		}
+		if err := f.file.Sync(); err != nil {
+			w.abort()
+			return nil, err
+		}
+	}
+	w.closeFiles()
+
+	// a snapshot left from a version rolled back is replaced
+	path := filepath.Join(w.dir, snapshotName(w.version))
+	if err := os.RemoveAll(path); err != nil {
+		w.abort()
+		return nil, err
+	}
+	if err := os.Rename(path+tmpSuffix, path); err != nil {
+		w.abort()
+		return nil, err
+	}
+	if err := syncDir(w.dir); err != nil {
+		return nil, err
+	}
+
+	if len(w.stack) == 0 {
+		return emptyHash, nil
+	}
+	return w.stack[0].hash, nil
+}
+
+// abort removes the snapshot being written.
+func (w *snapshotWriter) abort() {
+	w.closeFiles()
+	_ = os.RemoveAll(filepath.Join(w.dir, snapshotName(w.version)+tmpSuffix))
+}
+
+func (w *snapshotWriter) closeFiles() {
+	if w.nodesFile != nil {
+		_ = w.nodesFile.Close()
+		w.nodesFile = nil
+	}
+	if w.kvsFile != nil {
+		_ = w.kvsFile.Close()
+		w.kvsFile = nil
+	}
+}
+
+// writeSnapshot writes the snapshot of the committed tree t in dir.
+func writeSnapshot(dir string, t *tree) error {
+	w, err := newSnapshotWriter(dir, t.version)
+	if err != nil {
+		return err
+	}
+
+	exporter := newExporter(t)
+	for {
+		node, err := exporter.Next()
+		if errors.Is(err, iavl.ErrorExportDone) {
+			break
+		}
+		if err != nil {
+			w.abort()
+			return err
+		}
+		if err := w.add(node); err != nil {
+			w.abort()
+			return err
+		}
+	}
+
+	hash, err := w.commit()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, t.rootHash()) {
+		return fmt.Errorf("snapshot %d written with hash %X instead of %X", t.version, hash, t.rootHash())
+	}
+
+	return nil
+}
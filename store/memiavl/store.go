@@ -0,0 +1,766 @@
+package memiavl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtprotocrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	ics23 "github.com/confio/ics23/go"
+	"github.com/cosmos/iavl"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/internal/kv"
+	"cosmossdk.io/store/metrics"
+	pruningtypes "cosmossdk.io/store/pruning/types"
+	"cosmossdk.io/store/tracekv"
+	"cosmossdk.io/store/types"
+)
+
+const (
+	DefaultSnapshotInterval   = 1000
+	DefaultSnapshotKeepRecent = 1
+	DefaultKeepRecent         = 10
+)
+
+var (
+	_ types.KVStore                 = (*Store)(nil)
+	_ types.CommitStore             = (*Store)(nil)
+	_ types.CommitKVStore           = (*Store)(nil)
+	_ types.Queryable               = (*Store)(nil)
+	_ types.StoreWithInitialVersion = (*Store)(nil)
+)
+
+// Store is a CommitKVStore of an IAVL tree held in memory, producing the same
+// root hashes as iavl.Store given the same changes.
+//
+// The store is persisted in its directory as snapshots of the tree of some
+// versions, read from memory maps, and a write-ahead log of the changesets of
+// the versions committed since, replayed when the store is loaded. The
+// snapshots are written in the background every SnapshotInterval versions,
+// which then replace the nodes of the tree held in memory.
+//
+// Only the KeepRecent versions before the latest one are kept, in memory, for
+// queries. The versions of the snapshots kept can be loaded, with the
+// changesets of the following versions, to roll back the store.
+type Store struct {
+	dir     string
+	opts    types.MemIAVLOptions
+	logger  log.Logger
+	metrics metrics.StoreMetrics
+
+	// tree is the working tree and changeSet the changes made to it, in the
+	// order they are made, as the shape of the tree depends on it.
+	tree      *tree
+	changeSet iavl.ChangeSet
+	readOnly  bool
+
+	// versions holds the trees of the versions kept, the latest one last.
+	mtx      sync.RWMutex
+	versions []*tree
+
+	wal             *wal
+	snapshotVersion int64
+	snapshotting    *snapshotting
+}
+
+// LoadStore returns the memiavl store in dir, whose files it creates if it
+// does not exist yet, loaded at the version of id, or at its latest version if
+// it is 0. An error is returned if the version fails to load or if its hash is
+// not the one of id.
+func LoadStore(dir string, logger log.Logger, id types.CommitID, initialVersion uint64, opts types.MemIAVLOptions, metrics metrics.StoreMetrics) (*Store, error) {
+	if opts.SnapshotInterval == 0 {
+		opts.SnapshotInterval = DefaultSnapshotInterval
+	}
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+
+	st := &Store{
+		dir:     dir,
+		opts:    opts,
+		logger:  logger,
+		metrics: metrics,
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := st.load(id.Version); err != nil {
+		return nil, err
+	}
+	st.tree.initialVersion = int64(initialVersion)
+
+	if id.Version > 0 && !bytes.Equal(st.tree.rootHash(), id.Hash) {
+		return nil, fmt.Errorf("memiavl store %s loaded version %d with hash %X instead of %X", dir, id.Version, st.tree.rootHash(), id.Hash)
+	}
+
+	return st, nil
+}
+
+// Exists returns true if dir holds a memiavl store.
+func Exists(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, tmpSuffix) {
+			continue
+		}
+		if strings.HasPrefix(name, snapshotPrefix) || strings.HasPrefix(name, walPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// load loads the store at version, or at its latest version if it is 0, from
+// the latest snapshot before it and the write-ahead log.
+func (st *Store) load(version int64) error {
+	st.waitSnapshot()
+
+	// remove the snapshots left partially written
+	entries, err := os.ReadDir(st.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), snapshotPrefix) && strings.HasSuffix(entry.Name(), tmpSuffix) {
+			if err := os.RemoveAll(filepath.Join(st.dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	snapshots, err := listSnapshots(st.dir)
+	if err != nil {
+		return err
+	}
+
+	t := &tree{}
+	var snapshotVersion int64
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if version == 0 || snapshots[i] <= version {
+			snapshotVersion = snapshots[i]
+			break
+		}
+	}
+	if snapshotVersion > 0 {
+		snapshot, err := openSnapshot(st.dir, snapshotVersion)
+		if err != nil {
+			return err
+		}
+		t = snapshot.tree()
+	}
+
+	if st.wal != nil {
+		if err := st.wal.close(); err != nil {
+			return err
+		}
+	}
+	if st.wal, err = openWAL(st.dir); err != nil {
+		return err
+	}
+
+	err = st.wal.read(t.version, func(v int64, changeSet *iavl.ChangeSet) error {
+		if version > 0 && v > version {
+			return errStopReading
+		}
+		return replay(t, v, changeSet)
+	})
+	if err != nil {
+		return err
+	}
+
+	if version > 0 && t.version != version {
+		return fmt.Errorf("memiavl store %s: version %d does not exist, latest version is %d", st.dir, version, t.version)
+	}
+
+	if st.tree != nil {
+		t.initialVersion = st.tree.initialVersion
+	}
+	st.tree = t
+	st.changeSet = iavl.ChangeSet{}
+	st.snapshotVersion = snapshotVersion
+
+	st.mtx.Lock()
+	st.versions = nil
+	if t.version > 0 {
+		st.versions = []*tree{t.copy()}
+	}
+	st.mtx.Unlock()
+
+	return nil
+}
+
+// replay applies the changeset of version to the tree t and commits it.
+func replay(t *tree, version int64, changeSet *iavl.ChangeSet) error {
+	if t.version > 0 && version != t.version+1 {
+		return fmt.Errorf("changeset of version %d can't follow version %d", version, t.version)
+	}
+
+	for _, pair := range changeSet.Pairs {
+		if pair.Delete {
+			t.remove(pair.Key)
+		} else {
+			t.set(pair.Key, pair.Value)
+		}
+	}
+	t.saveVersion(version)
+
+	return nil
+}
+
+// Close waits for the snapshot being written, if any, and closes the
+// write-ahead log.
+func (st *Store) Close() error {
+	st.waitSnapshot()
+	return st.wal.close()
+}
+
+// GetImmutable returns a store of a version kept, which panics on writes.
+func (st *Store) GetImmutable(version int64) (*Store, error) {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	for _, t := range st.versions {
+		if t.version == version {
+			return &Store{
+				dir:      st.dir,
+				opts:     st.opts,
+				logger:   st.logger,
+				metrics:  st.metrics,
+				tree:     t,
+				readOnly: true,
+				versions: []*tree{t},
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: version %d is not kept by the memiavl store", iavl.ErrVersionDoesNotExist, version)
+}
+
+// Commit commits the working version, writes its changeset to the write-ahead
+// log, and writes a snapshot in the background every SnapshotInterval
+// versions. The versions greater or equal to the one committed, which are
+// left when a version before the latest one is loaded, are overwritten.
+func (st *Store) Commit() types.CommitID {
+	defer st.metrics.MeasureSince("store", "memiavl", "commit")
+
+	if st.readOnly {
+		panic("cannot commit an immutable memiavl store")
+	}
+
+	version := st.tree.commitVersion()
+	if version <= st.wal.lastVersion {
+		if err := st.truncateAfter(version - 1); err != nil {
+			panic(err)
+		}
+	}
+	if err := st.wal.write(version, &st.changeSet); err != nil {
+		panic(err)
+	}
+
+	hash := st.tree.saveVersion(version)
+	committed := st.tree.copy()
+	changeSet := st.changeSet
+	st.changeSet = iavl.ChangeSet{}
+
+	st.mtx.Lock()
+	st.versions = append(st.versions, committed)
+	if keep := int(st.opts.KeepRecent) + 1; len(st.versions) > keep {
+		st.versions = append([]*tree(nil), st.versions[len(st.versions)-keep:]...)
+	}
+	st.mtx.Unlock()
+
+	st.snapshot(committed, &changeSet)
+
+	return types.CommitID{
+		Version: version,
+		Hash:    hash,
+	}
+}
+
+// snapshot switches to the snapshot written in the background once done, or
+// starts writing the one of the committed tree if SnapshotInterval versions
+// were committed since the last one. Errors are only logged, as the
+// write-ahead log still holds the changesets of the versions committed.
+func (st *Store) snapshot(committed *tree, changeSet *iavl.ChangeSet) {
+	if s := st.snapshotting; s != nil {
+		s.add(committed.version, changeSet)
+
+		select {
+		case <-s.done:
+		default:
+			return
+		}
+
+		st.snapshotting = nil
+		if s.err == nil {
+			s.err = s.catchUp()
+		}
+		if s.err == nil && !bytes.Equal(s.tree.rootHash(), committed.rootHash()) {
+			s.err = fmt.Errorf("hash %X instead of %X at version %d", s.tree.rootHash(), committed.rootHash(), committed.version)
+		}
+		if s.err != nil {
+			st.logger.Error("failed to load the memiavl snapshot", "dir", st.dir, "version", s.version, "err", s.err)
+			return
+		}
+
+		s.tree.initialVersion = st.tree.initialVersion
+		st.tree = s.tree
+		st.mtx.Lock()
+		st.versions[len(st.versions)-1] = s.tree.copy()
+		st.mtx.Unlock()
+		st.snapshotVersion = s.version
+
+		if err := st.pruneSnapshots(); err != nil {
+			st.logger.Error("failed to prune the memiavl snapshots", "dir", st.dir, "err", err)
+		}
+		return
+	}
+
+	if committed.version-st.snapshotVersion < int64(st.opts.SnapshotInterval) {
+		return
+	}
+
+	// the changesets of the following versions start a new segment, which is
+	// kept with the snapshot
+	if err := st.wal.rotate(); err != nil {
+		st.logger.Error("failed to rotate the memiavl write-ahead log", "dir", st.dir, "err", err)
+		return
+	}
+
+	st.snapshotting = &snapshotting{
+		version: committed.version,
+		done:    make(chan struct{}),
+	}
+	go st.snapshotting.run(st.dir, committed)
+}
+
+// waitSnapshot waits for the snapshot being written, if any, and discards it
+// if it can't be switched to anymore.
+func (st *Store) waitSnapshot() {
+	if st.snapshotting == nil {
+		return
+	}
+
+	<-st.snapshotting.done
+	st.snapshotting = nil
+}
+
+// pruneSnapshots deletes the snapshots older than the SnapshotKeepRecent ones
+// before the latest, and the changesets of the write-ahead log before them.
+func (st *Store) pruneSnapshots() error {
+	snapshots, err := listSnapshots(st.dir)
+	if err != nil {
+		return err
+	}
+
+	keep := int(st.opts.SnapshotKeepRecent) + 1
+	if len(snapshots) <= keep {
+		if len(snapshots) > 0 {
+			return st.wal.deleteBefore(snapshots[0])
+		}
+		return nil
+	}
+
+	for _, version := range snapshots[:len(snapshots)-keep] {
+		if err := os.RemoveAll(filepath.Join(st.dir, snapshotName(version))); err != nil {
+			return err
+		}
+	}
+
+	return st.wal.deleteBefore(snapshots[len(snapshots)-keep])
+}
+
+// truncateAfter deletes the changesets and the snapshots of the versions
+// greater than version.
+func (st *Store) truncateAfter(version int64) error {
+	st.waitSnapshot()
+
+	if err := st.wal.truncateAfter(version); err != nil {
+		return err
+	}
+
+	snapshots, err := listSnapshots(st.dir)
+	if err != nil {
+		return err
+	}
+	for _, v := range snapshots {
+		if v > version {
+			if err := os.RemoveAll(filepath.Join(st.dir, snapshotName(v))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// snapshotting is a snapshot written in the background. Once written, its
+// tree is loaded and the changesets committed meanwhile are replayed on it.
+type snapshotting struct {
+	version int64
+	done    chan struct{}
+	tree    *tree
+	err     error
+
+	mtx        sync.Mutex
+	changeSets []versionedChangeSet
+	// replayed is the number of changesets replayed on the tree.
+	replayed int
+}
+
+type versionedChangeSet struct {
+	version   int64
+	changeSet *iavl.ChangeSet
+}
+
+func (s *snapshotting) add(version int64, changeSet *iavl.ChangeSet) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.changeSets = append(s.changeSets, versionedChangeSet{version, changeSet})
+}
+
+func (s *snapshotting) run(dir string, t *tree) {
+	defer close(s.done)
+
+	if s.err = writeSnapshot(dir, t); s.err != nil {
+		return
+	}
+
+	snapshot, err := openSnapshot(dir, t.version)
+	if err != nil {
+		s.err = err
+		return
+	}
+	s.tree = snapshot.tree()
+
+	s.err = s.catchUp()
+}
+
+// catchUp replays the changesets committed since the snapshot on its tree.
+func (s *snapshotting) catchUp() error {
+	for {
+		s.mtx.Lock()
+		changeSets := s.changeSets[s.replayed:]
+		s.mtx.Unlock()
+
+		if len(changeSets) == 0 {
+			return nil
+		}
+		for _, cs := range changeSets {
+			if err := replay(s.tree, cs.version, cs.changeSet); err != nil {
+				return err
+			}
+		}
+		s.replayed += len(changeSets)
+	}
+}
+
+// WorkingHash returns the hash of the working tree.
+func (st *Store) WorkingHash() []byte {
+	return st.tree.rootHash()
+}
+
+// LastCommitID implements Committer.
+func (st *Store) LastCommitID() types.CommitID {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	if len(st.versions) == 0 {
+		return types.CommitID{Hash: emptyHash}
+	}
+
+	latest := st.versions[len(st.versions)-1]
+	return types.CommitID{
+		Version: latest.version,
+		Hash:    latest.rootHash(),
+	}
+}
+
+// SetPruning panics as the versions kept are set by the options of the store.
+func (st *Store) SetPruning(_ pruningtypes.PruningOptions) {
+	panic("cannot set pruning options on an initialized memiavl store")
+}
+
+// GetPruning panics as the versions kept are set by the options of the store.
+func (st *Store) GetPruning() pruningtypes.PruningOptions {
+	panic("cannot get pruning options on an initialized memiavl store")
+}
+
+// VersionExists returns whether or not a given version is kept.
+func (st *Store) VersionExists(version int64) bool {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	for _, t := range st.versions {
+		if t.version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Implements Store.
+func (st *Store) GetStoreType() types.StoreType {
+	return types.StoreTypeMemIAVL
+}
+
+// Implements Store.
+func (st *Store) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st *Store) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(st, w, tc))
+}
+
+// Implements types.KVStore.
+func (st *Store) Set(key, value []byte) {
+	types.AssertValidKey(key)
+	types.AssertValidValue(value)
+	if st.readOnly {
+		panic("cannot set a key on an immutable memiavl store")
+	}
+
+	st.tree.set(key, value)
+	st.changeSet.Pairs = append(st.changeSet.Pairs, iavl.KVPair{Key: key, Value: value})
+}
+
+// Implements types.KVStore.
+func (st *Store) Get(key []byte) []byte {
+	defer st.metrics.MeasureSince("store", "memiavl", "get")
+	return st.tree.get(key)
+}
+
+// Implements types.KVStore.
+func (st *Store) Has(key []byte) bool {
+	defer st.metrics.MeasureSince("store", "memiavl", "has")
+	return st.tree.has(key)
+}
+
+// Implements types.KVStore.
+func (st *Store) Delete(key []byte) {
+	defer st.metrics.MeasureSince("store", "memiavl", "delete")
+	if st.readOnly {
+		panic("cannot delete a key on an immutable memiavl store")
+	}
+
+	if st.tree.remove(key) {
+		st.changeSet.Pairs = append(st.changeSet.Pairs, iavl.KVPair{Key: key, Delete: true})
+	}
+}
+
+// Implements types.KVStore.
+func (st *Store) Iterator(start, end []byte) types.Iterator {
+	return newIterator(st.tree.root, start, end, true)
+}
+
+// Implements types.KVStore.
+func (st *Store) ReverseIterator(start, end []byte) types.Iterator {
+	return newIterator(st.tree.root, start, end, false)
+}
+
+// SetInitialVersion sets the initial version of the tree. It is used when
+// starting a new chain at an arbitrary height.
+func (st *Store) SetInitialVersion(version int64) {
+	st.tree.initialVersion = version
+}
+
+// LoadVersionForOverwriting loads the store at targetVersion, which must be
+// after the oldest snapshot kept, and deletes the versions greater than it.
+func (st *Store) LoadVersionForOverwriting(targetVersion int64) (int64, error) {
+	st.waitSnapshot()
+
+	snapshots, err := listSnapshots(st.dir)
+	if err != nil {
+		return 0, err
+	}
+	if len(snapshots) > 0 && targetVersion < snapshots[0] {
+		return 0, fmt.Errorf("memiavl store %s: version %d is before the oldest snapshot %d", st.dir, targetVersion, snapshots[0])
+	}
+
+	if err := st.truncateAfter(targetVersion); err != nil {
+		return 0, err
+	}
+	if err := st.load(targetVersion); err != nil {
+		return 0, err
+	}
+
+	return targetVersion, nil
+}
+
+// Export exports the tree of a version kept, as iavl.Store.
+func (st *Store) Export(version int64) (*Exporter, error) {
+	istore, err := st.GetImmutable(version)
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "memiavl export failed for version %v", version)
+	}
+	return newExporter(istore.tree), nil
+}
+
+// Import imports the tree of version, exported by an iavl or memiavl store,
+// into the store, which must be empty.
+func (st *Store) Import(version int64) (*Importer, error) {
+	if st.readOnly {
+		return nil, errors.New("memiavl import failed: the store is immutable")
+	}
+	if st.tree.version > 0 || st.tree.root != nil {
+		return nil, errors.New("memiavl import failed: the store must be empty")
+	}
+
+	writer, err := newSnapshotWriter(st.dir, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Importer{store: st, writer: writer}, nil
+}
+
+// getHeight returns the height of a query, the version before the latest one
+// if it is kept and the height is 0, as iavl.Store.
+func (st *Store) getHeight(req abci.RequestQuery) int64 {
+	height := req.Height
+	if height == 0 {
+		latest := st.LastCommitID().Version
+		if st.VersionExists(latest - 1) {
+			height = latest - 1
+		} else {
+			height = latest
+		}
+	}
+	return height
+}
+
+// Query implements ABCI interface, allows queries, as iavl.Store.
+func (st *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
+	defer st.metrics.MeasureSince("store", "memiavl", "query")
+
+	if len(req.Data) == 0 {
+		return types.QueryResult(errorsmod.Wrap(types.ErrTxDecode, "query cannot be zero length"), false)
+	}
+
+	res.Height = st.getHeight(req)
+
+	switch req.Path {
+	case "/key":
+		key := req.Data
+		res.Key = key
+
+		istore, err := st.GetImmutable(res.Height)
+		if err != nil {
+			res.Log = iavl.ErrVersionDoesNotExist.Error()
+			break
+		}
+		res.Value = istore.tree.get(key)
+
+		if !req.Prove {
+			break
+		}
+
+		res.ProofOps = getProofFromTree(istore.tree, key, res.Value != nil)
+
+	case "/subspace":
+		pairs := kv.Pairs{
+			Pairs: make([]kv.Pair, 0),
+		}
+
+		subspace := req.Data
+		res.Key = subspace
+
+		iterator := types.KVStorePrefixIterator(st, subspace)
+		for ; iterator.Valid(); iterator.Next() {
+			pairs.Pairs = append(pairs.Pairs, kv.Pair{Key: iterator.Key(), Value: iterator.Value()})
+		}
+		iterator.Close()
+
+		bz, err := pairs.Marshal()
+		if err != nil {
+			panic(fmt.Errorf("failed to marshal KV pairs: %w", err))
+		}
+
+		res.Value = bz
+
+	default:
+		return types.QueryResult(errorsmod.Wrapf(types.ErrUnknownRequest, "unexpected query path: %v", req.Path), false)
+	}
+
+	return res
+}
+
+// getProofFromTree returns the proof of existence or absence of key in the
+// tree, panicking on error as the value was queried first.
+func getProofFromTree(t *tree, key []byte, exists bool) *cmtprotocrypto.ProofOps {
+	var (
+		proof *ics23.CommitmentProof
+		err   error
+	)
+
+	if exists {
+		proof, err = t.getMembershipProof(key)
+		if err != nil {
+			panic(fmt.Sprintf("unexpected value for empty proof: %s", err.Error()))
+		}
+	} else {
+		proof, err = t.getNonMembershipProof(key)
+		if err != nil {
+			panic(fmt.Sprintf("unexpected error for nonexistence proof: %s", err.Error()))
+		}
+	}
+
+	op := types.NewIavlCommitmentOp(key, proof)
+	return &cmtprotocrypto.ProofOps{Ops: []cmtprotocrypto.ProofOp{op.ProofOp()}}
+}
+
+// TraverseStateChanges calls fn with the changes of the versions from
+// startVersion to endVersion, excluded, held by the write-ahead log, sorted by
+// key as by iavl.Store. An error is returned if the changes of some of them
+// were deleted with the snapshots before them.
+func (st *Store) TraverseStateChanges(startVersion, endVersion int64, fn func(version int64, changeSet *iavl.ChangeSet) error) error {
+	snapshots, err := listSnapshots(st.dir)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 && startVersion <= snapshots[0] {
+		return fmt.Errorf("memiavl store %s: the changes before version %d are not kept", st.dir, snapshots[0]+1)
+	}
+
+	return st.wal.read(startVersion-1, func(version int64, changeSet *iavl.ChangeSet) error {
+		if version >= endVersion {
+			return errStopReading
+		}
+
+		// only the last change of each key is kept
+		changes := make(map[string]iavl.KVPair, len(changeSet.Pairs))
+		for _, pair := range changeSet.Pairs {
+			changes[string(pair.Key)] = pair
+		}
+
+		net := &iavl.ChangeSet{Pairs: make([]iavl.KVPair, 0, len(changes))}
+		for _, pair := range changes {
+			net.Pairs = append(net.Pairs, pair)
+		}
+		sort.Slice(net.Pairs, func(i, j int) bool {
+			return bytes.Compare(net.Pairs[i].Key, net.Pairs[j].Key) < 0
+		})
+
+		return fn(version, net)
+	})
+}
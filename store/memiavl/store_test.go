@@ -0,0 +1,272 @@
+package memiavl
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	iavlstore "cosmossdk.io/store/iavl"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/types"
+)
+
+var testOptions = types.MemIAVLOptions{
+	SnapshotInterval:   5,
+	SnapshotKeepRecent: 1,
+	KeepRecent:         2,
+}
+
+func newReference(t *testing.T) *iavlstore.Store {
+	st, err := iavlstore.LoadStore(dbm.NewMemDB(), log.NewNopLogger(), types.NewKVStoreKey("test"), types.CommitID{}, false, iavlstore.DefaultIAVLCacheSize, false, metrics.NewNoOpMetrics())
+	require.NoError(t, err)
+	return st.(*iavlstore.Store)
+}
+
+func loadStore(t *testing.T, dir string, id types.CommitID) *Store {
+	st, err := LoadStore(dir, log.NewNopLogger(), id, 0, testOptions, metrics.NewNoOpMetrics())
+	require.NoError(t, err)
+	return st
+}
+
+// commitRandomVersions makes the same random changes to the stores and
+// commits them, checking that their hashes match.
+func commitRandomVersions(t *testing.T, r *rand.Rand, st *Store, reference types.CommitKVStore, count int) types.CommitID {
+	var id types.CommitID
+	for i := 0; i < count; i++ {
+		for j := 0; j < 1+r.Intn(20); j++ {
+			key := []byte(fmt.Sprintf("key-%03d", r.Intn(100)))
+			if r.Intn(4) == 0 {
+				st.Delete(key)
+				reference.Delete(key)
+				continue
+			}
+			value := []byte(fmt.Sprintf("value-%d", r.Int()))
+			st.Set(key, value)
+			reference.Set(key, value)
+		}
+
+		require.Equal(t, reference.WorkingHash(), st.WorkingHash())
+		id = st.Commit()
+		require.Equal(t, reference.Commit(), id)
+	}
+	return id
+}
+
+func requireSameContent(t *testing.T, expected, actual types.KVStore) {
+	expectedIt, actualIt := expected.Iterator(nil, nil), actual.Iterator(nil, nil)
+	defer expectedIt.Close()
+	defer actualIt.Close()
+
+	for ; expectedIt.Valid(); expectedIt.Next() {
+		require.True(t, actualIt.Valid())
+		require.Equal(t, expectedIt.Key(), actualIt.Key())
+		require.Equal(t, expectedIt.Value(), actualIt.Value())
+		actualIt.Next()
+	}
+	require.False(t, actualIt.Valid())
+}
+
+func TestStoreReload(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	dir := t.TempDir()
+	reference := newReference(t)
+
+	st := loadStore(t, dir, types.CommitID{})
+	require.Equal(t, types.CommitID{Hash: emptyHash}, st.LastCommitID())
+	id := commitRandomVersions(t, r, st, reference, 23)
+	require.Equal(t, id, st.LastCommitID())
+	requireSameContent(t, reference, st)
+	require.NoError(t, st.Close())
+
+	// older snapshots and the changesets before them are deleted
+	snapshots, err := listSnapshots(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, snapshots)
+	require.LessOrEqual(t, len(snapshots), 2)
+
+	// a changeset partially written is discarded
+	w, err := openWAL(dir)
+	require.NoError(t, err)
+	f, err := os.OpenFile(w.segmentPath(w.segments[len(w.segments)-1]), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.Write(encodeChangeset(24, &iavl.ChangeSet{Pairs: []iavl.KVPair{{Key: []byte("key"), Value: []byte("value")}}})[:10])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	st = loadStore(t, dir, id)
+	require.Equal(t, id, st.LastCommitID())
+	requireSameContent(t, reference, st)
+	id = commitRandomVersions(t, r, st, reference, 10)
+	require.NoError(t, st.Close())
+
+	st = loadStore(t, dir, types.CommitID{})
+	require.Equal(t, id, st.LastCommitID())
+
+	// the hash of the version loaded is checked
+	_, err = LoadStore(dir, log.NewNopLogger(), types.CommitID{Version: id.Version, Hash: []byte("hash")}, 0, testOptions, metrics.NewNoOpMetrics())
+	require.Error(t, err)
+}
+
+func TestStoreLoadVersionForOverwriting(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	reference := newReference(t)
+	st := loadStore(t, t.TempDir(), types.CommitID{})
+	commitRandomVersions(t, r, st, reference, 12)
+
+	version, err := st.LoadVersionForOverwriting(11)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), version)
+	_, err = reference.LoadVersionForOverwriting(11)
+	require.NoError(t, err)
+	require.Equal(t, reference.LastCommitID(), st.LastCommitID())
+
+	commitRandomVersions(t, r, st, reference, 3)
+
+	_, err = st.LoadVersionForOverwriting(1)
+	require.Error(t, err)
+}
+
+func TestStoreQuery(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	reference := newReference(t)
+	st := loadStore(t, t.TempDir(), types.CommitID{})
+	commitRandomVersions(t, r, st, reference, 8)
+
+	for _, height := range []int64{0, 7, 8} {
+		for _, key := range []string{"key-000", "key-010", "key-050", "key-0505", "key-099", "a", "z"} {
+			req := abci.RequestQuery{Path: "/key", Data: []byte(key), Height: height, Prove: true}
+			require.Equal(t, reference.Query(req), st.Query(req), "height %d key %s", height, key)
+		}
+	}
+
+	req := abci.RequestQuery{Path: "/subspace", Data: []byte("key-0")}
+	require.Equal(t, reference.Query(req), st.Query(req))
+
+	res := st.Query(abci.RequestQuery{Path: "/key", Data: []byte("key-000"), Height: 1})
+	require.Equal(t, iavl.ErrVersionDoesNotExist.Error(), res.Log)
+}
+
+func TestStoreImmutable(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	reference := newReference(t)
+	st := loadStore(t, t.TempDir(), types.CommitID{})
+	id := commitRandomVersions(t, r, st, reference, 4)
+
+	istore, err := st.GetImmutable(id.Version)
+	require.NoError(t, err)
+	commitRandomVersions(t, r, st, reference, 1)
+
+	expected, err := reference.GetImmutable(id.Version)
+	require.NoError(t, err)
+	requireSameContent(t, expected, istore)
+	require.Equal(t, id, istore.LastCommitID())
+	require.Panics(t, func() { istore.Set([]byte("key"), []byte("value")) })
+	require.Panics(t, func() { istore.Delete([]byte("key")) })
+
+	require.True(t, st.VersionExists(id.Version-1))
+	require.False(t, st.VersionExists(id.Version-2))
+	_, err = st.GetImmutable(id.Version - 2)
+	require.ErrorIs(t, err, iavl.ErrVersionDoesNotExist)
+}
+
+func TestStoreExportImport(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	reference := newReference(t)
+	st := loadStore(t, t.TempDir(), types.CommitID{})
+	id := commitRandomVersions(t, r, st, reference, 7)
+
+	// memiavl to iavl
+	exporter, err := st.Export(id.Version)
+	require.NoError(t, err)
+	tree, err := iavl.NewMutableTree(dbm.NewMemDB(), 0, false)
+	require.NoError(t, err)
+	importer, err := tree.Import(id.Version)
+	require.NoError(t, err)
+	exportAll(t, exporter, importer)
+	hash, err := tree.Hash()
+	require.NoError(t, err)
+	require.Equal(t, id.Hash, hash)
+
+	// iavl to memiavl
+	iavlExporter, err := reference.Export(id.Version)
+	require.NoError(t, err)
+	imported := loadStore(t, t.TempDir(), types.CommitID{})
+	memImporter, err := imported.Import(id.Version)
+	require.NoError(t, err)
+	exportAll(t, iavlExporter, memImporter)
+	require.Equal(t, id, imported.LastCommitID())
+	requireSameContent(t, reference, imported)
+
+	commitRandomVersions(t, r, imported, reference, 3)
+
+	_, err = imported.Import(id.Version)
+	require.Error(t, err)
+}
+
+func exportAll(t *testing.T, exporter interface {
+	Next() (*iavl.ExportNode, error)
+}, importer interface {
+	Add(*iavl.ExportNode) error
+	Commit() error
+},
+) {
+	for {
+		node, err := exporter.Next()
+		if errors.Is(err, iavl.ErrorExportDone) {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, importer.Add(node))
+	}
+	require.NoError(t, importer.Commit())
+}
+
+func TestStoreTraverseStateChanges(t *testing.T) {
+	dir := t.TempDir()
+	st, err := LoadStore(dir, log.NewNopLogger(), types.CommitID{}, 10, types.MemIAVLOptions{}, metrics.NewNoOpMetrics())
+	require.NoError(t, err)
+
+	st.Set([]byte("b"), []byte("1"))
+	st.Set([]byte("a"), []byte("1"))
+	st.Commit()
+	st.Set([]byte("a"), []byte("2"))
+	st.Set([]byte("a"), []byte("3"))
+	st.Delete([]byte("b"))
+	st.Delete([]byte("c"))
+	st.Commit()
+	st.Set([]byte("c"), []byte("1"))
+	st.Commit()
+
+	var versions []int64
+	var changeSets []*iavl.ChangeSet
+	require.NoError(t, st.TraverseStateChanges(1, 12, func(version int64, changeSet *iavl.ChangeSet) error {
+		versions = append(versions, version)
+		changeSets = append(changeSets, changeSet)
+		return nil
+	}))
+
+	require.Equal(t, []int64{10, 11}, versions)
+	require.Equal(t, []*iavl.ChangeSet{
+		{Pairs: []iavl.KVPair{{Key: []byte("a"), Value: []byte("1")}, {Key: []byte("b"), Value: []byte("1")}}},
+		{Pairs: []iavl.KVPair{{Key: []byte("a"), Value: []byte("3")}, {Key: []byte("b"), Delete: true}}},
+	}, changeSets)
+
+	// the changes before a snapshot are not kept
+	require.NoError(t, st.Close())
+	w, err := newSnapshotWriter(dir, 11)
+	require.NoError(t, err)
+	_, err = w.commit()
+	require.NoError(t, err)
+	require.DirExists(t, filepath.Join(dir, snapshotName(11)))
+	require.Error(t, st.TraverseStateChanges(11, 13, func(int64, *iavl.ChangeSet) error { return nil }))
+	require.NoError(t, st.TraverseStateChanges(12, 13, func(int64, *iavl.ChangeSet) error { return nil }))
+}
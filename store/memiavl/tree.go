@@ -0,0 +1,320 @@
+package memiavl
+
+import (
+	"bytes"
+)
+
+// tree is an IAVL tree. Its nodes are created, rotated and hashed as in iavl,
+// so that it has the same root hash as an iavl tree given the same changes.
+//
+// The nodes of the working version are modified in place, the others are
+// copied on write, so that the trees of the committed versions are immutable
+// and can be read concurrently.
+type tree struct {
+	root           node
+	version        int64
+	initialVersion int64
+}
+
+// workingVersion returns the version of the nodes created or modified by the
+// changes of the working version, which iavl does not set to the initial
+// version.
+func (t *tree) workingVersion() int64 {
+	return t.version + 1
+}
+
+// commitVersion returns the version of the working version once committed.
+func (t *tree) commitVersion() int64 {
+	version := t.version + 1
+	if version == 1 && t.initialVersion > 0 {
+		version = t.initialVersion
+	}
+	return version
+}
+
+// saveVersion commits the working version as version and returns its root
+// hash.
+func (t *tree) saveVersion(version int64) []byte {
+	hash := t.rootHash()
+	t.version = version
+	return hash
+}
+
+// copy returns a copy of the tree, sharing its nodes.
+func (t *tree) copy() *tree {
+	c := *t
+	return &c
+}
+
+// rootHash returns the hash of the tree.
+func (t *tree) rootHash() []byte {
+	if t.root == nil {
+		return emptyHash
+	}
+	return t.root.Hash()
+}
+
+// size returns the number of leaves of the tree.
+func (t *tree) size() int64 {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.Size()
+}
+
+// get returns the value of key, or nil if it does not exist.
+func (t *tree) get(key []byte) []byte {
+	_, value := t.getWithIndex(key)
+	return value
+}
+
+// has returns true if key exists.
+func (t *tree) has(key []byte) bool {
+	leaf := t.leaf(key)
+	return leaf != nil && bytes.Equal(key, leaf.Key())
+}
+
+// leaf returns the leaf where the search of key ends.
+func (t *tree) leaf(key []byte) node {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+
+	for n.Height() > 0 {
+		if bytes.Compare(key, n.Key()) < 0 {
+			n = n.Left()
+		} else {
+			n = n.Right()
+		}
+	}
+
+	return n
+}
+
+// getWithIndex returns the index of key and its value, or the index it would
+// have and nil if it does not exist.
+func (t *tree) getWithIndex(key []byte) (int64, []byte) {
+	if t.root == nil {
+		return 0, nil
+	}
+
+	var index int64
+	n := t.root
+	for n.Height() > 0 {
+		if bytes.Compare(key, n.Key()) < 0 {
+			n = n.Left()
+		} else {
+			index += n.Left().Size()
+			n = n.Right()
+		}
+	}
+
+	switch bytes.Compare(key, n.Key()) {
+	case -1:
+		return index, nil
+	case 1:
+		return index + 1, nil
+	default:
+		return index, retainValue(n)
+	}
+}
+
+// getByIndex returns the leaf at index, or nil if it is out of range.
+func (t *tree) getByIndex(index int64) node {
+	if t.root == nil || index < 0 || index >= t.root.Size() {
+		return nil
+	}
+
+	n := t.root
+	for n.Height() > 0 {
+		left := n.Left()
+		if index < left.Size() {
+			n = left
+		} else {
+			index -= left.Size()
+			n = n.Right()
+		}
+	}
+
+	return n
+}
+
+// set sets the value of key and returns true if it existed.
+func (t *tree) set(key, value []byte) bool {
+	if t.root == nil {
+		t.root = newLeaf(key, value, t.workingVersion())
+		return false
+	}
+
+	var updated bool
+	t.root, updated = t.setRecursive(t.root, key, value)
+	return updated
+}
+
+func (t *tree) setRecursive(n node, key, value []byte) (node, bool) {
+	version := t.workingVersion()
+
+	if n.Height() == 0 {
+		switch bytes.Compare(key, n.Key()) {
+		case -1:
+			return &memNode{
+				height:  1,
+				size:    2,
+				version: version,
+				key:     retainKey(n),
+				left:    newLeaf(key, value, version),
+				right:   n,
+			}, false
+		case 1:
+			return &memNode{
+				height:  1,
+				size:    2,
+				version: version,
+				key:     key,
+				left:    n,
+				right:   newLeaf(key, value, version),
+			}, false
+		default:
+			return newLeaf(key, value, version), true
+		}
+	}
+
+	b := t.mutate(n)
+
+	var updated bool
+	if bytes.Compare(key, b.key) < 0 {
+		b.left, updated = t.setRecursive(b.left, key, value)
+	} else {
+		b.right, updated = t.setRecursive(b.right, key, value)
+	}
+
+	if updated {
+		return b, true
+	}
+
+	b.updateHeightSize()
+	return t.balance(b), false
+}
+
+// remove removes key and returns true if it existed.
+func (t *tree) remove(key []byte) bool {
+	if t.root == nil {
+		return false
+	}
+
+	root, _, removed := t.removeRecursive(t.root, key)
+	if removed {
+		t.root = root
+	}
+
+	return removed
+}
+
+// removeRecursive removes key from the subtree of n. It returns the new
+// subtree, nil if it is empty, and the new key of the closest ancestor it is
+// the right subtree of if it changes.
+func (t *tree) removeRecursive(n node, key []byte) (node, []byte, bool) {
+	if n.Height() == 0 {
+		if bytes.Equal(key, n.Key()) {
+			return nil, nil, true
+		}
+		return n, nil, false
+	}
+
+	if bytes.Compare(key, n.Key()) < 0 {
+		left, newKey, removed := t.removeRecursive(n.Left(), key)
+		if !removed {
+			return n, nil, false
+		}
+		if left == nil {
+			return n.Right(), retainKey(n), true
+		}
+
+		b := t.mutate(n)
+		b.left = left
+		b.updateHeightSize()
+		return t.balance(b), newKey, true
+	}
+
+	right, newKey, removed := t.removeRecursive(n.Right(), key)
+	if !removed {
+		return n, nil, false
+	}
+	if right == nil {
+		return n.Left(), nil, true
+	}
+
+	b := t.mutate(n)
+	b.right = right
+	if newKey != nil {
+		b.key = newKey
+	}
+	b.updateHeightSize()
+	return t.balance(b), nil, true
+}
+
+// mutate returns the branch n if it belongs to the working version, or a copy
+// of it for the working version.
+func (t *tree) mutate(n node) *memNode {
+	version := t.workingVersion()
+	if b, ok := n.(*memNode); ok && b.version == version {
+		b.hash = nil
+		return b
+	}
+
+	return &memNode{
+		height:  n.Height(),
+		size:    n.Size(),
+		version: version,
+		key:     retainKey(n),
+		left:    n.Left(),
+		right:   n.Right(),
+	}
+}
+
+// balance rebalances the branch b, whose height and size are up to date.
+func (t *tree) balance(b *memNode) node {
+	switch balance := balanceOf(b); {
+	case balance > 1:
+		if balanceOf(b.left) < 0 {
+			b.left = t.rotateLeft(b.left)
+		}
+		return t.rotateRight(b)
+
+	case balance < -1:
+		if balanceOf(b.right) > 0 {
+			b.right = t.rotateRight(b.right)
+		}
+		return t.rotateLeft(b)
+
+	default:
+		return b
+	}
+}
+
+func (t *tree) rotateRight(n node) *memNode {
+	b := t.mutate(n)
+	left := t.mutate(b.left)
+
+	b.left = left.right
+	left.right = b
+
+	b.updateHeightSize()
+	left.updateHeightSize()
+
+	return left
+}
+
+func (t *tree) rotateLeft(n node) *memNode {
+	b := t.mutate(n)
+	right := t.mutate(b.right)
+
+	b.right = right.left
+	right.left = b
+
+	b.updateHeightSize()
+	right.updateHeightSize()
+
+	return right
+}
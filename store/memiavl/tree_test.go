@@ -0,0 +1,104 @@
+package memiavl
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/iavl"
+	"github.com/stretchr/testify/require"
+)
+
+// applyRandomChanges makes random changes to both trees and returns them.
+func applyRandomChanges(t *testing.T, r *rand.Rand, tree *tree, reference *iavl.MutableTree, count int) *iavl.ChangeSet {
+	changeSet := &iavl.ChangeSet{}
+	for i := 0; i < count; i++ {
+		key := []byte(fmt.Sprintf("key-%03d", r.Intn(200)))
+		if r.Intn(4) == 0 {
+			_, removed, err := reference.Remove(key)
+			require.NoError(t, err)
+			require.Equal(t, removed, tree.remove(key))
+			changeSet.Pairs = append(changeSet.Pairs, iavl.KVPair{Key: key, Delete: true})
+			continue
+		}
+
+		value := []byte(fmt.Sprintf("value-%d", r.Int()))
+		updated, err := reference.Set(key, value)
+		require.NoError(t, err)
+		require.Equal(t, updated, tree.set(key, value))
+		changeSet.Pairs = append(changeSet.Pairs, iavl.KVPair{Key: key, Value: value})
+	}
+	return changeSet
+}
+
+func TestTreeHashesMatchIAVL(t *testing.T) {
+	for _, initialVersion := range []uint64{0, 1, 100} {
+		t.Run(fmt.Sprintf("initial version %d", initialVersion), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(initialVersion)))
+
+			reference, err := iavl.NewMutableTreeWithOpts(dbm.NewMemDB(), 0, &iavl.Options{InitialVersion: initialVersion}, false)
+			require.NoError(t, err)
+			tr := &tree{initialVersion: int64(initialVersion)}
+
+			for i := 0; i < 50; i++ {
+				applyRandomChanges(t, r, tr, reference, 1+r.Intn(30))
+
+				workingHash, err := reference.WorkingHash()
+				require.NoError(t, err)
+				require.Equal(t, workingHash, tr.rootHash())
+
+				hash, version, err := reference.SaveVersion()
+				require.NoError(t, err)
+				require.Equal(t, version, tr.commitVersion())
+				require.Equal(t, hash, tr.saveVersion(tr.commitVersion()))
+			}
+
+			require.Equal(t, reference.Size(), tr.size())
+			for i := int64(0); i < tr.size(); i++ {
+				key, value, err := reference.GetByIndex(i)
+				require.NoError(t, err)
+				leaf := tr.getByIndex(i)
+				require.Equal(t, key, leaf.Key())
+				require.Equal(t, value, leaf.Value())
+
+				index, got := tr.getWithIndex(key)
+				require.Equal(t, i, index)
+				require.Equal(t, value, got)
+			}
+		})
+	}
+}
+
+func TestIterator(t *testing.T) {
+	tr := &tree{}
+	for i := 0; i < 20; i += 2 {
+		tr.set([]byte{byte(i)}, []byte{byte(i)})
+	}
+	tr.saveVersion(1)
+
+	testCases := []struct {
+		start, end []byte
+		ascending  bool
+		expected   []byte
+	}{
+		{nil, nil, true, []byte{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}},
+		{nil, nil, false, []byte{18, 16, 14, 12, 10, 8, 6, 4, 2, 0}},
+		{[]byte{3}, []byte{10}, true, []byte{4, 6, 8}},
+		{[]byte{4}, []byte{10}, false, []byte{8, 6, 4}},
+		{[]byte{18}, nil, true, []byte{18}},
+		{nil, []byte{0}, true, nil},
+		{[]byte{19}, nil, false, nil},
+	}
+
+	for _, tc := range testCases {
+		var keys []byte
+		it := newIterator(tr.root, tc.start, tc.end, tc.ascending)
+		for ; it.Valid(); it.Next() {
+			require.Equal(t, it.Key(), it.Value())
+			keys = append(keys, it.Key()[0])
+		}
+		require.NoError(t, it.Close())
+		require.Equal(t, tc.expected, keys, "start %v end %v ascending %v", tc.start, tc.end, tc.ascending)
+	}
+}
@@ -0,0 +1,357 @@
+package memiavl
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/iavl"
+)
+
+const walPrefix = "wal-"
+
+// errStopReading is returned by the callbacks of wal.read to stop reading.
+var errStopReading = errors.New("stop reading")
+
+// wal is the write-ahead log of a store, holding the changesets of the
+// versions committed since its snapshots. It is split in segment files named
+// after the version of their first changeset, a new segment being started at
+// every snapshot so that the ones older than the snapshots kept can be
+// deleted.
+//
+// A changeset is written as the length and the CRC-32 checksum of its
+// encoding, as 4-byte little-endian integers, followed by its encoding: its
+// version as a varint and its number of pairs as a uvarint, followed by its
+// pairs, each as a byte set to 1 for a deletion, its key and, for an update,
+// its value, both prefixed with their length as a uvarint.
+type wal struct {
+	dir string
+
+	// segments holds the first versions of the segments, in ascending order.
+	segments []int64
+	// file is the last segment, opened to append changesets, or nil if the
+	// next changeset starts a new segment.
+	file        *os.File
+	lastVersion int64
+}
+
+// openWAL opens the write-ahead log in dir. A changeset partially written at
+// the end of the log is removed.
+func openWAL(dir string) (*wal, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &wal{dir: dir}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, walPrefix) {
+			continue
+		}
+		version, err := strconv.ParseInt(strings.TrimPrefix(name, walPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		w.segments = append(w.segments, version)
+	}
+	sort.Slice(w.segments, func(i, j int) bool { return w.segments[i] < w.segments[j] })
+
+	// only the last segment may end with a changeset partially written
+	for len(w.segments) > 0 {
+		last := w.segments[len(w.segments)-1]
+		end, lastVersion, err := w.scan(last, -1)
+		if err != nil {
+			return nil, err
+		}
+		if end == 0 {
+			if err := os.Remove(w.segmentPath(last)); err != nil {
+				return nil, err
+			}
+			w.segments = w.segments[:len(w.segments)-1]
+			continue
+		}
+		if err := os.Truncate(w.segmentPath(last), end); err != nil {
+			return nil, err
+		}
+		w.lastVersion = lastVersion
+		break
+	}
+
+	return w, nil
+}
+
+func (w *wal) segmentPath(version int64) string {
+	return filepath.Join(w.dir, fmt.Sprintf("%s%020d", walPrefix, version))
+}
+
+// scan reads the valid changesets of the segment of version up to the one of
+// untilVersion, or all of them if it is negative, and returns the offset of
+// their end and the version of the last one.
+func (w *wal) scan(segment, untilVersion int64) (int64, int64, error) {
+	f, err := os.Open(w.segmentPath(segment))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	var end, lastVersion int64
+	r := bufio.NewReader(f)
+	for untilVersion < 0 || lastVersion < untilVersion {
+		payload, err := readChangeset(r)
+		if err != nil {
+			break
+		}
+		version, _ := binary.Varint(payload)
+		end += 8 + int64(len(payload))
+		lastVersion = version
+	}
+
+	return end, lastVersion, nil
+}
+
+// write appends the changeset of version to the log and syncs it to disk.
+func (w *wal) write(version int64, changeSet *iavl.ChangeSet) error {
+	if w.file == nil {
+		f, err := os.OpenFile(w.segmentPath(version), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		if err := syncDir(w.dir); err != nil {
+			_ = f.Close()
+			return err
+		}
+		w.file = f
+		w.segments = append(w.segments, version)
+	}
+
+	if _, err := w.file.Write(encodeChangeset(version, changeSet)); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+
+	w.lastVersion = version
+	return nil
+}
+
+// rotate starts a new segment at the next changeset.
+func (w *wal) rotate() error {
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// read calls fn with the changesets of the versions greater than after, in
+// ascending order, until it returns an error. errStopReading stops reading
+// without error.
+func (w *wal) read(after int64, fn func(version int64, changeSet *iavl.ChangeSet) error) error {
+	for i, segment := range w.segments {
+		// skip the segments whose changesets are all before after
+		if i+1 < len(w.segments) && w.segments[i+1] <= after+1 {
+			continue
+		}
+
+		if err := w.readSegment(segment, after, fn); err != nil {
+			if errors.Is(err, errStopReading) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *wal) readSegment(segment, after int64, fn func(version int64, changeSet *iavl.ChangeSet) error) error {
+	f, err := os.Open(w.segmentPath(segment))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		payload, err := readChangeset(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the write-ahead log segment %d: %w", segment, err)
+		}
+
+		version, changeSet, err := decodeChangeset(payload)
+		if err != nil {
+			return fmt.Errorf("failed to read the write-ahead log segment %d: %w", segment, err)
+		}
+		if version <= after {
+			continue
+		}
+		if err := fn(version, changeSet); err != nil {
+			return err
+		}
+	}
+}
+
+// truncateAfter removes the changesets of the versions greater than version.
+func (w *wal) truncateAfter(version int64) error {
+	if err := w.rotate(); err != nil {
+		return err
+	}
+
+	for len(w.segments) > 0 {
+		last := w.segments[len(w.segments)-1]
+		if last <= version {
+			end, lastVersion, err := w.scan(last, version)
+			if err != nil {
+				return err
+			}
+			w.lastVersion = lastVersion
+			return os.Truncate(w.segmentPath(last), end)
+		}
+
+		if err := os.Remove(w.segmentPath(last)); err != nil {
+			return err
+		}
+		w.segments = w.segments[:len(w.segments)-1]
+	}
+
+	w.lastVersion = 0
+	return nil
+}
+
+// deleteBefore deletes the segments holding only changesets of versions lower
+// or equal to version.
+func (w *wal) deleteBefore(version int64) error {
+	for len(w.segments) > 1 && w.segments[1] <= version+1 {
+		if err := os.Remove(w.segmentPath(w.segments[0])); err != nil {
+			return err
+		}
+		w.segments = w.segments[1:]
+	}
+
+	return nil
+}
+
+// firstVersion returns the version of the first changeset of the log, or 0 if
+// it is empty.
+func (w *wal) firstVersion() int64 {
+	if len(w.segments) == 0 {
+		return 0
+	}
+	return w.segments[0]
+}
+
+func (w *wal) close() error {
+	return w.rotate()
+}
+
+func encodeChangeset(version int64, changeSet *iavl.ChangeSet) []byte {
+	buf := make([]byte, 8, 8+binary.MaxVarintLen64*2)
+	buf = binary.AppendVarint(buf, version)
+	buf = binary.AppendUvarint(buf, uint64(len(changeSet.Pairs)))
+	for _, pair := range changeSet.Pairs {
+		if pair.Delete {
+			buf = append(buf, 1)
+			buf = binary.AppendUvarint(buf, uint64(len(pair.Key)))
+			buf = append(buf, pair.Key...)
+			continue
+		}
+
+		buf = append(buf, 0)
+		buf = binary.AppendUvarint(buf, uint64(len(pair.Key)))
+		buf = append(buf, pair.Key...)
+		buf = binary.AppendUvarint(buf, uint64(len(pair.Value)))
+		buf = append(buf, pair.Value...)
+	}
+
+	payload := buf[8:]
+	binary.LittleEndian.PutUint32(buf, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:], crc32.ChecksumIEEE(payload))
+
+	return buf
+}
+
+// readChangeset reads the encoding of the next changeset of r, checking its
+// checksum.
+func readChangeset(r io.Reader) ([]byte, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	payload := make([]byte, binary.LittleEndian.Uint32(header[:]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:]) {
+		return nil, errors.New("changeset checksum mismatch")
+	}
+
+	return payload, nil
+}
+
+func decodeChangeset(payload []byte) (int64, *iavl.ChangeSet, error) {
+	errInvalid := errors.New("invalid changeset")
+
+	version, n := binary.Varint(payload)
+	if n <= 0 {
+		return 0, nil, errInvalid
+	}
+	payload = payload[n:]
+
+	count, n := binary.Uvarint(payload)
+	if n <= 0 || count > uint64(len(payload)) {
+		return 0, nil, errInvalid
+	}
+	payload = payload[n:]
+
+	readBytes := func() ([]byte, bool) {
+		length, n := binary.Uvarint(payload)
+		if n <= 0 || length > uint64(len(payload)-n) {
+			return nil, false
+		}
+		bz := payload[n : n+int(length)]
+		payload = payload[n+int(length):]
+		return bz, true
+	}
+
+	changeSet := &iavl.ChangeSet{Pairs: make([]iavl.KVPair, count)}
+	for i := range changeSet.Pairs {
+		if len(payload) == 0 {
+			return 0, nil, errInvalid
+		}
+		pair := &changeSet.Pairs[i]
+		pair.Delete = payload[0] == 1
+		payload = payload[1:]
+
+		var ok bool
+		if pair.Key, ok = readBytes(); !ok {
+			return 0, nil, errInvalid
+		}
+		if pair.Delete {
+			continue
+		}
+		if pair.Value, ok = readBytes(); !ok {
+			return 0, nil, errInvalid
+		}
+	}
+
+	return version, changeSet, nil
+}
@@ -31,6 +31,10 @@ type Manager struct {
 	// These are the heights that are multiples of snapshotInterval and kept for state sync snapshots.
 	// The heights are added to this list to be pruned when a snapshot is complete.
 	pruneSnapshotHeights *list.List
+	// pruneBacklog holds the [start, end) ranges of heights which UpdateOptions
+	// left to be pruned. They are guarded by pruneHeightsMx and moved to the
+	// heights to be pruned at most pruneBacklogBatchSize at a time.
+	pruneBacklog []int64
 }
 
 // NegativeHeightsError is returned when a negative height is provided to the manager.
@@ -47,8 +51,13 @@ func (e *NegativeHeightsError) Error() string {
 var (
 	pruneHeightsKey         = []byte("s/pruneheights")
 	pruneSnapshotHeightsKey = []byte("s/prunesnapshotheights")
+	pruneBacklogKey         = []byte("s/prunebacklog")
 )
 
+// pruneBacklogBatchSize is the maximum number of heights of the backlog left
+// by UpdateOptions which are pruned at each pruning interval.
+const pruneBacklogBatchSize = 100
+
 // NewManager returns a new Manager with the given db and logger.
 // The retuned manager uses a pruning strategy of "nothing" which
 // keeps all heights. Users of the Manager may change the strategy
@@ -68,6 +77,44 @@ func (m *Manager) SetOptions(opts types.PruningOptions) {
 	m.opts = opts
 }
 
+// UpdateOptions changes the pruning strategy of a manager already handling
+// heights, previousHeight being the next height to be handled by HandleHeight.
+// The heights kept by the former strategy which the new one no longer keeps
+// are added to a backlog, which is pruned a batch at a time by the next calls
+// to GetFlushAndResetPruningHeights. The snapshot heights of the backlog go
+// through the snapshot heights to be pruned once they are old enough.
+func (m *Manager) UpdateOptions(opts types.PruningOptions, previousHeight int64) error {
+	prev := m.opts
+	m.opts = opts
+	if opts.GetPruningStrategy() == types.PruningNothing {
+		return nil
+	}
+
+	// the heights below previousHeight-KeepRecent were kept to be pruned by
+	// the former strategy, unless it pruned nothing.
+	start := int64(1)
+	if prev.GetPruningStrategy() != types.PruningNothing {
+		start = previousHeight - int64(prev.KeepRecent)
+	}
+	end := previousHeight - int64(opts.KeepRecent)
+	if start < 1 {
+		start = 1
+	}
+
+	m.pruneHeightsMx.Lock()
+	defer m.pruneHeightsMx.Unlock()
+
+	// the heights of the backlog the new strategy keeps are no longer pruned.
+	backlog := make([]int64, 0, len(m.pruneBacklog)+2)
+	for i := 0; i+1 < len(m.pruneBacklog); i += 2 {
+		backlog = appendHeightRange(backlog, m.pruneBacklog[i], m.pruneBacklog[i+1], end)
+	}
+	m.pruneBacklog = appendHeightRange(backlog, start, end, end)
+
+	// flush the updates to disk so that they are not lost if crash happens.
+	return m.db.SetSync(pruneBacklogKey, int64SliceToBytes(m.pruneBacklog))
+}
+
 // GetOptions fetches the pruning strategy from the manager.
 func (m *Manager) GetOptions() types.PruningOptions {
 	return m.opts
@@ -82,19 +129,68 @@ func (m *Manager) GetFlushAndResetPruningHeights() ([]int64, error) {
 	m.pruneHeightsMx.Lock()
 	defer m.pruneHeightsMx.Unlock()
 
+	backlogHeights, err := m.popPruneBacklog()
+	if err != nil {
+		return nil, err
+	}
+
 	// flush the updates to disk so that it is not lost if crash happens.
 	if err := m.db.SetSync(pruneHeightsKey, int64SliceToBytes(m.pruneHeights)); err != nil {
 		return nil, err
 	}
 
 	// Return a copy to prevent data races.
-	pruningHeights := make([]int64, len(m.pruneHeights))
-	copy(pruningHeights, m.pruneHeights)
+	pruningHeights := make([]int64, 0, len(backlogHeights)+len(m.pruneHeights))
+	pruningHeights = append(pruningHeights, backlogHeights...)
+	pruningHeights = append(pruningHeights, m.pruneHeights...)
 	m.pruneHeights = m.pruneHeights[:0]
 
 	return pruningHeights, nil
 }
 
+// popPruneBacklog removes at most pruneBacklogBatchSize heights from the
+// backlog and returns them, except for the snapshot heights which are added
+// to the snapshot heights to be pruned. pruneHeightsMx must be held.
+func (m *Manager) popPruneBacklog() ([]int64, error) {
+	if len(m.pruneBacklog) == 0 {
+		return nil, nil
+	}
+
+	var heights, snapshotHeights []int64
+	for n := 0; n < pruneBacklogBatchSize && len(m.pruneBacklog) > 0; n++ {
+		height := m.pruneBacklog[0]
+		if m.snapshotInterval > 0 && height%int64(m.snapshotInterval) == 0 {
+			snapshotHeights = append(snapshotHeights, height)
+		} else {
+			heights = append(heights, height)
+		}
+
+		if height+1 < m.pruneBacklog[1] {
+			m.pruneBacklog[0] = height + 1
+		} else {
+			m.pruneBacklog = m.pruneBacklog[2:]
+		}
+	}
+
+	if len(snapshotHeights) > 0 {
+		m.pruneSnapshotHeightsMx.Lock()
+		defer m.pruneSnapshotHeightsMx.Unlock()
+
+		for _, height := range snapshotHeights {
+			m.pruneSnapshotHeights.PushBack(height)
+		}
+		if err := m.db.SetSync(pruneSnapshotHeightsKey, listToBytes(m.pruneSnapshotHeights)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.db.SetSync(pruneBacklogKey, int64SliceToBytes(m.pruneBacklog)); err != nil {
+		return nil, err
+	}
+
+	return heights, nil
+}
+
 // HandleHeight determines if previousHeight height needs to be kept for pruning at the right interval prescribed by
 // the pruning strategy. Returns previousHeight, if it was kept to be pruned at the next call to Prune(), 0 otherwise.
 // previousHeight must be greater than 0 for the handling to take effect since valid heights start at 1 and 0 represents
@@ -209,6 +305,17 @@ func (m *Manager) LoadPruningHeights(db dbm.DB) error {
 		m.pruneSnapshotHeights = loadedPruneSnapshotHeights
 	}
 
+	loadedPruneBacklog, err := loadPruningBacklog(db)
+	if err != nil {
+		return err
+	}
+
+	if len(loadedPruneBacklog) > 0 {
+		m.pruneHeightsMx.Lock()
+		defer m.pruneHeightsMx.Unlock()
+		m.pruneBacklog = loadedPruneBacklog
+	}
+
 	return nil
 }
 
@@ -237,6 +344,46 @@ func loadPruningHeights(db dbm.DB) ([]int64, error) {
 	return prunedHeights, nil
 }
 
+// appendHeightRange appends the heights [start, end) below limit to the ranges,
+// merging it with the last range if they overlap.
+func appendHeightRange(ranges []int64, start, end, limit int64) []int64 {
+	if end > limit {
+		end = limit
+	}
+	if start >= end {
+		return ranges
+	}
+
+	if n := len(ranges); n > 0 && ranges[n-1] >= start {
+		if ranges[n-1] < end {
+			ranges[n-1] = end
+		}
+		return ranges
+	}
+	return append(ranges, start, end)
+}
+
+func loadPruningBacklog(db dbm.DB) ([]int64, error) {
+	bz, err := db.Get(pruneBacklogKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pruning backlog: %w", err)
+	}
+	if len(bz)%16 != 0 {
+		return nil, fmt.Errorf("invalid pruning backlog length: %d", len(bz))
+	}
+
+	backlog := make([]int64, 0, len(bz)/8)
+	for offset := 0; offset < len(bz); offset += 8 {
+		h := int64(binary.BigEndian.Uint64(bz[offset : offset+8]))
+		if h < 0 {
+			return nil, &NegativeHeightsError{Height: h}
+		}
+		backlog = append(backlog, h)
+	}
+
+	return backlog, nil
+}
+
 func loadPruningSnapshotHeights(db dbm.DB) (*list.List, error) {
 	bz, err := db.Get(pruneSnapshotHeightsKey)
 	if err != nil {
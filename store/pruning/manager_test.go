@@ -532,3 +532,104 @@ func TestGetFlushAndResetPruningHeights_DbErr_Panic(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, heights)
 }
+
+func TestUpdateOptions(t *testing.T) {
+	testcases := map[string]struct {
+		from             types.PruningOptions
+		to               types.PruningOptions
+		snapshotInterval uint64
+		expectedHeights  []int64
+		// heights pruned after the next height is handled
+		expectedSnapshotHeights []int64
+	}{
+		"keep recent lowered": {
+			from:            types.NewCustomPruningOptions(5, 10),
+			to:              types.NewCustomPruningOptions(2, 10),
+			expectedHeights: []int64{17, 18, 19, 20},
+		},
+		"keep recent lowered - snapshot": {
+			from:                    types.NewCustomPruningOptions(5, 10),
+			to:                      types.NewCustomPruningOptions(2, 10),
+			snapshotInterval:        9,
+			expectedHeights:         []int64{17, 19, 20},
+			expectedSnapshotHeights: []int64{21, 18},
+		},
+		"keep recent raised": {
+			from:            types.NewCustomPruningOptions(2, 10),
+			to:              types.NewCustomPruningOptions(5, 10),
+			expectedHeights: []int64{17},
+		},
+		"from nothing": {
+			from:            types.NewPruningOptions(types.PruningNothing),
+			to:              types.NewCustomPruningOptions(17, 10),
+			expectedHeights: []int64{1, 2, 3, 4, 5},
+		},
+		"to nothing": {
+			from:            types.NewCustomPruningOptions(2, 10),
+			to:              types.NewPruningOptions(types.PruningNothing),
+			expectedHeights: []int64{},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			manager := pruning.NewManager(db.NewMemDB(), log.NewNopLogger())
+			manager.SetOptions(tc.from)
+			manager.SetSnapshotInterval(tc.snapshotInterval)
+
+			require.NoError(t, manager.UpdateOptions(tc.to, 22))
+			require.Equal(t, tc.to, manager.GetOptions())
+			manager.HandleHeight(22)
+
+			heights, err := manager.GetFlushAndResetPruningHeights()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedHeights, heights)
+
+			if tc.expectedSnapshotHeights != nil {
+				manager.HandleHeight(23)
+				heights, err = manager.GetFlushAndResetPruningHeights()
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedSnapshotHeights, heights)
+			}
+		})
+	}
+}
+
+func TestUpdateOptions_Backlog(t *testing.T) {
+	db := db.NewMemDB()
+	manager := pruning.NewManager(db, log.NewNopLogger())
+	manager.SetSnapshotInterval(50)
+	require.NoError(t, manager.UpdateOptions(types.NewCustomPruningOptions(0, 10), 251))
+
+	// the backlog is pruned 100 heights at a time, and the snapshot heights
+	// once the next height is handled
+	heights, err := manager.GetFlushAndResetPruningHeights()
+	require.NoError(t, err)
+	require.Len(t, heights, 98)
+	require.Equal(t, int64(1), heights[0])
+	require.Equal(t, int64(99), heights[97])
+
+	// the backlog is persisted
+	manager = pruning.NewManager(db, log.NewNopLogger())
+	manager.SetOptions(types.NewCustomPruningOptions(0, 10))
+	manager.SetSnapshotInterval(50)
+	require.NoError(t, manager.LoadPruningHeights(db))
+
+	manager.HandleHeight(251)
+	heights, err = manager.GetFlushAndResetPruningHeights()
+	require.NoError(t, err)
+	require.Len(t, heights, 101)
+	require.Equal(t, []int64{101, 102}, heights[:2])
+	require.Equal(t, []int64{199, 251, 50, 100}, heights[97:])
+
+	heights, err = manager.GetFlushAndResetPruningHeights()
+	require.NoError(t, err)
+	require.Len(t, heights, 49)
+	require.Equal(t, int64(201), heights[0])
+	require.Equal(t, int64(249), heights[48])
+
+	manager.HandleHeight(252)
+	heights, err = manager.GetFlushAndResetPruningHeights()
+	require.NoError(t, err)
+	require.Equal(t, []int64{252, 150, 200, 250}, heights)
+}
@@ -0,0 +1,189 @@
+package rootmulti
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	dbm "github.com/cosmos/cosmos-db"
+	iavltree "github.com/cosmos/iavl"
+
+	"cosmossdk.io/store/iavl"
+	"cosmossdk.io/store/memiavl"
+	"cosmossdk.io/store/types"
+)
+
+// SetMemIAVL loads the IAVL stores as memiavl stores, in a sub-directory of
+// opts.Dir named after their key. The IAVL stores of a node are migrated to
+// memiavl stores, with the same hashes, the first time they are loaded. It
+// must be called before loading a version.
+func (rs *Store) SetMemIAVL(opts types.MemIAVLOptions) {
+	rs.memIAVL = &opts
+}
+
+// isCommitmentStore returns true if the type is the one of an IAVL store or of
+// a memiavl store, which produces the same hashes and proofs.
+func isCommitmentStore(typ types.StoreType) bool {
+	return typ == types.StoreTypeIAVL || typ == types.StoreTypeMemIAVL
+}
+
+// commitmentStore is the interface of the IAVL and memiavl stores used by the
+// root store.
+type commitmentStore interface {
+	types.CommitKVStore
+	VersionExists(version int64) bool
+	LoadVersionForOverwriting(targetVersion int64) (int64, error)
+	TraverseStateChanges(startVersion, endVersion int64, fn func(version int64, changeSet *iavltree.ChangeSet) error) error
+}
+
+var (
+	_ commitmentStore = (*iavl.Store)(nil)
+	_ commitmentStore = (*memiavl.Store)(nil)
+)
+
+// exporter exports the nodes of the tree of a commitment store.
+type exporter interface {
+	Next() (*iavltree.ExportNode, error)
+	Close()
+}
+
+// importer imports the nodes of a tree into a commitment store.
+type importer interface {
+	Add(node *iavltree.ExportNode) error
+	Commit() error
+	Close()
+}
+
+// getImmutable returns the commitment store store at version, for reads. As
+// iavl.Store.GetImmutable, the store returned with an error is a nil store of
+// the type of store.
+func getImmutable(store types.CommitKVStore, version int64) (types.KVStore, error) {
+	switch store := store.(type) {
+	case *iavl.Store:
+		return store.GetImmutable(version)
+
+	case *memiavl.Store:
+		return store.GetImmutable(version)
+
+	default:
+		return nil, fmt.Errorf("unexpected commitment store type %T", store)
+	}
+}
+
+// exportStore returns an exporter of the commitment store store at version.
+func exportStore(store types.CommitKVStore, version int64) (exporter, error) {
+	switch store := store.(type) {
+	case *iavl.Store:
+		e, err := store.Export(version)
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+
+	case *memiavl.Store:
+		e, err := store.Export(version)
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected commitment store type %T", store)
+	}
+}
+
+// importIntoStore returns an importer of the tree of version into the
+// commitment store store.
+func importIntoStore(store types.CommitKVStore, version int64) (importer, error) {
+	switch store := store.(type) {
+	case *iavl.Store:
+		i, err := store.Import(version)
+		if err != nil {
+			return nil, err
+		}
+		return i, nil
+
+	case *memiavl.Store:
+		i, err := store.Import(version)
+		if err != nil {
+			return nil, err
+		}
+		return i, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected commitment store type %T", store)
+	}
+}
+
+// loadMemIAVLStore loads the memiavl store of key at the version of id,
+// migrating it from the IAVL store in db if it does not exist yet.
+func (rs *Store) loadMemIAVLStore(key types.StoreKey, id types.CommitID, params storeParams, db dbm.DB) (types.CommitKVStore, error) {
+	if rs.memIAVL == nil {
+		return nil, fmt.Errorf("memiavl options of store %s are not set", key.Name())
+	}
+
+	dir := filepath.Join(rs.memIAVL.Dir, key.Name())
+	if id.Version > 0 && !memiavl.Exists(dir) {
+		rs.logger.Info("migrating the IAVL store to memiavl", "store", key.Name(), "version", id.Version, "dir", dir)
+		if err := rs.migrateToMemIAVL(key, id, db, dir); err != nil {
+			return nil, fmt.Errorf("failed to migrate the IAVL store %s to memiavl: %w", key.Name(), err)
+		}
+	}
+
+	return memiavl.LoadStore(dir, rs.logger, id, params.initialVersion, *rs.memIAVL, rs.metrics)
+}
+
+// migrateToMemIAVL imports the tree of the IAVL store of key in db at the
+// version of id into a new memiavl store in dir.
+func (rs *Store) migrateToMemIAVL(key types.StoreKey, id types.CommitID, db dbm.DB, dir string) error {
+	store, err := iavl.LoadStore(db, rs.logger, key, id, true, rs.iavlCacheSize, rs.iavlDisableFastNode, rs.metrics)
+	if err != nil {
+		return err
+	}
+	exporter, err := store.(*iavl.Store).Export(id.Version)
+	if err != nil {
+		return err
+	}
+	defer exporter.Close()
+
+	memStore, err := memiavl.LoadStore(dir, rs.logger, types.CommitID{}, 0, *rs.memIAVL, rs.metrics)
+	if err != nil {
+		return err
+	}
+	defer memStore.Close()
+
+	importer, err := memStore.Import(id.Version)
+	if err != nil {
+		return err
+	}
+	defer importer.Close()
+
+	for {
+		node, err := exporter.Next()
+		if errors.Is(err, iavltree.ErrorExportDone) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := importer.Add(node); err != nil {
+			return err
+		}
+	}
+
+	return importer.Commit()
+}
+
+// closeMemIAVLStores closes the memiavl stores loaded, before they are loaded
+// again.
+func (rs *Store) closeMemIAVLStores() error {
+	for key := range rs.stores {
+		if store, ok := rs.GetCommitKVStore(key).(*memiavl.Store); ok {
+			if err := store.Close(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
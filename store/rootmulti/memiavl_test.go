@@ -0,0 +1,117 @@
+package rootmulti
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/memiavl"
+	pruningtypes "cosmossdk.io/store/pruning/types"
+	"cosmossdk.io/store/snapshots"
+	snapshottypes "cosmossdk.io/store/snapshots/types"
+	"cosmossdk.io/store/types"
+)
+
+// newMemIAVLMultiStore returns a root store of memiavl stores in dir, whose
+// snapshots written in the background are waited for at the end of the test.
+func newMemIAVLMultiStore(t *testing.T, db dbm.DB, dir string) *Store {
+	ms := newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetMemIAVL(types.MemIAVLOptions{
+		Dir:              dir,
+		SnapshotInterval: 3,
+		KeepRecent:       2,
+	})
+	t.Cleanup(func() { require.NoError(t, ms.closeMemIAVLStores()) })
+	return ms
+}
+
+func TestMemIAVLSameHashesAsIAVL(t *testing.T) {
+	dir := t.TempDir()
+	db := dbm.NewMemDB()
+
+	reference := newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	require.NoError(t, reference.LoadLatestVersion())
+	ms := newMemIAVLMultiStore(t, db, dir)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, types.StoreTypeMemIAVL, ms.GetCommitKVStore(testStoreKey1).GetStoreType())
+
+	commitVersions(reference, 10)
+	commitVersions(ms, 10)
+	require.Equal(t, reference.LastCommitID(), ms.LastCommitID())
+
+	for _, req := range []abci.RequestQuery{
+		{Path: "/store1/key", Data: []byte("k9"), Prove: true},
+		{Path: "/store2/key", Data: []byte("k8"), Height: 8, Prove: true},
+		{Path: "/store2/key", Data: []byte("k8"), Height: 9, Prove: true},
+	} {
+		require.Equal(t, reference.Query(req), ms.Query(req))
+	}
+
+	cms, err := ms.CacheMultiStoreWithVersion(9)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v9"), cms.GetKVStore(testStoreKey1).Get([]byte("k9")))
+
+	// the memiavl stores are loaded from their files
+	ms = newMemIAVLMultiStore(t, db, dir)
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, reference.LastCommitID(), ms.LastCommitID())
+
+	require.NoError(t, ms.RollbackToVersion(9))
+	require.NoError(t, reference.RollbackToVersion(9))
+	require.Equal(t, reference.LastCommitID(), ms.LastCommitID())
+
+	commitVersions(reference, 2)
+	commitVersions(ms, 2)
+	require.Equal(t, reference.LastCommitID(), ms.LastCommitID())
+}
+
+func TestMemIAVLMigration(t *testing.T) {
+	dir := t.TempDir()
+	db := dbm.NewMemDB()
+
+	ms := newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(ms, 5)
+	require.False(t, memiavl.Exists(filepath.Join(dir, testStoreKey1.Name())))
+
+	migrated := newMemIAVLMultiStore(t, db, dir)
+	require.NoError(t, migrated.LoadLatestVersion())
+	require.True(t, memiavl.Exists(filepath.Join(dir, testStoreKey1.Name())))
+	require.Equal(t, ms.LastCommitID(), migrated.LastCommitID())
+
+	reference := newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	require.NoError(t, reference.LoadLatestVersion())
+	commitVersions(reference, 8)
+	commitVersions(migrated, 3)
+	require.Equal(t, reference.LastCommitID(), migrated.LastCommitID())
+}
+
+func TestMemIAVLSnapshotRestore(t *testing.T) {
+	source := newMemIAVLMultiStore(t, dbm.NewMemDB(), t.TempDir())
+	require.NoError(t, source.LoadLatestVersion())
+	commitVersions(source, 4)
+
+	for _, target := range []*Store{
+		newMemIAVLMultiStore(t, dbm.NewMemDB(), t.TempDir()),
+		newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewPruningOptions(pruningtypes.PruningNothing)),
+	} {
+		require.NoError(t, target.LoadLatestVersion())
+
+		chunks := make(chan io.ReadCloser, 100)
+		go func() {
+			streamWriter := snapshots.NewStreamWriter(chunks)
+			defer streamWriter.Close()
+			require.NoError(t, source.Snapshot(4, streamWriter))
+		}()
+
+		streamReader, err := snapshots.NewStreamReader(chunks)
+		require.NoError(t, err)
+		_, err = target.Restore(4, snapshottypes.CurrentFormat, streamReader)
+		require.NoError(t, err)
+		require.Equal(t, source.LastCommitID(), target.LastCommitID())
+	}
+}
@@ -131,21 +131,35 @@ func TestMultistoreSnapshot_Checksum(t *testing.T) {
 			"a4a864e6c02c9fca5837ec80dc84f650b25276ed7e4820cf7516ced9f9901b86",
 			"980925390cc50f14998ecb1e87de719ca9dd7e72f5fefbe445397bf670f36c31",
 		}},
+		{snapshottypes.FormatSegmented, []string{
+			"80eba593381fad2cd0358a5911e0e4f9ee04cd868707c5a35c3c60837aa486ee",
+			"6e116396841e93c05a0206e74e756f101688f05090f15e013e9577982ac3b3d0",
+			"6280c69afae5d3ba8ba495658295f65f38d07c739076969b14198d0482836f13",
+			"fd9b1520de69c8600dbb6cb0e9f67ef0c761c1c6c92849ee3d18707c3399389b",
+			"c3ed28257fdb3ea7afe1025ecfe2edd4d4f741a43b57e0ff3fd39d0cc2f0e91c",
+			"450f671049f390488587a6132bef6bd9f3c74e559fbeb0a5b9fb3c0f2d973ee0",
+		}},
 	}
 	for _, tc := range testcases {
 		tc := tc
 		t.Run(fmt.Sprintf("Format %v", tc.format), func(t *testing.T) {
-			ch := make(chan io.ReadCloser)
-			go func() {
-				streamWriter := snapshots.NewStreamWriter(ch)
-				defer streamWriter.Close()
-				require.NotNil(t, streamWriter)
-				err := store.Snapshot(version, streamWriter)
-				require.NoError(t, err)
-			}()
+			var chunks <-chan io.ReadCloser
+			if tc.format == snapshottypes.FormatSegmented {
+				chunks = segmentedSnapshotChunks(t, store, version)
+			} else {
+				ch := make(chan io.ReadCloser)
+				go func() {
+					streamWriter := snapshots.NewStreamWriter(ch)
+					defer streamWriter.Close()
+					require.NotNil(t, streamWriter)
+					err := store.Snapshot(version, streamWriter)
+					require.NoError(t, err)
+				}()
+				chunks = ch
+			}
 			hashes := []string{}
 			hasher := sha256.New()
-			for chunk := range ch {
+			for chunk := range chunks {
 				hasher.Reset()
 				_, err := io.Copy(hasher, chunk)
 				require.NoError(t, err)
@@ -157,6 +171,23 @@ func TestMultistoreSnapshot_Checksum(t *testing.T) {
 	}
 }
 
+// segmentedSnapshotChunks returns the chunks of the snapshot of store at
+// version in the FormatSegmented format, taken by a snapshot manager.
+func segmentedSnapshotChunks(t *testing.T, store *rootmulti.Store, version uint64) <-chan io.ReadCloser {
+	t.Helper()
+
+	snapshotStore, err := snapshots.NewStore(dbm.NewMemDB(), t.TempDir())
+	require.NoError(t, err)
+	manager := snapshots.NewManager(snapshotStore, snapshottypes.NewSnapshotOptions(1, 1), store, nil, log.NewNopLogger())
+	snapshot, err := manager.Create(version)
+	require.NoError(t, err)
+	require.Equal(t, snapshottypes.FormatSegmented, snapshot.Format)
+
+	_, chunks, err := snapshotStore.Load(snapshot.Height, snapshot.Format)
+	require.NoError(t, err)
+	return chunks
+}
+
 func TestMultistoreSnapshot_Errors(t *testing.T) {
 	store := newMultiStoreWithMixedMountsAndBasicData(dbm.NewMemDB())
 
@@ -225,6 +256,49 @@ func TestMultistoreSnapshotRestore(t *testing.T) {
 	}
 }
 
+func TestMultistoreSegmentedSnapshotRestore(t *testing.T) {
+	newManager := func(multistore *rootmulti.Store) *snapshots.Manager {
+		store, err := snapshots.NewStore(dbm.NewMemDB(), t.TempDir())
+		require.NoError(t, err)
+		return snapshots.NewManager(store, snapshottypes.NewSnapshotOptions(1, 2), multistore, nil, log.NewNopLogger())
+	}
+
+	source := newMultiStoreWithGeneratedData(dbm.NewMemDB(), 5, 1000)
+	version := uint64(source.LastCommitID().Version)
+	sourceManager := newManager(source)
+	snapshot, err := sourceManager.Create(version)
+	require.NoError(t, err)
+	require.Equal(t, snapshottypes.FormatSegmented, snapshot.Format)
+
+	// the stores are snapshotted in parallel, but the output is deterministic
+	other, err := newManager(newMultiStoreWithGeneratedData(dbm.NewMemDB(), 5, 1000)).Create(version)
+	require.NoError(t, err)
+	require.Equal(t, snapshot.Hash, other.Hash)
+
+	target := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	for _, key := range source.StoreKeysByName() {
+		target.MountStoreWithDB(types.NewKVStoreKey(key.Name()), types.StoreTypeIAVL, nil)
+	}
+	require.NoError(t, target.LoadLatestVersion())
+
+	targetManager := newManager(target)
+	require.NoError(t, targetManager.Restore(*snapshot))
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := sourceManager.LoadChunk(snapshot.Height, snapshot.Format, i)
+		require.NoError(t, err)
+		done, err := targetManager.RestoreChunk(chunk)
+		require.NoError(t, err)
+		require.Equal(t, i == snapshot.Chunks-1, done)
+	}
+
+	assert.Equal(t, source.LastCommitID(), target.LastCommitID())
+	for _, key := range source.StoreKeysByName() {
+		sourceStore := source.GetStoreByName(key.Name()).(types.CommitKVStore)
+		targetStore := target.GetStoreByName(key.Name()).(types.CommitKVStore)
+		assertStoresEqual(t, sourceStore, targetStore, "store %q not equal", key.Name())
+	}
+}
+
 func benchmarkMultistoreSnapshot(b *testing.B, stores uint8, storeKeys uint64) {
 	b.Skip("Noisy with slow setup time, please see https://github.com/cosmos/cosmos-sdk/issues/8855.")
 
@@ -0,0 +1,169 @@
+package rootmulti
+
+import (
+	"fmt"
+
+	dbm "github.com/cosmos/cosmos-db"
+	iavltree "github.com/cosmos/iavl"
+
+	"cosmossdk.io/store/listenkv"
+	"cosmossdk.io/store/memiavl"
+	pruningtypes "cosmossdk.io/store/pruning/types"
+	"cosmossdk.io/store/storage"
+	"cosmossdk.io/store/types"
+)
+
+// SetStateStorage implements CommitMultiStore. The key/values written to the
+// IAVL stores at every version are also written to db, which then serves the
+// queries and the branches at past versions which do not require a proof.
+//
+// The pruning options of the Store then apply to the state storage, while the
+// IAVL stores only retain the recent versions with the PruningEverything
+// strategy, on top of the snapshot heights. When the state storage is behind
+// the loaded version, e.g. when it is enabled on an existing node or after a
+// state sync, it is filled from the versions retained by the IAVL stores.
+func (rs *Store) SetStateStorage(db dbm.DB) {
+	rs.ss = storage.NewDatabase(db)
+	rs.ssPruning = rs.pruningManager.GetOptions()
+	rs.pruningManager.SetOptions(pruningtypes.NewPruningOptions(pruningtypes.PruningEverything))
+}
+
+// recordStateChanges wraps the store of key so that the key/values written to
+// it are written to the state storage on the next commit.
+func (rs *Store) recordStateChanges(key types.StoreKey, store types.KVStore) types.KVStore {
+	listener := rs.ssListeners[key]
+	if listener == nil {
+		return store
+	}
+
+	return listenkv.NewStore(store, key, listener)
+}
+
+// commitStateChanges writes the key/values recorded since the last commit to
+// the state storage at version, after rolling back the versions it overwrites.
+func (rs *Store) commitStateChanges(version int64) error {
+	if rs.ssRollback {
+		rs.logger.Info("rolling back state storage", "to", version-1)
+		if err := rs.ss.Rollback(version - 1); err != nil {
+			return err
+		}
+		rs.ssRollback = false
+	}
+
+	var changeset []*types.StoreKVPair
+	for _, key := range keysFromStoreKeyMap(rs.ssListeners) {
+		changeset = append(changeset, rs.ssListeners[key].PopStateCache()...)
+	}
+
+	return rs.ss.ApplyChangeset(version, changeset)
+}
+
+// pruneStateStorage prunes the state storage at version according to the
+// pruning options of the Store.
+func (rs *Store) pruneStateStorage(version int64) error {
+	opts := rs.ssPruning
+	if opts.GetPruningStrategy() == pruningtypes.PruningNothing || opts.Interval == 0 ||
+		version%int64(opts.Interval) != 0 || version <= int64(opts.KeepRecent) {
+		return nil
+	}
+
+	rs.logger.Info("prune state storage", "height", version)
+	return rs.ss.Prune(version - int64(opts.KeepRecent))
+}
+
+// syncStateStorage brings the state storage to version, the version loaded in
+// the IAVL stores. A state storage ahead of version is rolled back if version
+// is the latest version of the Store, e.g. after a rollback or a commit which
+// did not complete. Otherwise the loading does not overwrite anything, and the
+// rollback is left to the next commit, if any. A state storage behind version
+// is filled with the state changes of the versions retained by the IAVL stores.
+func (rs *Store) syncStateStorage(version int64) error {
+	rs.ssRollback = false
+	latest, err := rs.ss.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+
+	if latest > version {
+		if version < GetLatestVersion(rs.db) {
+			rs.ssRollback = true
+			return nil
+		}
+
+		rs.logger.Info("rolling back state storage", "from", latest, "to", version)
+		return rs.ss.Rollback(version)
+	}
+	if latest == version {
+		return nil
+	}
+
+	rs.logger.Info("filling state storage from the IAVL stores", "from", latest, "to", version)
+
+	var storeInfos map[string]bool
+	for _, key := range keysFromStoreKeyMap(rs.ssListeners) {
+		store := rs.GetCommitKVStore(key).(commitmentStore)
+
+		// The changes are computed from the state at the latest version of the
+		// state storage, unless the store did not exist at that version. The
+		// memiavl stores only keep their latest versions in memory, but the
+		// changes since their oldest snapshot, which they check.
+		start := latest + 1
+		_, isMemIAVL := store.(*memiavl.Store)
+		if latest > 0 && !isMemIAVL && !store.VersionExists(latest) {
+			if storeInfos == nil {
+				cInfo, err := rs.GetCommitInfo(latest)
+				if err != nil {
+					return err
+				}
+
+				storeInfos = make(map[string]bool)
+				for _, storeInfo := range cInfo.StoreInfos {
+					storeInfos[storeInfo.Name] = true
+				}
+			}
+
+			if storeInfos[key.Name()] {
+				return fmt.Errorf("cannot fill the state storage of store %s from version %d: version pruned from the IAVL store", key.Name(), latest)
+			}
+			start = 1
+		}
+
+		err := store.TraverseStateChanges(start, version+1, func(v int64, changeSet *iavltree.ChangeSet) error {
+			pairs := make([]*types.StoreKVPair, len(changeSet.Pairs))
+			for i, pair := range changeSet.Pairs {
+				pairs[i] = &types.StoreKVPair{
+					StoreKey: key.Name(),
+					Delete:   pair.Delete,
+					Key:      pair.Key,
+					Value:    pair.Value,
+				}
+			}
+
+			return rs.ss.ApplyChangeset(v, pairs)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fill the state storage of store %s: %w", key.Name(), err)
+		}
+	}
+
+	// the version is reached even if no store changed at that version
+	return rs.ss.ApplyChangeset(version, nil)
+}
+
+// stateStorageHas returns true if version can be read from the state storage.
+func (rs *Store) stateStorageHas(version int64) bool {
+	if rs.ss == nil {
+		return false
+	}
+
+	earliest, err := rs.ss.GetEarliestVersion()
+	if err != nil {
+		return false
+	}
+	latest, err := rs.ss.GetLatestVersion()
+	if err != nil {
+		return false
+	}
+
+	return earliest > 0 && earliest <= version && version <= latest
+}
@@ -0,0 +1,248 @@
+package rootmulti
+
+import (
+	"fmt"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/iavl"
+	pruningtypes "cosmossdk.io/store/pruning/types"
+)
+
+// commitVersions commits numVersions versions to ms. At each version v, the
+// key "k<v>" is set to "v<v>" in store1 and the key "k<v-1>" is deleted from
+// store2 after being set at the previous version.
+func commitVersions(ms *Store, numVersions int) {
+	for i := 0; i < numVersions; i++ {
+		version := ms.LatestVersion() + 1
+		key, value := []byte(fmt.Sprintf("k%d", version)), []byte(fmt.Sprintf("v%d", version))
+
+		cms := ms.CacheMultiStore()
+		cms.GetKVStore(testStoreKey1).Set(key, value)
+		cms.GetKVStore(testStoreKey2).Set(key, value)
+		cms.GetKVStore(testStoreKey2).Delete([]byte(fmt.Sprintf("k%d", version-1)))
+		cms.Write()
+
+		ms.Commit()
+	}
+}
+
+// requireVersion checks the state of the stores written by commitVersions at
+// version in a branch of ms.
+func requireVersion(t *testing.T, ms *Store, version int64) {
+	t.Helper()
+
+	cms, err := ms.CacheMultiStoreWithVersion(version)
+	require.NoError(t, err)
+
+	store1 := cms.GetKVStore(testStoreKey1)
+	for v := int64(1); v <= version+1; v++ {
+		value := store1.Get([]byte(fmt.Sprintf("k%d", v)))
+		if v > version {
+			require.Nil(t, value)
+		} else {
+			require.Equal(t, []byte(fmt.Sprintf("v%d", v)), value)
+		}
+	}
+
+	itr := cms.GetKVStore(testStoreKey2).Iterator(nil, nil)
+	defer itr.Close()
+	require.True(t, itr.Valid())
+	require.Equal(t, []byte(fmt.Sprintf("k%d", version)), itr.Key())
+	itr.Next()
+	require.False(t, itr.Valid())
+}
+
+func TestStateStorage(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(dbm.NewMemDB())
+	require.NoError(t, ms.LoadLatestVersion())
+	require.Equal(t, pruningtypes.PruningNothing, ms.GetPruning().GetPruningStrategy())
+
+	commitVersions(ms, 20)
+
+	// the IAVL stores only retain the recent versions
+	require.False(t, ms.GetCommitKVStore(testStoreKey1).(*iavl.Store).VersionExists(5))
+
+	for version := int64(1); version <= 20; version++ {
+		requireVersion(t, ms, version)
+	}
+
+	// queries without proof are served at any version
+	res := ms.Query(abci.RequestQuery{Path: "/store1/key", Data: []byte("k3"), Height: 5})
+	require.EqualValues(t, 0, res.Code)
+	require.Equal(t, []byte("v3"), res.Value)
+	require.EqualValues(t, 5, res.Height)
+
+	res = ms.Query(abci.RequestQuery{Path: "/store2/key", Data: []byte("k3"), Height: 5})
+	require.EqualValues(t, 0, res.Code)
+	require.Nil(t, res.Value)
+
+	res = ms.Query(abci.RequestQuery{Path: "/store1/key", Data: []byte("k20")})
+	require.EqualValues(t, 0, res.Code)
+	require.Equal(t, []byte("v20"), res.Value)
+	require.EqualValues(t, 20, res.Height)
+
+	// queries with proof are served by the IAVL stores
+	res = ms.Query(abci.RequestQuery{Path: "/store1/key", Data: []byte("k20"), Height: 20, Prove: true})
+	require.EqualValues(t, 0, res.Code)
+	require.Equal(t, []byte("v20"), res.Value)
+	require.NotNil(t, res.ProofOps)
+
+	res = ms.Query(abci.RequestQuery{Path: "/store1/key", Data: []byte("k3"), Height: 5, Prove: true})
+	require.NotEqualValues(t, 0, res.Code)
+}
+
+func TestStateStorageSync(t *testing.T) {
+	db, ssDB := dbm.NewMemDB(), dbm.NewMemDB()
+
+	// the state storage is enabled on an existing node
+	ms := newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(ms, 5)
+
+	ms = newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(ssDB)
+	require.NoError(t, ms.LoadLatestVersion())
+	for version := int64(1); version <= 5; version++ {
+		requireVersion(t, ms, version)
+	}
+
+	// it is rolled back with the IAVL stores
+	commitVersions(ms, 1)
+	require.NoError(t, ms.RollbackToVersion(5))
+	latest, err := ms.ss.GetLatestVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, latest)
+
+	commitVersions(ms, 2)
+	for version := int64(1); version <= 7; version++ {
+		requireVersion(t, ms, version)
+	}
+
+	// a state storage behind the IAVL stores is filled from its latest version
+	ms = newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(ms, 3)
+
+	ms = newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(ssDB)
+	require.NoError(t, ms.LoadLatestVersion())
+	for version := int64(1); version <= 10; version++ {
+		requireVersion(t, ms, version)
+	}
+
+	// loading a past version does not overwrite anything
+	ms = newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(ssDB)
+	require.NoError(t, ms.LoadVersion(8))
+	latest, err = ms.ss.GetLatestVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 10, latest)
+
+	ms = newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(ssDB)
+	require.NoError(t, ms.LoadLatestVersion())
+	for version := int64(1); version <= 10; version++ {
+		requireVersion(t, ms, version)
+	}
+
+	// but the versions committed over are rolled back
+	require.NoError(t, ms.LoadVersion(8))
+	commitVersions(ms, 1)
+	latest, err = ms.ss.GetLatestVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 9, latest)
+	for version := int64(1); version <= 9; version++ {
+		requireVersion(t, ms, version)
+	}
+}
+
+func TestStateStoragePruning(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewCustomPruningOptions(3, 5))
+	ms.SetStateStorage(dbm.NewMemDB())
+	require.NoError(t, ms.LoadLatestVersion())
+
+	commitVersions(ms, 12)
+
+	earliest, err := ms.ss.GetEarliestVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 7, earliest)
+
+	for version := int64(7); version <= 12; version++ {
+		requireVersion(t, ms, version)
+	}
+	_, err = ms.CacheMultiStoreWithVersion(6)
+	require.Error(t, err)
+}
+
+func TestStateStorageUpdatePruning(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(dbm.NewMemDB())
+	require.NoError(t, ms.LoadLatestVersion())
+
+	commitVersions(ms, 8)
+	require.NoError(t, ms.UpdatePruning(pruningtypes.NewCustomPruningOptions(3, 10)))
+	commitVersions(ms, 12)
+
+	require.Equal(t, pruningtypes.NewCustomPruningOptions(3, 10), ms.GetPruning())
+	earliest, err := ms.ss.GetEarliestVersion()
+	require.NoError(t, err)
+	require.EqualValues(t, 17, earliest)
+}
+
+func TestStateStorageUpgrades(t *testing.T) {
+	db, ssDB := dbm.NewMemDB(), dbm.NewMemDB()
+
+	ms := newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(ssDB)
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(ms, 2)
+
+	// store2 is renamed to restore2
+	ms, upgrades := newMultiStoreWithModifiedMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(ssDB)
+	require.NoError(t, ms.LoadLatestVersionAndUpgrade(upgrades))
+	ms.Commit()
+
+	cms, err := ms.CacheMultiStoreWithVersion(3)
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), cms.GetKVStore(ms.keysByName["restore2"]).Get([]byte("k2")))
+
+	cms, err = ms.CacheMultiStoreWithVersion(2)
+	require.NoError(t, err)
+	require.Nil(t, cms.GetKVStore(ms.keysByName["restore2"]).Get([]byte("k2")))
+
+	value, err := ms.ss.Get("store2", 3, []byte("k2"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	value, err = ms.ss.Get("store2", 2, []byte("k2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), value)
+}
+
+// closeRecordingDB is a dbm.DB recording whether it is closed.
+type closeRecordingDB struct {
+	dbm.DB
+	closed bool
+}
+
+func (db *closeRecordingDB) Close() error {
+	db.closed = true
+	return db.DB.Close()
+}
+
+func TestStateStorageClose(t *testing.T) {
+	ssDB := &closeRecordingDB{DB: dbm.NewMemDB()}
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	ms.SetStateStorage(ssDB)
+	require.NoError(t, ms.LoadLatestVersion())
+	commitVersions(ms, 2)
+
+	require.NoError(t, ms.Close())
+	require.True(t, ssDB.closed)
+}
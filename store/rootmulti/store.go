@@ -23,10 +23,12 @@ import (
 	"cosmossdk.io/store/iavl"
 	"cosmossdk.io/store/listenkv"
 	"cosmossdk.io/store/mem"
+	"cosmossdk.io/store/memiavl"
 	"cosmossdk.io/store/metrics"
 	"cosmossdk.io/store/pruning"
 	pruningtypes "cosmossdk.io/store/pruning/types"
 	snapshottypes "cosmossdk.io/store/snapshots/types"
+	"cosmossdk.io/store/storage"
 	"cosmossdk.io/store/tracekv"
 	"cosmossdk.io/store/transient"
 	"cosmossdk.io/store/types"
@@ -75,11 +77,28 @@ type Store struct {
 	listeners           map[types.StoreKey]*types.MemoryListener
 	metrics             metrics.StoreMetrics
 	commitHeader        cmtproto.Header
+	pruningUpdateMutex  sync.Mutex
+	pruningUpdate       *pruningtypes.PruningOptions
+
+	// ss is the state storage, if enabled, with the listeners recording the
+	// changes of the IAVL stores written to it and its pruning options.
+	// ssRollback is set when a version before the latest one is loaded: the
+	// versions of the state storage after it are only rolled back when the
+	// next version is committed over them.
+	ss          *storage.Database
+	ssListeners map[types.StoreKey]*types.MemoryListener
+	ssPruning   pruningtypes.PruningOptions
+	ssRollback  bool
+
+	// memIAVL holds the options of the memiavl stores the IAVL stores are
+	// loaded as, if enabled.
+	memIAVL *types.MemIAVLOptions
 }
 
 var (
-	_ types.CommitMultiStore = (*Store)(nil)
-	_ types.Queryable        = (*Store)(nil)
+	_ types.CommitMultiStore             = (*Store)(nil)
+	_ types.Queryable                    = (*Store)(nil)
+	_ snapshottypes.SegmentedSnapshotter = (*Store)(nil)
 )
 
 // NewStore returns a reference to a new Store object with the provided DB. The
@@ -96,6 +115,7 @@ func NewStore(db dbm.DB, logger log.Logger, metricGatherer metrics.StoreMetrics)
 		stores:              make(map[types.StoreKey]types.CommitKVStore),
 		keysByName:          make(map[string]types.StoreKey),
 		listeners:           make(map[types.StoreKey]*types.MemoryListener),
+		ssListeners:         make(map[types.StoreKey]*types.MemoryListener),
 		removalMap:          make(map[types.StoreKey]bool),
 		pruningManager:      pruning.NewManager(db, logger),
 		metrics:             metricGatherer,
@@ -104,16 +124,68 @@ func NewStore(db dbm.DB, logger log.Logger, metricGatherer metrics.StoreMetrics)
 
 // GetPruning fetches the pruning strategy from the root store.
 func (rs *Store) GetPruning() pruningtypes.PruningOptions {
+	if rs.ss != nil {
+		return rs.ssPruning
+	}
+
 	return rs.pruningManager.GetOptions()
 }
 
 // SetPruning sets the pruning strategy on the root store and all the sub-stores.
 // Note, calling SetPruning on the root store prior to LoadVersion or
 // LoadLatestVersion performs a no-op as the stores aren't mounted yet.
+//
+// When the state storage is enabled, the pruning strategy applies to the state
+// storage instead of the IAVL stores.
 func (rs *Store) SetPruning(pruningOpts pruningtypes.PruningOptions) {
+	if rs.ss != nil {
+		rs.ssPruning = pruningOpts
+		return
+	}
+
 	rs.pruningManager.SetOptions(pruningOpts)
 }
 
+// UpdatePruning changes the pruning strategy of a loaded root store from the
+// next commit, e.g. while the node is running. Unlike SetPruning, it is safe to
+// call concurrently with Commit. The heights retained by the current strategy
+// which the new one no longer retains are pruned over the next pruning intervals.
+func (rs *Store) UpdatePruning(pruningOpts pruningtypes.PruningOptions) error {
+	if err := pruningOpts.Validate(); err != nil {
+		return err
+	}
+
+	rs.pruningUpdateMutex.Lock()
+	defer rs.pruningUpdateMutex.Unlock()
+	rs.pruningUpdate = &pruningOpts
+
+	return nil
+}
+
+// applyPruningUpdate applies the pruning strategy set by UpdatePruning, if any,
+// before the pruning of version.
+func (rs *Store) applyPruningUpdate(version int64) error {
+	rs.pruningUpdateMutex.Lock()
+	pruningOpts := rs.pruningUpdate
+	rs.pruningUpdate = nil
+	rs.pruningUpdateMutex.Unlock()
+
+	if pruningOpts == nil {
+		return nil
+	}
+
+	rs.logger.Info("update pruning options", "height", version,
+		"strategy", pruningOpts.Strategy, "keep-recent", pruningOpts.KeepRecent, "interval", pruningOpts.Interval)
+
+	// the state storage prunes all the versions below the ones kept at once
+	if rs.ss != nil {
+		rs.ssPruning = *pruningOpts
+		return nil
+	}
+
+	return rs.pruningManager.UpdateOptions(*pruningOpts, version-1)
+}
+
 // SetMetrics sets the metrics gatherer for the store package
 func (rs *Store) SetMetrics(metrics metrics.StoreMetrics) {
 	rs.metrics = metrics
@@ -226,8 +298,14 @@ func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
 		}
 	}
 
+	// the memiavl stores loaded are closed before their files are loaded again
+	if err := rs.closeMemIAVLStores(); err != nil {
+		return err
+	}
+
 	// load each Store (note this doesn't panic on unmounted keys now)
 	newStores := make(map[types.StoreKey]types.CommitKVStore)
+	rs.ssListeners = make(map[types.StoreKey]*types.MemoryListener)
 
 	storesKeys := make([]types.StoreKey, 0, len(rs.storesParams))
 
@@ -252,7 +330,7 @@ func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
 		// If it has been added, set the initial version
 		if upgrades.IsAdded(key.Name()) || upgrades.RenamedFrom(key.Name()) != "" {
 			storeParams.initialVersion = uint64(ver) + 1
-		} else if commitID.Version != ver && storeParams.typ == types.StoreTypeIAVL {
+		} else if commitID.Version != ver && isCommitmentStore(storeParams.typ) {
 			return fmt.Errorf("version of store %s mismatch root store's version; expected %d got %d; new stores should be added using StoreUpgrades", key.Name(), ver, commitID.Version)
 		}
 
@@ -262,10 +340,13 @@ func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
 		}
 
 		newStores[key] = store
+		if rs.ss != nil && isCommitmentStore(store.GetStoreType()) {
+			rs.ssListeners[key] = types.NewMemoryListener()
+		}
 
 		// If it was deleted, remove all data
 		if upgrades.IsDeleted(key.Name()) {
-			if err := deleteKVStore(store.(types.KVStore)); err != nil {
+			if err := deleteKVStore(rs.recordStateChanges(key, store)); err != nil {
 				return errorsmod.Wrapf(err, "failed to delete store %s", key.Name())
 			}
 			rs.removalMap[key] = true
@@ -281,8 +362,12 @@ func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
 				return errorsmod.Wrapf(err, "failed to load old store %s", oldName)
 			}
 
+			if rs.ss != nil && isCommitmentStore(oldStore.GetStoreType()) {
+				rs.ssListeners[oldKey] = types.NewMemoryListener()
+			}
+
 			// move all data
-			if err := moveKVStoreData(oldStore.(types.KVStore), store.(types.KVStore)); err != nil {
+			if err := moveKVStoreData(rs.recordStateChanges(oldKey, oldStore), rs.recordStateChanges(key, store)); err != nil {
 				return errorsmod.Wrapf(err, "failed to move store %s -> %s", oldName, key.Name())
 			}
 
@@ -296,6 +381,12 @@ func (rs *Store) loadVersion(ver int64, upgrades *types.StoreUpgrades) error {
 	rs.lastCommitInfo = cInfo
 	rs.stores = newStores
 
+	if rs.ss != nil {
+		if err := rs.syncStateStorage(ver); err != nil {
+			return errorsmod.Wrap(err, "failed to sync state storage")
+		}
+	}
+
 	// load any pruned heights we missed from disk to be pruned on the next run
 	if err := rs.pruningManager.LoadPruningHeights(rs.db); err != nil {
 		return err
@@ -471,6 +562,14 @@ func (rs *Store) Commit() types.CommitID {
 		rs.logger.Debug("commit header and version mismatch", "header_height", rs.commitHeader.Height, "version", version)
 	}
 
+	// The state storage is written first, it is rolled back to the version of
+	// the IAVL stores when loaded if the commit does not complete.
+	if rs.ss != nil {
+		if err := rs.commitStateChanges(version); err != nil {
+			panic(err)
+		}
+	}
+
 	rs.lastCommitInfo = commitStores(version, rs.stores, rs.removalMap)
 	rs.lastCommitInfo.Timestamp = rs.commitHeader.Time
 	defer rs.flushMetadata(rs.db, version, rs.lastCommitInfo)
@@ -487,10 +586,20 @@ func (rs *Store) Commit() types.CommitID {
 	// reset the removalMap
 	rs.removalMap = make(map[types.StoreKey]bool)
 
+	if err := rs.applyPruningUpdate(version); err != nil {
+		panic(err)
+	}
+
 	if err := rs.handlePruning(version); err != nil {
 		panic(err)
 	}
 
+	if rs.ss != nil {
+		if err := rs.pruneStateStorage(version); err != nil {
+			panic(err)
+		}
+	}
+
 	return types.CommitID{
 		Version: version,
 		Hash:    rs.lastCommitInfo.Hash(),
@@ -506,7 +615,7 @@ func (rs *Store) WorkingHash() []byte {
 	for _, key := range storeKeys {
 		store := rs.stores[key]
 
-		if store.GetStoreType() != types.StoreTypeIAVL {
+		if !isCommitmentStore(store.GetStoreType()) {
 			continue
 		}
 
@@ -543,7 +652,7 @@ func (rs *Store) CacheWrapWithTrace(_ io.Writer, _ types.TraceContext) types.Cac
 func (rs *Store) CacheMultiStore() types.CacheMultiStore {
 	stores := make(map[types.StoreKey]types.CacheWrapper)
 	for k, v := range rs.stores {
-		store := types.KVStore(v)
+		store := rs.recordStateChanges(k, v)
 		// Wire the listenkv.Store to allow listeners to observe the writes from the cache store,
 		// set same listeners on cache store will observe duplicated writes.
 		if rs.ListeningEnabled(k) {
@@ -558,14 +667,23 @@ func (rs *Store) CacheMultiStore() types.CacheMultiStore {
 // attempts to load stores at a given version (height). An error is returned if
 // any store cannot be loaded. This should only be used for querying and
 // iterating at past heights.
+//
+// The IAVL stores are read from the state storage if it is enabled and retains
+// the version.
 func (rs *Store) CacheMultiStoreWithVersion(version int64) (types.CacheMultiStore, error) {
 	cachedStores := make(map[types.StoreKey]types.CacheWrapper)
 	var commitInfo *types.CommitInfo
 	storeInfos := map[string]bool{}
+	fromStateStorage := rs.stateStorageHas(version)
 	for key, store := range rs.stores {
 		var cacheStore types.KVStore
 		switch store.GetStoreType() {
-		case types.StoreTypeIAVL:
+		case types.StoreTypeIAVL, types.StoreTypeMemIAVL:
+			if fromStateStorage {
+				cacheStore = storage.NewStore(rs.ss, key.Name(), version)
+				break
+			}
+
 			// If the store is wrapped with an inter-block cache, we must first unwrap
 			// it to get the underlying IAVL store.
 			store = rs.GetCommitKVStore(key)
@@ -573,7 +691,7 @@ func (rs *Store) CacheMultiStoreWithVersion(version int64) (types.CacheMultiStor
 			// Attempt to lazy-load an already saved IAVL store version. If the
 			// version does not exist or is pruned, an error should be returned.
 			var err error
-			cacheStore, err = store.(*iavl.Store).GetImmutable(version)
+			cacheStore, err = getImmutable(store, version)
 			// if we got error from loading a module store
 			// we fetch commit info of this version
 			// we use commit info to check if the store existed at this version or not
@@ -640,7 +758,7 @@ func (rs *Store) GetKVStore(key types.StoreKey) types.KVStore {
 	if s == nil {
 		panic(fmt.Sprintf("store does not exist for key: %s", key.Name()))
 	}
-	store := types.KVStore(s)
+	store := rs.recordStateChanges(key, s)
 
 	if rs.TracingEnabled() {
 		store = tracekv.NewStore(store, rs.traceWriter, rs.getTracingContext())
@@ -745,6 +863,18 @@ func (rs *Store) Query(req abci.RequestQuery) abci.ResponseQuery {
 
 	// trim the path and make the query
 	req.Path = subpath
+
+	// queries without proof of the IAVL stores are served by the state storage
+	if !req.Prove && isCommitmentStore(store.GetStoreType()) {
+		height := req.Height
+		if height == 0 {
+			height = rs.LatestVersion()
+		}
+		if rs.stateStorageHas(height) {
+			return storage.NewStore(rs.ss, storeName, height).Query(req)
+		}
+	}
+
 	res := queryable.Query(req)
 
 	if !req.Prove || !RequireProof(subpath) {
@@ -783,7 +913,7 @@ func (rs *Store) SetInitialVersion(version int64) error {
 	// Loop through all the stores, if it's an IAVL store, then set initial
 	// version on it.
 	for key, store := range rs.stores {
-		if store.GetStoreType() == types.StoreTypeIAVL {
+		if isCommitmentStore(store.GetStoreType()) {
 			// If the store is wrapped with an inter-block cache, we must first unwrap
 			// it to get the underlying IAVL store.
 			store = rs.GetCommitKVStore(key)
@@ -819,29 +949,51 @@ func parsePath(path string) (storeName, subpath string, err error) {
 // given format changes (at the byte level), the snapshot format must be bumped - see
 // TestMultistoreSnapshot_Checksum test.
 func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) error {
+	stores, err := rs.snapshotStores(height)
+	if err != nil {
+		return err
+	}
+
+	// Export each IAVL store. Stores are serialized as a stream of SnapshotItem Protobuf
+	// messages. The first item contains a SnapshotStore with store metadata (i.e. name),
+	// and the following messages contain a SnapshotNode (i.e. an ExportNode). Store changes
+	// are demarcated by new SnapshotStore items.
+	for _, store := range stores {
+		if err := rs.snapshotStore(height, store.name, store.CommitKVStore, protoWriter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// namedStore is an IAVL or memiavl store to snapshot.
+type namedStore struct {
+	types.CommitKVStore
+	name string
+}
+
+// snapshotStores returns the stores to snapshot at height, sorted by name.
+func (rs *Store) snapshotStores(height uint64) ([]namedStore, error) {
 	if height == 0 {
-		return errorsmod.Wrap(types.ErrLogic, "cannot snapshot height 0")
+		return nil, errorsmod.Wrap(types.ErrLogic, "cannot snapshot height 0")
 	}
 	if height > uint64(GetLatestVersion(rs.db)) {
-		return errorsmod.Wrapf(types.ErrLogic, "cannot snapshot future height %v", height)
+		return nil, errorsmod.Wrapf(types.ErrLogic, "cannot snapshot future height %v", height)
 	}
 
 	// Collect stores to snapshot (only IAVL stores are supported)
-	type namedStore struct {
-		*iavl.Store
-		name string
-	}
 	stores := []namedStore{}
 	keys := keysFromStoreKeyMap(rs.stores)
 	for _, key := range keys {
 		switch store := rs.GetCommitKVStore(key).(type) {
-		case *iavl.Store:
-			stores = append(stores, namedStore{name: key.Name(), Store: store})
+		case *iavl.Store, *memiavl.Store:
+			stores = append(stores, namedStore{name: key.Name(), CommitKVStore: store})
 		case *transient.Store, *mem.Store:
 			// Non-persisted stores shouldn't be snapshotted
 			continue
 		default:
-			return errorsmod.Wrapf(types.ErrLogic,
+			return nil, errorsmod.Wrapf(types.ErrLogic,
 				"don't know how to snapshot store %q of type %T", key.Name(), store)
 		}
 	}
@@ -849,67 +1001,87 @@ func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) error {
 		return strings.Compare(stores[i].name, stores[j].name) == -1
 	})
 
-	// Export each IAVL store. Stores are serialized as a stream of SnapshotItem Protobuf
-	// messages. The first item contains a SnapshotStore with store metadata (i.e. name),
-	// and the following messages contain a SnapshotNode (i.e. an ExportNode). Store changes
-	// are demarcated by new SnapshotStore items.
-	for _, store := range stores {
-		rs.logger.Debug("starting snapshot", "store", store.name, "height", height)
-		exporter, err := store.Export(int64(height))
+	return stores, nil
+}
+
+// snapshotStore writes the SnapshotStore item of the store followed by its
+// nodes exported at height.
+func (rs *Store) snapshotStore(height uint64, name string, store types.CommitKVStore, protoWriter protoio.Writer) error {
+	rs.logger.Debug("starting snapshot", "store", name, "height", height)
+	exporter, err := exportStore(store, int64(height))
+	if err != nil {
+		rs.logger.Error("snapshot failed; exporter error", "store", name, "err", err)
+		return err
+	}
+	defer exporter.Close()
+
+	err = protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
+		Item: &snapshottypes.SnapshotItem_Store{
+			Store: &snapshottypes.SnapshotStoreItem{
+				Name: name,
+			},
+		},
+	})
+	if err != nil {
+		rs.logger.Error("snapshot failed; item store write failed", "store", name, "err", err)
+		return err
+	}
+
+	nodeCount := 0
+	for {
+		node, err := exporter.Next()
+		if err == iavltree.ErrorExportDone {
+			rs.logger.Debug("snapshot Done", "store", name, "nodeCount", nodeCount)
+			break
+		} else if err != nil {
+			return err
+		}
+		err = protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
+			Item: &snapshottypes.SnapshotItem_IAVL{
+				IAVL: &snapshottypes.SnapshotIAVLItem{
+					Key:     node.Key,
+					Value:   node.Value,
+					Height:  int32(node.Height),
+					Version: node.Version,
+				},
+			},
+		})
 		if err != nil {
-			rs.logger.Error("snapshot failed; exporter error", "store", store.name, "err", err)
 			return err
 		}
+		nodeCount++
+	}
 
-		err = func() error {
-			defer exporter.Close()
+	return nil
+}
 
-			err := protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
-				Item: &snapshottypes.SnapshotItem_Store{
-					Store: &snapshottypes.SnapshotStoreItem{
-						Name: store.name,
-					},
-				},
-			})
-			if err != nil {
-				rs.logger.Error("snapshot failed; item store write failed", "store", store.name, "err", err)
-				return err
-			}
+// SnapshotStoreNames implements snapshottypes.SegmentedSnapshotter.
+func (rs *Store) SnapshotStoreNames(height uint64) ([]string, error) {
+	stores, err := rs.snapshotStores(height)
+	if err != nil {
+		return nil, err
+	}
 
-			nodeCount := 0
-			for {
-				node, err := exporter.Next()
-				if err == iavltree.ErrorExportDone {
-					rs.logger.Debug("snapshot Done", "store", store.name, "nodeCount", nodeCount)
-					break
-				} else if err != nil {
-					return err
-				}
-				err = protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
-					Item: &snapshottypes.SnapshotItem_IAVL{
-						IAVL: &snapshottypes.SnapshotIAVLItem{
-							Key:     node.Key,
-							Value:   node.Value,
-							Height:  int32(node.Height),
-							Version: node.Version,
-						},
-					},
-				})
-				if err != nil {
-					return err
-				}
-				nodeCount++
-			}
+	names := make([]string, len(stores))
+	for i, store := range stores {
+		names[i] = store.name
+	}
 
-			return nil
-		}()
+	return names, nil
+}
 
-		if err != nil {
-			return err
-		}
+// SnapshotStore implements snapshottypes.SegmentedSnapshotter.
+func (rs *Store) SnapshotStore(height uint64, name string, protoWriter protoio.Writer) error {
+	if height == 0 || height > uint64(GetLatestVersion(rs.db)) {
+		return errorsmod.Wrapf(types.ErrLogic, "cannot snapshot height %v", height)
 	}
 
-	return nil
+	store, ok := rs.GetStoreByName(name).(types.CommitKVStore)
+	if !ok || !isCommitmentStore(store.GetStoreType()) {
+		return errorsmod.Wrapf(types.ErrLogic, "cannot snapshot non-IAVL store %q", name)
+	}
+
+	return rs.snapshotStore(height, name, store, protoWriter)
 }
 
 // Restore implements snapshottypes.Snapshotter.
@@ -920,7 +1092,7 @@ func (rs *Store) Restore(
 	// Import nodes into stores. The first item is expected to be a SnapshotItem containing
 	// a SnapshotStoreItem, telling us which store to import into. The following items will contain
 	// SnapshotNodeItem (i.e. ExportNode) until we reach the next SnapshotStoreItem or EOF.
-	var importer *iavltree.Importer
+	var importer importer
 	var snapshotItem snapshottypes.SnapshotItem
 loop:
 	for {
@@ -941,44 +1113,19 @@ loop:
 				}
 				importer.Close()
 			}
-			store, ok := rs.GetStoreByName(item.Store.Name).(*iavl.Store)
-			if !ok || store == nil {
-				return snapshottypes.SnapshotItem{}, errorsmod.Wrapf(types.ErrLogic, "cannot import into non-IAVL store %q", item.Store.Name)
-			}
-			importer, err = store.Import(int64(height))
+			importer, err = rs.importStore(height, item.Store.Name)
 			if err != nil {
-				return snapshottypes.SnapshotItem{}, errorsmod.Wrap(err, "import failed")
+				return snapshottypes.SnapshotItem{}, err
 			}
 			defer importer.Close()
-			// Importer height must reflect the node height (which usually matches the block height, but not always)
-			rs.logger.Debug("restoring snapshot", "store", item.Store.Name)
 
 		case *snapshottypes.SnapshotItem_IAVL:
 			if importer == nil {
 				rs.logger.Error("failed to restore; received IAVL node item before store item")
 				return snapshottypes.SnapshotItem{}, errorsmod.Wrap(types.ErrLogic, "received IAVL node item before store item")
 			}
-			if item.IAVL.Height > math.MaxInt8 {
-				return snapshottypes.SnapshotItem{}, errorsmod.Wrapf(types.ErrLogic, "node height %v cannot exceed %v",
-					item.IAVL.Height, math.MaxInt8)
-			}
-			node := &iavltree.ExportNode{
-				Key:     item.IAVL.Key,
-				Value:   item.IAVL.Value,
-				Height:  int8(item.IAVL.Height),
-				Version: item.IAVL.Version,
-			}
-			// Protobuf does not differentiate between []byte{} as nil, but fortunately IAVL does
-			// not allow nil keys nor nil values for leaf nodes, so we can always set them to empty.
-			if node.Key == nil {
-				node.Key = []byte{}
-			}
-			if node.Height == 0 && node.Value == nil {
-				node.Value = []byte{}
-			}
-			err := importer.Add(node)
-			if err != nil {
-				return snapshottypes.SnapshotItem{}, errorsmod.Wrap(err, "IAVL node import failed")
+			if err := importNode(importer, item.IAVL); err != nil {
+				return snapshottypes.SnapshotItem{}, err
 			}
 
 		default:
@@ -994,8 +1141,97 @@ loop:
 		importer.Close()
 	}
 
+	return snapshotItem, rs.CompleteRestore(height)
+}
+
+// RestoreStore implements snapshottypes.SegmentedSnapshotter.
+func (rs *Store) RestoreStore(height uint64, name string, protoReader protoio.Reader) error {
+	var snapshotItem snapshottypes.SnapshotItem
+	if err := protoReader.ReadMsg(&snapshotItem); err != nil {
+		return errorsmod.Wrap(err, "invalid protobuf message")
+	}
+	if item := snapshotItem.GetStore(); item == nil || item.Name != name {
+		return errorsmod.Wrapf(types.ErrLogic, "expected store item of store %q", name)
+	}
+
+	importer, err := rs.importStore(height, name)
+	if err != nil {
+		return err
+	}
+	defer importer.Close()
+
+	for {
+		snapshotItem = snapshottypes.SnapshotItem{}
+		err := protoReader.ReadMsg(&snapshotItem)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errorsmod.Wrap(err, "invalid protobuf message")
+		}
+
+		item := snapshotItem.GetIAVL()
+		if item == nil {
+			return errorsmod.Wrapf(types.ErrLogic, "unexpected snapshot item %T in store %q", snapshotItem.Item, name)
+		}
+		if err := importNode(importer, item); err != nil {
+			return err
+		}
+	}
+
+	if err := importer.Commit(); err != nil {
+		return errorsmod.Wrap(err, "IAVL commit failed")
+	}
+
+	return nil
+}
+
+// CompleteRestore implements snapshottypes.SegmentedSnapshotter.
+func (rs *Store) CompleteRestore(height uint64) error {
 	rs.flushMetadata(rs.db, int64(height), rs.buildCommitInfo(int64(height)))
-	return snapshotItem, rs.LoadLatestVersion()
+	return rs.LoadLatestVersion()
+}
+
+// importStore returns an importer of the IAVL store name at height.
+func (rs *Store) importStore(height uint64, name string) (importer, error) {
+	store, ok := rs.GetStoreByName(name).(types.CommitKVStore)
+	if !ok || store == nil || !isCommitmentStore(store.GetStoreType()) {
+		return nil, errorsmod.Wrapf(types.ErrLogic, "cannot import into non-IAVL store %q", name)
+	}
+	importer, err := importIntoStore(store, int64(height))
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "import failed")
+	}
+	// Importer height must reflect the node height (which usually matches the block height, but not always)
+	rs.logger.Debug("restoring snapshot", "store", name)
+
+	return importer, nil
+}
+
+// importNode imports an IAVL node of a snapshot.
+func importNode(importer importer, item *snapshottypes.SnapshotIAVLItem) error {
+	if item.Height > math.MaxInt8 {
+		return errorsmod.Wrapf(types.ErrLogic, "node height %v cannot exceed %v",
+			item.Height, math.MaxInt8)
+	}
+	node := &iavltree.ExportNode{
+		Key:     item.Key,
+		Value:   item.Value,
+		Height:  int8(item.Height),
+		Version: item.Version,
+	}
+	// Protobuf does not differentiate between []byte{} as nil, but fortunately IAVL does
+	// not allow nil keys nor nil values for leaf nodes, so we can always set them to empty.
+	if node.Key == nil {
+		node.Key = []byte{}
+	}
+	if node.Height == 0 && node.Value == nil {
+		node.Value = []byte{}
+	}
+	if err := importer.Add(node); err != nil {
+		return errorsmod.Wrap(err, "IAVL node import failed")
+	}
+
+	return nil
 }
 
 func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID, params storeParams) (types.CommitKVStore, error) {
@@ -1012,11 +1248,13 @@ func (rs *Store) loadCommitStoreFromParams(key types.StoreKey, id types.CommitID
 	case types.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
 
-	case types.StoreTypeIAVL:
+	case types.StoreTypeIAVL, types.StoreTypeMemIAVL:
 		var store types.CommitKVStore
 		var err error
 
-		if params.initialVersion == 0 {
+		if rs.memIAVL != nil || params.typ == types.StoreTypeMemIAVL {
+			store, err = rs.loadMemIAVLStore(key, id, params, db)
+		} else if params.initialVersion == 0 {
 			store, err = iavl.LoadStore(db, rs.logger, key, id, rs.lazyLoading, rs.iavlCacheSize, rs.iavlDisableFastNode, rs.metrics)
 		} else {
 			store, err = iavl.LoadStoreWithInitialVersion(db, rs.logger, key, id, rs.lazyLoading, params.initialVersion, rs.iavlCacheSize, rs.iavlDisableFastNode, rs.metrics)
@@ -1077,6 +1315,17 @@ func (rs *Store) buildCommitInfo(version int64) *types.CommitInfo {
 	}
 }
 
+// Close closes the memiavl stores and the state storage. The store must not be
+// used afterwards.
+func (rs *Store) Close() error {
+	err := rs.closeMemIAVLStores()
+	if rs.ss != nil {
+		err = errors.Join(err, rs.ss.Close())
+	}
+
+	return err
+}
+
 // RollbackToVersion delete the versions after `target` and update the latest version.
 func (rs *Store) RollbackToVersion(target int64) error {
 	if target <= 0 {
@@ -1084,15 +1333,15 @@ func (rs *Store) RollbackToVersion(target int64) error {
 	}
 
 	for key, store := range rs.stores {
-		if store.GetStoreType() == types.StoreTypeIAVL {
+		if isCommitmentStore(store.GetStoreType()) {
 			// If the store is wrapped with an inter-block cache, we must first unwrap
 			// it to get the underlying IAVL store.
 			store = rs.GetCommitKVStore(key)
 			var err error
-			if rs.lazyLoading {
-				_, err = store.(*iavl.Store).LazyLoadVersionForOverwriting(target)
+			if iavlStore, ok := store.(*iavl.Store); ok && rs.lazyLoading {
+				_, err = iavlStore.LazyLoadVersionForOverwriting(target)
 			} else {
-				_, err = store.(*iavl.Store).LoadVersionForOverwriting(target)
+				_, err = store.(commitmentStore).LoadVersionForOverwriting(target)
 			}
 			if err != nil {
 				return err
@@ -632,6 +632,35 @@ func TestMultiStore_PruningRestart(t *testing.T) {
 	}
 }
 
+func TestMultiStore_UpdatePruning(t *testing.T) {
+	ms := newMultiStoreWithMounts(dbm.NewMemDB(), pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	require.NoError(t, ms.LoadLatestVersion())
+
+	for i := int64(0); i < 10; i++ {
+		ms.Commit()
+	}
+
+	require.Error(t, ms.UpdatePruning(pruningtypes.NewCustomPruningOptions(2, 0)))
+	require.NoError(t, ms.UpdatePruning(pruningtypes.NewCustomPruningOptions(2, 10)))
+	// the update applies from the next commit
+	require.Equal(t, pruningtypes.PruningNothing, ms.GetPruning().GetPruningStrategy())
+
+	for i := int64(0); i < 10; i++ {
+		ms.Commit()
+	}
+	require.Equal(t, pruningtypes.NewCustomPruningOptions(2, 10), ms.GetPruning())
+
+	// the heights kept by the former strategy are pruned along with the new ones
+	for v := int64(1); v <= 17; v++ {
+		_, err := ms.CacheMultiStoreWithVersion(v)
+		require.Error(t, err, "expected error when loading height: %d", v)
+	}
+	for v := int64(18); v <= 20; v++ {
+		_, err := ms.CacheMultiStoreWithVersion(v)
+		require.NoError(t, err, "expected no error when loading height: %d", v)
+	}
+}
+
 // TestUnevenStoresHeightCheck tests if loading root store correctly errors when
 // there's any module store with the wrong height
 func TestUnevenStoresHeightCheck(t *testing.T) {
@@ -0,0 +1,21 @@
+package snapshots
+
+import (
+	"io"
+
+	protoio "github.com/cosmos/gogoproto/io"
+)
+
+const (
+	SegmentMultiStore = segmentMultiStore
+	SegmentExtension  = segmentExtension
+)
+
+// WriteSegment writes a segment of the given kind and name holding the items
+// written by fn to w, as Manager does.
+func WriteSegment(w io.Writer, kind byte, name string, fn func(protoio.Writer) error) error {
+	header := segmentHeader{kind: kind, codec: segmentCodec, name: name}
+	return writeSegment(w, header, func(sw *segmentWriter) error {
+		return fn(protoio.NewDelimitedWriter(sw))
+	})
+}
@@ -3,7 +3,6 @@ package snapshots_test
 import (
 	"bufio"
 	"bytes"
-	"compress/zlib"
 	"crypto/sha256"
 	"errors"
 	"io"
@@ -62,7 +61,8 @@ func readChunks(chunks <-chan io.ReadCloser) [][]byte {
 	return bodies
 }
 
-// snapshotItems serialize a array of bytes as SnapshotItem_ExtensionPayload, and return the chunks.
+// snapshotItems serialize a array of bytes as SnapshotItem_ExtensionPayload in a multistore
+// segment, followed by the segment of the extension, and return the chunks.
 func snapshotItems(items [][]byte, ext snapshottypes.ExtensionSnapshotter) [][]byte {
 	// copy the same parameters from the code
 	snapshotChunkSize := uint64(10e6)
@@ -72,24 +72,26 @@ func snapshotItems(items [][]byte, ext snapshottypes.ExtensionSnapshotter) [][]b
 	go func() {
 		chunkWriter := snapshots.NewChunkWriter(ch, snapshotChunkSize)
 		bufWriter := bufio.NewWriterSize(chunkWriter, snapshotBufferSize)
-		zWriter, _ := zlib.NewWriterLevel(bufWriter, 7)
-		protoWriter := protoio.NewDelimitedWriter(zWriter)
-		for _, item := range items {
-			_ = snapshottypes.WriteExtensionPayload(protoWriter, item)
-		}
-		// write extension metadata
-		_ = protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
-			Item: &snapshottypes.SnapshotItem_Extension{
-				Extension: &snapshottypes.SnapshotExtensionMeta{
-					Name:   ext.SnapshotName(),
-					Format: ext.SnapshotFormat(),
-				},
-			},
+		_ = snapshots.WriteSegment(bufWriter, snapshots.SegmentMultiStore, "", func(protoWriter protoio.Writer) error {
+			for _, item := range items {
+				_ = snapshottypes.WriteExtensionPayload(protoWriter, item)
+			}
+			return nil
 		})
-		_ = ext.SnapshotExtension(0, func(payload []byte) error {
-			return snapshottypes.WriteExtensionPayload(protoWriter, payload)
+		_ = snapshots.WriteSegment(bufWriter, snapshots.SegmentExtension, ext.SnapshotName(), func(protoWriter protoio.Writer) error {
+			// write extension metadata
+			_ = protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
+				Item: &snapshottypes.SnapshotItem_Extension{
+					Extension: &snapshottypes.SnapshotExtensionMeta{
+						Name:   ext.SnapshotName(),
+						Format: ext.SnapshotFormat(),
+					},
+				},
+			})
+			return ext.SnapshotExtension(0, func(payload []byte) error {
+				return snapshottypes.WriteExtensionPayload(protoWriter, payload)
+			})
 		})
-		_ = protoWriter.Close()
 		_ = bufWriter.Flush()
 		_ = chunkWriter.Close()
 	}()
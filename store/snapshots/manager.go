@@ -1,16 +1,19 @@
 package snapshots
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"runtime"
 	"sort"
 	"sync"
 
 	"cosmossdk.io/log"
+	protoio "github.com/cosmos/gogoproto/io"
 
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/store/snapshots/types"
@@ -39,6 +42,9 @@ type Manager struct {
 	multistore types.Snapshotter
 	logger     log.Logger
 
+	// workers is the number of stores snapshotted or restored concurrently.
+	workers int
+
 	mtx                sync.Mutex
 	operation          operation
 	chRestore          chan<- io.ReadCloser
@@ -64,6 +70,10 @@ const (
 
 	chunkBufferSize = 4
 
+	// segmentBufferSize is the number of blocks of a segment buffered between
+	// the goroutine snapshotting or restoring a store and the snapshot stream.
+	segmentBufferSize = 4
+
 	snapshotMaxItemSize = int(64e6) // SDK has no key/value size limit, so we set an arbitrary limit
 )
 
@@ -80,6 +90,7 @@ func NewManager(store *Store, opts types.SnapshotOptions, multistore types.Snaps
 		multistore: multistore,
 		extensions: extensions,
 		logger:     logger,
+		workers:    runtime.GOMAXPROCS(0),
 	}
 }
 
@@ -190,43 +201,152 @@ func (m *Manager) Create(height uint64) (*types.Snapshot, error) {
 // createSnapshot do the heavy work of snapshotting after the validations of request are done
 // the produced chunks are written to the channel.
 func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
-	streamWriter := NewStreamWriter(ch)
-	if streamWriter == nil {
+	chunkWriter := NewChunkWriter(ch, snapshotChunkSize)
+	bufWriter := bufio.NewWriterSize(chunkWriter, snapshotBufferSize)
+
+	err := m.writeSegments(height, bufWriter)
+	if err == nil {
+		err = bufWriter.Flush()
+	}
+	if err != nil {
+		chunkWriter.CloseWithError(err)
 		return
 	}
-	defer func() {
-		if err := streamWriter.Close(); err != nil {
-			streamWriter.CloseWithError(err)
-		}
-	}()
+	if err := chunkWriter.Close(); err != nil {
+		chunkWriter.CloseWithError(err)
+	}
+}
 
-	if err := m.multistore.Snapshot(height, streamWriter); err != nil {
-		streamWriter.CloseWithError(err)
-		return
+// writeSegments writes a snapshot in the FormatSegmented format to w. The
+// stores of a SegmentedSnapshotter are snapshotted concurrently.
+func (m *Manager) writeSegments(height uint64, w io.Writer) error {
+	if segmented, ok := m.multistore.(types.SegmentedSnapshotter); ok {
+		if err := m.writeStoreSegments(height, segmented, w); err != nil {
+			return err
+		}
+	} else {
+		header := segmentHeader{kind: segmentMultiStore, codec: segmentCodec}
+		err := writeSegment(w, header, func(sw *segmentWriter) error {
+			return m.multistore.Snapshot(height, protoio.NewDelimitedWriter(sw))
+		})
+		if err != nil {
+			return err
+		}
 	}
+
 	for _, name := range m.sortedExtensionNames() {
 		extension := m.extensions[name]
-		// write extension metadata
-		err := streamWriter.WriteMsg(&types.SnapshotItem{
-			Item: &types.SnapshotItem_Extension{
-				Extension: &types.SnapshotExtensionMeta{
-					Name:   name,
-					Format: extension.SnapshotFormat(),
+		header := segmentHeader{kind: segmentExtension, codec: segmentCodec, name: name}
+		err := writeSegment(w, header, func(sw *segmentWriter) error {
+			protoWriter := protoio.NewDelimitedWriter(sw)
+			err := protoWriter.WriteMsg(&types.SnapshotItem{
+				Item: &types.SnapshotItem_Extension{
+					Extension: &types.SnapshotExtensionMeta{
+						Name:   name,
+						Format: extension.SnapshotFormat(),
+					},
 				},
-			},
+			})
+			if err != nil {
+				return err
+			}
+
+			payloadWriter := func(payload []byte) error {
+				return types.WriteExtensionPayload(protoWriter, payload)
+			}
+			return extension.SnapshotExtension(height, payloadWriter)
 		})
 		if err != nil {
-			streamWriter.CloseWithError(err)
-			return
+			return err
 		}
-		payloadWriter := func(payload []byte) error {
-			return types.WriteExtensionPayload(streamWriter, payload)
+	}
+
+	return nil
+}
+
+// storeSegment is the output of the snapshot of a store: its compressed
+// blocks, followed by its hash or an error once blocks is closed.
+type storeSegment struct {
+	blocks chan []byte
+	sum    []byte
+	err    error
+}
+
+// writeStoreSegments writes the segments of the stores of snapshotter to w,
+// in order. The stores are snapshotted by up to m.workers goroutines, started
+// in the order of the stores so that the segment being written always
+// progresses.
+func (m *Manager) writeStoreSegments(height uint64, snapshotter types.SegmentedSnapshotter, w io.Writer) error {
+	names, err := snapshotter.SnapshotStoreNames(height)
+	if err != nil {
+		return err
+	}
+
+	segments := make([]*storeSegment, len(names))
+	for i := range segments {
+		segments[i] = &storeSegment{blocks: make(chan []byte, segmentBufferSize)}
+	}
+
+	var wg sync.WaitGroup
+	aborted := make(chan struct{})
+	defer func() {
+		close(aborted)
+		wg.Wait()
+	}()
+
+	workers := make(chan struct{}, m.workers)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, name := range names {
+			select {
+			case workers <- struct{}{}:
+			case <-aborted:
+				return
+			}
+
+			wg.Add(1)
+			go func(segment *storeSegment, name string) {
+				defer wg.Done()
+				defer func() { <-workers }()
+				defer close(segment.blocks)
+
+				sw := newSegmentWriter(segmentCodec, func(block []byte) error {
+					select {
+					case segment.blocks <- block:
+						return nil
+					case <-aborted:
+						return errorsmod.Wrap(storetypes.ErrLogic, "snapshot aborted")
+					}
+				})
+				if segment.err = snapshotter.SnapshotStore(height, name, protoio.NewDelimitedWriter(sw)); segment.err != nil {
+					return
+				}
+				segment.sum, segment.err = sw.Finish()
+			}(segments[i], name)
 		}
-		if err := extension.SnapshotExtension(height, payloadWriter); err != nil {
-			streamWriter.CloseWithError(err)
-			return
+	}()
+
+	for i, name := range names {
+		if err := writeSegmentHeader(w, segmentHeader{kind: segmentStore, codec: segmentCodec, name: name}); err != nil {
+			return err
+		}
+
+		segment := segments[i]
+		for block := range segment.blocks {
+			if err := writeFrame(w, frameBlock, block); err != nil {
+				return err
+			}
+		}
+		if segment.err != nil {
+			return errorsmod.Wrapf(segment.err, "failed to snapshot store %s", name)
+		}
+		if err := writeFrame(w, frameEnd, segment.sum); err != nil {
+			return err
 		}
 	}
+
+	return nil
 }
 
 // List lists snapshots, mirroring ABCI ListSnapshots. It can be concurrent with other operations.
@@ -274,7 +394,7 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	defer m.mtx.Unlock()
 
 	// check multistore supported format preemptive
-	if snapshot.Format != types.CurrentFormat {
+	if !types.IsSupportedFormat(snapshot.Format) {
 		return errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
 	}
 	if snapshot.Height == 0 {
@@ -312,6 +432,10 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 
 // restoreSnapshot do the heavy work of snapshot restoration after preliminary checks on request have passed.
 func (m *Manager) restoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser) error {
+	if snapshot.Format == types.FormatSegmented {
+		return m.restoreSegments(snapshot, chChunks)
+	}
+
 	var nextItem types.SnapshotItem
 
 	streamReader, err := NewStreamReader(chChunks)
@@ -366,6 +490,236 @@ func (m *Manager) restoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.Re
 	return nil
 }
 
+// restoreSegments restores a snapshot in the FormatSegmented format. The store
+// segments of a SegmentedSnapshotter are restored concurrently by up to
+// m.workers goroutines, and completed before the extensions are restored.
+func (m *Manager) restoreSegments(snapshot types.Snapshot, chChunks <-chan io.ReadCloser) error {
+	chunkReader := NewChunkReader(chChunks)
+	defer chunkReader.Close()
+	reader := bufio.NewReader(chunkReader)
+	nextFrame := func() (byte, []byte, error) {
+		return readFrame(reader)
+	}
+
+	segmented, _ := m.multistore.(types.SegmentedSnapshotter)
+	restorer := newStoreRestorer(segmented, snapshot.Height, m.workers)
+	defer restorer.wait()
+	storesCompleted := false
+	completeStores := func() error {
+		if storesCompleted {
+			return nil
+		}
+		storesCompleted = true
+
+		if err := restorer.wait(); err != nil {
+			return err
+		}
+		if segmented == nil || restorer.multistore {
+			return nil
+		}
+		return segmented.CompleteRestore(snapshot.Height)
+	}
+
+	for {
+		header, err := readSegmentHeader(reader)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		switch header.kind {
+		case segmentStore:
+			if segmented == nil {
+				return errorsmod.Wrapf(storetypes.ErrLogic, "cannot restore store segment %q", header.name)
+			}
+			if storesCompleted || restorer.multistore {
+				return errorsmod.Wrapf(storetypes.ErrLogic, "unexpected store segment %q", header.name)
+			}
+			if err := restorer.restore(header, nextFrame); err != nil {
+				return err
+			}
+
+		case segmentMultiStore:
+			if storesCompleted || restorer.started {
+				return errorsmod.Wrap(storetypes.ErrLogic, "unexpected multistore segment")
+			}
+			restorer.multistore = true
+
+			segmentReader := newSegmentReader(header, nextFrame)
+			nextItem, err := m.multistore.Restore(snapshot.Height, snapshot.Format, protoio.NewDelimitedReader(segmentReader, snapshotMaxItemSize))
+			if err != nil {
+				return errorsmod.Wrap(err, "multistore restore")
+			}
+			if nextItem.Item != nil {
+				return errorsmod.Wrapf(storetypes.ErrLogic, "unknown snapshot item %T", nextItem.Item)
+			}
+			if err := segmentReader.Close(); err != nil {
+				return err
+			}
+
+		case segmentExtension:
+			if err := completeStores(); err != nil {
+				return err
+			}
+
+			segmentReader := newSegmentReader(header, nextFrame)
+			if err := m.restoreExtension(snapshot.Height, header.name, segmentReader); err != nil {
+				return err
+			}
+			if err := segmentReader.Close(); err != nil {
+				return err
+			}
+
+		default:
+			return errorsmod.Wrapf(types.ErrInvalidMetadata, "unknown snapshot segment kind %d", header.kind)
+		}
+	}
+
+	return completeStores()
+}
+
+// restoreExtension restores the extension name from the items of its segment.
+func (m *Manager) restoreExtension(height uint64, name string, segmentReader io.Reader) error {
+	protoReader := protoio.NewDelimitedReader(segmentReader, snapshotMaxItemSize)
+
+	var item types.SnapshotItem
+	if err := protoReader.ReadMsg(&item); err != nil {
+		return errorsmod.Wrap(err, "invalid protobuf message")
+	}
+	metadata := item.GetExtension()
+	if metadata == nil || metadata.Name != name {
+		return errorsmod.Wrapf(storetypes.ErrLogic, "invalid metadata of extension segment %q", name)
+	}
+	extension, ok := m.extensions[metadata.Name]
+	if !ok {
+		return errorsmod.Wrapf(storetypes.ErrLogic, "unknown extension snapshotter %s", metadata.Name)
+	}
+	if !IsFormatSupported(extension, metadata.Format) {
+		return errorsmod.Wrapf(types.ErrUnknownFormat, "format %v for extension %s", metadata.Format, metadata.Name)
+	}
+
+	// payloadReader returns io.EOF at the end of the segment
+	payloadReader := func() ([]byte, error) {
+		item.Reset()
+		if err := protoReader.ReadMsg(&item); err != nil {
+			return nil, err
+		}
+		payload := item.GetExtensionPayload()
+		if payload == nil {
+			return nil, errorsmod.Wrapf(storetypes.ErrLogic, "unknown snapshot item %T", item.Item)
+		}
+		return payload.Payload, nil
+	}
+
+	if err := extension.RestoreExtension(height, metadata.Format, payloadReader); err != nil {
+		return errorsmod.Wrapf(err, "extension %s restore", metadata.Name)
+	}
+
+	return nil
+}
+
+// storeRestorer restores the store segments of a SegmentedSnapshotter, each on
+// its own goroutine. The frames of a segment are read by the caller of restore
+// and passed to the goroutine restoring it.
+type storeRestorer struct {
+	snapshotter types.SegmentedSnapshotter
+	height      uint64
+
+	// started is true once a store segment is restored, and multistore once a
+	// multistore segment is restored instead.
+	started    bool
+	multistore bool
+
+	workers chan struct{}
+	wg      sync.WaitGroup
+	mtx     sync.Mutex
+	err     error
+}
+
+func newStoreRestorer(snapshotter types.SegmentedSnapshotter, height uint64, workers int) *storeRestorer {
+	return &storeRestorer{
+		snapshotter: snapshotter,
+		height:      height,
+		workers:     make(chan struct{}, workers),
+	}
+}
+
+type segmentFrame struct {
+	frame   byte
+	payload []byte
+}
+
+// restore reads the frames of the segment with the given header using
+// nextFrame, and restores it on a new goroutine once a worker is available.
+func (r *storeRestorer) restore(header segmentHeader, nextFrame func() (byte, []byte, error)) error {
+	if err := r.error(); err != nil {
+		return err
+	}
+	r.started = true
+
+	frames := make(chan segmentFrame, segmentBufferSize)
+	r.workers <- struct{}{}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() { <-r.workers }()
+
+		segmentReader := newSegmentReader(header, func() (byte, []byte, error) {
+			f, ok := <-frames
+			if !ok {
+				return 0, nil, io.ErrUnexpectedEOF
+			}
+			return f.frame, f.payload, nil
+		})
+
+		err := r.snapshotter.RestoreStore(r.height, header.name, protoio.NewDelimitedReader(segmentReader, snapshotMaxItemSize))
+		if err == nil {
+			err = segmentReader.Close()
+		}
+		if err != nil {
+			r.setError(errorsmod.Wrapf(err, "failed to restore store %s", header.name))
+		}
+
+		// drain the frames so that the reader is never blocked
+		for range frames { //nolint:revive // drain the channel
+		}
+	}()
+
+	defer close(frames)
+	for {
+		frame, payload, err := nextFrame()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		frames <- segmentFrame{frame: frame, payload: payload}
+		if frame == frameEnd {
+			return nil
+		}
+	}
+}
+
+// wait waits for the restoration of the segments, and returns the first error.
+func (r *storeRestorer) wait() error {
+	r.wg.Wait()
+	return r.error()
+}
+
+func (r *storeRestorer) error() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.err
+}
+
+func (r *storeRestorer) setError(err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.err == nil {
+		r.err = err
+	}
+}
+
 // RestoreChunk adds a chunk to an active snapshot restoration, mirroring ABCI ApplySnapshotChunk.
 // Chunks must be given until the restore is complete, returning true, or a chunk errors.
 func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
@@ -418,6 +772,33 @@ func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
 	return false, nil
 }
 
+// RestoreLocalSnapshot restores the state from a snapshot of the local snapshot store,
+// synchronously. It is used to restore a node offline, without state sync.
+func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
+	snapshot, chChunks, err := m.store.Load(height, format)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return errorsmod.Wrapf(storetypes.ErrInvalidRequest, "snapshot at height %v format %v does not exist", height, format)
+	}
+	defer DrainChunks(chChunks)
+
+	if !types.IsSupportedFormat(snapshot.Format) {
+		return errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if err := m.beginLocked(opRestore); err != nil {
+		return err
+	}
+	defer m.endLocked()
+
+	return m.restoreSnapshot(*snapshot, chChunks)
+}
+
 // sortedExtensionNames sort extension names for deterministic iteration.
 func (m *Manager) sortedExtensionNames() []string {
 	names := make([]string, 0, len(m.extensions))
@@ -95,7 +95,7 @@ func TestManager_Take(t *testing.T) {
 		Height: 5,
 		Format: snapshotter.SnapshotFormat(),
 		Chunks: 1,
-		Hash:   []uint8{0xc5, 0xf7, 0xfe, 0xea, 0xd3, 0x4d, 0x3e, 0x87, 0xff, 0x41, 0xa2, 0x27, 0xfa, 0xcb, 0x38, 0x17, 0xa, 0x5, 0xeb, 0x27, 0x4e, 0x16, 0x5e, 0xf3, 0xb2, 0x8b, 0x47, 0xd1, 0xe6, 0x94, 0x7e, 0x8b},
+		Hash:   []uint8{0x89, 0xaf, 0xcf, 0xec, 0x52, 0xf2, 0x88, 0x63, 0x70, 0xbc, 0x17, 0xba, 0x31, 0x8, 0x81, 0x5d, 0xf3, 0x57, 0x4f, 0xe3, 0x4e, 0x6a, 0x15, 0x3c, 0x7b, 0xc5, 0xbb, 0x57, 0x6a, 0xa4, 0x92, 0x30},
 		Metadata: types.Metadata{
 			ChunkHashes: checksums(expectChunks),
 		},
@@ -236,3 +236,60 @@ func TestManager_Restore(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestManager_RestoreSegmentHashMismatch(t *testing.T) {
+	target := &mockSnapshotter{
+		prunedHeights: make(map[int64]struct{}),
+	}
+	manager := snapshots.NewManager(setupStore(t), opts, target, nil, log.NewNopLogger())
+	err := manager.RegisterExtensions(newExtSnapshotter(0))
+	require.NoError(t, err)
+
+	// corrupt the hash which ends the extension segment, the chunk checksums
+	// are computed after the corruption
+	chunks := snapshotItems([][]byte{{1, 2, 3}}, newExtSnapshotter(10))
+	require.Len(t, chunks, 1)
+	chunks[0][len(chunks[0])-1] ^= 0xff
+
+	err = manager.Restore(types.Snapshot{
+		Height:   3,
+		Format:   types.CurrentFormat,
+		Hash:     []byte{1, 2, 3},
+		Chunks:   1,
+		Metadata: types.Metadata{ChunkHashes: checksums(chunks)},
+	})
+	require.NoError(t, err)
+
+	_, err = manager.RestoreChunk(chunks[0])
+	require.ErrorIs(t, err, types.ErrChunkHashMismatch)
+}
+
+func TestManager_RestoreLocalSnapshot(t *testing.T) {
+	store := setupStore(t)
+	expectItems := [][]byte{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	}
+	snapshot, err := store.Save(5, types.CurrentFormat, makeChunks(snapshotItems(expectItems, newExtSnapshotter(10))))
+	require.NoError(t, err)
+
+	target := &mockSnapshotter{
+		prunedHeights: make(map[int64]struct{}),
+	}
+	extSnapshotter := newExtSnapshotter(0)
+	manager := snapshots.NewManager(store, opts, target, nil, log.NewNopLogger())
+	require.NoError(t, manager.RegisterExtensions(extSnapshotter))
+
+	// unknown snapshots and formats are rejected
+	require.Error(t, manager.RestoreLocalSnapshot(4, types.CurrentFormat))
+	require.ErrorIs(t, manager.RestoreLocalSnapshot(1, 1), types.ErrUnknownFormat)
+
+	require.NoError(t, manager.RestoreLocalSnapshot(snapshot.Height, snapshot.Format))
+	assert.Equal(t, expectItems, target.items)
+	assert.Equal(t, 10, len(extSnapshotter.state))
+
+	// the restore is complete, other operations can proceed
+	_, err = manager.Prune(1)
+	require.NoError(t, err)
+}
@@ -0,0 +1,341 @@
+package snapshots
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"cosmossdk.io/errors"
+	snapshottypes "cosmossdk.io/store/snapshots/types"
+)
+
+// A snapshot in the FormatSegmented format is a sequence of segments, each
+// holding the delimited Protobuf items of a store, of the whole multistore or
+// of an extension. A segment is a sequence of frames:
+//
+//	header: frameHeader | uvarint(len) | kind | codec | name
+//	blocks: frameBlock  | uvarint(len) | compressed block
+//	end:    frameEnd    | uvarint(len) | sha256(items)
+//
+// The items of a segment are split into blocks of segmentBlockSize bytes,
+// compressed independently, and the segment ends with the hash of its items
+// so that it can be verified on its own.
+const (
+	frameHeader byte = 1
+	frameBlock  byte = 2
+	frameEnd    byte = 3
+
+	// segmentMultiStore holds the items of the whole multistore, when it is not
+	// a SegmentedSnapshotter.
+	segmentMultiStore byte = 1
+	// segmentStore holds the items of a store of a SegmentedSnapshotter.
+	segmentStore byte = 2
+	// segmentExtension holds the items of an extension.
+	segmentExtension byte = 3
+
+	codecZlib byte = 1
+	codecZstd byte = 2
+
+	// Do not change the block size, the codec nor its level without new snapshot
+	// format (must be uniform across nodes)
+	segmentBlockSize = 4 << 20
+	segmentCodec     = codecZstd
+
+	// segmentMaxFrameSize bounds the size of the frames read from a snapshot.
+	segmentMaxFrameSize = 2 * segmentBlockSize
+)
+
+// The zstd encoder and decoder can be used concurrently by EncodeAll and
+// DecodeAll.
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(segmentMaxFrameSize))
+)
+
+// segmentHeader identifies a segment.
+type segmentHeader struct {
+	kind  byte
+	codec byte
+	name  string
+}
+
+// writeFrame writes a frame of the given type and payload to w.
+func writeFrame(w io.Writer, frame byte, payload []byte) error {
+	bz := make([]byte, 0, 1+binary.MaxVarintLen64)
+	bz = append(bz, frame)
+	bz = binary.AppendUvarint(bz, uint64(len(payload)))
+	if _, err := w.Write(bz); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// readFrame reads a frame from r. It returns io.EOF if r is exhausted before
+// the frame.
+func readFrame(r *bufio.Reader) (frame byte, payload []byte, err error) {
+	frame, err = r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, unexpectedEOF(err)
+	}
+	if size > segmentMaxFrameSize {
+		return 0, nil, errors.Wrapf(snapshottypes.ErrInvalidMetadata, "snapshot frame of %d bytes exceeds %d", size, segmentMaxFrameSize)
+	}
+
+	payload = make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, unexpectedEOF(err)
+	}
+
+	return frame, payload, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// writeSegmentHeader writes the header frame of a segment to w.
+func writeSegmentHeader(w io.Writer, header segmentHeader) error {
+	payload := append([]byte{header.kind, header.codec}, header.name...)
+	return writeFrame(w, frameHeader, payload)
+}
+
+// readSegmentHeader reads the header frame of the next segment from r. It
+// returns io.EOF at the end of the snapshot.
+func readSegmentHeader(r *bufio.Reader) (segmentHeader, error) {
+	frame, payload, err := readFrame(r)
+	if err != nil {
+		return segmentHeader{}, err
+	}
+	if frame != frameHeader || len(payload) < 2 {
+		return segmentHeader{}, errors.Wrapf(snapshottypes.ErrInvalidMetadata, "expected a segment header, got frame %d", frame)
+	}
+
+	return segmentHeader{kind: payload[0], codec: payload[1], name: string(payload[2:])}, nil
+}
+
+// compressBlock compresses a block of a segment with codec.
+func compressBlock(codec byte, block []byte) ([]byte, error) {
+	switch codec {
+	case codecZstd:
+		return zstdEncoder.EncodeAll(block, nil), nil
+
+	case codecZlib:
+		var buf bytes.Buffer
+		zWriter, err := zlib.NewWriterLevel(&buf, snapshotCompressionLevel)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := zWriter.Write(block); err != nil {
+			return nil, err
+		}
+		if err := zWriter.Close(); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+
+	default:
+		return nil, errors.Wrapf(snapshottypes.ErrUnknownFormat, "snapshot compression codec %d", codec)
+	}
+}
+
+// decompressBlock decompresses a block of a segment compressed with codec.
+func decompressBlock(codec byte, compressed []byte) ([]byte, error) {
+	var (
+		block []byte
+		err   error
+	)
+	switch codec {
+	case codecZstd:
+		block, err = zstdDecoder.DecodeAll(compressed, nil)
+
+	case codecZlib:
+		var zReader io.ReadCloser
+		zReader, err = zlib.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, errors.Wrap(err, "zlib failure")
+		}
+		defer zReader.Close()
+
+		block, err = io.ReadAll(io.LimitReader(zReader, segmentBlockSize+1))
+
+	default:
+		return nil, errors.Wrapf(snapshottypes.ErrUnknownFormat, "snapshot compression codec %d", codec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(block) > segmentBlockSize {
+		return nil, errors.Wrapf(snapshottypes.ErrInvalidMetadata, "snapshot block exceeds %d bytes", segmentBlockSize)
+	}
+
+	return block, nil
+}
+
+// segmentWriter is an io.Writer splitting the items of a segment into blocks,
+// which are compressed and passed to emit.
+type segmentWriter struct {
+	codec byte
+	emit  func(block []byte) error
+	buf   []byte
+	hash  hash.Hash
+}
+
+func newSegmentWriter(codec byte, emit func(block []byte) error) *segmentWriter {
+	return &segmentWriter{
+		codec: codec,
+		emit:  emit,
+		hash:  sha256.New(),
+	}
+}
+
+// Write implements io.Writer.
+func (w *segmentWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		size := segmentBlockSize - len(w.buf)
+		if size > len(p) {
+			size = len(p)
+		}
+		w.buf = append(w.buf, p[:size]...)
+		p = p[size:]
+
+		if len(w.buf) == segmentBlockSize {
+			if err := w.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return n, nil
+}
+
+func (w *segmentWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	w.hash.Write(w.buf)
+	block, err := compressBlock(w.codec, w.buf)
+	if err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+
+	return w.emit(block)
+}
+
+// Finish emits the last block of the segment and returns its hash.
+func (w *segmentWriter) Finish() ([]byte, error) {
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+
+	return w.hash.Sum(nil), nil
+}
+
+// writeSegment writes a segment holding the items written by fn to w.
+func writeSegment(w io.Writer, header segmentHeader, fn func(*segmentWriter) error) error {
+	if err := writeSegmentHeader(w, header); err != nil {
+		return err
+	}
+
+	sw := newSegmentWriter(header.codec, func(block []byte) error {
+		return writeFrame(w, frameBlock, block)
+	})
+	if err := fn(sw); err != nil {
+		return err
+	}
+
+	sum, err := sw.Finish()
+	if err != nil {
+		return err
+	}
+
+	return writeFrame(w, frameEnd, sum)
+}
+
+// segmentReader is an io.Reader of the items of a segment, decompressing the
+// blocks of the frames returned by next. It returns io.EOF once the end frame
+// of the segment is read, or an error if the hash of the items does not match
+// the one of the end frame.
+type segmentReader struct {
+	header segmentHeader
+	next   func() (frame byte, payload []byte, err error)
+	buf    []byte
+	hash   hash.Hash
+	done   bool
+}
+
+func newSegmentReader(header segmentHeader, next func() (byte, []byte, error)) *segmentReader {
+	return &segmentReader{
+		header: header,
+		next:   next,
+		hash:   sha256.New(),
+	}
+}
+
+// Read implements io.Reader.
+func (r *segmentReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		frame, payload, err := r.next()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+
+		switch frame {
+		case frameBlock:
+			r.buf, err = decompressBlock(r.header.codec, payload)
+			if err != nil {
+				return 0, err
+			}
+			r.hash.Write(r.buf)
+
+		case frameEnd:
+			if sum := r.hash.Sum(nil); !bytes.Equal(sum, payload) {
+				return 0, errors.Wrapf(snapshottypes.ErrChunkHashMismatch, "segment %q: expected hash %x, got %x", r.header.name, payload, sum)
+			}
+			r.done = true
+
+		default:
+			return 0, errors.Wrapf(snapshottypes.ErrInvalidMetadata, "unexpected frame %d in segment %q", frame, r.header.name)
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close verifies that the items of the segment were all read.
+func (r *segmentReader) Close() error {
+	n, err := io.Copy(io.Discard, r)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("segment %q was not fully restored, %d bytes left", r.header.name, n)
+	}
+
+	return nil
+}
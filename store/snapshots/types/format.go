@@ -1,6 +1,23 @@
 package types
 
+const (
+	// FormatStream is the format of the snapshots serializing the multistore
+	// and the extensions into a single zlib compressed Protobuf stream.
+	FormatStream uint32 = 3
+
+	// FormatSegmented is the format of the snapshots serializing each store of
+	// the multistore and each extension into its own segment, compressed by
+	// blocks and terminated by its hash, so that the segments can be created
+	// and restored concurrently.
+	FormatSegmented uint32 = 4
+)
+
 // CurrentFormat is the currently used format for snapshots. Snapshots using the same format
 // must be identical across all nodes for a given height, so this must be bumped when the binary
 // snapshot output changes.
-const CurrentFormat uint32 = 3
+const CurrentFormat uint32 = FormatSegmented
+
+// IsSupportedFormat returns true if snapshots of the given format can be restored.
+func IsSupportedFormat(format uint32) bool {
+	return format == FormatStream || format == FormatSegmented
+}
@@ -26,6 +26,29 @@ type Snapshotter interface {
 	Restore(height uint64, format uint32, protoReader protoio.Reader) (SnapshotItem, error)
 }
 
+// SegmentedSnapshotter is a Snapshotter whose stores can be snapshotted and
+// restored independently and concurrently, each as a segment of a snapshot in
+// the FormatSegmented format.
+type SegmentedSnapshotter interface {
+	Snapshotter
+
+	// SnapshotStoreNames returns the names of the stores to snapshot at height,
+	// in the order of the snapshot.
+	SnapshotStoreNames(height uint64) ([]string, error)
+
+	// SnapshotStore writes the snapshot items of the store name at height into
+	// the protobuf writer. It may be called concurrently for different stores.
+	SnapshotStore(height uint64, name string, protoWriter protoio.Writer) error
+
+	// RestoreStore restores the store name at height from the snapshot items of
+	// the protobuf reader. It may be called concurrently for different stores.
+	RestoreStore(height uint64, name string, protoReader protoio.Reader) error
+
+	// CompleteRestore completes the restoration of the stores at height, once
+	// all of them are restored.
+	CompleteRestore(height uint64) error
+}
+
 // ExtensionPayloadReader read extension payloads,
 // it returns io.EOF when reached either end of stream or the extension boundaries.
 type ExtensionPayloadReader = func() ([]byte, error)
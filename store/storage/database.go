@@ -0,0 +1,428 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"cosmossdk.io/store/types"
+)
+
+const (
+	// metadata keys
+	latestVersionKey   = "m/latest"
+	earliestVersionKey = "m/earliest"
+
+	// dataPrefix is the prefix of the entries storing the values of the keys
+	dataPrefix = 'd'
+
+	// changelogPrefix is the prefix of the entries indexing the keys written
+	// at each version
+	changelogPrefix = 'c'
+
+	// value flags
+	valueFlagSet    byte = 0
+	valueFlagDelete byte = 1
+)
+
+var (
+	// ErrVersionPruned is returned when reading a version older than the
+	// earliest version retained by the Database.
+	ErrVersionPruned = errors.New("version pruned from the state storage")
+
+	// ErrVersionNotFound is returned when reading a version more recent than
+	// the latest version of the Database.
+	ErrVersionNotFound = errors.New("version not found in the state storage")
+)
+
+// Database is a versioned state storage. It stores the raw key/values written
+// to the stores of a multi store at every version as flat entries of the
+// underlying database, so that the state of any retained version can be read
+// without walking a tree.
+//
+// Every value written to a key at a version is stored under the key
+//
+//	'd' | uvarint(len(storeKey)) | storeKey | escape(key) | 0x00 0x00 | bigEndian(version)
+//
+// where escape replaces each 0x00 byte with 0x00 0xFF, so that the entries of
+// a store are ordered by key, then by version. Deletions are stored as
+// tombstones.
+//
+// The keys written at every version are also indexed in a changelog, under the
+// key
+//
+//	'c' | bigEndian(version) | 'd' | uvarint(len(storeKey)) | storeKey | escape(key)
+//
+// so that pruning and rolling back only visit the entries of the versions
+// involved.
+type Database struct {
+	db dbm.DB
+}
+
+// NewDatabase returns a Database storing its entries in db.
+func NewDatabase(db dbm.DB) *Database {
+	return &Database{db: db}
+}
+
+// GetLatestVersion returns the latest version written to the Database, or 0
+// if it is empty.
+func (d *Database) GetLatestVersion() (int64, error) {
+	return d.getVersion(latestVersionKey)
+}
+
+// GetEarliestVersion returns the earliest version which can be read from the
+// Database, or 0 if it is empty.
+func (d *Database) GetEarliestVersion() (int64, error) {
+	return d.getVersion(earliestVersionKey)
+}
+
+func (d *Database) getVersion(key string) (int64, error) {
+	bz, err := d.db.Get([]byte(key))
+	if err != nil || bz == nil {
+		return 0, err
+	}
+
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+func setVersion(batch dbm.Batch, key string, version int64) error {
+	return batch.Set([]byte(key), binary.BigEndian.AppendUint64(nil, uint64(version)))
+}
+
+// ApplyChangeset writes the given key/value pairs, in order, at version.
+func (d *Database) ApplyChangeset(version int64, pairs []*types.StoreKVPair) error {
+	latest, err := d.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	earliest, err := d.GetEarliestVersion()
+	if err != nil {
+		return err
+	}
+
+	batch := d.db.NewBatch()
+	defer batch.Close()
+
+	for _, pair := range pairs {
+		value := []byte{valueFlagDelete}
+		if !pair.Delete {
+			value = append([]byte{valueFlagSet}, pair.Value...)
+		}
+
+		if err := batch.Set(encodeEntryKey(pair.StoreKey, pair.Key, version), value); err != nil {
+			return err
+		}
+		if err := batch.Set(encodeChangelogKey(pair.StoreKey, pair.Key, version), []byte{}); err != nil {
+			return err
+		}
+	}
+
+	if version > latest {
+		if err := setVersion(batch, latestVersionKey, version); err != nil {
+			return err
+		}
+	}
+	if earliest == 0 || version < earliest {
+		if err := setVersion(batch, earliestVersionKey, version); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteSync()
+}
+
+// checkVersion returns an error if version cannot be read.
+func (d *Database) checkVersion(version int64) error {
+	earliest, err := d.GetEarliestVersion()
+	if err != nil {
+		return err
+	}
+	if version < earliest {
+		return fmt.Errorf("%w: version %d, earliest version %d", ErrVersionPruned, version, earliest)
+	}
+
+	latest, err := d.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	if version > latest {
+		return fmt.Errorf("%w: version %d, latest version %d", ErrVersionNotFound, version, latest)
+	}
+
+	return nil
+}
+
+// Get returns the value of key in the store named storeKey at version, or
+// nil if the key is not set.
+func (d *Database) Get(storeKey string, version int64, key []byte) ([]byte, error) {
+	if err := d.checkVersion(version); err != nil {
+		return nil, err
+	}
+
+	itr, err := d.db.ReverseIterator(encodeEntryKey(storeKey, key, 0), encodeEntryKey(storeKey, key, version+1))
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	if !itr.Valid() {
+		return nil, itr.Error()
+	}
+
+	value := itr.Value()
+	if value[0] == valueFlagDelete {
+		return nil, nil
+	}
+
+	return bytes.Clone(value[1:]), nil
+}
+
+// Has returns true if key is set in the store named storeKey at version.
+func (d *Database) Has(storeKey string, version int64, key []byte) (bool, error) {
+	value, err := d.Get(storeKey, version, key)
+	return value != nil, err
+}
+
+// Iterator returns an iterator over the domain [start, end) of the store
+// named storeKey at version, in ascending order of the keys.
+func (d *Database) Iterator(storeKey string, version int64, start, end []byte) (types.Iterator, error) {
+	return d.newIterator(storeKey, version, start, end, false)
+}
+
+// ReverseIterator returns an iterator over the domain [start, end) of the
+// store named storeKey at version, in descending order of the keys.
+func (d *Database) ReverseIterator(storeKey string, version int64, start, end []byte) (types.Iterator, error) {
+	return d.newIterator(storeKey, version, start, end, true)
+}
+
+func (d *Database) newIterator(storeKey string, version int64, start, end []byte, reverse bool) (types.Iterator, error) {
+	if (start != nil && len(start) == 0) || (end != nil && len(end) == 0) {
+		return nil, errors.New("key cannot be empty")
+	}
+	if err := d.checkVersion(version); err != nil {
+		return nil, err
+	}
+
+	prefix := storePrefix(storeKey)
+	rawStart := append(bytes.Clone(prefix), escape(start)...)
+	rawEnd := types.PrefixEndBytes(prefix)
+	if end != nil {
+		rawEnd = append(bytes.Clone(prefix), escape(end)...)
+	}
+
+	var (
+		source dbm.Iterator
+		err    error
+	)
+	if reverse {
+		source, err = d.db.ReverseIterator(rawStart, rawEnd)
+	} else {
+		source, err = d.db.Iterator(rawStart, rawEnd)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newIterator(source, len(prefix), version, start, end, reverse), nil
+}
+
+// Prune removes the entries which are only needed to read the versions older
+// than version, which becomes the earliest version of the Database. Only the
+// keys written from the earliest version to version are visited.
+func (d *Database) Prune(version int64) error {
+	earliest, err := d.GetEarliestVersion()
+	if err != nil {
+		return err
+	}
+	latest, err := d.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	if version <= earliest {
+		return nil
+	}
+	if version > latest {
+		return fmt.Errorf("%w: cannot prune up to version %d, latest version %d", ErrVersionNotFound, version, latest)
+	}
+
+	itr, err := d.db.Iterator(changelogVersionPrefix(earliest), changelogVersionPrefix(version+1))
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	batch := d.db.NewBatch()
+	defer batch.Close()
+
+	// An entry written at a version up to version supersedes the older entries
+	// of its key, which are removed. A tombstone older than version is removed
+	// as well, since the key is then unset from version on. The changelog is
+	// kept from version, as its tombstones are removed by the next pruning.
+	for ; itr.Valid(); itr.Next() {
+		entryPrefix, entryVersion := splitChangelogKey(itr.Key())
+		if err := d.deleteEntries(batch, entryPrefix, 0, entryVersion); err != nil {
+			return err
+		}
+
+		if entryVersion < version {
+			rawKey := entryKey(entryPrefix, entryVersion)
+			value, err := d.db.Get(rawKey)
+			if err != nil {
+				return err
+			}
+			if value != nil && value[0] == valueFlagDelete {
+				if err := batch.Delete(rawKey); err != nil {
+					return err
+				}
+			}
+			if err := batch.Delete(bytes.Clone(itr.Key())); err != nil {
+				return err
+			}
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+
+	if err := setVersion(batch, earliestVersionKey, version); err != nil {
+		return err
+	}
+
+	return batch.WriteSync()
+}
+
+// deleteEntries deletes in batch the entries of the escaped key, store prefix
+// included, from version start to version end, exclusive.
+func (d *Database) deleteEntries(batch dbm.Batch, entryPrefix []byte, start, end int64) error {
+	itr, err := d.db.Iterator(entryKey(entryPrefix, start), entryKey(entryPrefix, end))
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		if err := batch.Delete(bytes.Clone(itr.Key())); err != nil {
+			return err
+		}
+	}
+
+	return itr.Error()
+}
+
+// Rollback removes the entries written after version, which becomes the
+// latest version of the Database. Only the keys written after version are
+// visited.
+func (d *Database) Rollback(version int64) error {
+	itr, err := d.db.Iterator(changelogVersionPrefix(version+1), []byte{changelogPrefix + 1})
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+
+	batch := d.db.NewBatch()
+	defer batch.Close()
+
+	for ; itr.Valid(); itr.Next() {
+		entryPrefix, entryVersion := splitChangelogKey(itr.Key())
+		if err := batch.Delete(entryKey(entryPrefix, entryVersion)); err != nil {
+			return err
+		}
+		if err := batch.Delete(bytes.Clone(itr.Key())); err != nil {
+			return err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+
+	if err := setVersion(batch, latestVersionKey, version); err != nil {
+		return err
+	}
+
+	return batch.WriteSync()
+}
+
+// Close closes the underlying database.
+func (d *Database) Close() error {
+	return d.db.Close()
+}
+
+// storePrefix returns the prefix of the entries of the store named storeKey.
+func storePrefix(storeKey string) []byte {
+	prefix := make([]byte, 0, 1+binary.MaxVarintLen64+len(storeKey))
+	prefix = append(prefix, dataPrefix)
+	prefix = binary.AppendUvarint(prefix, uint64(len(storeKey)))
+	return append(prefix, storeKey...)
+}
+
+// encodeEntryKey returns the key of the entry storing the value of key in the
+// store named storeKey at version.
+func encodeEntryKey(storeKey string, key []byte, version int64) []byte {
+	return entryKey(append(storePrefix(storeKey), escape(key)...), version)
+}
+
+// entryKey returns the key of the entry of the escaped key, store prefix
+// included, at version.
+func entryKey(entryPrefix []byte, version int64) []byte {
+	bz := make([]byte, 0, len(entryPrefix)+10)
+	bz = append(bz, entryPrefix...)
+	bz = append(bz, 0, 0)
+	return binary.BigEndian.AppendUint64(bz, uint64(version))
+}
+
+// changelogVersionPrefix returns the prefix of the changelog entries of
+// version.
+func changelogVersionPrefix(version int64) []byte {
+	return binary.BigEndian.AppendUint64([]byte{changelogPrefix}, uint64(version))
+}
+
+// encodeChangelogKey returns the key of the changelog entry of key in the
+// store named storeKey at version.
+func encodeChangelogKey(storeKey string, key []byte, version int64) []byte {
+	bz := changelogVersionPrefix(version)
+	bz = append(bz, storePrefix(storeKey)...)
+	return append(bz, escape(key)...)
+}
+
+// splitChangelogKey returns the escaped key, store prefix included, and the
+// version of a changelog entry.
+func splitChangelogKey(rawKey []byte) (entryPrefix []byte, version int64) {
+	return rawKey[9:], int64(binary.BigEndian.Uint64(rawKey[1:9]))
+}
+
+// splitEntryKey returns the escaped key, store prefix included, and the
+// version of an entry.
+func splitEntryKey(rawKey []byte) (key []byte, version int64) {
+	n := len(rawKey) - 8
+	return rawKey[:n-2], int64(binary.BigEndian.Uint64(rawKey[n:]))
+}
+
+// escape escapes the 0x00 bytes of key so that escaped keys terminated by
+// 0x00 0x00 have the same order as the keys.
+func escape(key []byte) []byte {
+	escaped := make([]byte, 0, len(key))
+	for _, b := range key {
+		escaped = append(escaped, b)
+		if b == 0 {
+			escaped = append(escaped, 0xFF)
+		}
+	}
+
+	return escaped
+}
+
+// unescape reverses escape.
+func unescape(escaped []byte) []byte {
+	key := make([]byte, 0, len(escaped))
+	for i := 0; i < len(escaped); i++ {
+		key = append(key, escaped[i])
+		if escaped[i] == 0 {
+			i++
+		}
+	}
+
+	return key
+}
@@ -0,0 +1,250 @@
+package storage_test
+
+import (
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/storage"
+	"cosmossdk.io/store/types"
+)
+
+func set(storeKey, key, value string) *types.StoreKVPair {
+	return &types.StoreKVPair{StoreKey: storeKey, Key: []byte(key), Value: []byte(value)}
+}
+
+func del(storeKey, key string) *types.StoreKVPair {
+	return &types.StoreKVPair{StoreKey: storeKey, Key: []byte(key), Delete: true}
+}
+
+// newTestDatabase returns a Database with the versions:
+//
+//	1: a=1 b=1 c=1, other store a=x
+//	2: b=2, delete c
+//	3: c=3, delete a
+func newTestDatabase(t *testing.T) *storage.Database {
+	t.Helper()
+
+	db := storage.NewDatabase(dbm.NewMemDB())
+	require.NoError(t, db.ApplyChangeset(1, []*types.StoreKVPair{
+		set("store", "a", "1"), set("store", "b", "1"), set("store", "c", "1"), set("other", "a", "x"),
+	}))
+	require.NoError(t, db.ApplyChangeset(2, []*types.StoreKVPair{set("store", "b", "2"), del("store", "c")}))
+	require.NoError(t, db.ApplyChangeset(3, []*types.StoreKVPair{set("store", "c", "3"), del("store", "a")}))
+
+	return db
+}
+
+func collect(t *testing.T, itr types.Iterator) []string {
+	t.Helper()
+	defer itr.Close()
+
+	var kvs []string
+	for ; itr.Valid(); itr.Next() {
+		kvs = append(kvs, string(itr.Key())+"="+string(itr.Value()))
+	}
+	require.NoError(t, itr.Error())
+
+	return kvs
+}
+
+func TestDatabaseGet(t *testing.T) {
+	db := newTestDatabase(t)
+
+	latest, err := db.GetLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), latest)
+
+	testCases := []struct {
+		version int64
+		key     string
+		value   []byte
+	}{
+		{1, "a", []byte("1")},
+		{2, "a", []byte("1")},
+		{3, "a", nil},
+		{1, "b", []byte("1")},
+		{3, "b", []byte("2")},
+		{2, "c", nil},
+		{3, "c", []byte("3")},
+		{3, "d", nil},
+	}
+	for _, tc := range testCases {
+		value, err := db.Get("store", tc.version, []byte(tc.key))
+		require.NoError(t, err)
+		require.Equal(t, tc.value, value, "key %s at version %d", tc.key, tc.version)
+	}
+
+	value, err := db.Get("other", 3, []byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("x"), value)
+
+	_, err = db.Get("store", 4, []byte("a"))
+	require.ErrorIs(t, err, storage.ErrVersionNotFound)
+}
+
+func TestDatabaseIterator(t *testing.T) {
+	db := newTestDatabase(t)
+
+	itr, err := db.Iterator("store", 1, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a=1", "b=1", "c=1"}, collect(t, itr))
+
+	itr, err = db.Iterator("store", 2, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a=1", "b=2"}, collect(t, itr))
+
+	itr, err = db.ReverseIterator("store", 3, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c=3", "b=2"}, collect(t, itr))
+
+	itr, err = db.Iterator("store", 1, []byte("b"), []byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"b=1"}, collect(t, itr))
+
+	itr, err = db.ReverseIterator("store", 1, []byte("a"), []byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"b=1", "a=1"}, collect(t, itr))
+}
+
+func TestDatabaseKeyOrder(t *testing.T) {
+	db := storage.NewDatabase(dbm.NewMemDB())
+
+	// keys which are prefixes of each other or contain 0x00 and 0xFF bytes
+	keys := []string{"a", "a\x00", "a\x00\x00", "a\x00\x01", "a\x01", "a\xff", "a\xff\x00", "b"}
+	var pairs []*types.StoreKVPair
+	for i := len(keys) - 1; i >= 0; i-- {
+		pairs = append(pairs, set("store", keys[i], "v"))
+	}
+	require.NoError(t, db.ApplyChangeset(1, pairs))
+	require.NoError(t, db.ApplyChangeset(2, []*types.StoreKVPair{set("store", "a\x00", "w")}))
+
+	for _, version := range []int64{1, 2} {
+		itr, err := db.Iterator("store", version, nil, nil)
+		require.NoError(t, err)
+
+		var got []string
+		for ; itr.Valid(); itr.Next() {
+			got = append(got, string(itr.Key()))
+		}
+		require.NoError(t, itr.Close())
+		require.Equal(t, keys, got)
+	}
+
+	itr, err := db.Iterator("store", 2, []byte("a\x00"), []byte("a\x01"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a\x00=w", "a\x00\x00=v", "a\x00\x01=v"}, collect(t, itr))
+}
+
+func TestDatabasePrune(t *testing.T) {
+	db := newTestDatabase(t)
+	require.NoError(t, db.ApplyChangeset(4, []*types.StoreKVPair{set("store", "d", "4")}))
+
+	require.NoError(t, db.Prune(3))
+
+	earliest, err := db.GetEarliestVersion()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), earliest)
+
+	_, err = db.Get("store", 2, []byte("b"))
+	require.ErrorIs(t, err, storage.ErrVersionPruned)
+
+	for _, version := range []int64{3, 4} {
+		itr, err := db.Iterator("store", version, nil, nil)
+		require.NoError(t, err)
+		expected := []string{"b=2", "c=3"}
+		if version == 4 {
+			expected = append(expected, "d=4")
+		}
+		require.Equal(t, expected, collect(t, itr))
+	}
+
+	value, err := db.Get("other", 3, []byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("x"), value)
+
+	require.ErrorIs(t, db.Prune(5), storage.ErrVersionNotFound)
+}
+
+// countEntries returns the number of entries of db with the given prefix.
+func countEntries(t *testing.T, db dbm.DB, prefix byte) int {
+	t.Helper()
+
+	itr, err := db.Iterator([]byte{prefix}, []byte{prefix + 1})
+	require.NoError(t, err)
+	defer itr.Close()
+
+	n := 0
+	for ; itr.Valid(); itr.Next() {
+		n++
+	}
+	require.NoError(t, itr.Error())
+
+	return n
+}
+
+func TestDatabasePruneEntries(t *testing.T) {
+	memDB := dbm.NewMemDB()
+	db := storage.NewDatabase(memDB)
+	require.NoError(t, db.ApplyChangeset(1, []*types.StoreKVPair{set("store", "a", "1"), set("store", "b", "1")}))
+	require.NoError(t, db.ApplyChangeset(2, []*types.StoreKVPair{set("store", "a", "2"), del("store", "b")}))
+	require.NoError(t, db.ApplyChangeset(3, []*types.StoreKVPair{set("store", "a", "3")}))
+	require.NoError(t, db.ApplyChangeset(4, []*types.StoreKVPair{set("store", "c", "4")}))
+	require.Equal(t, 6, countEntries(t, memDB, 'd'))
+
+	// a=1 and b=1 are superseded, and the tombstone of b is kept to read the
+	// version 2
+	require.NoError(t, db.Prune(2))
+	require.Equal(t, 4, countEntries(t, memDB, 'd'))
+
+	// a=2 is superseded by a=3, and b is removed
+	require.NoError(t, db.Prune(4))
+	require.Equal(t, 2, countEntries(t, memDB, 'd'))
+	require.Equal(t, 1, countEntries(t, memDB, 'c'))
+
+	itr, err := db.Iterator("store", 4, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a=3", "c=4"}, collect(t, itr))
+}
+
+func TestDatabaseRollback(t *testing.T) {
+	db := newTestDatabase(t)
+
+	require.NoError(t, db.Rollback(2))
+
+	latest, err := db.GetLatestVersion()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), latest)
+
+	_, err = db.Get("store", 3, []byte("a"))
+	require.ErrorIs(t, err, storage.ErrVersionNotFound)
+
+	// the version can be written again
+	require.NoError(t, db.ApplyChangeset(3, []*types.StoreKVPair{set("store", "d", "3")}))
+	itr, err := db.Iterator("store", 3, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a=1", "b=2", "d=3"}, collect(t, itr))
+}
+
+func TestStore(t *testing.T) {
+	db := newTestDatabase(t)
+
+	store := storage.NewStore(db, "store", 2)
+	require.Equal(t, []byte("1"), store.Get([]byte("a")))
+	require.False(t, store.Has([]byte("c")))
+	require.Panics(t, func() { store.Set([]byte("a"), []byte("2")) })
+	require.Panics(t, func() { store.Delete([]byte("a")) })
+
+	// writes to a branch are not written to the database
+	cache := store.CacheWrap().(types.KVStore)
+	cache.Set([]byte("c"), []byte("2"))
+	var keys []string
+	for itr := cache.Iterator(nil, nil); itr.Valid(); itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+	require.Nil(t, store.Get([]byte("c")))
+
+	require.Panics(t, func() { storage.NewStore(db, "store", 4).Get([]byte("a")) })
+}
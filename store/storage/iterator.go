@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"bytes"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"cosmossdk.io/store/types"
+)
+
+var _ types.Iterator = (*iterator)(nil)
+
+// iterator iterates over the keys of a store at a version, skipping the
+// entries of the other versions and the deleted keys.
+type iterator struct {
+	source     dbm.Iterator
+	prefixLen  int
+	version    int64
+	start, end []byte
+	reverse    bool
+
+	key, value []byte
+	valid      bool
+}
+
+func newIterator(source dbm.Iterator, prefixLen int, version int64, start, end []byte, reverse bool) *iterator {
+	itr := &iterator{
+		source:    source,
+		prefixLen: prefixLen,
+		version:   version,
+		start:     start,
+		end:       end,
+		reverse:   reverse,
+	}
+	itr.Next()
+
+	return itr
+}
+
+// Domain implements types.Iterator.
+func (itr *iterator) Domain() (start, end []byte) {
+	return itr.start, itr.end
+}
+
+// Valid implements types.Iterator.
+func (itr *iterator) Valid() bool {
+	return itr.valid
+}
+
+// Key implements types.Iterator.
+func (itr *iterator) Key() []byte {
+	itr.assertIsValid()
+	return itr.key
+}
+
+// Value implements types.Iterator.
+func (itr *iterator) Value() []byte {
+	itr.assertIsValid()
+	return itr.value
+}
+
+// Next implements types.Iterator. It moves the iterator to the next key set
+// at the iterator's version.
+func (itr *iterator) Next() {
+	itr.valid = false
+	for itr.source.Valid() {
+		escapedKey, _ := splitEntryKey(itr.source.Key())
+		escapedKey = bytes.Clone(escapedKey)
+
+		// find the entry of the key at the iterator's version, i.e. the last
+		// entry not newer than the version
+		var value []byte
+		for ; itr.source.Valid(); itr.source.Next() {
+			entryKey, entryVersion := splitEntryKey(itr.source.Key())
+			if !bytes.Equal(entryKey, escapedKey) {
+				break
+			}
+
+			if entryVersion <= itr.version && (value == nil || !itr.reverse) {
+				value = bytes.Clone(itr.source.Value())
+			}
+		}
+
+		if value != nil && value[0] == valueFlagSet {
+			itr.key = unescape(escapedKey[itr.prefixLen:])
+			itr.value = value[1:]
+			itr.valid = true
+			return
+		}
+	}
+}
+
+// Error implements types.Iterator.
+func (itr *iterator) Error() error {
+	return itr.source.Error()
+}
+
+// Close implements types.Iterator.
+func (itr *iterator) Close() error {
+	return itr.source.Close()
+}
+
+func (itr *iterator) assertIsValid() {
+	if !itr.valid {
+		panic("iterator is invalid")
+	}
+}
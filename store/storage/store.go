@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/internal/kv"
+	"cosmossdk.io/store/tracekv"
+	"cosmossdk.io/store/types"
+)
+
+var (
+	_ types.KVStore   = (*Store)(nil)
+	_ types.Queryable = (*Store)(nil)
+)
+
+// Store is a read-only KVStore serving the state of a store of the multi
+// store at a version from a Database.
+type Store struct {
+	db       *Database
+	storeKey string
+	version  int64
+}
+
+// NewStore returns a Store reading the store named storeKey at version.
+func NewStore(db *Database, storeKey string, version int64) *Store {
+	return &Store{
+		db:       db,
+		storeKey: storeKey,
+		version:  version,
+	}
+}
+
+// GetStoreType implements Store.
+func (s *Store) GetStoreType() types.StoreType {
+	return types.StoreTypeDB
+}
+
+// Get implements types.KVStore, it panics on error.
+func (s *Store) Get(key []byte) []byte {
+	types.AssertValidKey(key)
+
+	value, err := s.db.Get(s.storeKey, s.version, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+// Has implements types.KVStore, it panics on error.
+func (s *Store) Has(key []byte) bool {
+	return s.Get(key) != nil
+}
+
+// Set implements types.KVStore, it always panics.
+func (s *Store) Set(_, _ []byte) {
+	panic("cannot call 'Set' on a historical state storage store")
+}
+
+// Delete implements types.KVStore, it always panics.
+func (s *Store) Delete(_ []byte) {
+	panic("cannot call 'Delete' on a historical state storage store")
+}
+
+// Iterator implements types.KVStore, it panics on error.
+func (s *Store) Iterator(start, end []byte) types.Iterator {
+	itr, err := s.db.Iterator(s.storeKey, s.version, start, end)
+	if err != nil {
+		panic(err)
+	}
+
+	return itr
+}
+
+// ReverseIterator implements types.KVStore, it panics on error.
+func (s *Store) ReverseIterator(start, end []byte) types.Iterator {
+	itr, err := s.db.ReverseIterator(s.storeKey, s.version, start, end)
+	if err != nil {
+		panic(err)
+	}
+
+	return itr
+}
+
+// CacheWrap implements types.KVStore.
+func (s *Store) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements types.KVStore.
+func (s *Store) CacheWrapWithTrace(w io.Writer, tc types.TraceContext) types.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
+
+// Query implements types.Queryable for the "/key" and "/subspace" paths of
+// the IAVL stores, at the version of the store. Proofs are not supported.
+func (s *Store) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
+	if len(req.Data) == 0 {
+		return types.QueryResult(errorsmod.Wrap(types.ErrTxDecode, "query cannot be zero length"), false)
+	}
+	if req.Prove {
+		return types.QueryResult(errorsmod.Wrap(types.ErrInvalidRequest, "state storage queries cannot be proven"), false)
+	}
+	if err := s.db.checkVersion(s.version); err != nil {
+		return types.QueryResult(errorsmod.Wrap(types.ErrInvalidRequest, err.Error()), false)
+	}
+
+	res.Height = s.version
+
+	switch req.Path {
+	case "/key":
+		res.Key = req.Data
+		res.Value = s.Get(req.Data)
+
+	case "/subspace":
+		pairs := kv.Pairs{
+			Pairs: make([]kv.Pair, 0),
+		}
+
+		res.Key = req.Data
+
+		iterator := types.KVStorePrefixIterator(s, req.Data)
+		for ; iterator.Valid(); iterator.Next() {
+			pairs.Pairs = append(pairs.Pairs, kv.Pair{Key: iterator.Key(), Value: iterator.Value()})
+		}
+		iterator.Close()
+
+		bz, err := pairs.Marshal()
+		if err != nil {
+			panic(fmt.Errorf("failed to marshal KV pairs: %w", err))
+		}
+
+		res.Value = bz
+
+	default:
+		return types.QueryResult(errorsmod.Wrapf(types.ErrUnknownRequest, "unexpected query path: %v", req.Path), false)
+	}
+
+	return res
+}
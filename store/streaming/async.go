@@ -0,0 +1,169 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/types"
+)
+
+const (
+	// asyncMaxAttempts is the number of times a message is delivered to the
+	// listener of an AsyncListener before it is given up.
+	asyncMaxAttempts = 5
+	// asyncRetryDelay is the delay before the first retry of a message, which
+	// doubles at every attempt.
+	asyncRetryDelay = 100 * time.Millisecond
+)
+
+var _ types.ABCIListener = (*AsyncListener)(nil)
+
+// AsyncListener is an ABCIListener delivering the messages of each block to
+// another ABCIListener in a background goroutine, so that a slow listener does
+// not stall the block execution and the Commit.
+//
+// The messages of a block are queued on Commit in a buffer of bufferSize
+// blocks, and delivered in order. Commit only waits for the listener when the
+// buffer is full. A message failing to be delivered is retried with a backoff;
+// if it still fails the rest of the block is given up and the error is
+// returned by the next ListenCommit.
+type AsyncListener struct {
+	listener types.ABCIListener
+	logger   log.Logger
+
+	current *asyncBlock
+	blocks  chan *asyncBlock
+	done    chan struct{}
+
+	mtx sync.Mutex
+	err error
+}
+
+// asyncBlock holds the deliveries of the messages of a block to the listener.
+type asyncBlock struct {
+	height     int64
+	deliveries []func() error
+}
+
+// NewAsyncListener returns an AsyncListener delivering the messages to
+// listener, buffering up to bufferSize blocks.
+func NewAsyncListener(listener types.ABCIListener, bufferSize int, logger log.Logger) *AsyncListener {
+	a := &AsyncListener{
+		listener: listener,
+		logger:   logger,
+		blocks:   make(chan *asyncBlock, bufferSize),
+		done:     make(chan struct{}),
+	}
+	go a.run()
+
+	return a
+}
+
+// ListenBeginBlock implements ABCIListener.
+func (a *AsyncListener) ListenBeginBlock(ctx context.Context, req abci.RequestBeginBlock, res abci.ResponseBeginBlock) error {
+	a.current = &asyncBlock{height: req.Header.Height}
+	a.add(func() error { return a.listener.ListenBeginBlock(ctx, req, res) })
+	return nil
+}
+
+// ListenDeliverTx implements ABCIListener.
+func (a *AsyncListener) ListenDeliverTx(ctx context.Context, req abci.RequestDeliverTx, res abci.ResponseDeliverTx) error {
+	a.add(func() error { return a.listener.ListenDeliverTx(ctx, req, res) })
+	return nil
+}
+
+// ListenEndBlock implements ABCIListener.
+func (a *AsyncListener) ListenEndBlock(ctx context.Context, req abci.RequestEndBlock, res abci.ResponseEndBlock) error {
+	a.add(func() error { return a.listener.ListenEndBlock(ctx, req, res) })
+	return nil
+}
+
+// ListenCommit implements ABCIListener. It queues the messages of the block,
+// and returns the error of a previous block given up since the last call.
+func (a *AsyncListener) ListenCommit(ctx context.Context, res abci.ResponseCommit, changeSet []*types.StoreKVPair) error {
+	a.add(func() error { return a.listener.ListenCommit(ctx, res, changeSet) })
+
+	block := a.current
+	a.current = nil
+	select {
+	case a.blocks <- block:
+	default:
+		a.logger.Info("streaming buffer is full, waiting for the listener", "height", block.height)
+		a.blocks <- block
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	err := a.err
+	a.err = nil
+
+	return err
+}
+
+// Close delivers the buffered blocks to the listener, stops the AsyncListener
+// and closes the listener if it is an io.Closer.
+func (a *AsyncListener) Close() error {
+	close(a.blocks)
+	<-a.done
+
+	a.mtx.Lock()
+	err := a.err
+	a.err = nil
+	a.mtx.Unlock()
+
+	if closer, ok := a.listener.(io.Closer); ok {
+		err = errors.Join(err, closer.Close())
+	}
+
+	return err
+}
+
+func (a *AsyncListener) add(delivery func() error) {
+	if a.current == nil {
+		a.current = &asyncBlock{}
+	}
+	a.current.deliveries = append(a.current.deliveries, delivery)
+}
+
+func (a *AsyncListener) run() {
+	defer close(a.done)
+
+	for block := range a.blocks {
+		if err := a.deliver(block); err != nil {
+			a.logger.Error("failed to stream block", "height", block.height, "err", err)
+
+			a.mtx.Lock()
+			a.err = err
+			a.mtx.Unlock()
+		}
+	}
+}
+
+// deliver delivers the messages of block to the listener, in order.
+func (a *AsyncListener) deliver(block *asyncBlock) error {
+	for _, delivery := range block.deliveries {
+		var err error
+		delay := asyncRetryDelay
+		for attempt := 1; ; attempt++ {
+			if err = delivery(); err == nil {
+				break
+			}
+			if attempt == asyncMaxAttempts {
+				return fmt.Errorf("streaming of block %d given up after %d attempts: %w", block.height, attempt, err)
+			}
+
+			a.logger.Error("failed to stream block, retrying", "height", block.height, "attempt", attempt, "err", err)
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+
+	return nil
+}
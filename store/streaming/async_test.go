@@ -0,0 +1,113 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"cosmossdk.io/log"
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// recordingListener records the messages it receives, failing the messages
+// of the heights in failures the given number of times.
+type recordingListener struct {
+	mtx      sync.Mutex
+	height   int64
+	messages []string
+	failures map[int64]int
+	wait     chan struct{}
+	closed   bool
+}
+
+func (l *recordingListener) record(msg string) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.failures[l.height] > 0 {
+		l.failures[l.height]--
+		return errors.New("listener failure")
+	}
+	l.messages = append(l.messages, fmt.Sprintf("%d:%s", l.height, msg))
+	return nil
+}
+
+func (l *recordingListener) ListenBeginBlock(_ context.Context, req abci.RequestBeginBlock, _ abci.ResponseBeginBlock) error {
+	if l.wait != nil {
+		<-l.wait
+	}
+	l.mtx.Lock()
+	l.height = req.Header.Height
+	l.mtx.Unlock()
+	return l.record("begin")
+}
+
+func (l *recordingListener) ListenEndBlock(context.Context, abci.RequestEndBlock, abci.ResponseEndBlock) error {
+	return l.record("end")
+}
+
+func (l *recordingListener) ListenDeliverTx(_ context.Context, req abci.RequestDeliverTx, _ abci.ResponseDeliverTx) error {
+	return l.record(string(req.Tx))
+}
+
+func (l *recordingListener) ListenCommit(context.Context, abci.ResponseCommit, []*storetypes.StoreKVPair) error {
+	return l.record("commit")
+}
+
+func (l *recordingListener) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.closed = true
+	return nil
+}
+
+func streamBlock(t *testing.T, listener storetypes.ABCIListener, height int64) error {
+	t.Helper()
+
+	ctx := context.Background()
+	require.NoError(t, listener.ListenBeginBlock(ctx, abci.RequestBeginBlock{Header: tmproto.Header{Height: height}}, abci.ResponseBeginBlock{}))
+	require.NoError(t, listener.ListenDeliverTx(ctx, abci.RequestDeliverTx{Tx: []byte("tx")}, abci.ResponseDeliverTx{}))
+	require.NoError(t, listener.ListenEndBlock(ctx, abci.RequestEndBlock{}, abci.ResponseEndBlock{}))
+	return listener.ListenCommit(ctx, abci.ResponseCommit{}, nil)
+}
+
+func TestAsyncListener(t *testing.T) {
+	// the listener is blocked until the buffer is filled, the commits do not
+	// wait for it
+	listener := &recordingListener{wait: make(chan struct{}), failures: map[int64]int{4: 2}}
+	async := NewAsyncListener(listener, 3, log.NewNopLogger())
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, streamBlock(t, async, height))
+	}
+	close(listener.wait)
+
+	// the failures of block 4 are retried
+	require.NoError(t, streamBlock(t, async, 4))
+	require.NoError(t, async.Close())
+
+	var expected []string
+	for height := 1; height <= 4; height++ {
+		expected = append(expected,
+			fmt.Sprintf("%d:begin", height), fmt.Sprintf("%d:tx", height),
+			fmt.Sprintf("%d:end", height), fmt.Sprintf("%d:commit", height))
+	}
+	require.Equal(t, expected, listener.messages)
+	require.True(t, listener.closed)
+}
+
+func TestAsyncListenerGiveUp(t *testing.T) {
+	listener := &recordingListener{failures: map[int64]int{1: asyncMaxAttempts}}
+	async := NewAsyncListener(listener, 1, log.NewNopLogger())
+
+	require.NoError(t, streamBlock(t, async, 1))
+	require.Error(t, async.Close())
+
+	// the rest of the block is given up
+	require.Empty(t, listener.messages)
+}
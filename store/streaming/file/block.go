@@ -0,0 +1,185 @@
+package file
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	streamingabci "cosmossdk.io/store/streaming/abci"
+)
+
+// A file is a sequence of records, one per block:
+//
+//	uvarint(len(body)) | body | crc32c(body)
+//
+// where the body is the sequence and the height of the block followed by its
+// messages, each one made of a kind byte, the uvarint length of the message
+// and the Protobuf message.
+const (
+	kindBeginBlock byte = 1
+	kindDeliverTx  byte = 2
+	kindEndBlock   byte = 3
+	kindCommit     byte = 4
+
+	// maxRecordSize bounds the size of the records read from a file.
+	maxRecordSize = 1 << 30
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// errCorruptRecord is returned when a record does not match its checksum.
+var errCorruptRecord = errors.New("corrupt record")
+
+// Block holds the ABCI messages and the state changes of a block.
+type Block struct {
+	// Sequence is the number of the block in the stream, incremented by one
+	// at every block written.
+	Sequence uint64
+	// Height is the height of the block.
+	Height int64
+
+	BeginBlock *streamingabci.ListenBeginBlockRequest
+	DeliverTxs []*streamingabci.ListenDeliverTxRequest
+	EndBlock   *streamingabci.ListenEndBlockRequest
+	Commit     *streamingabci.ListenCommitRequest
+}
+
+type message interface {
+	Size() int
+	MarshalTo([]byte) (int, error)
+	Unmarshal([]byte) error
+}
+
+// marshalRecord returns the record of block.
+func marshalRecord(block *Block) ([]byte, error) {
+	body := binary.AppendUvarint(nil, block.Sequence)
+	body = binary.AppendVarint(body, block.Height)
+
+	appendMessage := func(kind byte, msg message) error {
+		size := msg.Size()
+		body = append(body, kind)
+		body = binary.AppendUvarint(body, uint64(size))
+
+		offset := len(body)
+		body = append(body, make([]byte, size)...)
+		_, err := msg.MarshalTo(body[offset:])
+		return err
+	}
+
+	if block.BeginBlock != nil {
+		if err := appendMessage(kindBeginBlock, block.BeginBlock); err != nil {
+			return nil, err
+		}
+	}
+	for _, deliverTx := range block.DeliverTxs {
+		if err := appendMessage(kindDeliverTx, deliverTx); err != nil {
+			return nil, err
+		}
+	}
+	if block.EndBlock != nil {
+		if err := appendMessage(kindEndBlock, block.EndBlock); err != nil {
+			return nil, err
+		}
+	}
+	if block.Commit != nil {
+		if err := appendMessage(kindCommit, block.Commit); err != nil {
+			return nil, err
+		}
+	}
+
+	record := binary.AppendUvarint(make([]byte, 0, len(body)+binary.MaxVarintLen64+4), uint64(len(body)))
+	record = append(record, body...)
+	return binary.BigEndian.AppendUint32(record, crc32.Checksum(body, crcTable)), nil
+}
+
+// readRecord reads the next record from r, returning its block and its size.
+// It returns io.EOF if r is exhausted before the record and
+// io.ErrUnexpectedEOF if the record is incomplete.
+func readRecord(r *bufio.Reader) (*Block, int64, error) {
+	size, err := binary.ReadUvarint(r)
+	switch {
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		return nil, 0, err
+	case err != nil:
+		return nil, 0, fmt.Errorf("%w: %v", errCorruptRecord, err)
+	}
+	if size > maxRecordSize {
+		return nil, 0, fmt.Errorf("%w: record of %d bytes", errCorruptRecord, size)
+	}
+
+	body := make([]byte, size+4)
+	if _, err := io.ReadFull(r, body); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	body, sum := body[:size], body[size:]
+	if crc32.Checksum(body, crcTable) != binary.BigEndian.Uint32(sum) {
+		return nil, 0, errCorruptRecord
+	}
+
+	block, err := unmarshalBody(body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", errCorruptRecord, err)
+	}
+
+	return block, int64(uvarintSize(size)) + int64(size) + 4, nil
+}
+
+func unmarshalBody(body []byte) (*Block, error) {
+	var (
+		block Block
+		n     int
+	)
+	if block.Sequence, n = binary.Uvarint(body); n <= 0 {
+		return nil, errors.New("invalid sequence")
+	}
+	body = body[n:]
+	if block.Height, n = binary.Varint(body); n <= 0 {
+		return nil, errors.New("invalid height")
+	}
+	body = body[n:]
+
+	for len(body) > 0 {
+		kind := body[0]
+		size, n := binary.Uvarint(body[1:])
+		if n <= 0 || uint64(len(body)-1-n) < size {
+			return nil, errors.New("invalid message size")
+		}
+		bz := body[1+n : 1+n+int(size)]
+		body = body[1+n+int(size):]
+
+		var msg message
+		switch kind {
+		case kindBeginBlock:
+			block.BeginBlock = &streamingabci.ListenBeginBlockRequest{}
+			msg = block.BeginBlock
+		case kindDeliverTx:
+			deliverTx := &streamingabci.ListenDeliverTxRequest{}
+			block.DeliverTxs = append(block.DeliverTxs, deliverTx)
+			msg = deliverTx
+		case kindEndBlock:
+			block.EndBlock = &streamingabci.ListenEndBlockRequest{}
+			msg = block.EndBlock
+		case kindCommit:
+			block.Commit = &streamingabci.ListenCommitRequest{}
+			msg = block.Commit
+		default:
+			return nil, fmt.Errorf("unknown message kind %d", kind)
+		}
+		if err := msg.Unmarshal(bz); err != nil {
+			return nil, err
+		}
+	}
+
+	return &block, nil
+}
+
+func uvarintSize(x uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], x)
+}
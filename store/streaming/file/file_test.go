@@ -0,0 +1,155 @@
+package file_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/streaming/file"
+	storetypes "cosmossdk.io/store/types"
+)
+
+func writeBlock(t *testing.T, w *file.Writer, height int64) {
+	t.Helper()
+
+	ctx := context.Background()
+	require.NoError(t, w.ListenBeginBlock(ctx, abci.RequestBeginBlock{Header: tmproto.Header{Height: height}}, abci.ResponseBeginBlock{}))
+	require.NoError(t, w.ListenDeliverTx(ctx, abci.RequestDeliverTx{Tx: []byte{byte(height)}}, abci.ResponseDeliverTx{Code: 1}))
+	require.NoError(t, w.ListenEndBlock(ctx, abci.RequestEndBlock{Height: height}, abci.ResponseEndBlock{}))
+	require.NoError(t, w.ListenCommit(ctx, abci.ResponseCommit{RetainHeight: height}, []*storetypes.StoreKVPair{
+		{StoreKey: "acc", Key: []byte("a"), Value: []byte{byte(height)}},
+		{StoreKey: "bank", Key: []byte("b"), Value: []byte{byte(height)}},
+	}))
+}
+
+// readHeights reads the blocks of r until the end of the stream, and returns
+// their heights.
+func readHeights(t *testing.T, r *file.Reader) []int64 {
+	t.Helper()
+
+	var heights []int64
+	for {
+		block, err := r.Next()
+		if err == io.EOF {
+			return heights
+		}
+		require.NoError(t, err)
+		heights = append(heights, block.Height)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	dir := t.TempDir()
+	w, err := file.NewWriter(file.Config{Dir: dir, Keys: []string{"acc"}, MaxFileSize: 100, Fsync: true})
+	require.NoError(t, err)
+	for height := int64(1); height <= 5; height++ {
+		writeBlock(t, w, height)
+	}
+
+	// the files are rotated
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Greater(t, len(entries), 1)
+
+	r, err := file.NewReader(dir, 1)
+	require.NoError(t, err)
+	defer r.Close()
+
+	block, err := r.Next()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, block.Sequence)
+	require.EqualValues(t, 1, block.Height)
+	require.EqualValues(t, 1, block.BeginBlock.Req.Header.Height)
+	require.Len(t, block.DeliverTxs, 1)
+	require.Equal(t, []byte{1}, block.DeliverTxs[0].Req.Tx)
+	require.EqualValues(t, 1, block.DeliverTxs[0].Res.Code)
+	require.EqualValues(t, 1, block.EndBlock.Req.Height)
+	require.EqualValues(t, 1, block.Commit.Res.RetainHeight)
+	// only the state changes of the configured stores are written
+	require.Len(t, block.Commit.ChangeSet, 1)
+	require.Equal(t, "acc", block.Commit.ChangeSet[0].StoreKey)
+
+	require.Equal(t, []int64{2, 3, 4, 5}, readHeights(t, r))
+
+	// the reader follows the stream
+	writeBlock(t, w, 6)
+	require.Equal(t, []int64{6}, readHeights(t, r))
+	require.NoError(t, w.Close())
+
+	// the stream is read from a height
+	r, err = file.NewReader(dir, 4)
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, []int64{4, 5, 6}, readHeights(t, r))
+}
+
+func TestWriterResume(t *testing.T) {
+	dir := t.TempDir()
+	w, err := file.NewWriter(file.Config{Dir: dir, Keys: []string{"*"}})
+	require.NoError(t, err)
+	for height := int64(1); height <= 3; height++ {
+		writeBlock(t, w, height)
+	}
+	require.NoError(t, w.Close())
+
+	// the last record was not completely written
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	f, err := os.OpenFile(filepath.Join(dir, entries[0].Name()), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{100, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	w, err = file.NewWriter(file.Config{Dir: dir, Keys: []string{"*"}})
+	require.NoError(t, err)
+	sequence, height := w.LastBlock()
+	require.EqualValues(t, 3, sequence)
+	require.EqualValues(t, 3, height)
+
+	// the blocks already written are skipped
+	writeBlock(t, w, 3)
+	writeBlock(t, w, 4)
+	require.NoError(t, w.Close())
+
+	r, err := file.NewReader(dir, 1)
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, []int64{1, 2, 3, 4}, readHeights(t, r))
+}
+
+func TestReaderGap(t *testing.T) {
+	dir := t.TempDir()
+	w, err := file.NewWriter(file.Config{Dir: dir})
+	require.NoError(t, err)
+	writeBlock(t, w, 1)
+	writeBlock(t, w, 2)
+	// the block 3 is missing, e.g. it was buffered when the node stopped
+	writeBlock(t, w, 4)
+	require.NoError(t, w.Close())
+
+	r, err := file.NewReader(dir, 2)
+	require.NoError(t, err)
+	defer r.Close()
+	_, err = r.Next()
+	require.NoError(t, err)
+	block, err := r.Next()
+	require.ErrorIs(t, err, file.ErrGap)
+	require.EqualValues(t, 4, block.Height)
+	require.EqualValues(t, 3, block.Sequence)
+
+	// the stream starts after the height requested
+	r, err = file.NewReader(dir, 3)
+	require.NoError(t, err)
+	defer r.Close()
+	block, err = r.Next()
+	require.ErrorIs(t, err, file.ErrGap)
+	require.EqualValues(t, 4, block.Height)
+}
@@ -0,0 +1,186 @@
+package file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrGap is returned by Reader.Next along with a block when blocks are missing
+// from the stream before it.
+var ErrGap = errors.New("gap in the stream")
+
+// Reader reads the blocks written by a Writer to the files of a directory, in
+// order, starting from a height. It can follow the stream while it is written.
+type Reader struct {
+	dir string
+
+	name   string
+	file   *os.File
+	r      *bufio.Reader
+	offset int64
+
+	// fromHeight is the height of the first block to return
+	fromHeight int64
+	// sequence and height are the ones of the last block returned
+	sequence uint64
+	height   int64
+}
+
+// NewReader returns a Reader of the files of dir, starting at the block of
+// fromHeight.
+func NewReader(dir string, fromHeight int64) (*Reader, error) {
+	names, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reader{dir: dir, fromHeight: fromHeight}
+
+	// start from the last file starting at or before fromHeight
+	for i := len(names) - 1; i >= 0; i-- {
+		height, _ := fileHeight(names[i])
+		if height <= fromHeight || i == 0 {
+			if err := r.open(names[i]); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	return r, nil
+}
+
+// Next returns the next block of the stream. It returns io.EOF if no block
+// was written after the last one returned yet, in which case Next can be
+// called again later to follow the stream.
+//
+// If blocks are missing before the block returned, e.g. because of a crash of
+// the node before they were written, the block is returned along with an
+// error wrapping ErrGap.
+func (r *Reader) Next() (*Block, error) {
+	for {
+		block, err := r.readBlock()
+		if err != nil {
+			return nil, err
+		}
+		if block.Height < r.fromHeight {
+			r.sequence, r.height = block.Sequence, block.Height
+			continue
+		}
+
+		expectHeight := r.fromHeight
+		if r.height >= r.fromHeight {
+			expectHeight = r.height + 1
+		}
+
+		var gapErr error
+		switch {
+		case r.sequence > 0 && block.Sequence != r.sequence+1:
+			gapErr = fmt.Errorf("%w: block %d follows the block %d of the stream", ErrGap, block.Sequence, r.sequence)
+		case block.Height != expectHeight:
+			gapErr = fmt.Errorf("%w: expected height %d, got %d", ErrGap, expectHeight, block.Height)
+		}
+
+		r.sequence, r.height = block.Sequence, block.Height
+		return block, gapErr
+	}
+}
+
+// readBlock reads the next block from the current file, or from the next
+// file once the current one is exhausted.
+func (r *Reader) readBlock() (*Block, error) {
+	for {
+		if r.file == nil {
+			names, err := listFiles(r.dir)
+			if err != nil {
+				return nil, err
+			}
+			if len(names) == 0 {
+				return nil, io.EOF
+			}
+			if err := r.open(names[0]); err != nil {
+				return nil, err
+			}
+		}
+
+		block, size, err := readRecord(r.r)
+		switch {
+		case err == nil:
+			r.offset += size
+			return block, nil
+
+		case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+			// the record may still be being written, unless the stream
+			// continues in the next file
+			next, err := r.nextFile()
+			if err != nil {
+				return nil, err
+			}
+			if next == "" {
+				return nil, r.rewind()
+			}
+			if err := r.open(next); err != nil {
+				return nil, err
+			}
+
+		default:
+			return nil, fmt.Errorf("failed to read %s at offset %d: %w", r.name, r.offset, err)
+		}
+	}
+}
+
+// nextFile returns the name of the file following the current one, or "" if
+// it is the last one.
+func (r *Reader) nextFile() (string, error) {
+	names, err := listFiles(r.dir)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range names {
+		if name > r.name {
+			return name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// rewind moves back to the end of the last complete record of the current
+// file, and returns io.EOF.
+func (r *Reader) rewind() error {
+	if _, err := r.file.Seek(r.offset, io.SeekStart); err != nil {
+		return err
+	}
+	r.r.Reset(r.file)
+
+	return io.EOF
+}
+
+func (r *Reader) open(name string) error {
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	file, err := os.Open(filepath.Join(r.dir, name))
+	if err != nil {
+		return err
+	}
+
+	r.name, r.file, r.r, r.offset = name, file, bufio.NewReader(file), 0
+	return nil
+}
+
+// Close closes the current file.
+func (r *Reader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
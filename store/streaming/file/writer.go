@@ -0,0 +1,286 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	streamingabci "cosmossdk.io/store/streaming/abci"
+	"cosmossdk.io/store/types"
+)
+
+const fileExt = ".blocks"
+
+var _ types.ABCIListener = (*Writer)(nil)
+
+// Config defines the configuration of a Writer.
+type Config struct {
+	// Dir is the directory of the files.
+	Dir string
+	// Keys are the names of the stores whose state changes are written, all
+	// of them if Keys contains "*".
+	Keys []string
+	// MaxFileSize is the size in bytes after which the next block is written
+	// to a new file. The file is never rotated if it is 0.
+	MaxFileSize int64
+	// Fsync syncs the file to disk after every block.
+	Fsync bool
+}
+
+// Writer is an ABCIListener writing the messages and the state changes of
+// every block on Commit, as a record appended to the files of a directory.
+// The files are named after the height of their first block, and a new file
+// is started once a file exceeds the maximum size.
+//
+// The records hold the sequence of the block in the stream, so that a Reader
+// can detect the blocks missing from the files. On start, the Writer resumes
+// the stream after the last complete record, and skips the blocks which were
+// already written.
+type Writer struct {
+	cfg     Config
+	allKeys bool
+	keys    map[string]bool
+
+	file     *os.File
+	fileSize int64
+	sequence uint64
+	height   int64
+
+	block *Block
+}
+
+// NewWriter returns a Writer resuming the stream of the files of cfg.Dir.
+func NewWriter(cfg Config) (*Writer, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	w := &Writer{cfg: cfg, keys: make(map[string]bool)}
+	for _, key := range cfg.Keys {
+		if key == "*" {
+			w.allKeys = true
+		}
+		w.keys[key] = true
+	}
+
+	names, err := listFiles(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > 0 {
+		if err := w.resume(filepath.Join(cfg.Dir, names[len(names)-1])); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// resume opens the last file of the stream for appending, after truncating
+// the last record if it was not completely written.
+func (w *Writer) resume(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var offset int64
+	r := bufio.NewReader(file)
+	for {
+		block, size, err := readRecord(r)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, errCorruptRecord) {
+			break
+		}
+		if err != nil {
+			file.Close()
+			return err
+		}
+
+		offset += size
+		w.sequence, w.height = block.Sequence, block.Height
+	}
+
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file, w.fileSize = file, offset
+	return nil
+}
+
+// LastBlock returns the sequence and the height of the last block written, or
+// zeros if the stream is empty.
+func (w *Writer) LastBlock() (sequence uint64, height int64) {
+	return w.sequence, w.height
+}
+
+// ListenBeginBlock implements ABCIListener.
+func (w *Writer) ListenBeginBlock(_ context.Context, req abci.RequestBeginBlock, res abci.ResponseBeginBlock) error {
+	w.block = &Block{
+		Height:     req.Header.Height,
+		BeginBlock: &streamingabci.ListenBeginBlockRequest{Req: &req, Res: &res},
+	}
+	return nil
+}
+
+// ListenDeliverTx implements ABCIListener.
+func (w *Writer) ListenDeliverTx(_ context.Context, req abci.RequestDeliverTx, res abci.ResponseDeliverTx) error {
+	if w.block == nil {
+		return errors.New("DeliverTx streamed before BeginBlock")
+	}
+
+	w.block.DeliverTxs = append(w.block.DeliverTxs, &streamingabci.ListenDeliverTxRequest{
+		BlockHeight: w.block.Height,
+		Req:         &req,
+		Res:         &res,
+	})
+	return nil
+}
+
+// ListenEndBlock implements ABCIListener.
+func (w *Writer) ListenEndBlock(_ context.Context, req abci.RequestEndBlock, res abci.ResponseEndBlock) error {
+	if w.block == nil {
+		return errors.New("EndBlock streamed before BeginBlock")
+	}
+
+	w.block.EndBlock = &streamingabci.ListenEndBlockRequest{Req: &req, Res: &res}
+	return nil
+}
+
+// ListenCommit implements ABCIListener. It writes the record of the block.
+func (w *Writer) ListenCommit(_ context.Context, res abci.ResponseCommit, changeSet []*types.StoreKVPair) error {
+	block := w.block
+	w.block = nil
+	if block == nil {
+		return errors.New("Commit streamed before BeginBlock")
+	}
+
+	// the block was already written before a restart or a rollback
+	if block.Height <= w.height {
+		return nil
+	}
+
+	var pairs []*types.StoreKVPair
+	for _, pair := range changeSet {
+		if w.allKeys || w.keys[pair.StoreKey] {
+			pairs = append(pairs, pair)
+		}
+	}
+	block.Commit = &streamingabci.ListenCommitRequest{BlockHeight: block.Height, Res: &res, ChangeSet: pairs}
+	block.Sequence = w.sequence + 1
+
+	record, err := marshalRecord(block)
+	if err != nil {
+		return err
+	}
+	if err := w.write(block.Height, record); err != nil {
+		return fmt.Errorf("failed to write block %d: %w", block.Height, err)
+	}
+
+	w.sequence, w.height = block.Sequence, block.Height
+	return nil
+}
+
+// write appends record to the current file, or to a new file starting at
+// height if the current one exceeds the maximum size.
+func (w *Writer) write(height int64, record []byte) error {
+	if w.file != nil && w.cfg.MaxFileSize > 0 && w.fileSize >= w.cfg.MaxFileSize {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	if w.file == nil {
+		file, err := os.OpenFile(filepath.Join(w.cfg.Dir, fileName(height)), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		w.file, w.fileSize = file, 0
+
+		if w.cfg.Fsync {
+			if err := syncDir(w.cfg.Dir); err != nil {
+				return err
+			}
+		}
+	}
+
+	n, err := w.file.Write(record)
+	w.fileSize += int64(n)
+	if err != nil {
+		return err
+	}
+	if w.cfg.Fsync {
+		return w.file.Sync()
+	}
+
+	return nil
+}
+
+// Close closes the current file.
+func (w *Writer) Close() error {
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return f.Sync()
+}
+
+func fileName(height int64) string {
+	return fmt.Sprintf("%020d%s", height, fileExt)
+}
+
+// listFiles returns the names of the files of dir, in the order of the
+// stream.
+func listFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if _, ok := fileHeight(entry.Name()); ok && !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// fileHeight returns the height of the first block of the file name.
+func fileHeight(name string) (int64, bool) {
+	if !strings.HasSuffix(name, fileExt) {
+		return 0, false
+	}
+
+	height, err := strconv.ParseInt(strings.TrimSuffix(name, fileExt), 10, 64)
+	return height, err == nil
+}
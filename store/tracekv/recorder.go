@@ -0,0 +1,50 @@
+package tracekv
+
+import (
+	"sync"
+
+	"cosmossdk.io/store/types"
+)
+
+var _ types.AccessTracer = (*AccessRecorder)(nil)
+
+// AccessRecorder is an AccessTracer recording the accesses traced to it in
+// memory, in order. It is safe for concurrent use.
+type AccessRecorder struct {
+	mtx      sync.Mutex
+	accesses []types.Access
+}
+
+// NewAccessRecorder returns an empty AccessRecorder.
+func NewAccessRecorder() *AccessRecorder {
+	return &AccessRecorder{}
+}
+
+// TraceAccess implements the AccessTracer interface. It records a copy of
+// access.
+func (r *AccessRecorder) TraceAccess(access types.Access) {
+	if access.Key != nil {
+		access.Key = append([]byte{}, access.Key...)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.accesses = append(r.accesses, access)
+}
+
+// Accesses returns the accesses recorded since the last Reset.
+func (r *AccessRecorder) Accesses() []types.Access {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([]types.Access{}, r.accesses...)
+}
+
+// Reset discards the accesses recorded.
+func (r *AccessRecorder) Reset() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.accesses = nil
+}
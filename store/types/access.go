@@ -0,0 +1,70 @@
+package types
+
+import "bytes"
+
+// KeyRange is the domain [Start, End) of an iteration over a KVStore. A nil
+// Start or End is unbounded.
+type KeyRange struct {
+	Start, End []byte
+}
+
+// Contains returns true if key is in the range.
+func (r KeyRange) Contains(key []byte) bool {
+	return bytes.Compare(key, r.Start) >= 0 && (r.End == nil || bytes.Compare(key, r.End) < 0)
+}
+
+// AccessSet is the set of keys read and written through a branch of a
+// KVStore. Reads only contains the keys read from the parent store, i.e. not
+// the keys previously written through the branch.
+type AccessSet struct {
+	Reads  map[string]struct{}
+	Ranges []KeyRange
+	Writes map[string]struct{}
+}
+
+// NewAccessSet returns an empty AccessSet.
+func NewAccessSet() *AccessSet {
+	return &AccessSet{
+		Reads:  make(map[string]struct{}),
+		Writes: make(map[string]struct{}),
+	}
+}
+
+// DependsOn returns true if any of the given written keys was read, directly
+// or while iterating, through the branch.
+func (s *AccessSet) DependsOn(writes map[string]struct{}) bool {
+	for key := range s.Reads {
+		if _, ok := writes[key]; ok {
+			return true
+		}
+	}
+
+	if len(s.Ranges) == 0 {
+		return false
+	}
+
+	for key := range writes {
+		for _, r := range s.Ranges {
+			if r.Contains([]byte(key)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// AccessTrackingMultiStore is a CacheMultiStore which records the AccessSet
+// of each of its substores.
+type AccessTrackingMultiStore interface {
+	CacheMultiStore
+
+	// AccessSets returns the AccessSet of each tracked substore.
+	AccessSets() map[StoreKey]*AccessSet
+}
+
+// AccessTrackingBrancher is implemented by the multi stores which can be
+// branched into an AccessTrackingMultiStore.
+type AccessTrackingBrancher interface {
+	CacheMultiStoreWithAccessTracking() AccessTrackingMultiStore
+}
@@ -0,0 +1,34 @@
+package types_test
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/store/types"
+)
+
+func TestKeyRangeContains(t *testing.T) {
+	r := types.KeyRange{Start: []byte("b"), End: []byte("d")}
+	assert.Assert(t, !r.Contains([]byte("a")))
+	assert.Assert(t, r.Contains([]byte("b")))
+	assert.Assert(t, r.Contains([]byte("c")))
+	assert.Assert(t, !r.Contains([]byte("d")))
+
+	unbounded := types.KeyRange{}
+	assert.Assert(t, unbounded.Contains([]byte{}))
+	assert.Assert(t, unbounded.Contains([]byte("z")))
+}
+
+func TestAccessSetDependsOn(t *testing.T) {
+	set := types.NewAccessSet()
+	set.Reads["a"] = struct{}{}
+	set.Ranges = append(set.Ranges, types.KeyRange{Start: []byte("m"), End: []byte("p")})
+	set.Writes["x"] = struct{}{}
+
+	assert.Assert(t, !set.DependsOn(nil))
+	assert.Assert(t, set.DependsOn(map[string]struct{}{"a": {}}))
+	assert.Assert(t, set.DependsOn(map[string]struct{}{"b": {}, "n": {}}))
+	// keys only written through the branch are not dependencies
+	assert.Assert(t, !set.DependsOn(map[string]struct{}{"b": {}, "x": {}}))
+}
@@ -0,0 +1,54 @@
+package types
+
+// AccessOperation is the kind of an access to a KVStore.
+type AccessOperation string
+
+const (
+	AccessRead    AccessOperation = "read"
+	AccessHas     AccessOperation = "has"
+	AccessWrite   AccessOperation = "write"
+	AccessDelete  AccessOperation = "delete"
+	AccessIterKey AccessOperation = "iterKey"
+)
+
+// Access is an access to a KVStore along with the gas it was charged.
+type Access struct {
+	// Context holds the metadata of the access, e.g. the hash of the
+	// transaction which made it.
+	Context TraceContext
+	// StoreKey is the name of the store accessed.
+	StoreKey  string
+	Operation AccessOperation
+	// Key is the key accessed, or the current key of an iterator when it is
+	// charged gas, i.e. when it is opened and before it moves to the next
+	// key. It is nil if the iterator is exhausted.
+	Key []byte
+	// ValueSize is the size of the value read, written, or of the current
+	// value of an iterator.
+	ValueSize int
+	// Gas is the gas charged for the access.
+	Gas Gas
+}
+
+// AccessTracer receives the accesses made to KVStores. The key of an Access
+// must be copied if it is retained after TraceAccess returns.
+type AccessTracer interface {
+	TraceAccess(access Access)
+}
+
+type accessTracerWithContext struct {
+	parent  AccessTracer
+	context TraceContext
+}
+
+// AccessTracerWithContext returns an AccessTracer adding the metadata of tc to
+// the context of the accesses traced to tracer. The metadata already in the
+// context of an access takes precedence over the one of tc.
+func AccessTracerWithContext(tracer AccessTracer, tc TraceContext) AccessTracer {
+	return accessTracerWithContext{parent: tracer, context: tc}
+}
+
+func (t accessTracerWithContext) TraceAccess(access Access) {
+	access.Context = t.context.Clone().Merge(access.Context)
+	t.parent.TraceAccess(access)
+}
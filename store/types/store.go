@@ -194,6 +194,19 @@ type CommitMultiStore interface {
 	// SetIAVLLazyLoading enable/disable lazy loading on iavl.
 	SetLazyLoading(lazyLoading bool)
 
+	// SetStateStorage enables the state storage: the key/values written at
+	// every version are stored in db, which then serves the historical
+	// queries, while the IAVL trees only retain the recent versions needed for
+	// the root hash and the proofs. It must be called before loading a
+	// version.
+	SetStateStorage(db dbm.DB)
+
+	// SetMemIAVL loads the IAVL stores as memiavl stores, IAVL trees held in
+	// memory with the same root hashes and persisted as memory-mapped
+	// snapshots and a write-ahead log. It must be called before loading a
+	// version.
+	SetMemIAVL(opts MemIAVLOptions)
+
 	// RollbackToVersion rollback the db to specific version(height).
 	RollbackToVersion(version int64) error
 
@@ -208,6 +221,10 @@ type CommitMultiStore interface {
 
 	// SetMetrics sets the metrics for the KVStore
 	SetMetrics(metrics metrics.StoreMetrics)
+
+	// Close releases the resources held by the stores, e.g. the state storage
+	// and the memiavl stores. The CommitMultiStore must not be used afterwards.
+	Close() error
 }
 
 //---------subsp-------------------------------
@@ -316,6 +333,7 @@ const (
 	StoreTypeMemory
 	StoreTypeSMT
 	StoreTypePersistent
+	StoreTypeMemIAVL
 )
 
 func (st StoreType) String() string {
@@ -340,11 +358,34 @@ func (st StoreType) String() string {
 
 	case StoreTypePersistent:
 		return "StoreTypePersistent"
+
+	case StoreTypeMemIAVL:
+		return "StoreTypeMemIAVL"
 	}
 
 	return "unknown store type"
 }
 
+// MemIAVLOptions defines the options of the memiavl stores.
+type MemIAVLOptions struct {
+	// Dir is the directory of the stores, each in the sub-directory of its
+	// name.
+	Dir string
+
+	// SnapshotInterval is the number of versions between two snapshots of a
+	// store. The versions committed since the last snapshot are replayed from
+	// the write-ahead log when the store is loaded.
+	SnapshotInterval uint32
+
+	// SnapshotKeepRecent is the number of snapshots kept besides the latest,
+	// which the store can be rolled back to.
+	SnapshotKeepRecent uint32
+
+	// KeepRecent is the number of versions before the latest kept in memory
+	// to serve the queries and the state sync snapshots.
+	KeepRecent uint32
+}
+
 //----------------------------------------
 // Keys for accessing substores
 
@@ -141,3 +141,9 @@ require (
 
 // this seems to be required
 replace github.com/cosmos/cosmos-sdk => ../..
+
+// TODO: remove after the release of the store module
+replace cosmossdk.io/store => ../../store
+
+// TODO: remove after the release of the collections module
+replace cosmossdk.io/collections => ../../collections
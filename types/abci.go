@@ -34,3 +34,13 @@ type ProcessProposalHandler func(Context, abci.RequestProcessProposal) abci.Resp
 
 // PrepareProposalHandler defines a function type alias for preparing a proposal
 type PrepareProposalHandler func(Context, abci.RequestPrepareProposal) abci.ResponsePrepareProposal
+
+// ExtendVoteHandler defines a function type alias for extending a validator's
+// pre-commit vote for the block at the given height and hash with arbitrary
+// application data, e.g. oracle prices.
+type ExtendVoteHandler func(ctx Context, height int64, hash []byte) ([]byte, error)
+
+// VerifyVoteExtensionHandler defines a function type alias for verifying the
+// vote extension a validator attached to its pre-commit vote for the block at
+// the given height. An error rejects the vote extension.
+type VerifyVoteExtensionHandler func(ctx Context, valAddr []byte, height int64, voteExtension []byte) error
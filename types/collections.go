@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"time"
+
 	"cosmossdk.io/collections"
 	collcodec "cosmossdk.io/collections/codec"
 	"cosmossdk.io/math"
@@ -28,6 +31,11 @@ var (
 
 	// IntValue represents a collections.ValueCodec to work with Int.
 	IntValue collcodec.ValueCodec[math.Int] = intValueCodec{}
+
+	// TimeKey represents a collections.KeyCodec to work with time.Time.
+	// Keys are encoded with FormatTimeBytes, which retains state backwards
+	// compatibility with the stores keyed by time.
+	TimeKey collcodec.KeyCodec[time.Time] = timeKeyCodec{}
 )
 
 type addressUnion interface {
@@ -119,6 +127,66 @@ func AddressKeyAsIndexKey[T addressUnion](keyCodec collcodec.KeyCodec[T]) collco
 	}
 }
 
+// timeSize is the size of the encoding of the times formatted by FormatTimeBytes
+// whose year has four digits.
+var timeSize = len(FormatTimeBytes(time.Time{}))
+
+type timeKeyCodec struct{}
+
+func (timeKeyCodec) Encode(buffer []byte, key time.Time) (int, error) {
+	return copy(buffer, FormatTimeBytes(key)), nil
+}
+
+func (timeKeyCodec) Decode(buffer []byte) (int, time.Time, error) {
+	t, err := ParseTimeBytes(buffer)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("%w: %s", collections.ErrEncoding, err)
+	}
+	return len(buffer), t, nil
+}
+
+func (timeKeyCodec) Size(key time.Time) int {
+	return len(FormatTimeBytes(key))
+}
+
+func (timeKeyCodec) EncodeJSON(value time.Time) ([]byte, error) {
+	return value.MarshalJSON()
+}
+
+func (timeKeyCodec) DecodeJSON(b []byte) (time.Time, error) {
+	var t time.Time
+	err := t.UnmarshalJSON(b)
+	return t, err
+}
+
+func (timeKeyCodec) Stringify(key time.Time) string {
+	return FormatTimeString(key)
+}
+
+func (timeKeyCodec) KeyType() string {
+	return "sdk.Time"
+}
+
+// EncodeNonTerminal encodes the time like Encode does: its encoding is not
+// length prefixed, so only times formatted on timeSize bytes are supported.
+func (t timeKeyCodec) EncodeNonTerminal(buffer []byte, key time.Time) (int, error) {
+	if size := t.Size(key); size != timeSize {
+		return 0, fmt.Errorf("%w: non terminal time keys must be %d bytes long, got %d", collections.ErrEncoding, timeSize, size)
+	}
+	return t.Encode(buffer, key)
+}
+
+func (t timeKeyCodec) DecodeNonTerminal(buffer []byte) (int, time.Time, error) {
+	if len(buffer) < timeSize {
+		return 0, time.Time{}, fmt.Errorf("%w: non terminal time keys must be %d bytes long, got %d", collections.ErrEncoding, timeSize, len(buffer))
+	}
+	return t.Decode(buffer[:timeSize])
+}
+
+func (t timeKeyCodec) SizeNonTerminal(key time.Time) int {
+	return t.Size(key)
+}
+
 // Collection Codecs
 
 type intValueCodec struct{}
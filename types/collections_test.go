@@ -2,6 +2,7 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	"cosmossdk.io/collections/colltest"
 )
@@ -22,4 +23,8 @@ func TestCollectionsCorrectness(t *testing.T) {
 	t.Run("AddressIndexingKey", func(t *testing.T) {
 		colltest.TestKeyCodec(t, AddressKeyAsIndexKey(AccAddressKey), AccAddress{0x2, 0x5, 0x8})
 	})
+
+	t.Run("Time", func(t *testing.T) {
+		colltest.TestKeyCodec(t, TimeKey, time.Date(2023, 5, 17, 10, 30, 0, 123, time.UTC))
+	})
 }
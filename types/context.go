@@ -30,6 +30,7 @@ type Context struct {
 	txBytes              []byte
 	logger               log.Logger
 	voteInfo             []abci.VoteInfo
+	extendedVoteInfo     []abci.ExtendedVoteInfo
 	gasMeter             storetypes.GasMeter
 	blockGasMeter        storetypes.GasMeter
 	checkTx              bool
@@ -41,6 +42,7 @@ type Context struct {
 	kvGasConfig          storetypes.GasConfig
 	transientKVGasConfig storetypes.GasConfig
 	streamingManager     storetypes.StreamingManager
+	accessTracer         storetypes.AccessTracer
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -55,6 +57,7 @@ func (c Context) ChainID() string                               { return c.chain
 func (c Context) TxBytes() []byte                               { return c.txBytes }
 func (c Context) Logger() log.Logger                            { return c.logger }
 func (c Context) VoteInfos() []abci.VoteInfo                    { return c.voteInfo }
+func (c Context) ExtendedVoteInfos() []abci.ExtendedVoteInfo    { return c.extendedVoteInfo }
 func (c Context) GasMeter() storetypes.GasMeter                 { return c.gasMeter }
 func (c Context) BlockGasMeter() storetypes.GasMeter            { return c.blockGasMeter }
 func (c Context) IsCheckTx() bool                               { return c.checkTx }
@@ -65,6 +68,7 @@ func (c Context) Priority() int64                               { return c.prior
 func (c Context) KVGasConfig() storetypes.GasConfig             { return c.kvGasConfig }
 func (c Context) TransientKVGasConfig() storetypes.GasConfig    { return c.transientKVGasConfig }
 func (c Context) StreamingManager() storetypes.StreamingManager { return c.streamingManager }
+func (c Context) AccessTracer() storetypes.AccessTracer         { return c.accessTracer }
 
 // clone the header before returning
 func (c Context) BlockHeader() cmtproto.Header {
@@ -190,6 +194,14 @@ func (c Context) WithVoteInfos(voteInfo []abci.VoteInfo) Context {
 	return c
 }
 
+// WithExtendedVoteInfos returns a Context with updated consensus
+// ExtendedVoteInfo, i.e. the votes of the previous block with their vote
+// extensions, which are only known in PrepareProposal.
+func (c Context) WithExtendedVoteInfos(extendedVoteInfo []abci.ExtendedVoteInfo) Context {
+	c.extendedVoteInfo = extendedVoteInfo
+	return c
+}
+
 // WithGasMeter returns a Context with an updated transaction GasMeter.
 func (c Context) WithGasMeter(meter storetypes.GasMeter) Context {
 	c.gasMeter = meter
@@ -262,6 +274,13 @@ func (c Context) WithStreamingManager(sm storetypes.StreamingManager) Context {
 	return c
 }
 
+// WithAccessTracer returns a Context with an updated tracer of the accesses
+// made to the KVStores of the Context. A nil tracer disables the tracing.
+func (c Context) WithAccessTracer(tracer storetypes.AccessTracer) Context {
+	c.accessTracer = tracer
+	return c
+}
+
 // TODO: remove???
 func (c Context) IsZero() bool {
 	return c.ms == nil
@@ -286,12 +305,12 @@ func (c Context) Value(key interface{}) interface{} {
 
 // KVStore fetches a KVStore from the MultiStore.
 func (c Context) KVStore(key storetypes.StoreKey) storetypes.KVStore {
-	return gaskv.NewStore(c.ms.GetKVStore(key), c.gasMeter, c.kvGasConfig)
+	return gaskv.NewStoreWithTracer(c.ms.GetKVStore(key), c.gasMeter, c.kvGasConfig, key.Name(), c.accessTracer)
 }
 
 // TransientStore fetches a TransientStore from the MultiStore.
 func (c Context) TransientStore(key storetypes.StoreKey) storetypes.KVStore {
-	return gaskv.NewStore(c.ms.GetKVStore(key), c.gasMeter, c.transientKVGasConfig)
+	return gaskv.NewStoreWithTracer(c.ms.GetKVStore(key), c.gasMeter, c.transientKVGasConfig, key.Name(), c.accessTracer)
 }
 
 // CacheContext returns a new Context with the multi-store cached and a new
@@ -3,4 +3,10 @@ package grpc
 const (
 	// GRPCBlockHeightHeader is the gRPC header for block height.
 	GRPCBlockHeightHeader = "x-cosmos-block-height"
+	// GRPCQueryProveHeader is the gRPC header requesting the proofs of the
+	// keys read by a query, when set to "true".
+	GRPCQueryProveHeader = "x-cosmos-query-prove"
+	// GRPCQueryProofsTrailer is the gRPC trailer holding the proto encoded
+	// QueryProofs of a query made with GRPCQueryProveHeader.
+	GRPCQueryProofsTrailer = "x-cosmos-query-proofs-bin"
 )
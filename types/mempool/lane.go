@@ -0,0 +1,233 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ Mempool        = (*LaneMempool)(nil)
+	_ RecheckMempool = (*LaneMempool)(nil)
+	_ Iterator       = (*laneIterator)(nil)
+)
+
+// ErrNoMatchingLane is returned when a transaction does not match any lane of
+// a LaneMempool.
+var ErrNoMatchingLane = errors.New("tx does not match any mempool lane")
+
+type (
+	// Lane defines a partition of a LaneMempool. Each lane stores the
+	// transactions it matches in its own mempool and may use a guaranteed share
+	// of a block's space.
+	Lane struct {
+		// Name identifies the lane, e.g. "oracle" or "default".
+		Name string
+
+		// Mempool stores the transactions of the lane and defines their order
+		// within the lane.
+		Mempool Mempool
+
+		// Match returns true if the transaction belongs to the lane. A transaction
+		// is routed to the first lane, in order, that matches it. Match must be
+		// deterministic as it is also used to verify proposals.
+		Match func(tx sdk.Tx) bool
+
+		// MaxTxBytesPercent defines the percentage of a block's transaction bytes
+		// the lane's transactions may use. Zero means the lane may use all of the
+		// space left by the previous lanes, so it must not be followed by a lane
+		// with a share of the block's transaction bytes.
+		MaxTxBytesPercent uint64
+
+		// MaxGasPercent defines the percentage of a block's gas the lane's
+		// transactions may use. Zero means the lane may use all of the gas left by
+		// the previous lanes, so it must not be followed by a lane with a share of
+		// the block's gas.
+		MaxGasPercent uint64
+	}
+
+	// LaneMempool is a mempool composed of several lanes. Transactions are routed
+	// to a lane with the lane's match function and selected lane by lane, in the
+	// order the lanes were given. Since each lane may use at most its share of a
+	// block and the shares add up to at most 100%, every lane is guaranteed its
+	// share of the block regardless of the demand on the previous lanes.
+	LaneMempool struct {
+		lanes []Lane
+	}
+
+	// laneIterator iterates the lanes of a LaneMempool one after the other.
+	laneIterator struct {
+		ctx     context.Context
+		txs     [][]byte
+		lanes   []Lane
+		current Iterator
+	}
+)
+
+// MatchAll is a lane match function matching every transaction. It is meant to
+// be used by the last, default, lane of a LaneMempool.
+func MatchAll(sdk.Tx) bool { return true }
+
+// NewLaneMempool returns a LaneMempool composed of the given lanes, in order of
+// precedence. An error is returned if the lanes are invalid, i.e. a lane has
+// no name, mempool or match function, two lanes share a name, the lanes'
+// shares of the block space exceed 100%, or a lane without a share, which uses
+// all of the space left, is followed by a lane with a share.
+func NewLaneMempool(lanes ...Lane) (*LaneMempool, error) {
+	if len(lanes) == 0 {
+		return nil, errors.New("lane mempool must have at least one lane")
+	}
+
+	var (
+		names                      = make(map[string]struct{}, len(lanes))
+		totalBytesPct, totalGasPct uint64
+		// the previous lanes using all of the space left, if any
+		allBytesLane, allGasLane string
+	)
+	for _, lane := range lanes {
+		if lane.Name == "" {
+			return nil, errors.New("lane name cannot be empty")
+		}
+		if _, ok := names[lane.Name]; ok {
+			return nil, fmt.Errorf("duplicate lane %s", lane.Name)
+		}
+		names[lane.Name] = struct{}{}
+
+		if lane.Mempool == nil {
+			return nil, fmt.Errorf("lane %s has no mempool", lane.Name)
+		}
+		if lane.Match == nil {
+			return nil, fmt.Errorf("lane %s has no match function", lane.Name)
+		}
+
+		if lane.MaxTxBytesPercent > 0 && allBytesLane != "" {
+			return nil, fmt.Errorf("lane %s has a tx bytes share but follows lane %s which uses all of the tx bytes left", lane.Name, allBytesLane)
+		}
+		if lane.MaxGasPercent > 0 && allGasLane != "" {
+			return nil, fmt.Errorf("lane %s has a gas share but follows lane %s which uses all of the gas left", lane.Name, allGasLane)
+		}
+		if lane.MaxTxBytesPercent == 0 && allBytesLane == "" {
+			allBytesLane = lane.Name
+		}
+		if lane.MaxGasPercent == 0 && allGasLane == "" {
+			allGasLane = lane.Name
+		}
+
+		totalBytesPct += lane.MaxTxBytesPercent
+		totalGasPct += lane.MaxGasPercent
+	}
+
+	if totalBytesPct > 100 {
+		return nil, fmt.Errorf("lanes tx bytes shares exceed 100%%: %d%%", totalBytesPct)
+	}
+	if totalGasPct > 100 {
+		return nil, fmt.Errorf("lanes gas shares exceed 100%%: %d%%", totalGasPct)
+	}
+
+	return &LaneMempool{lanes: lanes}, nil
+}
+
+// Lanes returns the lanes of the mempool in order of precedence.
+func (mp *LaneMempool) Lanes() []Lane {
+	return mp.lanes
+}
+
+// LaneIndex returns the index of the lane the transaction is routed to, or
+// false if no lane matches it.
+func (mp *LaneMempool) LaneIndex(tx sdk.Tx) (int, bool) {
+	for i, lane := range mp.lanes {
+		if lane.Match(tx) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// Insert inserts the transaction into the first lane matching it. It returns
+// ErrNoMatchingLane if no lane matches the transaction.
+func (mp *LaneMempool) Insert(ctx context.Context, tx sdk.Tx) error {
+	i, ok := mp.LaneIndex(tx)
+	if !ok {
+		return ErrNoMatchingLane
+	}
+
+	return mp.lanes[i].Mempool.Insert(ctx, tx)
+}
+
+// Select returns an iterator over all lanes, in order of precedence. The
+// transactions of each lane are ordered by the lane's mempool.
+//
+// NOTE: It is not safe to use this iterator while removing transactions from
+// the underlying mempool.
+func (mp *LaneMempool) Select(ctx context.Context, txs [][]byte) Iterator {
+	iterator := &laneIterator{ctx: ctx, txs: txs, lanes: mp.lanes}
+	return iterator.nextLane()
+}
+
+// CountTx returns the number of transactions in all lanes.
+func (mp *LaneMempool) CountTx() int {
+	count := 0
+	for _, lane := range mp.lanes {
+		count += lane.Mempool.CountTx()
+	}
+
+	return count
+}
+
+// Remove removes the transaction from the first lane matching it.
+func (mp *LaneMempool) Remove(tx sdk.Tx) error {
+	i, ok := mp.LaneIndex(tx)
+	if !ok {
+		return ErrTxNotFound
+	}
+
+	return mp.lanes[i].Mempool.Remove(tx)
+}
+
+// Recheck rechecks every lane whose mempool implements RecheckMempool,
+// returning the total number of removed transactions.
+func (mp *LaneMempool) Recheck(ctx context.Context, fn RecheckFn) (int, error) {
+	removed := 0
+	for _, lane := range mp.lanes {
+		rmp, ok := lane.Mempool.(RecheckMempool)
+		if !ok {
+			continue
+		}
+
+		n, err := rmp.Recheck(ctx, fn)
+		removed += n
+		if err != nil {
+			return removed, fmt.Errorf("failed to recheck lane %s: %w", lane.Name, err)
+		}
+	}
+
+	return removed, nil
+}
+
+// nextLane moves the iterator to the first non-empty remaining lane.
+func (i *laneIterator) nextLane() Iterator {
+	for len(i.lanes) > 0 {
+		i.current = i.lanes[0].Mempool.Select(i.ctx, i.txs)
+		i.lanes = i.lanes[1:]
+		if i.current != nil {
+			return i
+		}
+	}
+
+	return nil
+}
+
+func (i *laneIterator) Next() Iterator {
+	if i.current = i.current.Next(); i.current != nil {
+		return i
+	}
+
+	return i.nextLane()
+}
+
+func (i *laneIterator) Tx() sdk.Tx {
+	return i.current.Tx()
+}
@@ -0,0 +1,144 @@
+package mempool_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func TestNewLaneMempool(t *testing.T) {
+	lane := func(name string, bytesPct, gasPct uint64) mempool.Lane {
+		return mempool.Lane{
+			Name:              name,
+			Mempool:           mempool.NewSenderNonceMempool(),
+			Match:             mempool.MatchAll,
+			MaxTxBytesPercent: bytesPct,
+			MaxGasPercent:     gasPct,
+		}
+	}
+
+	testCases := map[string]struct {
+		lanes  []mempool.Lane
+		expErr string
+	}{
+		"valid": {
+			lanes: []mempool.Lane{lane("a", 60, 40), lane("b", 40, 60), lane("c", 0, 0)},
+		},
+		"no lanes": {
+			expErr: "at least one lane",
+		},
+		"empty name": {
+			lanes:  []mempool.Lane{lane("", 0, 0)},
+			expErr: "lane name cannot be empty",
+		},
+		"duplicate name": {
+			lanes:  []mempool.Lane{lane("a", 0, 0), lane("a", 0, 0)},
+			expErr: "duplicate lane a",
+		},
+		"no mempool": {
+			lanes:  []mempool.Lane{{Name: "a", Match: mempool.MatchAll}},
+			expErr: "lane a has no mempool",
+		},
+		"no match function": {
+			lanes:  []mempool.Lane{{Name: "a", Mempool: mempool.NewSenderNonceMempool()}},
+			expErr: "lane a has no match function",
+		},
+		"tx bytes shares exceed 100%": {
+			lanes:  []mempool.Lane{lane("a", 60, 0), lane("b", 41, 0)},
+			expErr: "tx bytes shares exceed 100%",
+		},
+		"gas shares exceed 100%": {
+			lanes:  []mempool.Lane{lane("a", 0, 60), lane("b", 0, 41)},
+			expErr: "gas shares exceed 100%",
+		},
+		"tx bytes share after all the tx bytes left": {
+			lanes:  []mempool.Lane{lane("a", 0, 60), lane("b", 40, 0)},
+			expErr: "lane b has a tx bytes share but follows lane a",
+		},
+		"gas share after all the gas left": {
+			lanes:  []mempool.Lane{lane("a", 60, 0), lane("b", 0, 0), lane("c", 0, 40)},
+			expErr: "lane c has a gas share but follows lane a",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := mempool.NewLaneMempool(tc.lanes...)
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLaneMempool(t *testing.T) {
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 3)
+	oracle := accounts[0].Address
+
+	isOracleTx := func(tx sdk.Tx) bool {
+		return tx.(testTx).address.Equals(oracle)
+	}
+
+	oracleLane := mempool.NewPriorityMempool(mempool.DefaultPriorityNonceMempoolConfig())
+	defaultLane := mempool.NewPriorityMempool(mempool.DefaultPriorityNonceMempoolConfig())
+	mp, err := mempool.NewLaneMempool(
+		mempool.Lane{Name: "oracle", Mempool: oracleLane, Match: isOracleTx, MaxTxBytesPercent: 10},
+		mempool.Lane{Name: "default", Mempool: defaultLane, Match: mempool.MatchAll},
+	)
+	require.NoError(t, err)
+	require.Len(t, mp.Lanes(), 2)
+
+	// an empty mempool has no txs to select
+	require.Nil(t, mp.Select(ctx, nil))
+
+	txs := []testTx{
+		{id: 0, priority: 100, nonce: 0, address: accounts[1].Address},
+		{id: 1, priority: 1, nonce: 0, address: oracle},
+		{id: 2, priority: 50, nonce: 0, address: accounts[2].Address},
+		{id: 3, priority: 2, nonce: 1, address: oracle},
+	}
+	for _, tx := range txs {
+		require.NoError(t, mp.Insert(ctx.WithPriority(tx.priority), tx))
+	}
+
+	require.Equal(t, 4, mp.CountTx())
+	require.Equal(t, 2, oracleLane.CountTx())
+	require.Equal(t, 2, defaultLane.CountTx())
+
+	i, ok := mp.LaneIndex(txs[1])
+	require.True(t, ok)
+	require.Equal(t, 0, i)
+
+	// lanes are selected in order, txs within a lane in the lane's order
+	require.Equal(t, []int{1, 3, 0, 2}, fetchTxIDs(mp.Select(ctx, nil)))
+
+	require.NoError(t, mp.Remove(txs[1]))
+	require.NoError(t, mp.Remove(txs[0]))
+	require.ErrorIs(t, mp.Remove(txs[0]), mempool.ErrTxNotFound)
+	require.Equal(t, []int{3, 2}, fetchTxIDs(mp.Select(ctx, nil)))
+
+	// every lane is rechecked
+	removed, err := mp.Recheck(ctx, func(_ context.Context, _ sdk.Tx) error {
+		return errors.New("invalid")
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+	require.Equal(t, 0, mp.CountTx())
+
+	// txs matching no lane are rejected
+	mp, err = mempool.NewLaneMempool(mempool.Lane{Name: "oracle", Mempool: oracleLane, Match: isOracleTx})
+	require.NoError(t, err)
+	require.ErrorIs(t, mp.Insert(ctx, txs[0]), mempool.ErrNoMatchingLane)
+}
@@ -2,9 +2,13 @@ package mempool
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
+	"hash"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 )
 
 type Mempool interface {
@@ -41,3 +45,42 @@ var (
 	ErrTxNotFound           = errors.New("tx not found in mempool")
 	ErrMempoolTxMaxCapacity = errors.New("pool reached max tx capacity")
 )
+
+// unorderedSenderPrefix prefixes the sender of the unordered transactions in
+// a mempool, which are kept apart from the ordered transactions of the sender.
+// It cannot be part of a bech32 address.
+const unorderedSenderPrefix = "unordered/"
+
+// sigSenderNonce returns the sender and the nonce identifying a transaction in
+// a mempool, sig being the signature of the sender. They are the address and
+// the sequence of the sender for ordered transactions.
+//
+// Unordered transactions do not use their sequence, they are identified in a
+// namespace of the sender of their own, by the hash of the signature of the
+// sender, which commits to the whole transaction. The unordered transactions
+// of a sender, which do not depend on each other, are then selected in no
+// particular order.
+func sigSenderNonce(tx sdk.Tx, sig signing.SignatureV2) (string, uint64) {
+	sender := sdk.AccAddress(sig.PubKey.Address()).String()
+
+	unorderedTx, ok := tx.(sdk.TxWithUnordered)
+	if !ok || !unorderedTx.GetUnordered() {
+		return sender, sig.Sequence
+	}
+
+	h := sha256.New()
+	hashSignatureData(h, sig.Data)
+	return unorderedSenderPrefix + sender, binary.BigEndian.Uint64(h.Sum(nil))
+}
+
+// hashSignatureData writes the signatures of data to h.
+func hashSignatureData(h hash.Hash, data signing.SignatureData) {
+	switch data := data.(type) {
+	case *signing.SingleSignatureData:
+		h.Write(data.Signature)
+	case *signing.MultiSignatureData:
+		for _, sig := range data.Signatures {
+			hashSignatureData(h, sig)
+		}
+	}
+}
@@ -7,13 +7,15 @@ import (
 
 	"github.com/huandu/skiplist"
 
+	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
 )
 
 var (
-	_ Mempool  = (*PriorityNonceMempool[int64])(nil)
-	_ Iterator = (*PriorityNonceIterator[int64])(nil)
+	_ Mempool        = (*PriorityNonceMempool[int64])(nil)
+	_ RecheckMempool = (*PriorityNonceMempool[int64])(nil)
+	_ Iterator       = (*PriorityNonceIterator[int64])(nil)
 )
 
 type (
@@ -31,14 +33,28 @@ type (
 		// replacement rule based on tx priority or certain transaction fields.
 		TxReplacement func(op, np C, oTx, nTx sdk.Tx) bool
 
+		// TxEviction is a callback to be called when a new transaction is inserted
+		// into a mempool that has reached MaxTx. It is given the priority and the
+		// transaction of the eviction candidate, that is the lowest priority
+		// transaction which is also the last (nonce-wise) transaction of its sender,
+		// along with the priority and the new transaction. If it returns true, the
+		// candidate is evicted to make room for the new transaction. If TxEviction
+		// is nil, no transaction is ever evicted and Insert returns
+		// ErrMempoolTxMaxCapacity when the mempool is full.
+		TxEviction func(lp, np C, lTx, nTx sdk.Tx) bool
+
 		// MaxTx sets the maximum number of transactions allowed in the mempool with
 		// the semantics:
 		// - if MaxTx == 0, there is no cap on the number of transactions in the mempool
 		// - if MaxTx > 0, the mempool will cap the number of transactions it stores,
 		//   and will prioritize transactions by their priority and sender-nonce
-		//   (sequence number) when evicting transactions.
+		//   (sequence number) when evicting transactions, see TxEviction.
 		// - if MaxTx < 0, `Insert` is a no-op.
 		MaxTx int
+
+		// TxTTL defines for how long a transaction may stay in the mempool. Expired
+		// transactions are removed by Recheck. The zero value disables expiry.
+		TxTTL TxTTL
 	}
 
 	// PriorityNonceMempool is a mempool implementation that stores txs
@@ -53,6 +69,7 @@ type (
 		priorityCounts map[C]int
 		senderIndices  map[string]*skiplist.SkipList
 		scores         map[txMeta[C]]txMeta[C]
+		insertions     map[txMeta[C]]insertion
 		cfg            PriorityNonceMempoolConfig[C]
 	}
 
@@ -118,6 +135,48 @@ func DefaultPriorityNonceMempoolConfig() PriorityNonceMempoolConfig[int64] {
 	}
 }
 
+// NewPriorityTxEviction returns a TxEviction rule which evicts the lowest
+// priority transaction in favour of a new transaction only if the new
+// transaction has a strictly higher priority.
+func NewPriorityTxEviction[C comparable](txPriority TxPriority[C]) func(lp, np C, lTx, nTx sdk.Tx) bool {
+	return func(lp, np C, _, _ sdk.Tx) bool {
+		return txPriority.Compare(np, lp) > 0
+	}
+}
+
+// NewMinFeeBumpTxReplacement returns a TxReplacement rule which allows a
+// transaction to replace an existing transaction with the same sender and nonce
+// only if it pays, for every denomination of the existing transaction's fee, at
+// least minBumpPercent percent more than the existing transaction. If the
+// existing transaction pays no fee, the new transaction must pay a non-zero fee.
+// Transactions which do not implement sdk.FeeTx are never replaced.
+func NewMinFeeBumpTxReplacement[C comparable](minBumpPercent uint64) func(op, np C, oTx, nTx sdk.Tx) bool {
+	return func(_, _ C, oTx, nTx sdk.Tx) bool {
+		oFeeTx, ok := oTx.(sdk.FeeTx)
+		if !ok {
+			return false
+		}
+		nFeeTx, ok := nTx.(sdk.FeeTx)
+		if !ok {
+			return false
+		}
+
+		oFee, nFee := oFeeTx.GetFee(), nFeeTx.GetFee()
+		if oFee.IsZero() {
+			return !nFee.IsZero()
+		}
+
+		threshold := sdkmath.NewIntFromUint64(100 + minBumpPercent)
+		for _, coin := range oFee {
+			if nFee.AmountOf(coin.Denom).MulRaw(100).LT(coin.Amount.Mul(threshold)) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
 // skiplistComparable is a comparator for txKeys that first compares priority,
 // then weight, then sender, then nonce, uniquely identifying a transaction.
 //
@@ -160,6 +219,7 @@ func NewPriorityMempool[C comparable](cfg PriorityNonceMempoolConfig[C]) *Priori
 		priorityCounts: make(map[C]int),
 		senderIndices:  make(map[string]*skiplist.SkipList),
 		scores:         make(map[txMeta[C]]txMeta[C]),
+		insertions:     make(map[txMeta[C]]insertion),
 		cfg:            cfg,
 	}
 
@@ -193,10 +253,13 @@ func (mp *PriorityNonceMempool[C]) NextSenderTx(sender string) sdk.Tx {
 //
 // Inserting a duplicate tx with a different priority overwrites the existing tx,
 // changing the total order of the mempool.
+//
+// Inserting a new tx into a full mempool evicts an existing tx if the
+// configured TxEviction rule allows it, otherwise ErrMempoolTxMaxCapacity is
+// returned. Replacing an existing tx is always permitted when the mempool is
+// full.
 func (mp *PriorityNonceMempool[C]) Insert(ctx context.Context, tx sdk.Tx) error {
-	if mp.cfg.MaxTx > 0 && mp.CountTx() >= mp.cfg.MaxTx {
-		return ErrMempoolTxMaxCapacity
-	} else if mp.cfg.MaxTx < 0 {
+	if mp.cfg.MaxTx < 0 {
 		return nil
 	}
 
@@ -209,11 +272,18 @@ func (mp *PriorityNonceMempool[C]) Insert(ctx context.Context, tx sdk.Tx) error
 	}
 
 	sig := sigs[0]
-	sender := sdk.AccAddress(sig.PubKey.Address()).String()
+	sender, nonce := sigSenderNonce(tx, sig)
 	priority := mp.cfg.TxPriority.GetTxPriority(ctx, tx)
-	nonce := sig.Sequence
 	key := txMeta[C]{nonce: nonce, priority: priority, sender: sender}
 
+	sk := txMeta[C]{nonce: nonce, sender: sender}
+	oldScore, txExists := mp.scores[sk]
+	if !txExists && mp.cfg.MaxTx > 0 && mp.CountTx() >= mp.cfg.MaxTx {
+		if err := mp.evict(sender, priority, tx); err != nil {
+			return err
+		}
+	}
+
 	senderIndex, ok := mp.senderIndices[sender]
 	if !ok {
 		senderIndex = skiplist.New(skiplist.LessThanFunc(func(a, b any) int {
@@ -231,8 +301,7 @@ func (mp *PriorityNonceMempool[C]) Insert(ctx context.Context, tx sdk.Tx) error
 	//
 	// This O(log n) remove operation is rare and only happens when a tx's priority
 	// changes.
-	sk := txMeta[C]{nonce: nonce, sender: sender}
-	if oldScore, txExists := mp.scores[sk]; txExists {
+	if txExists {
 		if mp.cfg.TxReplacement != nil && !mp.cfg.TxReplacement(oldScore.priority, priority, senderIndex.Get(key).Value.(sdk.Tx), tx) {
 			return fmt.Errorf(
 				"tx doesn't fit the replacement rule, oldPriority: %v, newPriority: %v, oldTx: %v, newTx: %v",
@@ -261,6 +330,10 @@ func (mp *PriorityNonceMempool[C]) Insert(ctx context.Context, tx sdk.Tx) error
 	mp.scores[sk] = txMeta[C]{priority: priority}
 	mp.priorityIndex.Set(key, tx)
 
+	if mp.cfg.TxTTL.enabled() {
+		mp.insertions[sk] = newInsertion(ctx)
+	}
+
 	return nil
 }
 
@@ -424,8 +497,7 @@ func (mp *PriorityNonceMempool[C]) Remove(tx sdk.Tx) error {
 	}
 
 	sig := sigs[0]
-	sender := sdk.AccAddress(sig.PubKey.Address()).String()
-	nonce := sig.Sequence
+	sender, nonce := sigSenderNonce(tx, sig)
 
 	scoreKey := txMeta[C]{nonce: nonce, sender: sender}
 	score, ok := mp.scores[scoreKey]
@@ -434,19 +506,79 @@ func (mp *PriorityNonceMempool[C]) Remove(tx sdk.Tx) error {
 	}
 	tk := txMeta[C]{nonce: nonce, priority: score.priority, sender: sender, weight: score.weight}
 
-	senderTxs, ok := mp.senderIndices[sender]
-	if !ok {
+	if _, ok := mp.senderIndices[sender]; !ok {
 		return fmt.Errorf("sender %s not found", sender)
 	}
 
-	mp.priorityIndex.Remove(tk)
-	senderTxs.Remove(tk)
-	delete(mp.scores, scoreKey)
-	mp.priorityCounts[score.priority]--
+	mp.remove(tk)
 
 	return nil
 }
 
+// remove deletes the transaction identified by tk from all indices. The caller
+// must ensure the transaction exists in the mempool.
+func (mp *PriorityNonceMempool[C]) remove(tk txMeta[C]) {
+	mp.priorityIndex.Remove(tk)
+	mp.senderIndices[tk.sender].Remove(tk)
+	delete(mp.scores, txMeta[C]{nonce: tk.nonce, sender: tk.sender})
+	delete(mp.insertions, txMeta[C]{nonce: tk.nonce, sender: tk.sender})
+	mp.priorityCounts[tk.priority]--
+}
+
+// Recheck removes the expired transactions and the transactions for which fn
+// returns an error, in priority and sender-nonce order, returning the number of
+// removed transactions.
+func (mp *PriorityNonceMempool[C]) Recheck(ctx context.Context, fn RecheckFn) (int, error) {
+	if fn == nil && !mp.cfg.TxTTL.enabled() {
+		return 0, nil
+	}
+
+	return recheck(ctx, mp, fn, func(tx sdk.Tx) bool {
+		if !mp.cfg.TxTTL.enabled() {
+			return false
+		}
+
+		sender, nonce, err := txSenderNonce(tx)
+		if err != nil {
+			return false
+		}
+
+		in, ok := mp.insertions[txMeta[C]{nonce: nonce, sender: sender}]
+		return ok && mp.cfg.TxTTL.expired(sdk.UnwrapSDKContext(ctx), in)
+	})
+}
+
+// evict attempts to make room for tx, sent by sender with the given priority,
+// by removing the lowest priority transaction in the mempool that is the last
+// (nonce-wise) transaction of its sender, so that an eviction never leaves a
+// nonce gap behind. Transactions of the inserting sender are never evicted.
+// ErrMempoolTxMaxCapacity is returned if no transaction may be evicted.
+func (mp *PriorityNonceMempool[C]) evict(sender string, priority C, tx sdk.Tx) error {
+	if mp.cfg.TxEviction == nil {
+		return ErrMempoolTxMaxCapacity
+	}
+
+	for node := mp.priorityIndex.Back(); node != nil; node = node.Prev() {
+		key := node.Key().(txMeta[C])
+		if key.sender == sender {
+			continue
+		}
+
+		if mp.senderIndices[key.sender].Back().Key().(txMeta[C]).nonce != key.nonce {
+			continue
+		}
+
+		if !mp.cfg.TxEviction(key.priority, priority, node.Value.(sdk.Tx), tx) {
+			return ErrMempoolTxMaxCapacity
+		}
+
+		mp.remove(key)
+		return nil
+	}
+
+	return ErrMempoolTxMaxCapacity
+}
+
 func IsEmpty[C comparable](mempool Mempool) error {
 	mp := mempool.(*PriorityNonceMempool[C])
 	if mp.priorityIndex.Len() != 0 {
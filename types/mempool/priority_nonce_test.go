@@ -14,6 +14,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/mempool"
 	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	txsigning "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
 )
 
@@ -748,3 +749,190 @@ func TestNextSenderTx_TxReplacement(t *testing.T) {
 	iter := mp.Select(ctx, nil)
 	require.Equal(t, txs[3], iter.Tx())
 }
+
+func TestNextSenderTx_TxEviction(t *testing.T) {
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 3)
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	sa := accounts[0].Address
+	sb := accounts[1].Address
+	sc := accounts[2].Address
+
+	mp := mempool.NewPriorityMempool(
+		mempool.PriorityNonceMempoolConfig[int64]{
+			TxPriority: mempool.NewDefaultTxPriority(),
+			TxEviction: mempool.NewPriorityTxEviction(mempool.NewDefaultTxPriority()),
+			MaxTx:      3,
+		},
+	)
+
+	txs := []testTx{
+		{id: 0, priority: 5, nonce: 1, address: sa},
+		{id: 1, priority: 10, nonce: 2, address: sa},
+		{id: 2, priority: 20, nonce: 1, address: sb},
+	}
+	for _, tx := range txs {
+		require.NoError(t, mp.Insert(ctx.WithPriority(tx.priority), tx))
+	}
+	require.Equal(t, 3, mp.CountTx())
+
+	// the lowest priority tx (sa, nonce 1) is not the last tx of its sender, so
+	// (sa, nonce 2) is the eviction candidate, which pays more than the new tx.
+	tx := testTx{id: 3, priority: 8, nonce: 1, address: sc}
+	require.ErrorIs(t, mp.Insert(ctx.WithPriority(tx.priority), tx), mempool.ErrMempoolTxMaxCapacity)
+	require.Equal(t, 3, mp.CountTx())
+
+	// a tx paying more than the eviction candidate evicts it.
+	tx = testTx{id: 4, priority: 15, nonce: 1, address: sc}
+	require.NoError(t, mp.Insert(ctx.WithPriority(tx.priority), tx))
+	require.Equal(t, 3, mp.CountTx())
+	require.Equal(t, []int{2, 4, 0}, fetchTxIDs(mp.Select(ctx, nil)))
+
+	// txs of the inserting sender are never evicted.
+	tx = testTx{id: 5, priority: 30, nonce: 2, address: sa}
+	require.NoError(t, mp.Insert(ctx.WithPriority(tx.priority), tx))
+	require.Equal(t, 3, mp.CountTx())
+	require.Equal(t, []int{2, 0, 5}, fetchTxIDs(mp.Select(ctx, nil)))
+
+	// replacing an existing tx is permitted when the mempool is full.
+	tx = testTx{id: 6, priority: 25, nonce: 1, address: sb}
+	require.NoError(t, mp.Insert(ctx.WithPriority(tx.priority), tx))
+	require.Equal(t, 3, mp.CountTx())
+	require.Equal(t, []int{6, 0, 5}, fetchTxIDs(mp.Select(ctx, nil)))
+
+	// without an eviction rule, a full mempool rejects new txs.
+	mp = mempool.NewPriorityMempool(
+		mempool.PriorityNonceMempoolConfig[int64]{
+			TxPriority: mempool.NewDefaultTxPriority(),
+			MaxTx:      1,
+		},
+	)
+	require.NoError(t, mp.Insert(ctx.WithPriority(txs[0].priority), txs[0]))
+	require.ErrorIs(t, mp.Insert(ctx.WithPriority(txs[2].priority), txs[2]), mempool.ErrMempoolTxMaxCapacity)
+	require.Equal(t, 1, mp.CountTx())
+}
+
+func TestNextSenderTx_MinFeeBumpTxReplacement(t *testing.T) {
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 1)
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	sa := accounts[0].Address
+
+	mp := mempool.NewPriorityMempool(
+		mempool.PriorityNonceMempoolConfig[int64]{
+			TxPriority:    mempool.NewDefaultTxPriority(),
+			TxReplacement: mempool.NewMinFeeBumpTxReplacement[int64](10),
+			MaxTx:         1,
+		},
+	)
+
+	fee := func(amounts ...int64) sdk.Coins {
+		coins := sdk.NewCoins()
+		for i, amt := range amounts {
+			coins = coins.Add(sdk.NewInt64Coin(fmt.Sprintf("denom%d", i), amt))
+		}
+		return coins
+	}
+
+	txs := []testFeeTx{
+		{testTx: testTx{id: 0, nonce: 1, address: sa}, fee: fee(100, 50)},
+		{testTx: testTx{id: 1, nonce: 1, address: sa}, fee: fee(109, 100)}, // first denom bumped less than 10%
+		{testTx: testTx{id: 2, nonce: 1, address: sa}, fee: fee(200)},      // second denom not paid
+		{testTx: testTx{id: 3, nonce: 1, address: sa}, fee: fee(110, 55)},  // exactly 10% more
+	}
+
+	require.NoError(t, mp.Insert(ctx, txs[0]))
+	require.Error(t, mp.Insert(ctx, txs[1]))
+	require.Error(t, mp.Insert(ctx, txs[2]))
+	require.Error(t, mp.Insert(ctx, txs[0].testTx), "txs without fees are never replacements")
+	require.NoError(t, mp.Insert(ctx, txs[3]))
+	require.Equal(t, 1, mp.CountTx())
+	require.Equal(t, []int{3}, fetchTxIDs(mp.Select(ctx, nil)))
+}
+
+// testFeeTx is a dummy implementation of FeeTx used for testing.
+type testFeeTx struct {
+	testTx
+	fee sdk.Coins
+}
+
+var _ sdk.FeeTx = testFeeTx{}
+
+func (tx testFeeTx) GetGas() uint64 { return 0 }
+
+func (tx testFeeTx) GetFee() sdk.Coins { return tx.fee }
+
+func (tx testFeeTx) FeePayer() sdk.AccAddress { return tx.address }
+
+func (tx testFeeTx) FeeGranter() sdk.AccAddress { return nil }
+
+// testUnorderedTx is a dummy implementation of TxWithUnordered used for
+// testing, signed with its id as signature.
+type testUnorderedTx struct {
+	testTx
+	timeoutHeight    uint64
+	timeoutTimestamp time.Time
+}
+
+var _ sdk.TxWithUnordered = testUnorderedTx{}
+
+func (tx testUnorderedTx) GetTimeoutHeight() uint64 { return tx.timeoutHeight }
+
+func (tx testUnorderedTx) GetTimeoutTimeStamp() time.Time { return tx.timeoutTimestamp }
+
+func (tx testUnorderedTx) GetUnordered() bool { return true }
+
+func (tx testUnorderedTx) GetSignaturesV2() ([]txsigning.SignatureV2, error) {
+	sigs, err := tx.testTx.GetSignaturesV2()
+	if err != nil {
+		return nil, err
+	}
+
+	sigs[0].Data = &txsigning.SingleSignatureData{Signature: []byte{byte(tx.id)}}
+	return sigs, nil
+}
+
+func TestPriorityNonceMempool_UnorderedTx(t *testing.T) {
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 1)
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	sa := accounts[0].Address
+	now := time.Unix(1_000_000, 0)
+
+	mp := mempool.DefaultPriorityMempool()
+
+	// the sequence and the timeout of unordered txs do not identify them, they
+	// neither replace an ordered tx nor each other
+	txs := []sdk.Tx{
+		testTx{id: 0, priority: 10, nonce: 0, address: sa},
+		testUnorderedTx{testTx: testTx{id: 1, priority: 10, nonce: 0, address: sa}, timeoutTimestamp: now},
+		testUnorderedTx{testTx: testTx{id: 2, priority: 10, nonce: 0, address: sa}, timeoutTimestamp: now},
+		testUnorderedTx{testTx: testTx{id: 3, priority: 10, nonce: 1, address: sa}, timeoutHeight: 1},
+		testTx{id: 4, priority: 10, nonce: 1, address: sa},
+	}
+	for _, tx := range txs {
+		require.NoError(t, mp.Insert(ctx, tx))
+	}
+	require.Equal(t, 5, mp.CountTx())
+	require.ElementsMatch(t, []int{0, 1, 2, 3, 4}, fetchTxIDs(mp.Select(ctx, nil)))
+
+	// the same unordered tx is only inserted once
+	require.NoError(t, mp.Insert(ctx, txs[2]))
+	require.Equal(t, 5, mp.CountTx())
+
+	require.NoError(t, mp.Remove(txs[2]))
+	require.ElementsMatch(t, []int{0, 1, 3, 4}, fetchTxIDs(mp.Select(ctx, nil)))
+}
+
+func fetchTxIDs(iterator mempool.Iterator) []int {
+	var ids []int
+	for iterator != nil {
+		switch tx := iterator.Tx().(type) {
+		case testTx:
+			ids = append(ids, tx.id)
+		case testFeeTx:
+			ids = append(ids, tx.id)
+		case testUnorderedTx:
+			ids = append(ids, tx.id)
+		}
+		iterator = iterator.Next()
+	}
+	return ids
+}
@@ -0,0 +1,114 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// RecheckFn re-validates a transaction remaining in the mempool against the
+// state of the latest committed block. A non-nil error causes the transaction
+// to be removed from the mempool.
+type RecheckFn func(ctx context.Context, tx sdk.Tx) error
+
+// RecheckMempool defines a mempool whose transactions can be re-validated once
+// a block is committed, evicting the transactions that became invalid, e.g.
+// because their sequence became stale or their fee payer ran out of funds.
+// BaseApp runs a recheck pass after every Commit when its mempool implements
+// this interface.
+type RecheckMempool interface {
+	Mempool
+
+	// Recheck removes the expired transactions of the mempool, as defined by its
+	// TxTTL, and the transactions for which fn returns an error. Transactions are
+	// checked in the mempool's selection order, so a sender's transactions are
+	// checked in nonce order. fn may be nil in which case only expired
+	// transactions are removed. Recheck returns the number of removed
+	// transactions.
+	Recheck(ctx context.Context, fn RecheckFn) (int, error)
+}
+
+// TxTTL defines for how long a transaction may stay in the mempool before it
+// is removed by a recheck pass. Both limits are optional, a zero value
+// disables the corresponding limit.
+type TxTTL struct {
+	// Blocks is the number of committed blocks after which a transaction expires,
+	// counted from the block height the transaction was inserted at.
+	Blocks int64
+
+	// Duration is the time after which a transaction expires, measured using the
+	// block time of the block the transaction was inserted at and of the latest
+	// committed block.
+	Duration time.Duration
+}
+
+// enabled returns true if at least one of the limits is set.
+func (ttl TxTTL) enabled() bool {
+	return ttl.Blocks > 0 || ttl.Duration > 0
+}
+
+// expired returns true if a transaction inserted at in has expired at ctx.
+func (ttl TxTTL) expired(ctx sdk.Context, in insertion) bool {
+	if ttl.Blocks > 0 && ctx.BlockHeight()-in.height >= ttl.Blocks {
+		return true
+	}
+
+	return ttl.Duration > 0 && !ctx.BlockTime().Before(in.time.Add(ttl.Duration))
+}
+
+// insertion records the block height and time at which a transaction was
+// inserted into the mempool.
+type insertion struct {
+	height int64
+	time   time.Time
+}
+
+func newInsertion(ctx context.Context) insertion {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	return insertion{height: sdkCtx.BlockHeight(), time: sdkCtx.BlockTime()}
+}
+
+// recheck implements a recheck pass over any mempool. The transactions are
+// collected first, as it is not safe to remove transactions while iterating,
+// then the transactions for which expired returns true or fn returns an error
+// are removed.
+func recheck(ctx context.Context, mp Mempool, fn RecheckFn, expired func(tx sdk.Tx) bool) (int, error) {
+	var txs []sdk.Tx
+	for iterator := mp.Select(ctx, nil); iterator != nil; iterator = iterator.Next() {
+		txs = append(txs, iterator.Tx())
+	}
+
+	removed := 0
+	for _, tx := range txs {
+		if !expired(tx) && (fn == nil || fn(ctx, tx) == nil) {
+			continue
+		}
+
+		if err := mp.Remove(tx); err != nil && !errors.Is(err, ErrTxNotFound) {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// txSenderNonce returns the sender and nonce identifying a transaction in the
+// mempool, derived from the transaction's first signature.
+func txSenderNonce(tx sdk.Tx) (string, uint64, error) {
+	sigs, err := tx.(signing.SigVerifiableTx).GetSignaturesV2()
+	if err != nil {
+		return "", 0, err
+	}
+	if len(sigs) == 0 {
+		return "", 0, fmt.Errorf("tx must have at least one signer")
+	}
+
+	sender, nonce := sigSenderNonce(tx, sigs[0])
+	return sender, nonce, nil
+}
@@ -0,0 +1,125 @@
+package mempool_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func TestRecheck(t *testing.T) {
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 2)
+	sa := accounts[0].Address
+	sb := accounts[1].Address
+
+	newMempools := func(ttl mempool.TxTTL) map[string]mempool.RecheckMempool {
+		return map[string]mempool.RecheckMempool{
+			"priority nonce": mempool.NewPriorityMempool(mempool.PriorityNonceMempoolConfig[int64]{
+				TxPriority: mempool.NewDefaultTxPriority(),
+				TxTTL:      ttl,
+			}),
+			"sender nonce": mempool.NewSenderNonceMempool(mempool.SenderNonceTTLOpt(ttl)),
+		}
+	}
+
+	now := time.Now().UTC()
+	ctxAt := func(height int64, blockTime time.Time) sdk.Context {
+		return sdk.NewContext(nil, cmtproto.Header{Height: height, Time: blockTime}, false, log.NewNopLogger())
+	}
+
+	t.Run("ttl by blocks", func(t *testing.T) {
+		for name, mp := range newMempools(mempool.TxTTL{Blocks: 2}) {
+			t.Run(name, func(t *testing.T) {
+				require.NoError(t, mp.Insert(ctxAt(1, now), testTx{id: 0, nonce: 0, address: sa}))
+				require.NoError(t, mp.Insert(ctxAt(2, now), testTx{id: 1, nonce: 0, address: sb}))
+
+				removed, err := mp.Recheck(ctxAt(2, now), nil)
+				require.NoError(t, err)
+				require.Equal(t, 0, removed)
+				require.Equal(t, 2, mp.CountTx())
+
+				removed, err = mp.Recheck(ctxAt(3, now), nil)
+				require.NoError(t, err)
+				require.Equal(t, 1, removed)
+				require.Equal(t, []int{1}, fetchTxIDs(mp.Select(ctxAt(3, now), nil)))
+
+				removed, err = mp.Recheck(ctxAt(4, now), nil)
+				require.NoError(t, err)
+				require.Equal(t, 1, removed)
+				require.Equal(t, 0, mp.CountTx())
+			})
+		}
+	})
+
+	t.Run("ttl by duration", func(t *testing.T) {
+		for name, mp := range newMempools(mempool.TxTTL{Duration: time.Minute}) {
+			t.Run(name, func(t *testing.T) {
+				require.NoError(t, mp.Insert(ctxAt(1, now), testTx{id: 0, nonce: 0, address: sa}))
+				require.NoError(t, mp.Insert(ctxAt(1, now.Add(30*time.Second)), testTx{id: 1, nonce: 0, address: sb}))
+
+				removed, err := mp.Recheck(ctxAt(100, now.Add(59*time.Second)), nil)
+				require.NoError(t, err)
+				require.Equal(t, 0, removed)
+
+				removed, err = mp.Recheck(ctxAt(100, now.Add(time.Minute)), nil)
+				require.NoError(t, err)
+				require.Equal(t, 1, removed)
+				require.Equal(t, []int{1}, fetchTxIDs(mp.Select(ctxAt(100, now), nil)))
+			})
+		}
+	})
+
+	t.Run("recheck function", func(t *testing.T) {
+		for name, mp := range newMempools(mempool.TxTTL{}) {
+			t.Run(name, func(t *testing.T) {
+				ctx := ctxAt(1, now)
+				txs := []testTx{
+					{id: 0, nonce: 0, address: sa},
+					{id: 1, nonce: 1, address: sa},
+					{id: 2, nonce: 0, address: sb},
+				}
+				for _, tx := range txs {
+					require.NoError(t, mp.Insert(ctx, tx))
+				}
+
+				// without a recheck function and expiry, nothing is removed.
+				removed, err := mp.Recheck(ctx, nil)
+				require.NoError(t, err)
+				require.Equal(t, 0, removed)
+
+				// a sender's txs are rechecked in nonce order.
+				var checked []int
+				removed, err = mp.Recheck(ctx, func(_ context.Context, tx sdk.Tx) error {
+					checked = append(checked, tx.(testTx).id)
+					if tx.(testTx).address.Equals(sa) {
+						return errors.New("insufficient funds")
+					}
+					return nil
+				})
+				require.NoError(t, err)
+				require.Equal(t, 2, removed)
+				require.ElementsMatch(t, []int{0, 1, 2}, checked)
+				require.Less(t, indexOf(checked, 0), indexOf(checked, 1))
+				require.Equal(t, []int{2}, fetchTxIDs(mp.Select(ctx, nil)))
+			})
+		}
+	})
+}
+
+func indexOf(ids []int, id int) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -14,8 +14,9 @@ import (
 )
 
 var (
-	_ Mempool  = (*SenderNonceMempool)(nil)
-	_ Iterator = (*senderNonceMempoolIterator)(nil)
+	_ Mempool        = (*SenderNonceMempool)(nil)
+	_ RecheckMempool = (*SenderNonceMempool)(nil)
+	_ Iterator       = (*senderNonceMempoolIterator)(nil)
 )
 
 var DefaultMaxTx = 0
@@ -35,6 +36,8 @@ type SenderNonceMempool struct {
 	rnd        *rand.Rand
 	maxTx      int
 	existingTx map[txKey]bool
+	ttl        TxTTL
+	insertions map[txKey]insertion
 }
 
 type SenderNonceOptions func(*SenderNonceMempool)
@@ -53,6 +56,7 @@ func NewSenderNonceMempool(opts ...SenderNonceOptions) *SenderNonceMempool {
 		senders:    senderMap,
 		maxTx:      DefaultMaxTx,
 		existingTx: existingTx,
+		insertions: make(map[txKey]insertion),
 	}
 
 	var seed int64
@@ -95,6 +99,19 @@ func SenderNonceMaxTxOpt(maxTx int) SenderNonceOptions {
 	}
 }
 
+// SenderNonceTTLOpt Option To set for how long a tx may stay in the mempool
+// before it is removed by Recheck when calling the constructor
+// NewSenderNonceMempool.
+//
+// Example:
+//
+//	NewSenderNonceMempool(SenderNonceTTLOpt(TxTTL{Blocks: 10}))
+func SenderNonceTTLOpt(ttl TxTTL) SenderNonceOptions {
+	return func(snp *SenderNonceMempool) {
+		snp.ttl = ttl
+	}
+}
+
 func (snm *SenderNonceMempool) setSeed(seed int64) {
 	s1 := rand.NewSource(seed)
 	snm.rnd = rand.New(s1) //#nosec // math/rand is seeded from crypto/rand by default
@@ -115,7 +132,7 @@ func (snm *SenderNonceMempool) NextSenderTx(sender string) sdk.Tx {
 
 // Insert adds a tx to the mempool. It returns an error if the tx does not have
 // at least one signer. Note, priority is ignored.
-func (snm *SenderNonceMempool) Insert(_ context.Context, tx sdk.Tx) error {
+func (snm *SenderNonceMempool) Insert(ctx context.Context, tx sdk.Tx) error {
 	if snm.maxTx > 0 && snm.CountTx() >= snm.maxTx {
 		return ErrMempoolTxMaxCapacity
 	}
@@ -132,8 +149,7 @@ func (snm *SenderNonceMempool) Insert(_ context.Context, tx sdk.Tx) error {
 	}
 
 	sig := sigs[0]
-	sender := sdk.AccAddress(sig.PubKey.Address()).String()
-	nonce := sig.Sequence
+	sender, nonce := sigSenderNonce(tx, sig)
 
 	senderTxs, found := snm.senders[sender]
 	if !found {
@@ -146,6 +162,10 @@ func (snm *SenderNonceMempool) Insert(_ context.Context, tx sdk.Tx) error {
 	key := txKey{nonce: nonce, address: sender}
 	snm.existingTx[key] = true
 
+	if snm.ttl.enabled() {
+		snm.insertions[key] = newInsertion(ctx)
+	}
+
 	return nil
 }
 
@@ -199,8 +219,7 @@ func (snm *SenderNonceMempool) Remove(tx sdk.Tx) error {
 	}
 
 	sig := sigs[0]
-	sender := sdk.AccAddress(sig.PubKey.Address()).String()
-	nonce := sig.Sequence
+	sender, nonce := sigSenderNonce(tx, sig)
 
 	senderTxs, found := snm.senders[sender]
 	if !found {
@@ -218,10 +237,34 @@ func (snm *SenderNonceMempool) Remove(tx sdk.Tx) error {
 
 	key := txKey{nonce: nonce, address: sender}
 	delete(snm.existingTx, key)
+	delete(snm.insertions, key)
 
 	return nil
 }
 
+// Recheck removes the expired txs and the txs for which fn returns an error,
+// returning the number of removed txs. A sender's txs are checked in nonce
+// order.
+func (snm *SenderNonceMempool) Recheck(ctx context.Context, fn RecheckFn) (int, error) {
+	if fn == nil && !snm.ttl.enabled() {
+		return 0, nil
+	}
+
+	return recheck(ctx, snm, fn, func(tx sdk.Tx) bool {
+		if !snm.ttl.enabled() {
+			return false
+		}
+
+		sender, nonce, err := txSenderNonce(tx)
+		if err != nil {
+			return false
+		}
+
+		in, ok := snm.insertions[txKey{nonce: nonce, address: sender}]
+		return ok && snm.ttl.expired(sdk.UnwrapSDKContext(ctx), in)
+	})
+}
+
 type senderNonceMempoolIterator struct {
 	rnd           *rand.Rand
 	currentTx     *skiplist.Element
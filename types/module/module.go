@@ -35,6 +35,7 @@ import (
 	"fmt"
 	"sort"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/genesis"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -186,6 +187,14 @@ type HasServices interface {
 	RegisterServices(Configurator)
 }
 
+// HasCollectionsSchema is the interface for modules whose state is described by a
+// collections.Schema. It allows clients to list the collections of the module and to
+// decode the raw key value pairs of its store without module specific decoders. An
+// empty schema, without any collection, is ignored.
+type HasCollectionsSchema interface {
+	CollectionsSchema() collections.Schema
+}
+
 // HasConsensusVersion is the interface for declaring a module consensus version.
 type HasConsensusVersion interface {
 	// ConsensusVersion is a sequence number for state-breaking change of the
@@ -353,6 +362,10 @@ func (m *Manager) SetOrderBeginBlockers(moduleNames ...string) {
 	m.assertNoForgottenModules("SetOrderBeginBlockers", moduleNames,
 		func(moduleName string) bool {
 			module := m.Modules[moduleName]
+			if _, hasBeginBlock := module.(appmodule.HasBeginBlocker); hasBeginBlock {
+				return !hasBeginBlock
+			}
+
 			_, hasBeginBlock := module.(BeginBlockAppModule)
 			return !hasBeginBlock
 		})
@@ -0,0 +1,26 @@
+package query
+
+import "bytes"
+
+// ProofOpQueryProofs is the type of the ProofOp holding the proto encoded
+// QueryProofs of a gRPC query made through ABCI with Prove set.
+const ProofOpQueryProofs = "cosmos:query_proofs"
+
+// Value returns the value of key in the store storeKey, which is nil if the
+// key is absent and empty but not nil if its value is empty, and whether the
+// proofs hold a proof for the key.
+func (qp *QueryProofs) Value(storeKey string, key []byte) ([]byte, bool) {
+	for _, p := range qp.Proofs {
+		if p.StoreKey == storeKey && bytes.Equal(p.Key, key) {
+			if !p.Exists {
+				return nil, true
+			}
+			if p.Value == nil {
+				return []byte{}, true
+			}
+			return p.Value, true
+		}
+	}
+
+	return nil, false
+}
@@ -13,15 +13,19 @@ import (
 	signing "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -364,6 +368,23 @@ type TxBody struct {
 	// timeout is the block height after which this transaction will not
 	// be processed by the chain
 	TimeoutHeight uint64 `protobuf:"varint,3,opt,name=timeout_height,json=timeoutHeight,proto3" json:"timeout_height,omitempty"`
+	// unordered, when set to true, indicates that the transaction signer(s)
+	// intend for the transaction to be evaluated and executed in an un-ordered
+	// fashion. Specifically, the account's sequence will NOT be checked or
+	// incremented, which allows for fire-and-forget as well as concurrent
+	// transaction execution.
+	//
+	// Note, when set to true, the timeout_height and/or timeout_timestamp must
+	// be set, within the bounds defined by the chain, and the transaction is
+	// deduplicated by its hash until it times out.
+	//
+	// Since: cosmos-sdk 0.48
+	Unordered bool `protobuf:"varint,4,opt,name=unordered,proto3" json:"unordered,omitempty"`
+	// timeout_timestamp is the block time after which this transaction will not
+	// be processed by the chain.
+	//
+	// Since: cosmos-sdk 0.48
+	TimeoutTimestamp *time.Time `protobuf:"bytes,5,opt,name=timeout_timestamp,json=timeoutTimestamp,proto3,stdtime" json:"timeout_timestamp,omitempty"`
 	// extension_options are arbitrary options that can be added by chains
 	// when the default options are not sufficient. If any of these are present
 	// and can't be handled, the transaction will be rejected
@@ -428,6 +449,20 @@ func (m *TxBody) GetTimeoutHeight() uint64 {
 	return 0
 }
 
+func (m *TxBody) GetUnordered() bool {
+	if m != nil {
+		return m.Unordered
+	}
+	return false
+}
+
+func (m *TxBody) GetTimeoutTimestamp() *time.Time {
+	if m != nil {
+		return m.TimeoutTimestamp
+	}
+	return nil
+}
+
 func (m *TxBody) GetExtensionOptions() []*types.Any {
 	if m != nil {
 		return m.ExtensionOptions
@@ -1020,71 +1055,74 @@ func init() {
 func init() { proto.RegisterFile("cosmos/tx/v1beta1/tx.proto", fileDescriptor_96d1575ffde80842) }
 
 var fileDescriptor_96d1575ffde80842 = []byte{
-	// 1015 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xcf, 0x6f, 0x1b, 0x45,
-	0x14, 0xf6, 0x66, 0x6d, 0xc7, 0x7e, 0x4d, 0xda, 0x74, 0x14, 0x21, 0xc7, 0x51, 0xdd, 0xe0, 0xaa,
-	0xe0, 0x4b, 0x76, 0xd3, 0xf4, 0x40, 0x41, 0x08, 0xb0, 0x1b, 0xaa, 0x54, 0xa5, 0x20, 0x4d, 0x72,
-	0xea, 0x65, 0x35, 0x5e, 0x4f, 0xd6, 0xa3, 0x7a, 0x67, 0x96, 0x9d, 0x59, 0xb0, 0xff, 0x08, 0xa4,
-	0x0a, 0x09, 0x71, 0xe1, 0xc0, 0x99, 0x33, 0x7f, 0x44, 0x4f, 0xa8, 0xe2, 0xc4, 0x09, 0xaa, 0xe4,
-	0x88, 0xc4, 0xbf, 0x00, 0x9a, 0xd9, 0xd9, 0x4d, 0x5a, 0x5c, 0x1b, 0x04, 0xea, 0x69, 0xe7, 0xc7,
-	0xf7, 0xbe, 0xf9, 0xde, 0xbc, 0x6f, 0xdf, 0x40, 0x3b, 0x14, 0x32, 0x16, 0xd2, 0x57, 0x53, 0xff,
-	0x8b, 0x5b, 0x43, 0xaa, 0xc8, 0x2d, 0x5f, 0x4d, 0xbd, 0x24, 0x15, 0x4a, 0xa0, 0xab, 0xf9, 0x9e,
-	0xa7, 0xa6, 0x9e, 0xdd, 0x6b, 0x6f, 0x46, 0x22, 0x12, 0x66, 0xd7, 0xd7, 0xa3, 0x1c, 0xd8, 0xde,
-	0xb5, 0x24, 0x61, 0x3a, 0x4b, 0x94, 0xf0, 0xe3, 0x6c, 0xa2, 0x98, 0x64, 0x51, 0xc9, 0x58, 0x2c,
-	0x58, 0x78, 0xc7, 0xc2, 0x87, 0x44, 0xd2, 0x12, 0x13, 0x0a, 0xc6, 0xed, 0xfe, 0xdb, 0xe7, 0x9a,
-	0x24, 0x8b, 0x38, 0xe3, 0xe7, 0x4c, 0x76, 0x6e, 0x81, 0x5b, 0x91, 0x10, 0xd1, 0x84, 0xfa, 0x66,
-	0x36, 0xcc, 0x4e, 0x7c, 0xc2, 0x67, 0xc5, 0x56, 0xce, 0x11, 0xe4, 0x5a, 0x6d, 0x22, 0x66, 0xd2,
-	0xfd, 0xca, 0x81, 0x95, 0xe3, 0x29, 0xda, 0x85, 0xea, 0x50, 0x8c, 0x66, 0x2d, 0x67, 0xc7, 0xe9,
-	0x5d, 0xda, 0xdf, 0xf2, 0xfe, 0x96, 0xac, 0x77, 0x3c, 0x1d, 0x88, 0xd1, 0x0c, 0x1b, 0x18, 0xba,
-	0x03, 0x4d, 0x92, 0xa9, 0x71, 0xc0, 0xf8, 0x89, 0x68, 0xad, 0x98, 0x98, 0xed, 0x39, 0x31, 0xfd,
-	0x4c, 0x8d, 0xef, 0xf3, 0x13, 0x81, 0x1b, 0xc4, 0x8e, 0x50, 0x07, 0x40, 0xcb, 0x26, 0x2a, 0x4b,
-	0xa9, 0x6c, 0xb9, 0x3b, 0x6e, 0x6f, 0x0d, 0x5f, 0x58, 0xe9, 0x72, 0xa8, 0x1d, 0x4f, 0x31, 0xf9,
-	0x12, 0x5d, 0x03, 0xd0, 0x47, 0x05, 0xc3, 0x99, 0xa2, 0xd2, 0xe8, 0x5a, 0xc3, 0x4d, 0xbd, 0x32,
-	0xd0, 0x0b, 0xe8, 0x2d, 0xb8, 0x52, 0x2a, 0xb0, 0x98, 0x15, 0x83, 0x59, 0x2f, 0x8e, 0xca, 0x71,
-	0xcb, 0xce, 0xfb, 0xda, 0x81, 0xd5, 0x23, 0x16, 0xf1, 0x03, 0x11, 0xfe, 0x5f, 0x47, 0x6e, 0x41,
-	0x23, 0x1c, 0x13, 0xc6, 0x03, 0x36, 0x6a, 0xb9, 0x3b, 0x4e, 0xaf, 0x89, 0x57, 0xcd, 0xfc, 0xfe,
-	0x08, 0xdd, 0x84, 0xcb, 0x24, 0x0c, 0x45, 0xc6, 0x55, 0xc0, 0xb3, 0x78, 0x48, 0xd3, 0x56, 0x75,
-	0xc7, 0xe9, 0x55, 0xf1, 0xba, 0x5d, 0xfd, 0xd4, 0x2c, 0x76, 0xff, 0x70, 0x60, 0xc3, 0x8a, 0x3a,
-	0x60, 0x29, 0x0d, 0x55, 0x3f, 0x9b, 0x2e, 0x53, 0x77, 0x1b, 0x20, 0xc9, 0x86, 0x13, 0x16, 0x06,
-	0x8f, 0xe9, 0xcc, 0xd6, 0x64, 0xd3, 0xcb, 0x3d, 0xe1, 0x15, 0x9e, 0xf0, 0xfa, 0x7c, 0x86, 0x9b,
-	0x39, 0xee, 0x01, 0x9d, 0xfd, 0x77, 0xa9, 0xa8, 0x0d, 0x0d, 0x49, 0x3f, 0xcf, 0x28, 0x0f, 0x69,
-	0xab, 0x66, 0x00, 0xe5, 0x1c, 0xf5, 0xc0, 0x55, 0x2c, 0x69, 0xd5, 0x8d, 0x96, 0x37, 0xe6, 0x79,
-	0x8a, 0x25, 0x58, 0x43, 0xba, 0xdf, 0xac, 0x40, 0x3d, 0x37, 0x18, 0xda, 0x83, 0x46, 0x4c, 0xa5,
-	0x24, 0x91, 0x49, 0xd2, 0x7d, 0x65, 0x16, 0x25, 0x0a, 0x21, 0xa8, 0xc6, 0x34, 0xce, 0x7d, 0xd8,
-	0xc4, 0x66, 0xac, 0xd5, 0x2b, 0x16, 0x53, 0x91, 0xa9, 0x60, 0x4c, 0x59, 0x34, 0x56, 0x26, 0xbd,
-	0x2a, 0x5e, 0xb7, 0xab, 0x87, 0x66, 0x11, 0x0d, 0xe0, 0x2a, 0x9d, 0x2a, 0xca, 0x25, 0x13, 0x3c,
-	0x10, 0x89, 0x62, 0x82, 0xcb, 0xd6, 0x9f, 0xab, 0x0b, 0x8e, 0xdd, 0x28, 0xf1, 0x9f, 0xe5, 0x70,
-	0xf4, 0x08, 0x3a, 0x5c, 0xf0, 0x20, 0x4c, 0x99, 0x62, 0x21, 0x99, 0x04, 0x73, 0x08, 0xaf, 0x2c,
-	0x20, 0xdc, 0xe6, 0x82, 0xdf, 0xb5, 0xb1, 0x1f, 0xbf, 0xc4, 0xdd, 0xfd, 0xde, 0x81, 0x46, 0xf1,
-	0x13, 0xa1, 0x8f, 0x60, 0x4d, 0x1b, 0x97, 0xa6, 0xc6, 0x81, 0xc5, 0xed, 0x5c, 0x9b, 0x73, 0xaf,
-	0x47, 0x06, 0x66, 0xfe, 0xbc, 0x4b, 0xb2, 0x1c, 0x4b, 0x5d, 0x90, 0x13, 0x4a, 0xad, 0x39, 0xe6,
-	0x15, 0xe4, 0x1e, 0xa5, 0x58, 0x43, 0x8a, 0xd2, 0xb9, 0xcb, 0x4b, 0xf7, 0xad, 0x03, 0x70, 0x7e,
-	0xde, 0x4b, 0x36, 0x74, 0xfe, 0x99, 0x0d, 0xef, 0x40, 0x33, 0x16, 0x23, 0xba, 0xac, 0x9d, 0x3c,
-	0x14, 0x23, 0x9a, 0xb7, 0x93, 0xd8, 0x8e, 0x5e, 0xb0, 0x9f, 0xfb, 0xa2, 0xfd, 0xba, 0xcf, 0x57,
-	0xa0, 0x51, 0x84, 0xa0, 0xf7, 0xa1, 0x2e, 0x19, 0x8f, 0x26, 0xd4, 0x6a, 0xea, 0x2e, 0xe0, 0xf7,
-	0x8e, 0x0c, 0xf2, 0xb0, 0x82, 0x6d, 0x0c, 0x7a, 0x17, 0x6a, 0xa6, 0x6d, 0x5b, 0x71, 0x6f, 0x2e,
-	0x0a, 0x7e, 0xa8, 0x81, 0x87, 0x15, 0x9c, 0x47, 0xb4, 0xfb, 0x50, 0xcf, 0xe9, 0xd0, 0x3b, 0x50,
-	0xd5, 0xba, 0x8d, 0x80, 0xcb, 0xfb, 0x37, 0x2e, 0x70, 0x14, 0x8d, 0xfc, 0x62, 0xfd, 0x34, 0x1f,
-	0x36, 0x01, 0xed, 0x27, 0x0e, 0xd4, 0x0c, 0x2b, 0x7a, 0x00, 0x8d, 0x21, 0x53, 0x24, 0x4d, 0x49,
-	0x71, 0xb7, 0x7e, 0x41, 0x93, 0x3f, 0x37, 0x5e, 0xf9, 0xba, 0x14, 0x5c, 0x77, 0x45, 0x9c, 0x90,
-	0x50, 0x0d, 0x98, 0xea, 0xeb, 0x30, 0x5c, 0x12, 0xa0, 0xf7, 0x00, 0xca, 0x5b, 0xd7, 0xad, 0xcc,
-	0x5d, 0x76, 0xed, 0xcd, 0xe2, 0xda, 0xe5, 0xa0, 0x06, 0xae, 0xcc, 0xe2, 0xee, 0xef, 0x0e, 0xb8,
-	0xf7, 0x28, 0x45, 0x21, 0xd4, 0x49, 0xac, 0xbb, 0x82, 0x35, 0x65, 0xf9, 0x80, 0xe8, 0x57, 0xed,
-	0x82, 0x14, 0xc6, 0x07, 0x7b, 0x4f, 0x7f, 0xbd, 0x5e, 0xf9, 0xe1, 0xb7, 0xeb, 0xbd, 0x88, 0xa9,
-	0x71, 0x36, 0xf4, 0x42, 0x11, 0xfb, 0xc5, 0x8b, 0x69, 0x3e, 0xbb, 0x72, 0xf4, 0xd8, 0x57, 0xb3,
-	0x84, 0x4a, 0x13, 0x20, 0xb1, 0xa5, 0x46, 0xdb, 0xd0, 0x8c, 0x88, 0x0c, 0x26, 0x2c, 0x66, 0xca,
-	0x14, 0xa2, 0x8a, 0x1b, 0x11, 0x91, 0x9f, 0xe8, 0x39, 0xf2, 0xa0, 0x96, 0x90, 0x19, 0x4d, 0xf3,
-	0x36, 0x36, 0x68, 0xfd, 0xfc, 0xe3, 0xee, 0xa6, 0xd5, 0xd0, 0x1f, 0x8d, 0x52, 0x2a, 0xe5, 0x91,
-	0x4a, 0x19, 0x8f, 0x70, 0x0e, 0x43, 0xfb, 0xb0, 0x1a, 0xa5, 0x84, 0x2b, 0xdb, 0xd7, 0x16, 0x45,
-	0x14, 0xc0, 0xee, 0x77, 0x0e, 0xb8, 0xc7, 0x2c, 0x79, 0x3d, 0xd9, 0xee, 0x41, 0x5d, 0xb1, 0x24,
-	0xa1, 0x69, 0xde, 0xd7, 0x16, 0xe8, 0xb3, 0xb8, 0xee, 0x4f, 0x0e, 0xac, 0xf7, 0xb3, 0x69, 0xfe,
-	0x33, 0x1e, 0x10, 0x45, 0x74, 0x92, 0x24, 0x87, 0x1a, 0xb7, 0x2c, 0x4c, 0xd2, 0x02, 0xd1, 0x07,
-	0xd0, 0xd0, 0x76, 0x0c, 0x46, 0x22, 0xb4, 0x6e, 0xbf, 0xf1, 0x8a, 0x0e, 0x73, 0xf1, 0x75, 0xc2,
-	0xab, 0xd2, 0x3e, 0xa2, 0x85, 0xcb, 0xdd, 0x7f, 0xe9, 0x72, 0xb4, 0x01, 0xae, 0x64, 0x91, 0xa9,
-	0xc6, 0x1a, 0xd6, 0xc3, 0xc1, 0x87, 0x4f, 0x4f, 0x3b, 0xce, 0xb3, 0xd3, 0x8e, 0xf3, 0xfc, 0xb4,
-	0xe3, 0x3c, 0x39, 0xeb, 0x54, 0x9e, 0x9d, 0x75, 0x2a, 0xbf, 0x9c, 0x75, 0x2a, 0x8f, 0x6e, 0x2e,
-	0xbf, 0x4e, 0x5f, 0x4d, 0x87, 0x75, 0xd3, 0x70, 0x6e, 0xff, 0x15, 0x00, 0x00, 0xff, 0xff, 0x4d,
-	0x26, 0x62, 0x70, 0xd8, 0x09, 0x00, 0x00,
+	// 1065 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0x41, 0x6f, 0x1b, 0x45,
+	0x14, 0xf6, 0x7a, 0x6d, 0xc7, 0x7e, 0x4d, 0xda, 0x74, 0x14, 0xa1, 0x8d, 0x43, 0x9d, 0xe0, 0xaa,
+	0xe0, 0x4b, 0xd6, 0x69, 0x7a, 0xa0, 0x20, 0x04, 0xd8, 0x0d, 0x55, 0xaa, 0x12, 0x90, 0x26, 0x39,
+	0xf5, 0xb2, 0x1a, 0xef, 0x4e, 0xd6, 0xa3, 0x7a, 0x67, 0x96, 0x9d, 0x59, 0xb0, 0xaf, 0xdc, 0x91,
+	0x22, 0x2e, 0x5c, 0x38, 0x70, 0xe6, 0xcc, 0x8f, 0xe8, 0x09, 0x55, 0x9c, 0x38, 0xd1, 0x2a, 0x39,
+	0x22, 0xf1, 0x17, 0x40, 0x3b, 0x3b, 0xbb, 0x49, 0xd3, 0x24, 0x06, 0x81, 0x38, 0xed, 0xce, 0x9b,
+	0xef, 0x7d, 0xf3, 0xbd, 0x99, 0x6f, 0xe6, 0x41, 0xdb, 0x17, 0x32, 0x12, 0xb2, 0xaf, 0xa6, 0xfd,
+	0x2f, 0xef, 0x8e, 0xa8, 0x22, 0x77, 0xfb, 0x6a, 0xea, 0xc6, 0x89, 0x50, 0x02, 0xdd, 0xcc, 0xe7,
+	0x5c, 0x35, 0x75, 0xcd, 0x5c, 0x7b, 0x25, 0x14, 0xa1, 0xd0, 0xb3, 0xfd, 0xec, 0x2f, 0x07, 0xb6,
+	0x37, 0x0d, 0x89, 0x9f, 0xcc, 0x62, 0x25, 0xfa, 0x51, 0x3a, 0x51, 0x4c, 0xb2, 0xb0, 0x64, 0x2c,
+	0x02, 0x06, 0xde, 0x31, 0xf0, 0x11, 0x91, 0xb4, 0xc4, 0xf8, 0x82, 0x71, 0x33, 0xff, 0xce, 0xa9,
+	0x26, 0xc9, 0x42, 0xce, 0xf8, 0x29, 0x93, 0x19, 0x1b, 0xe0, 0x6a, 0x28, 0x44, 0x38, 0xa1, 0x7d,
+	0x3d, 0x1a, 0xa5, 0x87, 0x7d, 0xc2, 0x67, 0x66, 0x6a, 0xfd, 0xfc, 0x94, 0x62, 0x11, 0x95, 0x8a,
+	0x44, 0x71, 0x91, 0x9b, 0x2f, 0xe2, 0xe5, 0xc5, 0x98, 0x4a, 0xf5, 0xa0, 0xfb, 0x8d, 0x05, 0xd5,
+	0x83, 0x29, 0xda, 0x84, 0xda, 0x48, 0x04, 0x33, 0xc7, 0xda, 0xb0, 0x7a, 0xd7, 0xb6, 0x57, 0xdd,
+	0xd7, 0x76, 0xc3, 0x3d, 0x98, 0x0e, 0x45, 0x30, 0xc3, 0x1a, 0x86, 0xee, 0x43, 0x8b, 0xa4, 0x6a,
+	0xec, 0x31, 0x7e, 0x28, 0x9c, 0xaa, 0xce, 0x59, 0xbb, 0x20, 0x67, 0x90, 0xaa, 0xf1, 0x23, 0x7e,
+	0x28, 0x70, 0x93, 0x98, 0x3f, 0xd4, 0x01, 0xc8, 0xea, 0x22, 0x2a, 0x4d, 0xa8, 0x74, 0xec, 0x0d,
+	0xbb, 0xb7, 0x88, 0xcf, 0x44, 0xba, 0x1c, 0xea, 0x07, 0x53, 0x4c, 0xbe, 0x42, 0xb7, 0x00, 0xb2,
+	0xa5, 0xbc, 0xd1, 0x4c, 0x51, 0xa9, 0x75, 0x2d, 0xe2, 0x56, 0x16, 0x19, 0x66, 0x01, 0xf4, 0x36,
+	0xdc, 0x28, 0x15, 0x18, 0x4c, 0x55, 0x63, 0x96, 0x8a, 0xa5, 0x72, 0xdc, 0xbc, 0xf5, 0xbe, 0xb5,
+	0x60, 0x61, 0x9f, 0x85, 0x7c, 0x47, 0xf8, 0xff, 0xd5, 0x92, 0xab, 0xd0, 0xf4, 0xc7, 0x84, 0x71,
+	0x8f, 0x05, 0x8e, 0xbd, 0x61, 0xf5, 0x5a, 0x78, 0x41, 0x8f, 0x1f, 0x05, 0xe8, 0x0e, 0x5c, 0x27,
+	0xbe, 0x2f, 0x52, 0xae, 0x3c, 0x9e, 0x46, 0x23, 0x9a, 0x38, 0xb5, 0x0d, 0xab, 0x57, 0xc3, 0x4b,
+	0x26, 0xfa, 0x99, 0x0e, 0x76, 0xff, 0xb0, 0x60, 0xd9, 0x88, 0xda, 0x61, 0x09, 0xf5, 0xd5, 0x20,
+	0x9d, 0xce, 0x53, 0x77, 0x0f, 0x20, 0x4e, 0x47, 0x13, 0xe6, 0x7b, 0x4f, 0xe9, 0xcc, 0x9c, 0xc9,
+	0x8a, 0x9b, 0x3b, 0xc3, 0x2d, 0x9c, 0xe1, 0x0e, 0xf8, 0x0c, 0xb7, 0x72, 0xdc, 0x63, 0x3a, 0xfb,
+	0xf7, 0x52, 0x51, 0x1b, 0x9a, 0x92, 0x7e, 0x91, 0x52, 0xee, 0x53, 0xa7, 0xae, 0x01, 0xe5, 0x18,
+	0xf5, 0xc0, 0x56, 0x2c, 0x76, 0x1a, 0x5a, 0xcb, 0x1b, 0x17, 0x79, 0x8a, 0xc5, 0x38, 0x83, 0x74,
+	0xbf, 0xb6, 0xa1, 0x91, 0x1b, 0x0c, 0x6d, 0x41, 0x33, 0xa2, 0x52, 0x92, 0x50, 0x17, 0x69, 0x5f,
+	0x5a, 0x45, 0x89, 0x42, 0x08, 0x6a, 0x11, 0x8d, 0x72, 0x1f, 0xb6, 0xb0, 0xfe, 0xcf, 0xd4, 0x67,
+	0x97, 0x40, 0xa4, 0xca, 0x1b, 0x53, 0x16, 0x8e, 0x95, 0x2e, 0xaf, 0x86, 0x97, 0x4c, 0x74, 0x57,
+	0x07, 0xd1, 0x9b, 0xd0, 0x4a, 0xb9, 0x48, 0x02, 0x9a, 0xd0, 0x40, 0xd7, 0xd7, 0xc4, 0xa7, 0x01,
+	0xb4, 0x07, 0x37, 0x0b, 0x92, 0xf2, 0x46, 0xe9, 0x22, 0xaf, 0x6d, 0xb7, 0x5f, 0xd3, 0x74, 0x50,
+	0x20, 0x86, 0xb5, 0xa3, 0x17, 0xeb, 0x16, 0x5e, 0x36, 0xa9, 0x65, 0x1c, 0x0d, 0xe1, 0x26, 0x9d,
+	0x2a, 0xca, 0x25, 0x13, 0xdc, 0x13, 0xb1, 0x62, 0x82, 0x4b, 0xe7, 0xcf, 0x85, 0x2b, 0x6a, 0x5c,
+	0x2e, 0xf1, 0x9f, 0xe7, 0x70, 0xf4, 0x04, 0x3a, 0x5c, 0x70, 0xcf, 0x4f, 0x98, 0x62, 0x3e, 0x99,
+	0x78, 0x17, 0x10, 0xde, 0xb8, 0x82, 0x70, 0x8d, 0x0b, 0xfe, 0xc0, 0xe4, 0x7e, 0x72, 0x8e, 0xbb,
+	0xfb, 0x83, 0x05, 0xcd, 0xe2, 0xc6, 0xa2, 0x8f, 0x61, 0x31, 0xbb, 0x25, 0x34, 0xd1, 0x76, 0x2f,
+	0x8e, 0xe2, 0xd6, 0x05, 0x87, 0xb8, 0xaf, 0x61, 0xfa, 0x9a, 0x5f, 0x93, 0xe5, 0xbf, 0xcc, 0x4e,
+	0xff, 0x90, 0x52, 0xa7, 0x7a, 0xe9, 0xe9, 0x3f, 0xa4, 0x14, 0x67, 0x90, 0xc2, 0x27, 0xf6, 0x7c,
+	0x9f, 0x7c, 0x67, 0x01, 0x9c, 0xae, 0x77, 0xce, 0xf3, 0xd6, 0xdf, 0xf3, 0xfc, 0x7d, 0x68, 0x45,
+	0x22, 0xa0, 0xf3, 0xde, 0xae, 0x3d, 0x11, 0xd0, 0xfc, 0xed, 0x8a, 0xcc, 0xdf, 0x2b, 0x5e, 0xb7,
+	0x5f, 0xf5, 0x7a, 0xf7, 0x65, 0x15, 0x9a, 0x45, 0x0a, 0xfa, 0x00, 0x1a, 0x92, 0xf1, 0x70, 0x42,
+	0x8d, 0xa6, 0xee, 0x15, 0xfc, 0xee, 0xbe, 0x46, 0xee, 0x56, 0xb0, 0xc9, 0x41, 0xef, 0x41, 0x5d,
+	0x37, 0x11, 0x23, 0xee, 0xad, 0xab, 0x92, 0xf7, 0x32, 0xe0, 0x6e, 0x05, 0xe7, 0x19, 0xed, 0x01,
+	0x34, 0x72, 0x3a, 0xf4, 0x2e, 0xd4, 0x32, 0xdd, 0x5a, 0xc0, 0xf5, 0xed, 0xdb, 0x67, 0x38, 0x8a,
+	0xb6, 0x72, 0xf6, 0xfc, 0x32, 0x3e, 0xac, 0x13, 0xda, 0x47, 0x16, 0xd4, 0x35, 0x2b, 0x7a, 0x0c,
+	0xcd, 0x11, 0x53, 0x24, 0x49, 0x48, 0xb1, 0xb7, 0xfd, 0x82, 0x26, 0x6f, 0x7e, 0x6e, 0xd9, 0xeb,
+	0x0a, 0xae, 0x07, 0x22, 0x8a, 0x89, 0xaf, 0x86, 0x4c, 0x0d, 0xb2, 0x34, 0x5c, 0x12, 0xa0, 0xf7,
+	0x01, 0xca, 0x5d, 0xcf, 0xde, 0x4d, 0x7b, 0xde, 0xb6, 0xb7, 0x8a, 0x6d, 0x97, 0xc3, 0x3a, 0xd8,
+	0x32, 0x8d, 0xba, 0xbf, 0x5b, 0x60, 0x3f, 0xa4, 0x14, 0xf9, 0xd0, 0x20, 0x51, 0xf6, 0x04, 0x19,
+	0x53, 0x96, 0xdd, 0x2a, 0xeb, 0xb1, 0x67, 0xa4, 0x30, 0x3e, 0xdc, 0x7a, 0xf6, 0xdb, 0x7a, 0xe5,
+	0xc7, 0x17, 0xeb, 0xbd, 0x90, 0xa9, 0x71, 0x3a, 0x72, 0x7d, 0x11, 0xf5, 0x8b, 0xfe, 0xad, 0x3f,
+	0x9b, 0x32, 0x78, 0xda, 0x57, 0xb3, 0x98, 0x4a, 0x9d, 0x20, 0xb1, 0xa1, 0x46, 0x6b, 0xd0, 0x0a,
+	0x89, 0xf4, 0x26, 0x2c, 0x62, 0x4a, 0x1f, 0x44, 0x0d, 0x37, 0x43, 0x22, 0x3f, 0xcd, 0xc6, 0xc8,
+	0x85, 0x7a, 0x4c, 0x66, 0x34, 0xc9, 0xdf, 0xcc, 0xa1, 0xf3, 0xcb, 0x4f, 0x9b, 0x2b, 0x46, 0xc3,
+	0x20, 0x08, 0x12, 0x2a, 0xe5, 0xbe, 0x4a, 0x18, 0x0f, 0x71, 0x0e, 0x43, 0xdb, 0xb0, 0x10, 0x26,
+	0x84, 0x2b, 0xf3, 0x88, 0x5e, 0x95, 0x51, 0x00, 0xbb, 0xdf, 0x5b, 0x60, 0x1f, 0xb0, 0xf8, 0xff,
+	0xa9, 0x76, 0x0b, 0x1a, 0x8a, 0xc5, 0x31, 0x4d, 0x9c, 0xea, 0x1c, 0x7d, 0x06, 0xd7, 0xfd, 0xd9,
+	0x82, 0xa5, 0x41, 0x3a, 0xcd, 0x2f, 0xe3, 0x0e, 0x51, 0x24, 0x2b, 0x92, 0xe4, 0x50, 0xc7, 0x9a,
+	0x43, 0x52, 0x00, 0xd1, 0x87, 0xd0, 0xcc, 0xec, 0xe8, 0x05, 0xc2, 0x37, 0x6e, 0xbf, 0x7d, 0xc9,
+	0x0b, 0x73, 0xb6, 0x15, 0xe2, 0x05, 0x99, 0x47, 0x4a, 0x97, 0xdb, 0xff, 0xd0, 0xe5, 0x68, 0x19,
+	0x6c, 0xc9, 0x42, 0x7d, 0x1a, 0x8b, 0x38, 0xfb, 0x1d, 0x7e, 0xf4, 0xec, 0xb8, 0x63, 0x3d, 0x3f,
+	0xee, 0x58, 0x2f, 0x8f, 0x3b, 0xd6, 0xd1, 0x49, 0xa7, 0xf2, 0xfc, 0xa4, 0x53, 0xf9, 0xf5, 0xa4,
+	0x53, 0x79, 0x72, 0x67, 0xfe, 0x76, 0xf6, 0xd5, 0x74, 0xd4, 0xd0, 0x0f, 0xce, 0xbd, 0xbf, 0x06,
+	0x00, 0x6d, 0x04, 0x2d, 0x7f, 0x66, 0x0a, 0x00, 0x00,
 }
 
 func (m *Tx) Marshal() (dAtA []byte, err error) {
@@ -1361,6 +1399,26 @@ func (m *TxBody) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0xfa
 		}
 	}
+	if m.TimeoutTimestamp != nil {
+		n5, err5 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(*m.TimeoutTimestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.TimeoutTimestamp):])
+		if err5 != nil {
+			return 0, err5
+		}
+		i -= n5
+		i = encodeVarintTx(dAtA, i, uint64(n5))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Unordered {
+		i--
+		if m.Unordered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.TimeoutHeight != 0 {
 		i = encodeVarintTx(dAtA, i, uint64(m.TimeoutHeight))
 		i--
@@ -1939,6 +1997,13 @@ func (m *TxBody) Size() (n int) {
 	if m.TimeoutHeight != 0 {
 		n += 1 + sovTx(uint64(m.TimeoutHeight))
 	}
+	if m.Unordered {
+		n += 2
+	}
+	if m.TimeoutTimestamp != nil {
+		l = github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.TimeoutTimestamp)
+		n += 1 + l + sovTx(uint64(l))
+	}
 	if len(m.ExtensionOptions) > 0 {
 		for _, e := range m.ExtensionOptions {
 			l = e.Size()
@@ -2952,6 +3017,62 @@ func (m *TxBody) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unordered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Unordered = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutTimestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TimeoutTimestamp == nil {
+				m.TimeoutTimestamp = new(time.Time)
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(m.TimeoutTimestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 1023:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ExtensionOptions", wireType)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	fmt "fmt"
 	strings "strings"
+	"time"
 
 	"github.com/cosmos/gogoproto/proto"
 
@@ -67,6 +68,24 @@ type (
 		GetTimeoutHeight() uint64
 	}
 
+	// TxWithTimeoutTimeStamp extends the Tx interface by allowing a transaction
+	// to set a block time timeout. A zero time means no timeout.
+	TxWithTimeoutTimeStamp interface {
+		Tx
+
+		GetTimeoutTimeStamp() time.Time
+	}
+
+	// TxWithUnordered extends the Tx interface by allowing a transaction to be
+	// unordered, i.e. executed regardless of its signers' sequences. Unordered
+	// transactions must set a timeout height and/or a timeout timestamp.
+	TxWithUnordered interface {
+		TxWithTimeoutHeight
+		TxWithTimeoutTimeStamp
+
+		GetUnordered() bool
+	}
+
 	// HasValidateBasic defines a type that has a ValidateBasic method.
 	// ValidateBasic is deprecated and now facultative.
 	// Prefer validating messages directly in the msg server.
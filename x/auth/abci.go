@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/x/auth/keeper"
+)
+
+// BeginBlocker is called at the beginning of every block
+func BeginBlocker(ctx context.Context, ak keeper.AccountKeeper) error {
+	// delete the unordered transactions which timed out, they can no longer be
+	// executed again
+	return ak.RemoveExpiredUnorderedTxs(ctx)
+}
@@ -21,6 +21,9 @@ type HandlerOptions struct {
 	SignModeHandler        *txsigning.HandlerMap
 	SigGasConsumer         func(meter storetypes.GasMeter, sig signing.SignatureV2, params types.Params) error
 	TxFeeChecker           TxFeeChecker
+	// UnorderedTxKeeper records the unordered transactions to prevent their
+	// replay. Unordered transactions are rejected if it is nil.
+	UnorderedTxKeeper UnorderedTxKeeper
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
@@ -44,6 +47,7 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 		NewExtensionOptionsDecorator(options.ExtensionOptionChecker),
 		NewValidateBasicDecorator(),
 		NewTxTimeoutHeightDecorator(),
+		NewUnorderedTxDecorator(DefaultMaxUnorderedTxTimeoutHeight, DefaultMaxUnorderedTxTimeoutDuration, options.UnorderedTxKeeper),
 		NewValidateMemoDecorator(options.AccountKeeper),
 		NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
 		NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, options.TxFeeChecker),
@@ -172,7 +172,7 @@ func isIncompleteSignature(data signing.SignatureData) bool {
 
 type (
 	// TxTimeoutHeightDecorator defines an AnteHandler decorator that checks for a
-	// tx height timeout.
+	// tx height and timestamp timeout.
 	TxTimeoutHeightDecorator struct{}
 
 	// TxWithTimeoutHeight defines the interface a tx must implement in order for
@@ -193,7 +193,9 @@ func NewTxTimeoutHeightDecorator() TxTimeoutHeightDecorator {
 // AnteHandle implements an AnteHandler decorator for the TxHeightTimeoutDecorator
 // type where the current block height is checked against the tx's height timeout.
 // If a height timeout is provided (non-zero) and is less than the current block
-// height, then an error is returned.
+// height, then an error is returned. Likewise, if the tx implements
+// sdk.TxWithTimeoutTimeStamp and a timestamp timeout is provided (non-zero) and
+// is before the current block time, then an error is returned.
 func (txh TxTimeoutHeightDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
 	timeoutTx, ok := tx.(TxWithTimeoutHeight)
 	if !ok {
@@ -207,5 +209,14 @@ func (txh TxTimeoutHeightDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 		)
 	}
 
+	if timeoutTimestampTx, ok := tx.(sdk.TxWithTimeoutTimeStamp); ok {
+		timeoutTimestamp := timeoutTimestampTx.GetTimeoutTimeStamp()
+		if !timeoutTimestamp.IsZero() && ctx.BlockTime().After(timeoutTimestamp) {
+			return ctx, errorsmod.Wrapf(
+				sdkerrors.ErrTxTimeout, "block time: %s, timeout timestamp: %s", ctx.BlockTime(), timeoutTimestamp,
+			)
+		}
+	}
+
 	return next(ctx, tx, simulate)
 }
@@ -2,6 +2,7 @@ package ante
 
 import (
 	"context"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
@@ -16,6 +17,13 @@ type AccountKeeper interface {
 	GetModuleAddress(moduleName string) sdk.AccAddress
 }
 
+// UnorderedTxKeeper defines the contract needed to record the unordered
+// transactions which have been executed, to prevent their replay.
+type UnorderedTxKeeper interface {
+	ContainsUnorderedTx(ctx context.Context, txHash []byte) (bool, error)
+	AddUnorderedTx(ctx context.Context, txHash []byte, timeoutHeight uint64, timeoutTimestamp time.Time) error
+}
+
 // FeegrantKeeper defines the expected feegrant keeper.
 type FeegrantKeeper interface {
 	UseGrantedFees(ctx context.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) error
@@ -236,6 +236,36 @@ func OnlyLegacyAminoSigners(sigData signing.SignatureData) bool {
 	}
 }
 
+// SignsTxBodyBytes checks SignatureData to see if all signers are using a sign
+// mode which signs the raw TxBody bytes, i.e. SIGN_MODE_DIRECT or
+// SIGN_MODE_DIRECT_AUX, and therefore all of its fields.
+func SignsTxBodyBytes(sigData signing.SignatureData) bool {
+	switch v := sigData.(type) {
+	case *signing.SingleSignatureData:
+		return v.SignMode == signing.SignMode_SIGN_MODE_DIRECT || v.SignMode == signing.SignMode_SIGN_MODE_DIRECT_AUX
+	case *signing.MultiSignatureData:
+		for _, s := range v.Signatures {
+			if !SignsTxBodyBytes(s) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// usesTxBodyOnlyFields returns true if tx sets TxBody fields which are only
+// signed by the sign modes signing the raw TxBody bytes.
+func usesTxBodyOnlyFields(tx sdk.Tx) bool {
+	if unorderedTx, ok := tx.(sdk.TxWithUnordered); ok && unorderedTx.GetUnordered() {
+		return true
+	}
+
+	timeoutTx, ok := tx.(sdk.TxWithTimeoutTimeStamp)
+	return ok && !timeoutTx.GetTimeoutTimeStamp().IsZero()
+}
+
 func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
 	sigTx, ok := tx.(authsigning.Tx)
 	if !ok {
@@ -251,6 +281,26 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 
 	signerAddrs := sigTx.GetSigners()
 
+	// the sequence of unordered txs is not checked, they are protected against
+	// replay by the UnorderedTxDecorator instead
+	unordered, err := isRecordedUnorderedTx(ctx, svd.ak, tx)
+	if err != nil {
+		return ctx, err
+	}
+
+	// the legacy sign modes do not sign the unordered and timeout timestamp
+	// fields, which could then be altered
+	if !simulate && usesTxBodyOnlyFields(tx) {
+		for _, sig := range sigs {
+			if !SignsTxBodyBytes(sig.Data) {
+				return ctx, errorsmod.Wrap(
+					sdkerrors.ErrNotSupported,
+					"unordered and timeout timestamp txs must be signed with SIGN_MODE_DIRECT or SIGN_MODE_DIRECT_AUX",
+				)
+			}
+		}
+	}
+
 	// check that signer length and signature length are the same
 	if len(sigs) != len(signerAddrs) {
 		return ctx, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "invalid number of signer;  expected: %d, got %d", len(signerAddrs), len(sigs))
@@ -269,7 +319,10 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 		}
 
 		// Check account sequence number.
-		if sig.Sequence != acc.GetSequence() {
+		sequence := acc.GetSequence()
+		if unordered {
+			sequence = sig.Sequence
+		} else if sig.Sequence != sequence {
 			return ctx, errorsmod.Wrapf(
 				sdkerrors.ErrWrongSequence,
 				"account sequence mismatch, expected %d, got %d", acc.GetSequence(), sig.Sequence,
@@ -292,7 +345,7 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 				Address:       acc.GetAddress().String(),
 				ChainID:       chainID,
 				AccountNumber: accNum,
-				Sequence:      acc.GetSequence(),
+				Sequence:      sequence,
 				PubKey: &anypb.Any{
 					TypeUrl: anyPk.TypeUrl,
 					Value:   anyPk.Value,
@@ -323,7 +376,8 @@ func (svd SigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simul
 }
 
 // IncrementSequenceDecorator handles incrementing sequences of all signers.
-// Use the IncrementSequenceDecorator decorator to prevent replay attacks. Note,
+// Use the IncrementSequenceDecorator decorator to prevent replay attacks. The
+// sequences are not incremented for unordered txs. Note,
 // there is need to execute IncrementSequenceDecorator on RecheckTx since
 // BaseApp.Commit() will set the check state based on the latest header.
 //
@@ -347,6 +401,10 @@ func (isd IncrementSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, sim
 		return ctx, errorsmod.Wrap(sdkerrors.ErrTxDecode, "invalid transaction type")
 	}
 
+	if unorderedTx, ok := tx.(sdk.TxWithUnordered); ok && unorderedTx.GetUnordered() {
+		return next(ctx, tx, simulate)
+	}
+
 	// increment sequence of all signers
 	for _, addr := range sigTx.GetSigners() {
 		acc := isd.ak.GetAccount(ctx, addr)
@@ -0,0 +1,171 @@
+package ante
+
+import (
+	"crypto/sha256"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+const (
+	// DefaultMaxUnorderedTxTimeoutHeight is the default maximum number of blocks
+	// an unordered transaction's timeout height may be ahead of the current
+	// block height.
+	DefaultMaxUnorderedTxTimeoutHeight = 1024
+
+	// DefaultMaxUnorderedTxTimeoutDuration is the default maximum duration an
+	// unordered transaction's timeout timestamp may be ahead of the current block
+	// time.
+	DefaultMaxUnorderedTxTimeoutDuration = 10 * time.Minute
+)
+
+var _ sdk.AnteDecorator = UnorderedTxDecorator{}
+
+// UnorderedTxDecorator defines an AnteHandler decorator that prevents the
+// replay of unordered transactions, i.e. transactions which do not use, nor
+// increment, the sequence of their signers. An unordered transaction must have
+// a timeout height and/or timeout timestamp, bounded by the decorator, and its
+// hash is recorded by the UnorderedTxKeeper until it times out so that it
+// cannot be executed twice. The hashes of the transactions which timed out are
+// removed by the begin blocker of the auth module. Ordered transactions are
+// passed through.
+//
+// The hash of an unordered transaction is the SHA-256 hash of its body bytes
+// followed by its auth info bytes, which are signed, so that the transaction
+// cannot be replayed with different signatures, e.g. a subset of the signatures
+// of a multisig.
+//
+// CONTRACT: The UnorderedTxDecorator must run before the
+// SigVerificationDecorator, which only accepts unordered transactions recorded
+// by the UnorderedTxKeeper.
+type UnorderedTxDecorator struct {
+	maxTimeoutHeight   uint64
+	maxTimeoutDuration time.Duration
+	keeper             UnorderedTxKeeper
+}
+
+// NewUnorderedTxDecorator returns a new UnorderedTxDecorator. Unordered
+// transactions are rejected if keeper is nil.
+func NewUnorderedTxDecorator(
+	maxTimeoutHeight uint64, maxTimeoutDuration time.Duration, keeper UnorderedTxKeeper,
+) UnorderedTxDecorator {
+	return UnorderedTxDecorator{
+		maxTimeoutHeight:   maxTimeoutHeight,
+		maxTimeoutDuration: maxTimeoutDuration,
+		keeper:             keeper,
+	}
+}
+
+func (d UnorderedTxDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	unorderedTx, ok := tx.(sdk.TxWithUnordered)
+	if !ok || !unorderedTx.GetUnordered() {
+		return next(ctx, tx, simulate)
+	}
+
+	if d.keeper == nil {
+		return ctx, errorsmod.Wrap(sdkerrors.ErrNotSupported, "unordered transactions are not supported")
+	}
+
+	timeoutHeight := unorderedTx.GetTimeoutHeight()
+	timeoutTimestamp := unorderedTx.GetTimeoutTimeStamp()
+	if timeoutHeight == 0 && timeoutTimestamp.IsZero() {
+		return ctx, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unordered transaction must have a timeout height or timeout timestamp")
+	}
+
+	blockHeight := uint64(ctx.BlockHeight())
+	if timeoutHeight > 0 {
+		if blockHeight > timeoutHeight {
+			return ctx, errorsmod.Wrapf(
+				sdkerrors.ErrTxTimeoutHeight, "block height: %d, timeout height: %d", blockHeight, timeoutHeight,
+			)
+		}
+		if timeoutHeight > blockHeight+d.maxTimeoutHeight {
+			return ctx, errorsmod.Wrapf(
+				sdkerrors.ErrInvalidRequest,
+				"unordered transaction timeout height %d exceeds the maximum of %d", timeoutHeight, blockHeight+d.maxTimeoutHeight,
+			)
+		}
+	}
+
+	blockTime := ctx.BlockTime()
+	if !timeoutTimestamp.IsZero() {
+		if blockTime.After(timeoutTimestamp) {
+			return ctx, errorsmod.Wrapf(
+				sdkerrors.ErrTxTimeout, "block time: %s, timeout timestamp: %s", blockTime, timeoutTimestamp,
+			)
+		}
+		if maxTimestamp := blockTime.Add(d.maxTimeoutDuration); timeoutTimestamp.After(maxTimestamp) {
+			return ctx, errorsmod.Wrapf(
+				sdkerrors.ErrInvalidRequest,
+				"unordered transaction timeout timestamp %s exceeds the maximum of %s", timeoutTimestamp, maxTimestamp,
+			)
+		}
+	}
+
+	txHash, err := unorderedTxHash(ctx.TxBytes())
+	if err != nil {
+		return ctx, err
+	}
+	found, err := d.keeper.ContainsUnorderedTx(ctx, txHash)
+	if err != nil {
+		return ctx, err
+	}
+	if found {
+		return ctx, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unordered transaction %X has already been executed", txHash)
+	}
+
+	if err := d.keeper.AddUnorderedTx(ctx, txHash, timeoutHeight, timeoutTimestamp); err != nil {
+		return ctx, err
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// isRecordedUnorderedTx returns true if tx is an unordered transaction recorded
+// by ak, i.e. ak implements UnorderedTxKeeper and the UnorderedTxDecorator
+// accepted tx.
+func isRecordedUnorderedTx(ctx sdk.Context, ak AccountKeeper, tx sdk.Tx) (bool, error) {
+	unorderedTx, ok := tx.(sdk.TxWithUnordered)
+	if !ok || !unorderedTx.GetUnordered() {
+		return false, nil
+	}
+
+	keeper, ok := ak.(UnorderedTxKeeper)
+	if !ok {
+		return false, errorsmod.Wrap(sdkerrors.ErrNotSupported, "unordered transactions are not supported")
+	}
+
+	txHash, err := unorderedTxHash(ctx.TxBytes())
+	if err != nil {
+		return false, err
+	}
+	found, err := keeper.ContainsUnorderedTx(ctx, txHash)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, errorsmod.Wrap(sdkerrors.ErrLogic, "unordered transaction was not checked by the UnorderedTxDecorator")
+	}
+
+	return true, nil
+}
+
+// unorderedTxHash returns the hash recording the unordered transaction encoded
+// as txBytes, i.e. the SHA-256 hash of its body bytes followed by its auth info
+// bytes. The signatures are not hashed since they can be changed by anyone
+// relaying the transaction.
+func unorderedTxHash(txBytes []byte) ([]byte, error) {
+	var raw txtypes.TxRaw
+	if err := raw.Unmarshal(txBytes); err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrTxDecode, err.Error())
+	}
+
+	h := sha256.New()
+	h.Write(raw.BodyBytes)
+	h.Write(raw.AuthInfoBytes)
+	return h.Sum(nil), nil
+}
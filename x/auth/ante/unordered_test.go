@@ -0,0 +1,202 @@
+package ante_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+)
+
+func TestUnorderedTxDecorator(t *testing.T) {
+	suite := SetupTestSuite(t, false)
+
+	blockTime := time.Unix(1_000_000, 0).UTC()
+	suite.ctx = suite.ctx.WithBlockHeight(10).WithBlockTime(blockTime)
+
+	antehandler := sdk.ChainAnteDecorators(ante.NewUnorderedTxDecorator(100, time.Hour, suite.accountKeeper))
+
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+	msg := testdata.NewTestMsg(addr1)
+
+	testCases := []struct {
+		name             string
+		unordered        bool
+		timeoutHeight    uint64
+		timeoutTimestamp time.Time
+		expectedErr      error
+	}{
+		{"ordered tx", false, 0, time.Time{}, nil},
+		{"no timeout", true, 0, time.Time{}, sdkerrors.ErrInvalidRequest},
+		{"timeout height", true, 20, time.Time{}, nil},
+		{"timed out height", true, 9, time.Time{}, sdkerrors.ErrTxTimeoutHeight},
+		{"timeout height too far", true, 111, time.Time{}, sdkerrors.ErrInvalidRequest},
+		{"timeout timestamp", true, 0, blockTime.Add(time.Minute), nil},
+		{"timed out timestamp", true, 0, blockTime.Add(-time.Second), sdkerrors.ErrTxTimeout},
+		{"timeout timestamp too far", true, 0, blockTime.Add(time.Hour + time.Second), sdkerrors.ErrInvalidRequest},
+		{"timeout height and timestamp", true, 15, blockTime.Add(time.Minute), nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+			require.NoError(t, suite.txBuilder.SetMsgs(msg))
+			suite.txBuilder.SetTimeoutHeight(tc.timeoutHeight)
+			suite.txBuilder.SetTimeoutTimestamp(tc.timeoutTimestamp)
+			suite.txBuilder.SetUnordered(tc.unordered)
+
+			privs, accNums, accSeqs := []cryptotypes.PrivKey{priv1}, []uint64{0}, []uint64{0}
+			tx, err := suite.CreateTestTx(suite.ctx, privs, accNums, accSeqs, suite.ctx.ChainID(), signing.SignMode_SIGN_MODE_DIRECT)
+			require.NoError(t, err)
+
+			txBytes, err := suite.clientCtx.TxConfig.TxEncoder()(tx)
+			require.NoError(t, err)
+
+			ctx, _ := suite.ctx.WithTxBytes(txBytes).CacheContext()
+			_, err = antehandler(ctx, tx, false)
+			require.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil || !tc.unordered {
+				return
+			}
+
+			// the same tx cannot be executed twice until it times out
+			_, err = antehandler(ctx, tx, false)
+			require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+			// nor with different signatures
+			var raw txtypes.TxRaw
+			require.NoError(t, raw.Unmarshal(txBytes))
+			raw.Signatures = append(raw.Signatures, []byte("signature"))
+			malleatedBytes, err := raw.Marshal()
+			require.NoError(t, err)
+			_, err = antehandler(ctx.WithTxBytes(malleatedBytes), tx, false)
+			require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+		})
+	}
+}
+
+func TestUnorderedTxSequence(t *testing.T) {
+	suite := SetupTestSuite(t, false)
+	suite.ctx = suite.ctx.WithBlockHeight(10)
+
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+	acc1 := suite.accountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	require.NoError(t, acc1.SetSequence(5))
+	suite.accountKeeper.SetAccount(suite.ctx, acc1)
+
+	antehandler := sdk.ChainAnteDecorators(
+		ante.NewUnorderedTxDecorator(100, time.Hour, suite.accountKeeper),
+		ante.NewSetPubKeyDecorator(suite.accountKeeper),
+		ante.NewSigVerificationDecorator(suite.accountKeeper, suite.clientCtx.TxConfig.SignModeHandler()),
+		ante.NewIncrementSequenceDecorator(suite.accountKeeper),
+	)
+
+	testCases := []struct {
+		name        string
+		sequence    uint64
+		legacyAmino bool
+		expectedErr error
+	}{
+		{"any sequence", 0, false, nil},
+		{"account sequence", 5, false, nil},
+		{"legacy amino json", 5, true, sdkerrors.ErrNotSupported},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+			require.NoError(t, suite.txBuilder.SetMsgs(testdata.NewTestMsg(addr1)))
+			suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+			suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+			suite.txBuilder.SetTimeoutHeight(20)
+			suite.txBuilder.SetUnordered(true)
+
+			accNums, accSeqs := []uint64{acc1.GetAccountNumber()}, []uint64{tc.sequence}
+			tx, err := suite.CreateTestTx(suite.ctx, []cryptotypes.PrivKey{priv1}, accNums, accSeqs, suite.ctx.ChainID(), signing.SignMode_SIGN_MODE_DIRECT)
+			require.NoError(t, err)
+			if tc.legacyAmino {
+				// legacy amino json does not sign the unordered field
+				sigs, err := tx.GetSignaturesV2()
+				require.NoError(t, err)
+				sigs[0].Data.(*signing.SingleSignatureData).SignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+				require.NoError(t, suite.txBuilder.SetSignatures(sigs...))
+				tx = suite.txBuilder.GetTx()
+			}
+			txBytes, err := suite.clientCtx.TxConfig.TxEncoder()(tx)
+			require.NoError(t, err)
+
+			ctx, _ := suite.ctx.WithTxBytes(txBytes).CacheContext()
+			_, err = antehandler(ctx, tx, false)
+			require.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			// the sequence of the signer is not incremented
+			require.Equal(t, uint64(5), suite.accountKeeper.GetAccount(ctx, addr1).GetSequence())
+		})
+	}
+
+	// an unordered tx not checked by the UnorderedTxDecorator is rejected
+	suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+	require.NoError(t, suite.txBuilder.SetMsgs(testdata.NewTestMsg(addr1)))
+	suite.txBuilder.SetTimeoutHeight(20)
+	suite.txBuilder.SetUnordered(true)
+	tx, err := suite.CreateTestTx(suite.ctx, []cryptotypes.PrivKey{priv1}, []uint64{acc1.GetAccountNumber()}, []uint64{0}, suite.ctx.ChainID(), signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+	txBytes, err := suite.clientCtx.TxConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+
+	svd := ante.NewSigVerificationDecorator(suite.accountKeeper, suite.clientCtx.TxConfig.SignModeHandler())
+	_, err = sdk.ChainAnteDecorators(ante.NewSetPubKeyDecorator(suite.accountKeeper), svd)(suite.ctx.WithTxBytes(txBytes), tx, false)
+	require.ErrorIs(t, err, sdkerrors.ErrLogic)
+}
+
+func TestTxTimeoutTimestampDecorator(t *testing.T) {
+	suite := SetupTestSuite(t, true)
+
+	antehandler := sdk.ChainAnteDecorators(ante.NewTxTimeoutHeightDecorator())
+
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+	msg := testdata.NewTestMsg(addr1)
+	blockTime := time.Unix(1_000_000, 0).UTC()
+
+	testCases := []struct {
+		name        string
+		timeout     time.Time
+		expectedErr error
+	}{
+		{"default value", time.Time{}, nil},
+		{"no timeout (later timestamp)", blockTime.Add(time.Second), nil},
+		{"no timeout (same timestamp)", blockTime, nil},
+		{"timeout (earlier timestamp)", blockTime.Add(-time.Second), sdkerrors.ErrTxTimeout},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+			require.NoError(t, suite.txBuilder.SetMsgs(msg))
+			suite.txBuilder.SetTimeoutTimestamp(tc.timeout)
+
+			privs, accNums, accSeqs := []cryptotypes.PrivKey{priv1}, []uint64{0}, []uint64{0}
+			tx, err := suite.CreateTestTx(suite.ctx, privs, accNums, accSeqs, suite.ctx.ChainID(), signing.SignMode_SIGN_MODE_DIRECT)
+			require.NoError(t, err)
+
+			_, err = antehandler(suite.ctx.WithBlockTime(blockTime), tx, true)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
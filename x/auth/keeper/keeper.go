@@ -76,6 +76,13 @@ type AccountKeeper struct {
 	// State
 	ParamsState   collections.Item[types.Params] // NOTE: name is this because it conflicts with the Params gRPC method impl
 	AccountNumber collections.Sequence
+
+	// UnorderedTxs contains the hashes of the unordered transactions which have
+	// been executed and not yet timed out, it is pruned using the
+	// UnorderedTxsByHeight and UnorderedTxsByTime indexes.
+	UnorderedTxs         collections.KeySet[[]byte]
+	UnorderedTxsByHeight collections.KeySet[collections.Pair[uint64, []byte]]
+	UnorderedTxsByTime   collections.KeySet[collections.Pair[int64, []byte]]
 }
 
 var _ AccountKeeperI = &AccountKeeper{}
@@ -106,6 +113,15 @@ func NewAccountKeeper(
 		authority:     authority,
 		ParamsState:   collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
 		AccountNumber: collections.NewSequence(sb, types.GlobalAccountNumberKey, "account_number"),
+		UnorderedTxs:  collections.NewKeySet(sb, types.UnorderedTxsKey, "unordered_txs", collections.BytesKey),
+		UnorderedTxsByHeight: collections.NewKeySet(
+			sb, types.UnorderedTxsByHeightKey, "unordered_txs_by_height",
+			collections.PairKeyCodec(collections.Uint64Key, collections.BytesKey),
+		),
+		UnorderedTxsByTime: collections.NewKeySet(
+			sb, types.UnorderedTxsByTimeKey, "unordered_txs_by_time",
+			collections.PairKeyCodec(collections.Int64Key, collections.BytesKey),
+		),
 	}
 }
 
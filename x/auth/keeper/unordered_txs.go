@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cosmossdk.io/collections"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ContainsUnorderedTx returns true if the unordered transaction with the given
+// hash has been executed and has not yet timed out.
+func (ak AccountKeeper) ContainsUnorderedTx(ctx context.Context, txHash []byte) (bool, error) {
+	return ak.UnorderedTxs.Has(ctx, txHash)
+}
+
+// AddUnorderedTx records the hash of an executed unordered transaction until
+// it times out, i.e. until the block height exceeds timeoutHeight or the block
+// time exceeds timeoutTimestamp. A zero timeoutHeight or timeoutTimestamp is
+// ignored, at least one of them must be set.
+func (ak AccountKeeper) AddUnorderedTx(ctx context.Context, txHash []byte, timeoutHeight uint64, timeoutTimestamp time.Time) error {
+	if err := ak.UnorderedTxs.Set(ctx, txHash); err != nil {
+		return err
+	}
+
+	if timeoutHeight > 0 {
+		if err := ak.UnorderedTxsByHeight.Set(ctx, collections.Join(timeoutHeight, txHash)); err != nil {
+			return err
+		}
+	}
+
+	if !timeoutTimestamp.IsZero() {
+		if err := ak.UnorderedTxsByTime.Set(ctx, collections.Join(timeoutTimestamp.UnixNano(), txHash)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveExpiredUnorderedTxs removes the unordered transactions which timed
+// out, as of the block height and time of ctx, since they can no longer be
+// executed again.
+func (ak AccountKeeper) RemoveExpiredUnorderedTxs(ctx context.Context) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	err := removeExpiredUnorderedTxs(ctx, ak.UnorderedTxs, ak.UnorderedTxsByHeight, uint64(sdkCtx.BlockHeight()))
+	if err != nil {
+		return err
+	}
+
+	return removeExpiredUnorderedTxs(ctx, ak.UnorderedTxs, ak.UnorderedTxsByTime, sdkCtx.BlockTime().UnixNano())
+}
+
+// removeExpiredUnorderedTxs removes from txs and index the transactions whose
+// timeout in index is before the given one.
+func removeExpiredUnorderedTxs[T any](
+	ctx context.Context,
+	txs collections.KeySet[[]byte],
+	index collections.KeySet[collections.Pair[T, []byte]],
+	timeout T,
+) error {
+	rng := new(collections.Range[collections.Pair[T, []byte]]).EndExclusive(collections.Join(timeout, []byte{}))
+	iter, err := index.Iterate(ctx, rng)
+	if errors.Is(err, collections.ErrInvalidIterator) {
+		// nothing expired
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	expired, err := iter.Keys()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := index.Remove(ctx, key); err != nil {
+			return err
+		}
+		if err := txs.Remove(ctx, key.K2()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package keeper_test
+
+import (
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func (suite *KeeperTestSuite) TestRemoveExpiredUnorderedTxs() {
+	blockTime := time.Unix(1_000_000, 0).UTC()
+	ctx := suite.ctx.WithBlockHeight(10).WithBlockTime(blockTime)
+
+	suite.Require().NoError(suite.accountKeeper.AddUnorderedTx(ctx, []byte("height"), 10, time.Time{}))
+	suite.Require().NoError(suite.accountKeeper.AddUnorderedTx(ctx, []byte("time"), 0, blockTime))
+	suite.Require().NoError(suite.accountKeeper.AddUnorderedTx(ctx, []byte("both"), 100, blockTime.Add(time.Minute)))
+
+	requireUnorderedTxs := func(expected map[string]bool) {
+		suite.T().Helper()
+		for hash, found := range expected {
+			contains, err := suite.accountKeeper.ContainsUnorderedTx(ctx, []byte(hash))
+			suite.Require().NoError(err)
+			suite.Require().Equal(found, contains, hash)
+		}
+	}
+
+	// nothing is expired at the timeouts
+	suite.Require().NoError(auth.BeginBlocker(ctx, suite.accountKeeper))
+	requireUnorderedTxs(map[string]bool{"height": true, "time": true, "both": true})
+
+	// the txs are expired past their timeout height or timestamp
+	ctx = ctx.WithBlockHeight(11).WithBlockTime(blockTime.Add(time.Second))
+	suite.Require().NoError(auth.BeginBlocker(ctx, suite.accountKeeper))
+	requireUnorderedTxs(map[string]bool{"height": false, "time": false, "both": true})
+
+	ctx = ctx.WithBlockTime(blockTime.Add(time.Minute + time.Second))
+	suite.Require().NoError(auth.BeginBlocker(ctx, suite.accountKeeper))
+	requireUnorderedTxs(map[string]bool{"both": false})
+}
@@ -1,6 +1,8 @@
 package legacytx
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -59,6 +61,16 @@ func (s *StdTxBuilder) SetTimeoutHeight(height uint64) {
 	s.TimeoutHeight = height
 }
 
+// SetTimeoutTimestamp panics, as StdTx does not support timestamp timeouts.
+func (s *StdTxBuilder) SetTimeoutTimestamp(_ time.Time) {
+	panic("StdTxBuilder does not support timeout timestamps")
+}
+
+// SetUnordered panics, as StdTx does not support unordered transactions.
+func (s *StdTxBuilder) SetUnordered(_ bool) {
+	panic("StdTxBuilder does not support unordered transactions")
+}
+
 // SetFeeGranter does nothing for stdtx
 func (s *StdTxBuilder) SetFeeGranter(_ sdk.AccAddress) {}
 
@@ -29,6 +29,18 @@ var (
 
 	// AccountNumberStoreKeyPrefix prefix for account-by-id store
 	AccountNumberStoreKeyPrefix = []byte("accountNumber")
+
+	// UnorderedTxsKey is the prefix for the hashes of the unordered transactions
+	// which have been executed and not yet timed out.
+	UnorderedTxsKey = collections.NewPrefix(3)
+
+	// UnorderedTxsByHeightKey is the prefix for the unordered transaction hashes
+	// indexed by timeout height.
+	UnorderedTxsByHeightKey = collections.NewPrefix(4)
+
+	// UnorderedTxsByTimeKey is the prefix for the unordered transaction hashes
+	// indexed by timeout timestamp.
+	UnorderedTxsByTimeKey = collections.NewPrefix(5)
 )
 
 // AddressStoreKey turn an address to key used to get it from the account store
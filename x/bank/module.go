@@ -7,6 +7,7 @@ import (
 	"time"
 
 	modulev1 "cosmossdk.io/api/cosmos/bank/module/v1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/log"
@@ -37,9 +38,10 @@ import (
 const ConsensusVersion = 4
 
 var (
-	_ module.AppModule           = AppModule{}
-	_ module.AppModuleBasic      = AppModuleBasic{}
-	_ module.AppModuleSimulation = AppModule{}
+	_ module.AppModule            = AppModule{}
+	_ module.AppModuleBasic       = AppModuleBasic{}
+	_ module.AppModuleSimulation  = AppModule{}
+	_ module.HasCollectionsSchema = AppModule{}
 )
 
 // AppModuleBasic defines the basic application module used by the bank module.
@@ -115,6 +117,16 @@ func (am AppModule) IsOnePerModuleType() {}
 // IsAppModule implements the appmodule.AppModule interface.
 func (am AppModule) IsAppModule() {}
 
+// CollectionsSchema implements module.HasCollectionsSchema. The schema is
+// empty if the keeper of the module is not a keeper.BaseKeeper.
+func (am AppModule) CollectionsSchema() collections.Schema {
+	bk, ok := am.keeper.(keeper.BaseKeeper)
+	if !ok {
+		return collections.Schema{}
+	}
+	return bk.Schema
+}
+
 // RegisterServices registers module services.
 func (am AppModule) RegisterServices(cfg module.Configurator) {
 	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(am.keeper))
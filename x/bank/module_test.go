@@ -0,0 +1,23 @@
+package bank_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+)
+
+// customKeeper is a bank keeper which is not a keeper.BaseKeeper.
+type customKeeper struct {
+	bankkeeper.Keeper
+}
+
+func TestCollectionsSchemaCustomKeeper(t *testing.T) {
+	encCfg := moduletestutil.MakeTestEncodingConfig(bank.AppModuleBasic{})
+	am := bank.NewAppModule(encCfg.Codec, customKeeper{}, nil, nil)
+
+	require.Empty(t, am.CollectionsSchema().Collections())
+}
@@ -0,0 +1,47 @@
+package ante
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/x/circuit/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CircuitBreaker is an interface that defines the methods for a circuit breaker.
+type CircuitBreaker interface {
+	IsAllowed(ctx context.Context, typeURL string) (bool, error)
+}
+
+// CircuitBreakerDecorator is an AnteDecorator that checks if the transaction type is allowed to enter the mempool or be executed.
+type CircuitBreakerDecorator struct {
+	circuitKeeper CircuitBreaker
+}
+
+// NewCircuitBreakerDecorator creates a new CircuitBreakerDecorator.
+func NewCircuitBreakerDecorator(ck CircuitBreaker) CircuitBreakerDecorator {
+	return CircuitBreakerDecorator{
+		circuitKeeper: ck,
+	}
+}
+
+// AnteHandle rejects the transaction if any of its messages has been disabled
+// by the circuit breaker.
+func (cbd CircuitBreakerDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	// loop through all the messages and check if the message type is allowed
+	for _, msg := range tx.GetMsgs() {
+		msgTypeURL := sdk.MsgTypeURL(msg)
+
+		isAllowed, err := cbd.circuitKeeper.IsAllowed(ctx, msgTypeURL)
+		if err != nil {
+			return ctx, err
+		}
+
+		if !isAllowed {
+			return ctx, errorsmod.Wrapf(types.ErrMsgDisabled, "tx type not allowed: %s", msgTypeURL)
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
@@ -0,0 +1,93 @@
+package ante_test
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	storetypes "cosmossdk.io/store/types"
+	"cosmossdk.io/x/circuit/ante"
+	"cosmossdk.io/x/circuit/keeper"
+	"cosmossdk.io/x/circuit/types"
+
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+type mockCircuitBreaker struct {
+	disabled map[string]bool
+}
+
+func (cb mockCircuitBreaker) IsAllowed(_ context.Context, typeURL string) (bool, error) {
+	return !cb.disabled[typeURL], nil
+}
+
+type mockTx struct {
+	msgs []sdk.Msg
+}
+
+func (tx mockTx) GetMsgs() []sdk.Msg   { return tx.msgs }
+func (tx mockTx) ValidateBasic() error { return nil }
+
+func TestCircuitBreakerDecorator(t *testing.T) {
+	trip := types.NewMsgTripCircuitBreaker("authority", []string{"cosmos.bank.v1beta1.MsgSend"})
+	reset := types.NewMsgResetCircuitBreaker("authority", []string{"cosmos.bank.v1beta1.MsgSend"})
+
+	decorator := ante.NewCircuitBreakerDecorator(mockCircuitBreaker{
+		disabled: map[string]bool{sdk.MsgTypeURL(trip): true},
+	})
+
+	nextCalled := false
+	next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		nextCalled = true
+		return ctx, nil
+	}
+
+	_, err := decorator.AnteHandle(sdk.Context{}, mockTx{msgs: []sdk.Msg{reset}}, false, next)
+	assert.NilError(t, err)
+	assert.Assert(t, nextCalled)
+
+	nextCalled = false
+	_, err = decorator.AnteHandle(sdk.Context{}, mockTx{msgs: []sdk.Msg{reset, trip}}, false, next)
+	assert.ErrorContains(t, err, "msg disabled")
+	assert.Assert(t, !nextCalled)
+}
+
+func TestCircuitBreakerDecoratorWithKeeper(t *testing.T) {
+	encCfg := moduletestutil.MakeTestEncodingConfig()
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test"))
+
+	authority := authtypes.NewModuleAddress("gov")
+	ac := addresscodec.NewBech32Codec(sdk.GetConfig().GetBech32AccountAddrPrefix())
+	k := keeper.NewKeeper(encCfg.Codec, runtime.NewKVStoreService(key), authority.String(), ac)
+	msgServer := keeper.NewMsgServerImpl(k)
+
+	decorator := ante.NewCircuitBreakerDecorator(&k)
+	next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) { return ctx, nil }
+	send := &banktypes.MsgSend{FromAddress: authority.String(), ToAddress: authority.String()}
+
+	// the type URL is tripped without its leading "/"
+	_, err := msgServer.TripCircuitBreaker(ctx, types.NewMsgTripCircuitBreaker(authority.String(), []string{"cosmos.bank.v1beta1.MsgSend"}))
+	assert.NilError(t, err)
+
+	_, err = decorator.AnteHandle(ctx, mockTx{msgs: []sdk.Msg{send}}, false, next)
+	assert.ErrorContains(t, err, "msg disabled")
+
+	// the circuit messages are always allowed
+	reset := types.NewMsgResetCircuitBreaker(authority.String(), []string{sdk.MsgTypeURL(send)})
+	_, err = decorator.AnteHandle(ctx, mockTx{msgs: []sdk.Msg{reset}}, false, next)
+	assert.NilError(t, err)
+
+	_, err = msgServer.ResetCircuitBreaker(ctx, reset)
+	assert.NilError(t, err)
+
+	_, err = decorator.AnteHandle(ctx, mockTx{msgs: []sdk.Msg{send}}, false, next)
+	assert.NilError(t, err)
+}
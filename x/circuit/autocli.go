@@ -0,0 +1,36 @@
+package circuit
+
+import (
+	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+	circuitv1 "cosmossdk.io/api/cosmos/circuit/v1"
+)
+
+// AutoCLIOptions implements the autocli.HasAutoCLIConfig interface.
+func (am AppModule) AutoCLIOptions() *autocliv1.ModuleOptions {
+	return &autocliv1.ModuleOptions{
+		Query: &autocliv1.ServiceCommandDescriptor{
+			Service: circuitv1.Query_ServiceDesc.ServiceName,
+			RpcCommandOptions: []*autocliv1.RpcCommandOptions{
+				{
+					RpcMethod:      "Account",
+					Use:            "account [address]",
+					Short:          "Query a specific account's permissions",
+					PositionalArgs: []*autocliv1.PositionalArgDescriptor{{ProtoField: "address"}},
+				},
+				{
+					RpcMethod: "Accounts",
+					Use:       "accounts",
+					Short:     "Query all account permissions",
+				},
+				{
+					RpcMethod: "DisabledList",
+					Use:       "disabled-list",
+					Short:     "Query a list of all disabled message types",
+				},
+			},
+		},
+		Tx: &autocliv1.ServiceCommandDescriptor{
+			Service: circuitv1.Msg_ServiceDesc.ServiceName,
+		},
+	}
+}
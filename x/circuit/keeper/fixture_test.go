@@ -0,0 +1,130 @@
+package keeper
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/regen-network/gocuke"
+	"gotest.tools/v3/assert"
+
+	storetypes "cosmossdk.io/store/types"
+	"cosmossdk.io/x/circuit/types"
+
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+type baseFixture struct {
+	t   gocuke.TestingT
+	err error
+	ctx context.Context
+
+	k         Keeper
+	msgServer types.MsgServer
+	addrs     map[string]sdk.AccAddress
+	authority sdk.AccAddress
+}
+
+func initFixture(t gocuke.TestingT) *baseFixture {
+	s := &baseFixture{t: t, addrs: map[string]sdk.AccAddress{}}
+
+	encCfg := moduletestutil.MakeTestEncodingConfig()
+	types.RegisterInterfaces(encCfg.InterfaceRegistry)
+
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	s.ctx = testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test"))
+
+	s.authority = authtypes.NewModuleAddress("gov")
+	ac := addresscodec.NewBech32Codec(sdk.GetConfig().GetBech32AccountAddrPrefix())
+	s.k = NewKeeper(encCfg.Codec, runtime.NewKVStoreService(key), s.authority.String(), ac)
+	s.msgServer = NewMsgServerImpl(s.k)
+
+	return s
+}
+
+// addr returns a deterministic test address for the given account name.
+func (s *baseFixture) addr(name string) sdk.AccAddress {
+	if addr, ok := s.addrs[name]; ok {
+		return addr
+	}
+
+	addr := sdk.AccAddress(name)
+	s.addrs[name] = addr
+	return addr
+}
+
+func (s *baseFixture) setPermissions(name string, perms types.Permissions) {
+	err := s.k.Permissions.Set(s.ctx, s.addr(name), perms)
+	assert.NilError(s.t, err)
+}
+
+func (s *baseFixture) HasPermission(a, b string) {
+	level, ok := types.Permissions_Level_value[b]
+	assert.Assert(s.t, ok, "unknown permission level %s", b)
+	s.setPermissions(a, types.Permissions{Level: types.Permissions_Level(level)})
+}
+
+func (s *baseFixture) HasNoPermissions(a string) {
+	err := s.k.Permissions.Remove(s.ctx, s.addr(a))
+	assert.NilError(s.t, err)
+}
+
+func (s *baseFixture) HasPermissionToTripCircuitBreakerFor(a, b string) {
+	s.setPermissions(a, types.Permissions{
+		Level:         types.Permissions_LEVEL_SOME_MSGS,
+		LimitTypeUrls: []string{b},
+	})
+}
+
+func (s *baseFixture) disable(msgTypeURL string) {
+	err := s.k.DisableList.Set(s.ctx, types.NormalizeMsgTypeURL(msgTypeURL))
+	assert.NilError(s.t, err)
+}
+
+func (s *baseFixture) ExpectSuccess() {
+	assert.NilError(s.t, s.err)
+}
+
+func (s *baseFixture) ExpectSucesss() {
+	s.ExpectSuccess()
+}
+
+func (s *baseFixture) ExpectAnError(a string) {
+	assert.ErrorContains(s.t, s.err, a)
+}
+
+func (s *baseFixture) ExpectThatHasNoPermissions(a string) {
+	has, err := s.k.Permissions.Has(s.ctx, s.addr(a))
+	assert.NilError(s.t, err)
+	assert.Assert(s.t, !has)
+}
+
+var (
+	docLevelRegexp   = regexp.MustCompile(`"level":\s*"(\w+)"`)
+	docTypeURLRegexp = regexp.MustCompile(`\w+(?:\.\w+)+`)
+)
+
+// docTypeURLs returns the msg type URLs of a feature doc string. The doc
+// strings are parsed loosely, as they are not always valid JSON.
+func docTypeURLs(doc gocuke.DocString) []string {
+	return docTypeURLRegexp.FindAllString(doc.Content, -1)
+}
+
+// docPermissions returns the permissions of a feature doc string, i.e. its
+// level and the type URLs it lists.
+func (s *baseFixture) docPermissions(doc gocuke.DocString) types.Permissions {
+	match := docLevelRegexp.FindStringSubmatch(doc.Content)
+	assert.Assert(s.t, match != nil, "no permission level in %s", doc.Content)
+
+	level, ok := types.Permissions_Level_value[match[1]]
+	assert.Assert(s.t, ok, "unknown permission level %s", match[1])
+
+	return types.Permissions{
+		Level:         types.Permissions_Level(level),
+		LimitTypeUrls: docTypeURLs(doc),
+	}
+}
@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/x/circuit/types"
+)
+
+// ExportGenesis returns the circuit module's genesis state.
+func (k *Keeper) ExportGenesis(ctx context.Context) (data *types.GenesisState) {
+	var (
+		permissions  []*types.GenesisAccountPermissions
+		disabledMsgs []string
+	)
+
+	err := k.Permissions.Walk(ctx, nil, func(address []byte, perm types.Permissions) (stop bool) {
+		add, err := k.addressCodec.BytesToString(address)
+		if err != nil {
+			panic(err)
+		}
+		// Convert the Permissions struct to a GenesisAccountPermissions struct
+		// and add it to the permissions slice
+		permissions = append(permissions, &types.GenesisAccountPermissions{
+			Address:     add,
+			Permissions: &perm,
+		})
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
+	}
+
+	err = k.DisableList.Walk(ctx, nil, func(msgUrl string) (stop bool) {
+		disabledMsgs = append(disabledMsgs, msgUrl)
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
+	}
+
+	return &types.GenesisState{
+		AccountPermissions: permissions,
+		DisabledTypeUrls:   disabledMsgs,
+	}
+}
+
+// InitGenesis initializes the circuit module's state from a given genesis state.
+func (k *Keeper) InitGenesis(ctx context.Context, genState *types.GenesisState) {
+	for _, accounts := range genState.AccountPermissions {
+		add, err := k.addressCodec.StringToBytes(accounts.Address)
+		if err != nil {
+			panic(err)
+		}
+
+		// Set the permissions for the account
+		perms := *accounts.Permissions
+		perms.LimitTypeUrls = types.NormalizeMsgTypeURLs(perms.LimitTypeUrls)
+		if err := k.Permissions.Set(ctx, add, perms); err != nil {
+			panic(err)
+		}
+	}
+	for _, url := range genState.DisabledTypeUrls {
+		// Set the message URLs to disabled
+		if err := k.DisableList.Set(ctx, types.NormalizeMsgTypeURL(url)); err != nil {
+			panic(err)
+		}
+	}
+}
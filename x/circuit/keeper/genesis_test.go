@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/x/circuit/types"
+)
+
+func TestGenesis(t *testing.T) {
+	f := initFixture(t)
+
+	// exporting an empty store must not fail
+	assert.DeepEqual(t, &types.GenesisState{}, f.k.ExportGenesis(f.ctx))
+
+	genesis := &types.GenesisState{
+		AccountPermissions: []*types.GenesisAccountPermissions{
+			{
+				Address:     f.addr("acct1").String(),
+				Permissions: &types.Permissions{Level: types.Permissions_LEVEL_SUPER_ADMIN},
+			},
+			{
+				Address: f.addr("acct2").String(),
+				Permissions: &types.Permissions{
+					Level:         types.Permissions_LEVEL_SOME_MSGS,
+					LimitTypeUrls: []string{"cosmos.bank.v1beta1.MsgSend"},
+				},
+			},
+		},
+		DisabledTypeUrls: []string{"cosmos.bank.v1beta1.MsgMultiSend", "cosmos.bank.v1beta1.MsgSend"},
+	}
+	assert.NilError(t, genesis.Validate())
+
+	f.k.InitGenesis(f.ctx, genesis)
+
+	exported := f.k.ExportGenesis(f.ctx)
+	assert.Equal(t, len(genesis.AccountPermissions), len(exported.AccountPermissions))
+	assert.DeepEqual(t, types.NormalizeMsgTypeURLs(genesis.DisabledTypeUrls), exported.DisabledTypeUrls)
+
+	for _, url := range genesis.DisabledTypeUrls {
+		allowed, err := f.k.IsAllowed(f.ctx, url)
+		assert.NilError(t, err)
+		assert.Assert(t, !allowed)
+	}
+
+	genesis.DisabledTypeUrls = append(genesis.DisabledTypeUrls, "/cosmos.circuit.v1.MsgResetCircuitBreaker")
+	assert.ErrorContains(t, genesis.Validate(), "cannot be disabled")
+}
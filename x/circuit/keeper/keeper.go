@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/core/address"
+	"cosmossdk.io/core/store"
+	"cosmossdk.io/x/circuit/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Keeper defines the circuit module's keeper.
+type Keeper struct {
+	cdc          codec.BinaryCodec
+	storeService store.KVStoreService
+
+	authority []byte
+
+	addressCodec address.Codec
+
+	Schema collections.Schema
+	// Permissions contains the permissions for each account
+	Permissions collections.Map[[]byte, types.Permissions]
+	// DisableList contains the message URLs that are disabled
+	DisableList collections.KeySet[string]
+}
+
+// NewKeeper constructs a new Circuit Keeper instance
+func NewKeeper(cdc codec.BinaryCodec, storeService store.KVStoreService, authority string, addressCodec address.Codec) Keeper {
+	auth, err := addressCodec.StringToBytes(authority)
+	if err != nil {
+		panic(err)
+	}
+
+	sb := collections.NewSchemaBuilder(storeService)
+
+	k := Keeper{
+		cdc:          cdc,
+		storeService: storeService,
+		authority:    auth,
+		addressCodec: addressCodec,
+		Permissions: collections.NewMap(
+			sb,
+			types.AccountPermissionPrefix,
+			"permissions",
+			collections.BytesKey,
+			codec.CollValue[types.Permissions](cdc),
+		),
+		DisableList: collections.NewKeySet(
+			sb,
+			types.DisableListPrefix,
+			"disable_list",
+			collections.StringKey,
+		),
+	}
+
+	schema, err := sb.Build()
+	if err != nil {
+		panic(err)
+	}
+	k.Schema = schema
+
+	return k
+}
+
+// GetAuthority returns the x/circuit module's authority.
+func (k *Keeper) GetAuthority() []byte {
+	return k.authority
+}
+
+// IsAllowed returns true when msg URL is not found in the DisableList for given context, else false.
+// The messages of the circuit module are always allowed, and msg URL may be
+// given with or without its leading "/".
+// It satisfies the baseapp.CircuitBreaker interface.
+func (k *Keeper) IsAllowed(ctx context.Context, msgURL string) (bool, error) {
+	if types.IsCircuitMsg(msgURL) {
+		return true, nil
+	}
+
+	has, err := k.DisableList.Has(ctx, types.NormalizeMsgTypeURL(msgURL))
+	return !has, err
+}
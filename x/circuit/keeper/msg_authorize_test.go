@@ -4,11 +4,11 @@ import (
 	"testing"
 
 	"github.com/regen-network/gocuke"
-	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/x/circuit/types"
 )
 
 func TestAuthorize(t *testing.T) {
-	t.Skip("TODO: uncomment this after implementing")
 	gocuke.NewRunner(t, &authorizeSuite{}).Path("../features/msg_authorize.feature").Run()
 }
 
@@ -16,30 +16,20 @@ type authorizeSuite struct {
 	*baseFixture
 }
 
-func (s *authorizeSuite) Before(t *testing.T) {
+func (s *authorizeSuite) Before(t gocuke.TestingT) {
 	s.baseFixture = initFixture(t)
 }
 
-func (s *authorizeSuite) HasPermission(a, b string) {
-	panic("PENDING")
-}
-
-func (s *authorizeSuite) HasNoPermissions(a string) {
-	panic("PENDING")
-}
-
 func (s *authorizeSuite) AttemptsToGrantThePermissions(a, b string, c gocuke.DocString) {
-	panic("PENDING")
-}
-
-func (s *authorizeSuite) ExpectSuccess() {
-	assert.NilError(s.t, s.err)
+	s.authorize(a, b, c)
 }
 
-func (s *authorizeSuite) ExpectAnError(a string) {
-	assert.Error(s.t, s.err, a)
+func (s *authorizeSuite) AttemptsToRevokeThePermissions(a, b string, c gocuke.DocString) {
+	s.authorize(a, b, c)
 }
 
-func (s *authorizeSuite) ExpectThatHasNoPermissions(a string) {
-	panic("PENDING")
+func (s *authorizeSuite) authorize(granter, grantee string, doc gocuke.DocString) {
+	perms := s.docPermissions(doc)
+	msg := types.NewMsgAuthorizeCircuitBreaker(s.addr(granter).String(), s.addr(grantee).String(), &perms)
+	_, s.err = s.msgServer.AuthorizeCircuitBreaker(s.ctx, msg)
 }
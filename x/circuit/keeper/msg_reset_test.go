@@ -4,42 +4,43 @@ import (
 	"testing"
 
 	"github.com/regen-network/gocuke"
-	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/x/circuit/types"
 )
 
 func TestReset(t *testing.T) {
-	t.Skip("TODO: uncomment this after implementing")
 	gocuke.NewRunner(t, &resetSuite{}).Path("../features/msg_reset.feature").Run()
 }
 
 type resetSuite struct {
 	*baseFixture
-}
 
-func (s *resetSuite) Before(t *testing.T) {
-	s.baseFixture = initFixture(t)
+	// enabled are the messages which are not disabled before the account
+	// attempts to reset them
+	enabled map[string]bool
 }
 
-func (s *resetSuite) HasPermission(a, b string) {
-	panic("PENDING")
-}
-
-func (s *resetSuite) HasNoPermissions(a string) {
-	panic("PENDING")
+func (s *resetSuite) Before(t gocuke.TestingT) {
+	s.baseFixture = initFixture(t)
+	s.enabled = map[string]bool{}
 }
 
-func (s *resetSuite) AttemptsToResetCircuit(a, b string, c gocuke.DocString) {
-	panic("PENDING")
+func (s *resetSuite) HasPermissionHasBeenEnabled(a, b, c string) {
+	s.HasPermission(a, b)
+	s.enabled[c] = true
 }
 
-func (s *resetSuite) ExpectSuccess() {
-	assert.NilError(s.t, s.err)
+func (s *resetSuite) AttemptsToEnableADisabledMessage(a string, b gocuke.DocString) {
+	s.AttemptsToResetADisabledMessage(a, b)
 }
 
-func (s *resetSuite) ExpectAnError(a string) {
-	assert.Error(s.t, s.err, a)
-}
+func (s *resetSuite) AttemptsToResetADisabledMessage(a string, b gocuke.DocString) {
+	msgTypeURLs := docTypeURLs(b)
+	for _, msgTypeURL := range msgTypeURLs {
+		if !s.enabled[msgTypeURL] {
+			s.disable(msgTypeURL)
+		}
+	}
 
-func (s *resetSuite) ExpectThatHasNoPermissions(a string) {
-	panic("PENDING")
+	_, s.err = s.msgServer.ResetCircuitBreaker(s.ctx, types.NewMsgResetCircuitBreaker(s.addr(a).String(), msgTypeURLs))
 }
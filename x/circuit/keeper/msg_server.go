@@ -0,0 +1,237 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"cosmossdk.io/collections"
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/x/circuit/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+type msgServer struct {
+	Keeper
+}
+
+var _ types.MsgServer = msgServer{}
+
+// NewMsgServerImpl returns an implementation of the circuit MsgServer interface
+// for the provided Keeper.
+func NewMsgServerImpl(keeper Keeper) types.MsgServer {
+	return &msgServer{Keeper: keeper}
+}
+
+// AuthorizeCircuitBreaker grants (or revokes, using LEVEL_NONE_UNSPECIFIED)
+// circuit breaker permissions to an account. The module authority and super
+// admins may grant any permissions, accounts with LEVEL_ALL_MSGS may only grant
+// LEVEL_SOME_MSGS.
+func (srv msgServer) AuthorizeCircuitBreaker(goCtx context.Context, msg *types.MsgAuthorizeCircuitBreaker) (*types.MsgAuthorizeCircuitBreakerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	address, err := srv.addressCodec.StringToBytes(msg.Granter)
+	if err != nil {
+		return nil, errorsmod.Wrapf(types.ErrInvalidAddress, "granter: %s", err)
+	}
+
+	if msg.Permissions == nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "permissions cannot be nil")
+	}
+
+	// if the granter is the module authority no need to check perms
+	if !bytes.Equal(address, srv.GetAuthority()) {
+		perms, err := srv.getPermissions(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case perms.Level == types.Permissions_LEVEL_SUPER_ADMIN:
+		case perms.Level == types.Permissions_LEVEL_ALL_MSGS && msg.Permissions.Level == types.Permissions_LEVEL_SOME_MSGS:
+		default:
+			return nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only super admins can authorize users with these permissions")
+		}
+	}
+
+	grantee, err := srv.addressCodec.StringToBytes(msg.Grantee)
+	if err != nil {
+		return nil, errorsmod.Wrapf(types.ErrInvalidAddress, "grantee: %s", err)
+	}
+
+	if err := msg.Permissions.Validate(); err != nil {
+		return nil, err
+	}
+
+	if msg.Permissions.Level == types.Permissions_LEVEL_NONE_UNSPECIFIED {
+		err = srv.Permissions.Remove(ctx, grantee)
+	} else {
+		perms := *msg.Permissions
+		perms.LimitTypeUrls = types.NormalizeMsgTypeURLs(perms.LimitTypeUrls)
+		err = srv.Permissions.Set(ctx, grantee, perms)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			"authorize_circuit_breaker",
+			sdk.NewAttribute("granter", msg.Granter),
+			sdk.NewAttribute("grantee", msg.Grantee),
+			sdk.NewAttribute("permission", msg.Permissions.String()),
+		),
+	})
+
+	return &types.MsgAuthorizeCircuitBreakerResponse{
+		Success: true,
+	}, nil
+}
+
+// TripCircuitBreaker disables execution of the given msg type URLs, provided
+// the caller has permission to trip every one of them. The type URLs are stored
+// with their leading "/", as matched by IsAllowed.
+func (srv msgServer) TripCircuitBreaker(goCtx context.Context, msg *types.MsgTripCircuitBreaker) (*types.MsgTripCircuitBreakerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	address, err := srv.addressCodec.StringToBytes(msg.Authority)
+	if err != nil {
+		return nil, errorsmod.Wrapf(types.ErrInvalidAddress, "authority: %s", err)
+	}
+
+	if len(msg.MsgTypeUrls) == 0 {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "msg_type_urls cannot be empty")
+	}
+
+	perms, err := srv.getPermissions(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	msgTypeURLs := types.NormalizeMsgTypeURLs(msg.MsgTypeUrls)
+	for _, msgTypeURL := range msgTypeURLs {
+		if types.IsCircuitMsg(msgTypeURL) {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "circuit module message %s cannot be disabled", msgTypeURL)
+		}
+
+		isAllowed, err := srv.IsAllowed(ctx, msgTypeURL)
+		if err != nil {
+			return nil, err
+		}
+		if !isAllowed {
+			return nil, errorsmod.Wrapf(types.ErrMsgDisabled, "message %s is already disabled", msgTypeURL)
+		}
+
+		if !srv.canToggle(address, perms, msgTypeURL) {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "account does not have permission to trip circuit breaker for message %s", msgTypeURL)
+		}
+
+		if err = srv.DisableList.Set(ctx, msgTypeURL); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			"trip_circuit_breaker",
+			sdk.NewAttribute("authority", msg.Authority),
+			sdk.NewAttribute("msg_url", strings.Join(msgTypeURLs, ",")),
+		),
+	})
+
+	return &types.MsgTripCircuitBreakerResponse{
+		Success: true,
+	}, nil
+}
+
+// ResetCircuitBreaker re-enables execution of the given msg type URLs, or of
+// every disabled msg if no type URLs are given. Any account with circuit
+// breaker permissions may reset the circuit breaker of any msg.
+func (srv msgServer) ResetCircuitBreaker(goCtx context.Context, msg *types.MsgResetCircuitBreaker) (*types.MsgResetCircuitBreakerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	address, err := srv.addressCodec.StringToBytes(msg.Authority)
+	if err != nil {
+		return nil, errorsmod.Wrapf(types.ErrInvalidAddress, "authority: %s", err)
+	}
+
+	perms, err := srv.getPermissions(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if perms.Level == types.Permissions_LEVEL_NONE_UNSPECIFIED && !bytes.Equal(address, srv.GetAuthority()) {
+		return nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "account does not have permission to reset the circuit breaker")
+	}
+
+	msgTypeURLs := types.NormalizeMsgTypeURLs(msg.MsgTypeUrls)
+	if len(msgTypeURLs) == 0 {
+		err = srv.DisableList.Walk(ctx, nil, func(msgTypeURL string) (stop bool) {
+			msgTypeURLs = append(msgTypeURLs, msgTypeURL)
+			return false
+		})
+		if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+			return nil, err
+		}
+
+		if len(msgTypeURLs) == 0 {
+			return nil, errorsmod.Wrap(types.ErrMsgEnabled, "no message is disabled")
+		}
+	}
+
+	for _, msgTypeURL := range msgTypeURLs {
+		isAllowed, err := srv.IsAllowed(ctx, msgTypeURL)
+		if err != nil {
+			return nil, err
+		}
+		if isAllowed {
+			return nil, errorsmod.Wrapf(types.ErrMsgEnabled, "message %s is not disabled", msgTypeURL)
+		}
+
+		if err = srv.DisableList.Remove(ctx, msgTypeURL); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			"reset_circuit_breaker",
+			sdk.NewAttribute("authority", msg.Authority),
+			sdk.NewAttribute("msg_url", strings.Join(msgTypeURLs, ",")),
+		),
+	})
+
+	return &types.MsgResetCircuitBreakerResponse{Success: true}, nil
+}
+
+// getPermissions returns the permissions of the given account, or empty
+// permissions if the account has none.
+func (srv msgServer) getPermissions(ctx context.Context, address []byte) (types.Permissions, error) {
+	perms, err := srv.Permissions.Get(ctx, address)
+	if err != nil && !errors.Is(err, collections.ErrNotFound) {
+		return types.Permissions{}, err
+	}
+
+	return perms, nil
+}
+
+// canToggle reports whether the account may trip the circuit breaker for the
+// given normalized msg type URL.
+func (srv msgServer) canToggle(address []byte, perms types.Permissions, msgTypeURL string) bool {
+	switch perms.Level {
+	case types.Permissions_LEVEL_SUPER_ADMIN, types.Permissions_LEVEL_ALL_MSGS:
+		return true
+	case types.Permissions_LEVEL_SOME_MSGS:
+		for _, url := range perms.LimitTypeUrls {
+			if types.NormalizeMsgTypeURL(url) == msgTypeURL {
+				return true
+			}
+		}
+		return false
+	default:
+		return bytes.Equal(address, srv.GetAuthority())
+	}
+}
@@ -4,42 +4,53 @@ import (
 	"testing"
 
 	"github.com/regen-network/gocuke"
-	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/x/circuit/types"
 )
 
 func TestTrip(t *testing.T) {
-	t.Skip("TODO: uncomment this after implementing")
 	gocuke.NewRunner(t, &tripSuite{}).Path("../features/msg_trip.feature").Run()
 }
 
 type tripSuite struct {
 	*baseFixture
+
+	// tripped is true if the circuit breaker is already tripped for the
+	// messages the account attempts to disable
+	tripped bool
 }
 
-func (s *tripSuite) Before(t *testing.T) {
+func (s *tripSuite) Before(t gocuke.TestingT) {
 	s.baseFixture = initFixture(t)
 }
 
-func (s *tripSuite) HasPermission(a, b string) {
-	panic("PENDING")
+func (s *tripSuite) HasPermissionToDisable(a, b string) {
+	s.HasPermissionToTripCircuitBreakerFor(a, b)
 }
 
-func (s *tripSuite) HasNoPermissions(a string) {
-	panic("PENDING")
+func (s *tripSuite) HasPermissionToDiable(a, b string) {
+	s.HasPermissionToTripCircuitBreakerFor(a, b)
 }
 
-func (s *tripSuite) AttemptsToTripCircuit(a, b string, c gocuke.DocString) {
-	panic("PENDING")
+func (s *tripSuite) HasPermissionToDisableAnd(a, b, c string) {
+	s.setPermissions(a, types.Permissions{
+		Level:         types.Permissions_LEVEL_SOME_MSGS,
+		LimitTypeUrls: []string{b, c},
+	})
 }
 
-func (s *tripSuite) ExpectSuccess() {
-	assert.NilError(s.t, s.err)
+func (s *tripSuite) HasPermissionToDiableIsAlreadyTripped(a, b string) {
+	s.HasPermissionToTripCircuitBreakerFor(a, b)
+	s.tripped = true
 }
 
-func (s *tripSuite) ExpectAnError(a string) {
-	assert.Error(s.t, s.err, a)
-}
+func (s *tripSuite) AttemptsToDisableMsgExecution(a string, b gocuke.DocString) {
+	msgTypeURLs := docTypeURLs(b)
+	if s.tripped {
+		for _, msgTypeURL := range msgTypeURLs {
+			s.disable(msgTypeURL)
+		}
+	}
 
-func (s *tripSuite) ExpectThatHasNoPermissions(a string) {
-	panic("PENDING")
+	_, s.err = s.msgServer.TripCircuitBreaker(s.ctx, types.NewMsgTripCircuitBreaker(s.addr(a).String(), msgTypeURLs))
 }
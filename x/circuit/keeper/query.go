@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/x/circuit/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+var _ types.QueryServer = QueryServer{}
+
+// QueryServer implements the circuit module's gRPC query service.
+type QueryServer struct {
+	keeper Keeper
+}
+
+// NewQueryServer returns an implementation of the circuit QueryServer
+// interface for the provided Keeper.
+func NewQueryServer(keeper Keeper) types.QueryServer {
+	return &QueryServer{keeper: keeper}
+}
+
+// Account returns account permissions.
+func (qs QueryServer) Account(goCtx context.Context, req *types.QueryAccountRequest) (*types.AccountResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	add, err := qs.keeper.addressCodec.StringToBytes(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	perms, err := qs.keeper.Permissions.Get(ctx, add)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AccountResponse{Permission: &perms}, nil
+}
+
+// Accounts returns the permissions of all accounts.
+func (qs QueryServer) Accounts(goCtx context.Context, req *types.QueryAccountsRequest) (*types.AccountsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	results, pageRes, err := query.CollectionPaginate[[]byte, types.Permissions](ctx, qs.keeper.Permissions, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*types.GenesisAccountPermissions, 0, len(results))
+	for _, result := range results {
+		address, err := qs.keeper.addressCodec.BytesToString(result.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		perms := result.Value
+		accounts = append(accounts, &types.GenesisAccountPermissions{
+			Address:     address,
+			Permissions: &perms,
+		})
+	}
+
+	return &types.AccountsResponse{Accounts: accounts, Pagination: pageRes}, nil
+}
+
+// DisabledList returns a list of disabled message urls.
+func (qs QueryServer) DisabledList(goCtx context.Context, req *types.QueryDisabledListRequest) (*types.DisabledListResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	var msgs []string
+	err := qs.keeper.DisableList.Walk(ctx, nil, func(msgUrl string) bool {
+		msgs = append(msgs, msgUrl)
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		return nil, err
+	}
+
+	return &types.DisabledListResponse{DisabledList: msgs}, nil
+}
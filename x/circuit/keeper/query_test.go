@@ -0,0 +1,33 @@
+package keeper
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/x/circuit/types"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+func TestQueryServer(t *testing.T) {
+	f := initFixture(t)
+	qs := NewQueryServer(f.k)
+
+	f.HasPermission("acct1", "LEVEL_SUPER_ADMIN")
+	f.HasPermission("acct2", "LEVEL_ALL_MSGS")
+	f.disable("cosmos.bank.v1beta1.MsgSend")
+
+	res, err := qs.Account(f.ctx, &types.QueryAccountRequest{Address: f.addr("acct1").String()})
+	assert.NilError(t, err)
+	assert.Equal(t, types.Permissions_LEVEL_SUPER_ADMIN, res.Permission.Level)
+
+	accounts, err := qs.Accounts(f.ctx, &types.QueryAccountsRequest{Pagination: &query.PageRequest{Limit: 1, CountTotal: true}})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(accounts.Accounts))
+	assert.Equal(t, uint64(2), accounts.Pagination.Total)
+
+	list, err := qs.DisabledList(f.ctx, &types.QueryDisabledListRequest{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"/cosmos.bank.v1beta1.MsgSend"}, list.DisabledList)
+}
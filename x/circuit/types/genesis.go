@@ -0,0 +1,42 @@
+package types
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// DefaultGenesisState returns a default genesis state for the circuit module.
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{}
+}
+
+// Validate performs basic validation of the circuit genesis state: every
+// account must carry valid permissions and type URLs must not be empty nor
+// belong to the circuit module.
+func (gs *GenesisState) Validate() error {
+	for _, account := range gs.AccountPermissions {
+		if account.Address == "" {
+			return fmt.Errorf("invalid account address: %s", account.Address)
+		}
+		if account.Permissions == nil {
+			return fmt.Errorf("permissions for account %s must not be nil", account.Address)
+		}
+		if err := account.Permissions.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, url := range gs.DisabledTypeUrls {
+		if url == "" {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "disabled type url cannot be empty")
+		}
+		if IsCircuitMsg(url) {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "circuit module message %s cannot be disabled", url)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ sdk.Msg = &MsgAuthorizeCircuitBreaker{}
+	_ sdk.Msg = &MsgTripCircuitBreaker{}
+	_ sdk.Msg = &MsgResetCircuitBreaker{}
+)
+
+// IsCircuitMsg returns true if msgTypeURL is the type URL of a message of the
+// circuit module. Their execution cannot be disabled, otherwise the circuit
+// breaker could not be reset nor its permissions be changed anymore.
+func IsCircuitMsg(msgTypeURL string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(msgTypeURL, "/"), "cosmos.circuit.")
+}
+
+// NormalizeMsgTypeURL returns msgTypeURL with a leading "/", as returned by
+// sdk.MsgTypeURL, so that a message type is matched whether or not its type URL
+// is given with the leading "/".
+func NormalizeMsgTypeURL(msgTypeURL string) string {
+	return "/" + strings.TrimPrefix(msgTypeURL, "/")
+}
+
+// NormalizeMsgTypeURLs returns the normalized form of every type URL of
+// msgTypeURLs.
+func NormalizeMsgTypeURLs(msgTypeURLs []string) []string {
+	if msgTypeURLs == nil {
+		return nil
+	}
+
+	normalized := make([]string, len(msgTypeURLs))
+	for i, msgTypeURL := range msgTypeURLs {
+		normalized[i] = NormalizeMsgTypeURL(msgTypeURL)
+	}
+	return normalized
+}
+
+// NewMsgAuthorizeCircuitBreaker creates a new MsgAuthorizeCircuitBreaker instance.
+func NewMsgAuthorizeCircuitBreaker(granter, grantee string, permission *Permissions) *MsgAuthorizeCircuitBreaker {
+	return &MsgAuthorizeCircuitBreaker{
+		Granter:     granter,
+		Grantee:     grantee,
+		Permissions: permission,
+	}
+}
+
+// GetSigners returns the expected signers for a MsgAuthorizeCircuitBreaker.
+func (m MsgAuthorizeCircuitBreaker) GetSigners() []sdk.AccAddress {
+	granter, _ := sdk.AccAddressFromBech32(m.Granter)
+	return []sdk.AccAddress{granter}
+}
+
+// NewMsgTripCircuitBreaker creates a new MsgTripCircuitBreaker instance.
+func NewMsgTripCircuitBreaker(authority string, urls []string) *MsgTripCircuitBreaker {
+	return &MsgTripCircuitBreaker{
+		Authority:   authority,
+		MsgTypeUrls: urls,
+	}
+}
+
+// GetSigners returns the expected signers for a MsgTripCircuitBreaker.
+func (m MsgTripCircuitBreaker) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(m.Authority)
+	return []sdk.AccAddress{authority}
+}
+
+// NewMsgResetCircuitBreaker creates a new MsgResetCircuitBreaker instance.
+func NewMsgResetCircuitBreaker(authority string, urls []string) *MsgResetCircuitBreaker {
+	return &MsgResetCircuitBreaker{
+		Authority:   authority,
+		MsgTypeUrls: urls,
+	}
+}
+
+// GetSigners returns the expected signers for a MsgResetCircuitBreaker.
+func (m MsgResetCircuitBreaker) GetSigners() []sdk.AccAddress {
+	authority, _ := sdk.AccAddressFromBech32(m.Authority)
+	return []sdk.AccAddress{authority}
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Validate checks that limit_type_urls is only used together with
+// LEVEL_SOME_MSGS and that LEVEL_SOME_MSGS always carries it.
+func (p *Permissions) Validate() error {
+	if p.Level == Permissions_LEVEL_SOME_MSGS {
+		if len(p.LimitTypeUrls) == 0 {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "limit_type_urls must be set when using LEVEL_SOME_MSGS")
+		}
+		return nil
+	}
+
+	if len(p.LimitTypeUrls) != 0 {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "limit_type_urls must be empty when using %s", p.Level)
+	}
+
+	return nil
+}
@@ -6,7 +6,6 @@ package types
 import (
 	context "context"
 	fmt "fmt"
-	_ "github.com/cosmos/cosmos-sdk/types/msgservice"
 	query "github.com/cosmos/cosmos-sdk/types/query"
 	grpc1 "github.com/cosmos/gogoproto/grpc"
 	proto "github.com/cosmos/gogoproto/proto"
@@ -314,41 +313,39 @@ func init() {
 func init() { proto.RegisterFile("cosmos/circuit/v1/query.proto", fileDescriptor_87c65073a3d3c1e1) }
 
 var fileDescriptor_87c65073a3d3c1e1 = []byte{
-	// 531 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0xcf, 0x6b, 0x13, 0x41,
-	0x14, 0xc7, 0x33, 0x2d, 0xb5, 0xed, 0x6b, 0x45, 0x1d, 0x0b, 0x86, 0x6d, 0xbb, 0xd6, 0x2d, 0x36,
-	0xa1, 0x96, 0x1d, 0x12, 0xc1, 0x8b, 0x20, 0x28, 0x62, 0x3d, 0x78, 0x68, 0x73, 0xf4, 0xa0, 0x4c,
-	0x76, 0x87, 0x65, 0x30, 0xd9, 0xd9, 0xee, 0xdb, 0x04, 0x8b, 0x08, 0xd2, 0x93, 0xde, 0x44, 0xff,
-	0x06, 0xef, 0x1e, 0xfc, 0x23, 0x3c, 0x16, 0xbc, 0x78, 0x94, 0x44, 0xf0, 0xdf, 0x90, 0xec, 0xce,
-	0x6c, 0x36, 0x76, 0x6a, 0x8f, 0x33, 0xef, 0x7d, 0xbf, 0xf3, 0x79, 0x3f, 0x76, 0x61, 0x33, 0x50,
-	0xd8, 0x57, 0xc8, 0x02, 0x99, 0x06, 0x03, 0x99, 0xb1, 0x61, 0x8b, 0x1d, 0x0d, 0x44, 0x7a, 0xec,
-	0x27, 0xa9, 0xca, 0x14, 0xbd, 0x56, 0x84, 0x7d, 0x1d, 0xf6, 0x87, 0x2d, 0x67, 0x57, 0x2b, 0xba,
-	0x1c, 0x45, 0x91, 0xcb, 0x86, 0xad, 0xae, 0xc8, 0x78, 0x8b, 0x25, 0x3c, 0x92, 0x31, 0xcf, 0xa4,
-	0x8a, 0x0b, 0xb9, 0x73, 0x43, 0xe7, 0xf6, 0x31, 0x9a, 0x38, 0xf7, 0x31, 0xd2, 0x01, 0xcb, 0xb3,
-	0xd9, 0x71, 0x22, 0x50, 0x87, 0x37, 0x22, 0xa5, 0xa2, 0x9e, 0x60, 0x3c, 0x91, 0x8c, 0xc7, 0xb1,
-	0xca, 0x72, 0x53, 0x13, 0x5d, 0xd7, 0x62, 0xf3, 0x78, 0x95, 0xd8, 0x63, 0x70, 0xfd, 0x70, 0x72,
-	0x7c, 0x18, 0x04, 0x6a, 0x10, 0x67, 0x1d, 0x71, 0x34, 0x10, 0x98, 0xd1, 0x3a, 0x2c, 0xf2, 0x30,
-	0x4c, 0x05, 0x62, 0x9d, 0x6c, 0x91, 0xe6, 0x72, 0xc7, 0x1c, 0xbd, 0x43, 0xb8, 0x52, 0xe6, 0x62,
-	0xa2, 0x62, 0x14, 0xf4, 0x01, 0x40, 0x22, 0xd2, 0xbe, 0x44, 0x94, 0x2a, 0xce, 0xf3, 0x57, 0xda,
-	0xae, 0x7f, 0xa6, 0x15, 0xfe, 0x41, 0x99, 0x84, 0x9d, 0x8a, 0xc2, 0x7b, 0x01, 0x6b, 0x55, 0x06,
-	0x34, 0x10, 0x4f, 0x00, 0xa6, 0x2d, 0xd2, 0xbe, 0x3b, 0xc6, 0x77, 0xd2, 0x4f, 0xbf, 0xa8, 0x44,
-	0xf7, 0xd3, 0x3f, 0xe0, 0x91, 0xd0, 0xda, 0x4e, 0x45, 0xe9, 0x7d, 0x21, 0x70, 0x75, 0xea, 0xad,
-	0xa1, 0x9f, 0xc2, 0x12, 0xd7, 0x77, 0x75, 0xb2, 0x35, 0xdf, 0x5c, 0x69, 0xef, 0x59, 0x90, 0xf7,
-	0x45, 0x2c, 0x50, 0xa2, 0x56, 0x57, 0x0b, 0x28, 0xd5, 0x74, 0x7f, 0x06, 0x73, 0x2e, 0xc7, 0x6c,
-	0x5c, 0x88, 0x59, 0x60, 0xcc, 0x70, 0x3a, 0x50, 0xcf, 0xfb, 0xf0, 0x58, 0x22, 0xef, 0xf6, 0x44,
-	0xf8, 0x4c, 0xa2, 0x19, 0x88, 0x77, 0x1f, 0xd6, 0x66, 0xaf, 0x75, 0x19, 0xdb, 0x70, 0x39, 0xd4,
-	0xf7, 0x2f, 0x7b, 0x12, 0xb3, 0xbc, 0x96, 0xe5, 0xce, 0x6a, 0x58, 0x49, 0x6e, 0x7f, 0x9b, 0x87,
-	0x85, 0xdc, 0x99, 0x7e, 0x20, 0xb0, 0xa8, 0x8b, 0xa1, 0x3b, 0x96, 0x7a, 0x2d, 0xbb, 0xe0, 0x78,
-	0x96, 0xbc, 0x7f, 0x56, 0xc0, 0x6b, 0xbf, 0xff, 0xf3, 0x75, 0x97, 0x9c, 0xfc, 0xf8, 0xfd, 0x79,
-	0xae, 0x41, 0x6f, 0xb3, 0xb3, 0xeb, 0x6a, 0xba, 0xc5, 0xde, 0xe8, 0x45, 0x7a, 0x4b, 0x4f, 0x08,
-	0x2c, 0x99, 0xb1, 0xd0, 0xc6, 0x05, 0x30, 0x66, 0x29, 0x9c, 0xed, 0xf3, 0x69, 0xca, 0xe1, 0x7a,
-	0xcd, 0x29, 0xce, 0x26, 0x5d, 0xff, 0x0f, 0x0e, 0xfd, 0x44, 0x60, 0xb5, 0xda, 0x58, 0x7a, 0xe7,
-	0x3c, 0x10, 0xcb, 0x54, 0x1c, 0x1b, 0xb5, 0x6d, 0x4c, 0xde, 0xde, 0x14, 0xe8, 0x16, 0xbd, 0x69,
-	0x01, 0xd2, 0xf3, 0xca, 0x67, 0xe8, 0x2c, 0xbc, 0x9b, 0x64, 0x3f, 0xba, 0xf7, 0x7d, 0xe4, 0x92,
-	0xd3, 0x91, 0x4b, 0x7e, 0x8d, 0x5c, 0xf2, 0x71, 0xec, 0xd6, 0x4e, 0xc7, 0x6e, 0xed, 0xe7, 0xd8,
-	0xad, 0x3d, 0xdf, 0x28, 0x0c, 0x30, 0x7c, 0xe5, 0x4b, 0xc5, 0x5e, 0x97, 0x46, 0xf9, 0x4f, 0xa1,
-	0x7b, 0x29, 0xff, 0xb4, 0xef, 0xfe, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xab, 0xa8, 0x92, 0x5a, 0xad,
-	0x04, 0x00, 0x00,
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xc1, 0x6b, 0xd4, 0x4e,
+	0x14, 0xc7, 0x77, 0x5a, 0x7e, 0xbf, 0xb6, 0xaf, 0x15, 0x75, 0xec, 0x61, 0x49, 0xdb, 0x58, 0x53,
+	0xec, 0x2e, 0xb5, 0x64, 0xd8, 0x15, 0xbc, 0x08, 0x82, 0x22, 0xd6, 0x83, 0x87, 0x36, 0x47, 0x0f,
+	0xca, 0x6c, 0x32, 0x2c, 0x83, 0xdb, 0x4c, 0x9a, 0x97, 0x5d, 0x2c, 0xe2, 0xa5, 0x27, 0xbd, 0x89,
+	0xfe, 0x0d, 0x1e, 0x05, 0xff, 0x0c, 0x8f, 0x05, 0x2f, 0x1e, 0x65, 0x57, 0xf0, 0xdf, 0x90, 0x4e,
+	0x66, 0xb2, 0x59, 0x3b, 0xb5, 0xc7, 0xcc, 0x7c, 0xbf, 0x2f, 0x9f, 0xf7, 0xbe, 0x6f, 0x60, 0x23,
+	0x56, 0x78, 0xa8, 0x90, 0xc5, 0x32, 0x8f, 0x87, 0xb2, 0x60, 0xa3, 0x0e, 0x3b, 0x1a, 0x8a, 0xfc,
+	0x38, 0xcc, 0x72, 0x55, 0x28, 0x7a, 0xbd, 0xbc, 0x0e, 0xcd, 0x75, 0x38, 0xea, 0x78, 0x3b, 0xc6,
+	0xd1, 0xe3, 0x28, 0x4a, 0x2d, 0x1b, 0x75, 0x7a, 0xa2, 0xe0, 0x1d, 0x96, 0xf1, 0xbe, 0x4c, 0x79,
+	0x21, 0x55, 0x5a, 0xda, 0x3d, 0x47, 0xf5, 0xe2, 0x38, 0x13, 0x68, 0xae, 0xd7, 0xfb, 0x4a, 0xf5,
+	0x07, 0x82, 0xf1, 0x4c, 0x32, 0x9e, 0xa6, 0xaa, 0xd0, 0x5e, 0x7b, 0xbb, 0x66, 0xcc, 0xf6, 0x1f,
+	0x75, 0xb0, 0x80, 0xc1, 0x8d, 0x83, 0xb3, 0xcf, 0x87, 0x71, 0xac, 0x86, 0x69, 0x11, 0x89, 0xa3,
+	0xa1, 0xc0, 0x82, 0x36, 0x61, 0x81, 0x27, 0x49, 0x2e, 0x10, 0x9b, 0x64, 0x93, 0xb4, 0x97, 0x22,
+	0xfb, 0x19, 0x1c, 0xc0, 0xd5, 0x4a, 0x8b, 0x99, 0x4a, 0x51, 0xd0, 0x07, 0x00, 0x99, 0xc8, 0x0f,
+	0x25, 0xa2, 0x54, 0xa9, 0xd6, 0x2f, 0x77, 0xfd, 0xf0, 0x5c, 0xc7, 0xe1, 0x7e, 0x25, 0xc2, 0xa8,
+	0xe6, 0x08, 0x5e, 0xc0, 0x6a, 0x9d, 0x01, 0x2d, 0xc4, 0x13, 0x80, 0xe9, 0x24, 0x4c, 0xdd, 0x6d,
+	0x5b, 0xf7, 0x6c, 0x6c, 0x61, 0xd9, 0x89, 0x19, 0x5b, 0xb8, 0xcf, 0xfb, 0xc2, 0x78, 0xa3, 0x9a,
+	0x33, 0xf8, 0x4c, 0xe0, 0xda, 0xb4, 0xb6, 0x81, 0x7e, 0x0a, 0x8b, 0xdc, 0x9c, 0x35, 0xc9, 0xe6,
+	0x7c, 0x7b, 0xb9, 0xbb, 0xeb, 0x40, 0xde, 0x13, 0xa9, 0x40, 0x89, 0xc6, 0x5d, 0x6f, 0xa0, 0x72,
+	0xd3, 0xbd, 0x19, 0xcc, 0x39, 0x8d, 0xd9, 0xba, 0x14, 0xb3, 0xc4, 0x98, 0xe1, 0xf4, 0xa0, 0xa9,
+	0xe7, 0xf0, 0x58, 0x22, 0xef, 0x0d, 0x44, 0xf2, 0x4c, 0xa2, 0x0d, 0x24, 0xb8, 0x0f, 0xab, 0xb3,
+	0xc7, 0xa6, 0x8d, 0x2d, 0xb8, 0x92, 0x98, 0xf3, 0x97, 0x03, 0x89, 0x85, 0xee, 0x65, 0x29, 0x5a,
+	0x49, 0x6a, 0xe2, 0xee, 0x97, 0x79, 0xf8, 0x4f, 0x57, 0xa6, 0xef, 0x09, 0x2c, 0x98, 0x66, 0xe8,
+	0xb6, 0xa3, 0x5f, 0xc7, 0x2e, 0x78, 0x81, 0x43, 0xf7, 0xd7, 0x0a, 0x04, 0xdd, 0x77, 0xbf, 0xbf,
+	0xee, 0x90, 0x93, 0xef, 0xbf, 0x3e, 0xcd, 0xb5, 0xe8, 0x6d, 0x76, 0x7e, 0x5d, 0xed, 0xb4, 0xd8,
+	0x1b, 0xb3, 0x48, 0x6f, 0xe9, 0x09, 0x81, 0x45, 0x1b, 0x0b, 0x6d, 0x5d, 0x02, 0x63, 0x97, 0xc2,
+	0xdb, 0xba, 0x98, 0xa6, 0x0a, 0x37, 0x68, 0x4f, 0x71, 0x36, 0xe8, 0xda, 0x3f, 0x70, 0xe8, 0x47,
+	0x02, 0x2b, 0xf5, 0xc1, 0xd2, 0x3b, 0x17, 0x81, 0x38, 0x52, 0xf1, 0x5c, 0xd4, 0xae, 0x98, 0x82,
+	0xdd, 0x29, 0xd0, 0x2d, 0x7a, 0xd3, 0x01, 0x64, 0xf2, 0xd2, 0x19, 0x3e, 0xba, 0xf7, 0x7c, 0xbd,
+	0x54, 0x60, 0xf2, 0x2a, 0x94, 0x8a, 0xbd, 0xae, 0x94, 0xfa, 0xd5, 0x7f, 0x1b, 0xfb, 0xe4, 0x74,
+	0xec, 0x93, 0x9f, 0x63, 0x9f, 0x7c, 0x98, 0xf8, 0x8d, 0xd3, 0x89, 0xdf, 0xf8, 0x31, 0xf1, 0x1b,
+	0xbd, 0xff, 0xf5, 0x9b, 0xbe, 0xfb, 0x67, 0x00, 0xd9, 0x8d, 0x49, 0x1e, 0x8d, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,61 @@
+package feemarket
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/x/feemarket/keeper"
+	"cosmossdk.io/x/feemarket/types"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EndBlocker distributes the base fees collected in the block and adjusts the
+// base fee of the next block to the gas used by the block. It is a no-op if the
+// module state was not initialized, e.g. when the genesis has no feemarket
+// state.
+func EndBlocker(goCtx context.Context, k keeper.Keeper) error {
+	defer telemetry.ModuleMeasureSince(types.ModuleName, time.Now(), telemetry.MetricKeyEndBlocker)
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	params, err := k.GetParams(ctx)
+	if errors.Is(err, collections.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := k.DistributeBaseFees(ctx, params); err != nil {
+		return err
+	}
+
+	baseFee, err := k.GetBaseFee(ctx)
+	if err != nil {
+		return err
+	}
+
+	gasUsed := ctx.BlockGasMeter().GasConsumedToLimit()
+	nextBaseFee := params.NextBaseFee(baseFee, gasUsed)
+	if err := k.SetBaseFee(ctx, nextBaseFee); err != nil {
+		return err
+	}
+
+	if nextBaseFeeFloat, err := nextBaseFee.Float64(); err == nil {
+		telemetry.ModuleSetGauge(types.ModuleName, float32(nextBaseFeeFloat), "base_fee")
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeBaseFee,
+			sdk.NewAttribute(types.AttributeKeyBaseFee, nextBaseFee.String()),
+			sdk.NewAttribute(types.AttributeKeyGasUsed, strconv.FormatUint(gasUsed, 10)),
+		),
+	)
+
+	return nil
+}
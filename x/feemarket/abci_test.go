@@ -0,0 +1,89 @@
+package feemarket_test
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"cosmossdk.io/x/feemarket"
+	"cosmossdk.io/x/feemarket/keeper"
+	"cosmossdk.io/x/feemarket/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+type mockAccountKeeper struct{}
+
+func (mockAccountKeeper) GetModuleAddress(moduleName string) sdk.AccAddress {
+	return authtypes.NewModuleAddress(moduleName)
+}
+
+// mockBankKeeper only holds the balance of the feemarket module account.
+type mockBankKeeper struct {
+	balance sdk.Coins
+	burnt   sdk.Coins
+}
+
+func (bk *mockBankKeeper) GetBalance(_ context.Context, _ sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, bk.balance.AmountOf(denom))
+}
+
+func (bk *mockBankKeeper) SendCoinsFromModuleToModule(context.Context, string, string, sdk.Coins) error {
+	return nil
+}
+
+func (bk *mockBankKeeper) BlockedAddr(sdk.AccAddress) bool { return false }
+
+func (bk *mockBankKeeper) BurnCoins(_ context.Context, _ string, amt sdk.Coins) error {
+	bk.balance = bk.balance.Sub(amt...)
+	bk.burnt = bk.burnt.Add(amt...)
+	return nil
+}
+
+func TestEndBlocker(t *testing.T) {
+	encCfg := moduletestutil.MakeTestEncodingConfig(feemarket.AppModuleBasic{})
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test"))
+
+	bk := &mockBankKeeper{}
+	k := keeper.NewKeeper(encCfg.Codec, runtime.NewKVStoreService(key), mockAccountKeeper{}, bk, "authority")
+
+	// the end blocker is a no-op until the module state is initialized
+	assert.NilError(t, feemarket.EndBlocker(ctx, k))
+
+	params := types.DefaultParams()
+	params.MinBaseFee = math.LegacyNewDecWithPrec(1, 3)
+	params.TargetBlockGas = 1000
+	k.InitGenesis(ctx, types.NewGenesisState(params, math.LegacyNewDec(1)))
+
+	endBlock := func(gasUsed uint64) math.LegacyDec {
+		t.Helper()
+		blockGasMeter := storetypes.NewGasMeter(2 * params.TargetBlockGas)
+		blockGasMeter.ConsumeGas(gasUsed, "test")
+		assert.NilError(t, feemarket.EndBlocker(ctx.WithBlockGasMeter(blockGasMeter), k))
+
+		baseFee, err := k.GetBaseFee(ctx)
+		assert.NilError(t, err)
+		return baseFee
+	}
+
+	// the base fee increases by 12.5% after a full block
+	assert.Assert(t, math.LegacyNewDecWithPrec(1125, 3).Equal(endBlock(2000)))
+	// and is unchanged after a block at target
+	assert.Assert(t, math.LegacyNewDecWithPrec(1125, 3).Equal(endBlock(1000)))
+	// and decreases by 12.5% after an empty block
+	assert.Assert(t, math.LegacyMustNewDecFromStr("0.984375").Equal(endBlock(0)))
+
+	// the base fees collected in the block are burnt
+	bk.balance = sdk.NewCoins(sdk.NewInt64Coin(params.FeeDenom, 42))
+	endBlock(1000)
+	assert.Assert(t, bk.balance.IsZero())
+	assert.DeepEqual(t, sdk.NewCoins(sdk.NewInt64Coin(params.FeeDenom, 42)), bk.burnt)
+}
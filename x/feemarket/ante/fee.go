@@ -0,0 +1,86 @@
+package ante
+
+import (
+	"context"
+	"errors"
+
+	"cosmossdk.io/collections"
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"cosmossdk.io/x/feemarket/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// FeeMarketKeeper defines the feemarket keeper methods used by the
+// BaseFeeDecorator.
+type FeeMarketKeeper interface {
+	GetParams(ctx context.Context) (types.Params, error)
+	GetBaseFee(ctx context.Context) (math.LegacyDec, error)
+	CollectBaseFee(ctx context.Context, baseFee sdk.Coins) error
+}
+
+// BaseFeeDecorator is an AnteDecorator that enforces the base fee of the fee
+// market. Unlike the validators' min-gas-prices, the base fee is enforced by
+// consensus and adjusts to the block gas usage.
+//
+// It must be placed after the auth module's DeductFeeDecorator, as it moves
+// the base fee portion of the fee deducted into the fee collector to the
+// feemarket module account, where it is burnt or routed at the end of the
+// block. The remainder of the fee is left in the fee collector as a tip.
+type BaseFeeDecorator struct {
+	feeMarketKeeper FeeMarketKeeper
+}
+
+// NewBaseFeeDecorator creates a new BaseFeeDecorator.
+func NewBaseFeeDecorator(fk FeeMarketKeeper) BaseFeeDecorator {
+	return BaseFeeDecorator{
+		feeMarketKeeper: fk,
+	}
+}
+
+// AnteHandle rejects the transaction if its fee is lower than its gas limit
+// times the base fee, and collects the base fee portion of the fee. Simulated
+// and genesis transactions, and all transactions while the fee market is
+// disabled or not initialized, are not subject to the base fee.
+func (bfd BaseFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if simulate || ctx.BlockHeight() == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, errorsmod.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	params, err := bfd.feeMarketKeeper.GetParams(ctx)
+	if errors.Is(err, collections.ErrNotFound) {
+		return next(ctx, tx, simulate)
+	} else if err != nil {
+		return ctx, err
+	}
+	if !params.Enabled {
+		return next(ctx, tx, simulate)
+	}
+
+	baseFee, err := bfd.feeMarketKeeper.GetBaseFee(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	requiredFee := params.RequiredFee(baseFee, feeTx.GetGas())
+	if feeTx.GetFee().AmountOf(params.FeeDenom).LT(requiredFee.Amount) {
+		return ctx, errorsmod.Wrapf(
+			sdkerrors.ErrInsufficientFee,
+			"insufficient fee; got: %s required: %s (base fee %s%s)",
+			feeTx.GetFee(), requiredFee, baseFee, params.FeeDenom,
+		)
+	}
+
+	if err := bfd.feeMarketKeeper.CollectBaseFee(ctx, sdk.NewCoins(requiredFee)); err != nil {
+		return ctx, err
+	}
+
+	return next(ctx, tx, simulate)
+}
@@ -0,0 +1,97 @@
+package ante_test
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/math"
+	"cosmossdk.io/x/feemarket/ante"
+	"cosmossdk.io/x/feemarket/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+type mockFeeMarketKeeper struct {
+	params    types.Params
+	baseFee   math.LegacyDec
+	collected sdk.Coins
+}
+
+func (k *mockFeeMarketKeeper) GetParams(context.Context) (types.Params, error) {
+	return k.params, nil
+}
+
+func (k *mockFeeMarketKeeper) GetBaseFee(context.Context) (math.LegacyDec, error) {
+	return k.baseFee, nil
+}
+
+func (k *mockFeeMarketKeeper) CollectBaseFee(_ context.Context, baseFee sdk.Coins) error {
+	k.collected = k.collected.Add(baseFee...)
+	return nil
+}
+
+type mockFeeTx struct {
+	gas uint64
+	fee sdk.Coins
+}
+
+func (tx mockFeeTx) GetMsgs() []sdk.Msg         { return nil }
+func (tx mockFeeTx) ValidateBasic() error       { return nil }
+func (tx mockFeeTx) GetGas() uint64             { return tx.gas }
+func (tx mockFeeTx) GetFee() sdk.Coins          { return tx.fee }
+func (tx mockFeeTx) FeePayer() sdk.AccAddress   { return nil }
+func (tx mockFeeTx) FeeGranter() sdk.AccAddress { return nil }
+
+func TestBaseFeeDecorator(t *testing.T) {
+	params := types.DefaultParams()
+	params.Enabled = true
+	denom := params.FeeDenom
+
+	testCases := []struct {
+		name         string
+		enabled      bool
+		height       int64
+		simulate     bool
+		fee          sdk.Coins
+		expErr       error
+		expCollected sdk.Coins
+	}{
+		{"sufficient fee", true, 10, false, sdk.NewCoins(sdk.NewInt64Coin(denom, 150)), nil, sdk.NewCoins(sdk.NewInt64Coin(denom, 100))},
+		{"exact fee", true, 10, false, sdk.NewCoins(sdk.NewInt64Coin(denom, 100)), nil, sdk.NewCoins(sdk.NewInt64Coin(denom, 100))},
+		{"insufficient fee", true, 10, false, sdk.NewCoins(sdk.NewInt64Coin(denom, 99)), sdkerrors.ErrInsufficientFee, nil},
+		{"fee in another denom", true, 10, false, sdk.NewCoins(sdk.NewInt64Coin("other", 1000)), sdkerrors.ErrInsufficientFee, nil},
+		{"disabled", false, 10, false, nil, nil, nil},
+		{"genesis tx", true, 0, false, nil, nil, nil},
+		{"simulation", true, 10, true, nil, nil, nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			params.Enabled = tc.enabled
+			fk := &mockFeeMarketKeeper{params: params, baseFee: math.LegacyNewDecWithPrec(1, 3)}
+			decorator := ante.NewBaseFeeDecorator(fk)
+
+			nextCalled := false
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				return ctx, nil
+			}
+
+			ctx := sdk.Context{}.WithBlockHeight(tc.height)
+			_, err := decorator.AnteHandle(ctx, mockFeeTx{gas: 100_000, fee: tc.fee}, tc.simulate, next)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				assert.Assert(t, !nextCalled)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Assert(t, nextCalled)
+			assert.DeepEqual(t, tc.expCollected, fk.collected)
+		})
+	}
+}
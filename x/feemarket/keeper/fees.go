@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/x/feemarket/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// CollectBaseFee moves the base fee portion of a transaction fee, deducted
+// by the auth module into the fee collector, to the feemarket module account
+// so that it is not distributed to the validators.
+func (k Keeper) CollectBaseFee(ctx context.Context, baseFee sdk.Coins) error {
+	if baseFee.IsZero() {
+		return nil
+	}
+
+	return k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, types.ModuleName, baseFee)
+}
+
+// DistributeBaseFees burns the base fees collected by the feemarket module
+// account, or sends them to the base fee recipient module account if set.
+func (k Keeper) DistributeBaseFees(ctx context.Context, params types.Params) error {
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+	balance := k.bankKeeper.GetBalance(ctx, moduleAddr, params.FeeDenom)
+	if balance.IsZero() {
+		return nil
+	}
+
+	fees := sdk.NewCoins(balance)
+	if params.BaseFeeRecipient == "" {
+		return k.bankKeeper.BurnCoins(ctx, types.ModuleName, fees)
+	}
+
+	return k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, params.BaseFeeRecipient, fees)
+}
+
+// ValidateBaseFeeRecipient checks that the base fees can be sent to recipient
+// by the end blocker: it must be empty, for the base fees to be burnt, or the
+// name of a module account which can receive funds and is not the bonded pool,
+// whose balance must match the bonded tokens.
+func (k Keeper) ValidateBaseFeeRecipient(recipient string) error {
+	if recipient == "" {
+		return nil
+	}
+
+	addr := k.authKeeper.GetModuleAddress(recipient)
+	if addr == nil {
+		return errorsmod.Wrapf(types.ErrInvalidBaseFeeRecipient, "%s is not a module account", recipient)
+	}
+	if recipient == stakingtypes.BondedPoolName {
+		return errorsmod.Wrapf(types.ErrInvalidBaseFeeRecipient, "%s is the bonded pool", recipient)
+	}
+	if k.bankKeeper.BlockedAddr(addr) {
+		return errorsmod.Wrapf(types.ErrInvalidBaseFeeRecipient, "%s is not allowed to receive funds", recipient)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/x/feemarket/types"
+)
+
+// InitGenesis initializes the feemarket module's state from a given genesis state.
+func (k Keeper) InitGenesis(ctx context.Context, data *types.GenesisState) {
+	if err := k.ValidateBaseFeeRecipient(data.Params.BaseFeeRecipient); err != nil {
+		panic(err)
+	}
+
+	if err := k.SetParams(ctx, data.Params); err != nil {
+		panic(err)
+	}
+
+	if err := k.SetBaseFee(ctx, data.BaseFee); err != nil {
+		panic(err)
+	}
+}
+
+// ExportGenesis returns the feemarket module's genesis state.
+func (k Keeper) ExportGenesis(ctx context.Context) *types.GenesisState {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	baseFee, err := k.GetBaseFee(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	return types.NewGenesisState(params, baseFee)
+}
@@ -0,0 +1,196 @@
+package keeper_test
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"cosmossdk.io/x/feemarket/keeper"
+	"cosmossdk.io/x/feemarket/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// moduleAccounts are the module accounts known to mockAccountKeeper.
+var moduleAccounts = []string{
+	types.ModuleName, authtypes.FeeCollectorName, govtypes.ModuleName, "distribution", stakingtypes.BondedPoolName,
+}
+
+type mockAccountKeeper struct{}
+
+func (mockAccountKeeper) GetModuleAddress(moduleName string) sdk.AccAddress {
+	for _, name := range moduleAccounts {
+		if name == moduleName {
+			return authtypes.NewModuleAddress(moduleName)
+		}
+	}
+	return nil
+}
+
+// mockBankKeeper keeps the balances of module accounts by module name.
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+	burnt    sdk.Coins
+	blocked  map[string]bool
+}
+
+func (bk *mockBankKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return bk.blocked[addr.String()]
+}
+
+func (bk *mockBankKeeper) GetBalance(_ context.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	for name, balance := range bk.balances {
+		if authtypes.NewModuleAddress(name).Equals(addr) {
+			return sdk.NewCoin(denom, balance.AmountOf(denom))
+		}
+	}
+	return sdk.NewCoin(denom, math.ZeroInt())
+}
+
+func (bk *mockBankKeeper) SendCoinsFromModuleToModule(_ context.Context, senderModule, recipientModule string, amt sdk.Coins) error {
+	balance, hasNeg := bk.balances[senderModule].SafeSub(amt...)
+	if hasNeg {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	bk.balances[senderModule] = balance
+	bk.balances[recipientModule] = bk.balances[recipientModule].Add(amt...)
+	return nil
+}
+
+func (bk *mockBankKeeper) BurnCoins(_ context.Context, moduleName string, amt sdk.Coins) error {
+	bk.balances[moduleName] = bk.balances[moduleName].Sub(amt...)
+	bk.burnt = bk.burnt.Add(amt...)
+	return nil
+}
+
+type fixture struct {
+	ctx        sdk.Context
+	k          keeper.Keeper
+	bankKeeper *mockBankKeeper
+	authority  string
+}
+
+func initFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	encCfg := moduletestutil.MakeTestEncodingConfig()
+	types.RegisterInterfaces(encCfg.InterfaceRegistry)
+
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(key, storetypes.NewTransientStoreKey("transient_test"))
+
+	bk := &mockBankKeeper{
+		balances: map[string]sdk.Coins{},
+		blocked:  map[string]bool{authtypes.NewModuleAddress(authtypes.FeeCollectorName).String(): true},
+	}
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName).String()
+	k := keeper.NewKeeper(encCfg.Codec, runtime.NewKVStoreService(key), mockAccountKeeper{}, bk, authority)
+	k.InitGenesis(ctx, types.DefaultGenesisState())
+
+	return &fixture{ctx: ctx, k: k, bankKeeper: bk, authority: authority}
+}
+
+func TestGenesis(t *testing.T) {
+	f := initFixture(t)
+
+	params := types.DefaultParams()
+	params.Enabled = true
+	params.BaseFeeRecipient = "distribution"
+	genesis := types.NewGenesisState(params, math.LegacyNewDecWithPrec(5, 2))
+
+	f.k.InitGenesis(f.ctx, genesis)
+	assert.DeepEqual(t, genesis, f.k.ExportGenesis(f.ctx))
+
+	// the end blocker could not send the base fees to an invalid recipient
+	genesis.Params.BaseFeeRecipient = "unknown"
+	assert.Assert(t, func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		f.k.InitGenesis(f.ctx, genesis)
+		return false
+	}())
+}
+
+func TestUpdateParams(t *testing.T) {
+	f := initFixture(t)
+	msgServer := keeper.NewMsgServerImpl(f.k)
+
+	params := types.DefaultParams()
+	params.MinBaseFee = math.LegacyNewDecWithPrec(1, 2)
+
+	_, err := msgServer.UpdateParams(f.ctx, &types.MsgUpdateParams{Authority: "invalid", Params: params})
+	assert.ErrorContains(t, err, "invalid authority")
+
+	invalid := params
+	invalid.TargetBlockGas = 0
+	_, err = msgServer.UpdateParams(f.ctx, &types.MsgUpdateParams{Authority: f.authority, Params: invalid})
+	assert.ErrorContains(t, err, "target block gas must be positive")
+
+	for _, recipient := range []string{"unknown", authtypes.FeeCollectorName, stakingtypes.BondedPoolName} {
+		invalid = params
+		invalid.BaseFeeRecipient = recipient
+		_, err = msgServer.UpdateParams(f.ctx, &types.MsgUpdateParams{Authority: f.authority, Params: invalid})
+		assert.ErrorIs(t, err, types.ErrInvalidBaseFeeRecipient)
+	}
+
+	_, err = msgServer.UpdateParams(f.ctx, &types.MsgUpdateParams{Authority: f.authority, Params: params})
+	assert.NilError(t, err)
+
+	got, err := f.k.GetParams(f.ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, params, got)
+
+	// the base fee is raised to the new min base fee
+	baseFee, err := f.k.GetBaseFee(f.ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, params.MinBaseFee.Equal(baseFee), baseFee.String())
+}
+
+func TestQueryBaseFee(t *testing.T) {
+	f := initFixture(t)
+	queryServer := keeper.NewQueryServer(f.k)
+
+	assert.NilError(t, f.k.SetBaseFee(f.ctx, math.LegacyNewDecWithPrec(25, 3)))
+
+	res, err := queryServer.BaseFee(f.ctx, &types.QueryBaseFeeRequest{})
+	assert.NilError(t, err)
+	assert.Equal(t, sdk.DefaultBondDenom, res.BaseFee.Denom)
+	assert.Assert(t, math.LegacyNewDecWithPrec(25, 3).Equal(res.BaseFee.Amount))
+	assert.Assert(t, !res.Enabled)
+
+	paramsRes, err := queryServer.Params(f.ctx, &types.QueryParamsRequest{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, types.DefaultParams(), paramsRes.Params)
+}
+
+func TestCollectAndDistributeBaseFees(t *testing.T) {
+	f := initFixture(t)
+	params := types.DefaultParams()
+	fees := sdk.NewCoins(sdk.NewInt64Coin(params.FeeDenom, 100))
+
+	f.bankKeeper.balances[authtypes.FeeCollectorName] = sdk.NewCoins(sdk.NewInt64Coin(params.FeeDenom, 150))
+	assert.NilError(t, f.k.CollectBaseFee(f.ctx, fees))
+	assert.DeepEqual(t, fees, f.bankKeeper.balances[types.ModuleName])
+	assert.Equal(t, int64(50), f.bankKeeper.balances[authtypes.FeeCollectorName].AmountOf(params.FeeDenom).Int64())
+
+	// the base fees are burnt by default
+	assert.NilError(t, f.k.DistributeBaseFees(f.ctx, params))
+	assert.DeepEqual(t, fees, f.bankKeeper.burnt)
+	assert.Assert(t, f.bankKeeper.balances[types.ModuleName].IsZero())
+
+	// or routed to the base fee recipient
+	params.BaseFeeRecipient = "distribution"
+	f.bankKeeper.balances[types.ModuleName] = fees
+	assert.NilError(t, f.k.DistributeBaseFees(f.ctx, params))
+	assert.DeepEqual(t, fees, f.bankKeeper.balances["distribution"])
+	assert.DeepEqual(t, fees, f.bankKeeper.burnt)
+}
@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/x/feemarket/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ types.QueryServer = QueryServer{}
+
+// QueryServer implements the feemarket module's gRPC query service.
+type QueryServer struct {
+	keeper Keeper
+}
+
+// NewQueryServer returns an implementation of the feemarket QueryServer
+// interface for the provided Keeper.
+func NewQueryServer(keeper Keeper) types.QueryServer {
+	return &QueryServer{keeper: keeper}
+}
+
+// Params returns the params of the feemarket module.
+func (qs QueryServer) Params(ctx context.Context, _ *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	params, err := qs.keeper.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+// BaseFee returns the minimum gas price of the transactions of the next
+// block, which wallets can use to estimate the fees of their transactions.
+func (qs QueryServer) BaseFee(ctx context.Context, _ *types.QueryBaseFeeRequest) (*types.QueryBaseFeeResponse, error) {
+	params, err := qs.keeper.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	baseFee, err := qs.keeper.GetBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryBaseFeeResponse{
+		BaseFee: sdk.NewDecCoinFromDec(params.FeeDenom, baseFee),
+		Enabled: params.Enabled,
+	}, nil
+}
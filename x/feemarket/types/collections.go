@@ -0,0 +1,43 @@
+package types
+
+import (
+	collcodec "cosmossdk.io/collections/codec"
+	"cosmossdk.io/math"
+)
+
+// LegacyDecValue is a collections.ValueCodec to work with LegacyDec.
+var LegacyDecValue collcodec.ValueCodec[math.LegacyDec] = legacyDecValueCodec{}
+
+type legacyDecValueCodec struct{}
+
+func (legacyDecValueCodec) Encode(value math.LegacyDec) ([]byte, error) {
+	return value.Marshal()
+}
+
+func (legacyDecValueCodec) Decode(b []byte) (math.LegacyDec, error) {
+	v := new(math.LegacyDec)
+	if err := v.Unmarshal(b); err != nil {
+		return math.LegacyDec{}, err
+	}
+	return *v, nil
+}
+
+func (legacyDecValueCodec) EncodeJSON(value math.LegacyDec) ([]byte, error) {
+	return value.MarshalJSON()
+}
+
+func (legacyDecValueCodec) DecodeJSON(b []byte) (math.LegacyDec, error) {
+	v := new(math.LegacyDec)
+	if err := v.UnmarshalJSON(b); err != nil {
+		return math.LegacyDec{}, err
+	}
+	return *v, nil
+}
+
+func (legacyDecValueCodec) Stringify(value math.LegacyDec) string {
+	return value.String()
+}
+
+func (legacyDecValueCodec) ValueType() string {
+	return "math.LegacyDec"
+}
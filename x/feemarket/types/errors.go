@@ -0,0 +1,8 @@
+package types
+
+import "cosmossdk.io/errors"
+
+// x/feemarket module sentinel errors
+var (
+	ErrInvalidBaseFeeRecipient = errors.Register(ModuleName, 2, "invalid base fee recipient")
+)
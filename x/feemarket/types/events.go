@@ -0,0 +1,9 @@
+package types
+
+// feemarket module event types
+const (
+	EventTypeBaseFee = "base_fee"
+
+	AttributeKeyBaseFee = "base_fee"
+	AttributeKeyGasUsed = "gas_used"
+)
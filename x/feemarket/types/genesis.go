@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+
+	"cosmossdk.io/math"
+)
+
+// NewGenesisState creates a new genesis state for the feemarket module.
+func NewGenesisState(params Params, baseFee math.LegacyDec) *GenesisState {
+	return &GenesisState{
+		Params:  params,
+		BaseFee: baseFee,
+	}
+}
+
+// DefaultGenesisState returns a default genesis state for the feemarket
+// module, starting at the min base fee.
+func DefaultGenesisState() *GenesisState {
+	params := DefaultParams()
+	return NewGenesisState(params, params.MinBaseFee)
+}
+
+// Validate performs basic validation of the feemarket genesis state: the
+// params must be valid and the base fee within their bounds.
+func (gs *GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+
+	if gs.BaseFee.IsNil() || !gs.Params.ClampBaseFee(gs.BaseFee).Equal(gs.BaseFee) {
+		return fmt.Errorf("base fee %s is out of the min and max base fee bounds", gs.BaseFee)
+	}
+
+	return nil
+}
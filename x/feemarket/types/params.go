@@ -0,0 +1,121 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewParams returns Params instance with the given values.
+func NewParams(
+	enabled bool, feeDenom string, minBaseFee, maxBaseFee math.LegacyDec,
+	targetBlockGas, baseFeeChangeDenominator uint64, baseFeeRecipient string,
+) Params {
+	return Params{
+		Enabled:                  enabled,
+		FeeDenom:                 feeDenom,
+		MinBaseFee:               minBaseFee,
+		MaxBaseFee:               maxBaseFee,
+		TargetBlockGas:           targetBlockGas,
+		BaseFeeChangeDenominator: baseFeeChangeDenominator,
+		BaseFeeRecipient:         baseFeeRecipient,
+	}
+}
+
+// DefaultParams returns default x/feemarket module parameters. The base fee
+// is not enforced by default.
+func DefaultParams() Params {
+	return Params{
+		Enabled:                  false,
+		FeeDenom:                 sdk.DefaultBondDenom,
+		MinBaseFee:               math.LegacyNewDecWithPrec(1, 3),
+		MaxBaseFee:               math.LegacyZeroDec(),
+		TargetBlockGas:           15_000_000,
+		BaseFeeChangeDenominator: 8,
+		BaseFeeRecipient:         "",
+	}
+}
+
+// Validate does the sanity check on the params.
+func (p Params) Validate() error {
+	if err := sdk.ValidateDenom(p.FeeDenom); err != nil {
+		return fmt.Errorf("invalid fee denom: %w", err)
+	}
+	if p.MinBaseFee.IsNil() || !p.MinBaseFee.IsPositive() {
+		return fmt.Errorf("min base fee must be positive: %s", p.MinBaseFee)
+	}
+	if p.MaxBaseFee.IsNil() || p.MaxBaseFee.IsNegative() {
+		return fmt.Errorf("max base fee cannot be negative: %s", p.MaxBaseFee)
+	}
+	if !p.MaxBaseFee.IsZero() && p.MaxBaseFee.LT(p.MinBaseFee) {
+		return fmt.Errorf(
+			"max base fee (%s) must be greater than or equal to min base fee (%s)",
+			p.MaxBaseFee, p.MinBaseFee,
+		)
+	}
+	if p.TargetBlockGas == 0 {
+		return errors.New("target block gas must be positive")
+	}
+	if p.BaseFeeChangeDenominator == 0 {
+		return errors.New("base fee change denominator must be positive")
+	}
+	if p.BaseFeeRecipient != strings.TrimSpace(p.BaseFeeRecipient) {
+		return fmt.Errorf("invalid base fee recipient: %q", p.BaseFeeRecipient)
+	}
+
+	return nil
+}
+
+// ClampBaseFee returns baseFee bounded by the min and max base fees.
+func (p Params) ClampBaseFee(baseFee math.LegacyDec) math.LegacyDec {
+	if baseFee.LT(p.MinBaseFee) {
+		return p.MinBaseFee
+	}
+	if !p.MaxBaseFee.IsZero() && baseFee.GT(p.MaxBaseFee) {
+		return p.MaxBaseFee
+	}
+
+	return baseFee
+}
+
+// NextBaseFee returns the base fee following a block which used gasUsed gas
+// with the given base fee, as in EIP-1559. The base fee changes by
+// baseFee * (gasUsed - target) / target / BaseFeeChangeDenominator, the
+// difference between gasUsed and the target being bounded by the target.
+func (p Params) NextBaseFee(baseFee math.LegacyDec, gasUsed uint64) math.LegacyDec {
+	target := p.TargetBlockGas
+
+	var diff uint64
+	if gasUsed > target {
+		diff = gasUsed - target
+	} else {
+		diff = target - gasUsed
+	}
+	if diff > target {
+		diff = target
+	}
+
+	delta := baseFee.
+		MulInt(math.NewIntFromUint64(diff)).
+		QuoInt(math.NewIntFromUint64(target)).
+		QuoInt(math.NewIntFromUint64(p.BaseFeeChangeDenominator))
+
+	if gasUsed > target {
+		baseFee = baseFee.Add(delta)
+	} else {
+		baseFee = baseFee.Sub(delta)
+	}
+
+	return p.ClampBaseFee(baseFee)
+}
+
+// RequiredFee returns the minimum fee of a transaction with the given gas
+// limit, i.e. its gas limit times the base fee, rounded up.
+func (p Params) RequiredFee(baseFee math.LegacyDec, gasLimit uint64) sdk.Coin {
+	amount := baseFee.MulInt(math.NewIntFromUint64(gasLimit)).Ceil().RoundInt()
+	return sdk.NewCoin(p.FeeDenom, amount)
+}
@@ -0,0 +1,102 @@
+package types_test
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"cosmossdk.io/math"
+	"cosmossdk.io/x/feemarket/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		malleate func(*types.Params)
+		expErr   string
+	}{
+		{"default params", func(*types.Params) {}, ""},
+		{"invalid fee denom", func(p *types.Params) { p.FeeDenom = "" }, "invalid fee denom"},
+		{"zero min base fee", func(p *types.Params) { p.MinBaseFee = math.LegacyZeroDec() }, "min base fee must be positive"},
+		{"negative max base fee", func(p *types.Params) { p.MaxBaseFee = math.LegacyNewDec(-1) }, "max base fee cannot be negative"},
+		{"max base fee lower than min", func(p *types.Params) { p.MaxBaseFee = math.LegacyNewDecWithPrec(1, 4) }, "max base fee"},
+		{"max base fee equal to min", func(p *types.Params) { p.MaxBaseFee = p.MinBaseFee }, ""},
+		{"zero target block gas", func(p *types.Params) { p.TargetBlockGas = 0 }, "target block gas must be positive"},
+		{"zero change denominator", func(p *types.Params) { p.BaseFeeChangeDenominator = 0 }, "base fee change denominator must be positive"},
+		{"invalid recipient", func(p *types.Params) { p.BaseFeeRecipient = " distribution" }, "invalid base fee recipient"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			params := types.DefaultParams()
+			tc.malleate(&params)
+
+			err := params.Validate()
+			if tc.expErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestNextBaseFee(t *testing.T) {
+	params := types.DefaultParams()
+	params.MinBaseFee = math.LegacyNewDec(1)
+	params.MaxBaseFee = math.LegacyNewDec(200)
+	params.TargetBlockGas = 1000
+	params.BaseFeeChangeDenominator = 8
+
+	testCases := []struct {
+		name    string
+		baseFee int64
+		gasUsed uint64
+		expFee  math.LegacyDec
+	}{
+		{"at target", 100, 1000, math.LegacyNewDec(100)},
+		{"full block", 100, 2000, math.LegacyNewDecWithPrec(1125, 1)},
+		{"over full block is bounded", 100, 5000, math.LegacyNewDecWithPrec(1125, 1)},
+		{"half target", 100, 500, math.LegacyNewDecWithPrec(9375, 2)},
+		{"empty block", 100, 0, math.LegacyNewDecWithPrec(875, 1)},
+		{"clamped to min", 1, 0, math.LegacyNewDec(1)},
+		{"clamped to max", 200, 2000, math.LegacyNewDec(200)},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			next := params.NextBaseFee(math.LegacyNewDec(tc.baseFee), tc.gasUsed)
+			assert.Assert(t, tc.expFee.Equal(next), "expected %s, got %s", tc.expFee, next)
+		})
+	}
+
+	// no max base fee
+	params.MaxBaseFee = math.LegacyZeroDec()
+	next := params.NextBaseFee(math.LegacyNewDec(200), 2000)
+	assert.Assert(t, math.LegacyNewDec(225).Equal(next), next.String())
+}
+
+func TestRequiredFee(t *testing.T) {
+	params := types.DefaultParams()
+
+	fee := params.RequiredFee(math.LegacyNewDecWithPrec(15, 4), 1001)
+	assert.Equal(t, params.FeeDenom, fee.Denom)
+	assert.Equal(t, int64(2), fee.Amount.Int64()) // 1.5015 rounded up
+
+	fee = params.RequiredFee(math.LegacyNewDec(2), 0)
+	assert.Assert(t, fee.IsZero())
+}
+
+func TestGenesisStateValidate(t *testing.T) {
+	assert.NilError(t, types.DefaultGenesisState().Validate())
+
+	gs := types.DefaultGenesisState()
+	gs.BaseFee = math.LegacyNewDecWithPrec(1, 4)
+	assert.ErrorContains(t, gs.Validate(), "out of the min and max base fee bounds")
+
+	gs = types.DefaultGenesisState()
+	gs.Params.TargetBlockGas = 0
+	assert.ErrorContains(t, gs.Validate(), "target block gas")
+}
@@ -2,9 +2,11 @@ package keeper
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"time"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
 
@@ -17,31 +19,24 @@ import (
 
 // GetDelegation returns a specific delegation.
 func (k Keeper) GetDelegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (delegation types.Delegation, found bool) {
-	store := ctx.KVStore(k.storeKey)
-	key := types.GetDelegationKey(delAddr, valAddr)
-
-	value := store.Get(key)
-	if value == nil {
+	delegation, err := k.Delegations.Get(ctx, collections.Join(delAddr, valAddr))
+	if errors.Is(err, collections.ErrNotFound) {
 		return delegation, false
 	}
-
-	delegation = types.MustUnmarshalDelegation(k.cdc, value)
+	if err != nil {
+		panic(err)
+	}
 
 	return delegation, true
 }
 
 // IterateAllDelegations iterates through all of the delegations.
 func (k Keeper) IterateAllDelegations(ctx sdk.Context, cb func(delegation types.Delegation) (stop bool)) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.DelegationKey)
-	defer iterator.Close()
-
-	for ; iterator.Valid(); iterator.Next() {
-		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Value())
-		if cb(delegation) {
-			break
-		}
+	err := k.Delegations.Walk(ctx, nil, func(_ collections.Pair[sdk.AccAddress, sdk.ValAddress], delegation types.Delegation) bool {
+		return cb(delegation)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
@@ -58,24 +53,18 @@ func (k Keeper) GetAllDelegations(ctx sdk.Context) (delegations []types.Delegati
 // GetValidatorDelegations returns all delegations to a specific validator.
 // Useful for querier.
 func (k Keeper) GetValidatorDelegations(ctx sdk.Context, valAddr sdk.ValAddress) (delegations []types.Delegation) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.GetDelegationsByValPrefixKey(valAddr))
-	defer iterator.Close()
-
-	for ; iterator.Valid(); iterator.Next() {
-		var delegation types.Delegation
-		valAddr, delAddr, err := types.ParseDelegationsByValKey(iterator.Key())
+	rng := collections.NewPrefixedPairRange[sdk.ValAddress, sdk.AccAddress](valAddr)
+	err := k.Delegations.Indexes.Validator.Walk(ctx, rng, func(valAddr sdk.ValAddress, delAddr sdk.AccAddress) bool {
+		delegation, err := k.Delegations.Get(ctx, collections.Join(delAddr, valAddr))
 		if err != nil {
 			panic(err)
 		}
 
-		bz := store.Get(types.GetDelegationKey(delAddr, valAddr))
-		if err := k.cdc.Unmarshal(bz, &delegation); err != nil {
-			panic(err)
-		}
-
 		delegations = append(delegations, delegation)
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
 	return delegations
@@ -84,36 +73,34 @@ func (k Keeper) GetValidatorDelegations(ctx sdk.Context, valAddr sdk.ValAddress)
 // GetDelegatorDelegations returns a given amount of all the delegations from a
 // delegator.
 func (k Keeper) GetDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress, maxRetrieve uint16) (delegations []types.Delegation) {
-	delegations = make([]types.Delegation, maxRetrieve)
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetDelegationsKey(delegator)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey)
-	defer iterator.Close()
+	delegations = make([]types.Delegation, 0, maxRetrieve)
+	if maxRetrieve == 0 {
+		return delegations
+	}
 
-	i := 0
-	for ; iterator.Valid() && i < int(maxRetrieve); iterator.Next() {
-		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Value())
-		delegations[i] = delegation
-		i++
+	rng := collections.NewPrefixedPairRange[sdk.AccAddress, sdk.ValAddress](delegator)
+	err := k.Delegations.Walk(ctx, rng, func(_ collections.Pair[sdk.AccAddress, sdk.ValAddress], delegation types.Delegation) bool {
+		delegations = append(delegations, delegation)
+		return len(delegations) == int(maxRetrieve)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
-	return delegations[:i] // trim if the array length < maxRetrieve
+	return delegations
 }
 
-// SetDelegation sets a delegation.
+// SetDelegation sets a delegation and its validator delegator index.
 func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
 	delegatorAddress, err := k.authKeeper.StringToBytes(delegation.DelegatorAddress)
 	if err != nil {
 		panic(err)
 	}
 
-	store := ctx.KVStore(k.storeKey)
-	b := types.MustMarshalDelegation(k.cdc, delegation)
-	store.Set(types.GetDelegationKey(delegatorAddress, delegation.GetValidatorAddr()), b)
-
-	// set the delegation in validator delegator index
-	store.Set(types.GetDelegationsByValKey(delegation.GetValidatorAddr(), delegatorAddress), []byte{})
+	err = k.Delegations.Set(ctx, collections.Join(sdk.AccAddress(delegatorAddress), delegation.GetValidatorAddr()), delegation)
+	if err != nil {
+		panic(err)
+	}
 }
 
 // RemoveDelegation removes a delegation
@@ -128,44 +115,37 @@ func (k Keeper) RemoveDelegation(ctx sdk.Context, delegation types.Delegation) e
 		return err
 	}
 
-	store := ctx.KVStore(k.storeKey)
-	store.Delete(types.GetDelegationKey(delegatorAddress, delegation.GetValidatorAddr()))
-	store.Delete(types.GetDelegationsByValKey(delegation.GetValidatorAddr(), delegatorAddress))
-
-	return nil
+	return k.Delegations.Remove(ctx, collections.Join(sdk.AccAddress(delegatorAddress), delegation.GetValidatorAddr()))
 }
 
 // GetUnbondingDelegations returns a given amount of all the delegator unbonding-delegations.
 func (k Keeper) GetUnbondingDelegations(ctx sdk.Context, delegator sdk.AccAddress, maxRetrieve uint16) (unbondingDelegations []types.UnbondingDelegation) {
-	unbondingDelegations = make([]types.UnbondingDelegation, maxRetrieve)
-
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetUBDsKey(delegator)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey)
-	defer iterator.Close()
+	unbondingDelegations = make([]types.UnbondingDelegation, 0, maxRetrieve)
+	if maxRetrieve == 0 {
+		return unbondingDelegations
+	}
 
-	i := 0
-	for ; iterator.Valid() && i < int(maxRetrieve); iterator.Next() {
-		unbondingDelegation := types.MustUnmarshalUBD(k.cdc, iterator.Value())
-		unbondingDelegations[i] = unbondingDelegation
-		i++
+	rng := collections.NewPrefixedPairRange[sdk.AccAddress, sdk.ValAddress](delegator)
+	err := k.UnbondingDelegations.Walk(ctx, rng, func(_ collections.Pair[sdk.AccAddress, sdk.ValAddress], ubd types.UnbondingDelegation) bool {
+		unbondingDelegations = append(unbondingDelegations, ubd)
+		return len(unbondingDelegations) == int(maxRetrieve)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
-	return unbondingDelegations[:i] // trim if the array length < maxRetrieve
+	return unbondingDelegations
 }
 
 // GetUnbondingDelegation returns a unbonding delegation.
 func (k Keeper) GetUnbondingDelegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (ubd types.UnbondingDelegation, found bool) {
-	store := ctx.KVStore(k.storeKey)
-	key := types.GetUBDKey(delAddr, valAddr)
-	value := store.Get(key)
-
-	if value == nil {
+	ubd, err := k.UnbondingDelegations.Get(ctx, collections.Join(delAddr, valAddr))
+	if errors.Is(err, collections.ErrNotFound) {
 		return ubd, false
 	}
-
-	ubd = types.MustUnmarshalUBD(k.cdc, value)
+	if err != nil {
+		panic(err)
+	}
 
 	return ubd, true
 }
@@ -173,16 +153,18 @@ func (k Keeper) GetUnbondingDelegation(ctx sdk.Context, delAddr sdk.AccAddress,
 // GetUnbondingDelegationsFromValidator returns all unbonding delegations from a
 // particular validator.
 func (k Keeper) GetUnbondingDelegationsFromValidator(ctx sdk.Context, valAddr sdk.ValAddress) (ubds []types.UnbondingDelegation) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.GetUBDsByValIndexKey(valAddr))
-	defer iterator.Close()
+	rng := collections.NewPrefixedPairRange[sdk.ValAddress, sdk.AccAddress](valAddr)
+	err := k.UnbondingDelegations.Indexes.Validator.Walk(ctx, rng, func(valAddr sdk.ValAddress, delAddr sdk.AccAddress) bool {
+		ubd, err := k.UnbondingDelegations.Get(ctx, collections.Join(delAddr, valAddr))
+		if err != nil {
+			panic(err)
+		}
 
-	for ; iterator.Valid(); iterator.Next() {
-		key := types.GetUBDKeyFromValIndexKey(iterator.Key())
-		value := store.Get(key)
-		ubd := types.MustUnmarshalUBD(k.cdc, value)
 		ubds = append(ubds, ubd)
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
 	return ubds
@@ -190,17 +172,16 @@ func (k Keeper) GetUnbondingDelegationsFromValidator(ctx sdk.Context, valAddr sd
 
 // IterateUnbondingDelegations iterates through all of the unbonding delegations.
 func (k Keeper) IterateUnbondingDelegations(ctx sdk.Context, fn func(index int64, ubd types.UnbondingDelegation) (stop bool)) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.UnbondingDelegationKey)
-	defer iterator.Close()
-
-	for i := int64(0); iterator.Valid(); iterator.Next() {
-		ubd := types.MustUnmarshalUBD(k.cdc, iterator.Value())
-		if stop := fn(i, ubd); stop {
-			break
+	i := int64(0)
+	err := k.UnbondingDelegations.Walk(ctx, nil, func(_ collections.Pair[sdk.AccAddress, sdk.ValAddress], ubd types.UnbondingDelegation) bool {
+		if fn(i, ubd) {
+			return true
 		}
 		i++
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
@@ -218,16 +199,12 @@ func (k Keeper) GetDelegatorUnbonding(ctx sdk.Context, delegator sdk.AccAddress)
 
 // IterateDelegatorUnbondingDelegations iterates through a delegator's unbonding delegations.
 func (k Keeper) IterateDelegatorUnbondingDelegations(ctx sdk.Context, delegator sdk.AccAddress, cb func(ubd types.UnbondingDelegation) (stop bool)) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.GetUBDsKey(delegator))
-	defer iterator.Close()
-
-	for ; iterator.Valid(); iterator.Next() {
-		ubd := types.MustUnmarshalUBD(k.cdc, iterator.Value())
-		if cb(ubd) {
-			break
-		}
+	rng := collections.NewPrefixedPairRange[sdk.AccAddress, sdk.ValAddress](delegator)
+	err := k.UnbondingDelegations.Walk(ctx, rng, func(_ collections.Pair[sdk.AccAddress, sdk.ValAddress], ubd types.UnbondingDelegation) bool {
+		return cb(ubd)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
@@ -253,32 +230,23 @@ func (k Keeper) GetDelegatorBonded(ctx sdk.Context, delegator sdk.AccAddress) ma
 
 // IterateDelegatorDelegations iterates through one delegator's delegations.
 func (k Keeper) IterateDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress, cb func(delegation types.Delegation) (stop bool)) {
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetDelegationsKey(delegator)
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey)
-	defer iterator.Close()
-
-	for ; iterator.Valid(); iterator.Next() {
-		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Value())
-		if cb(delegation) {
-			break
-		}
+	rng := collections.NewPrefixedPairRange[sdk.AccAddress, sdk.ValAddress](delegator)
+	err := k.Delegations.Walk(ctx, rng, func(_ collections.Pair[sdk.AccAddress, sdk.ValAddress], delegation types.Delegation) bool {
+		return cb(delegation)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
 // IterateDelegatorRedelegations iterates through one delegator's redelegations.
 func (k Keeper) IterateDelegatorRedelegations(ctx sdk.Context, delegator sdk.AccAddress, cb func(red types.Redelegation) (stop bool)) {
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetREDsKey(delegator)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey)
-	defer iterator.Close()
-
-	for ; iterator.Valid(); iterator.Next() {
-		red := types.MustUnmarshalRED(k.cdc, iterator.Value())
-		if cb(red) {
-			break
-		}
+	rng := collections.NewPrefixedTripleRange[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress](delegator)
+	err := k.Redelegations.Walk(ctx, rng, func(_ collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress], red types.Redelegation) bool {
+		return cb(red)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
@@ -299,15 +267,13 @@ func (k Keeper) SetUnbondingDelegation(ctx sdk.Context, ubd types.UnbondingDeleg
 		panic(err)
 	}
 
-	store := ctx.KVStore(k.storeKey)
-	bz := types.MustMarshalUBD(k.cdc, ubd)
 	valAddr, err := sdk.ValAddressFromBech32(ubd.ValidatorAddress)
 	if err != nil {
 		panic(err)
 	}
-	key := types.GetUBDKey(delAddr, valAddr)
-	store.Set(key, bz)
-	store.Set(types.GetUBDByValIndexKey(delAddr, valAddr), []byte{}) // index, store empty bytes
+	if err := k.UnbondingDelegations.Set(ctx, collections.Join(sdk.AccAddress(delAddr), valAddr), ubd); err != nil {
+		panic(err)
+	}
 }
 
 // RemoveUnbondingDelegation removes the unbonding delegation object and associated index.
@@ -317,14 +283,13 @@ func (k Keeper) RemoveUnbondingDelegation(ctx sdk.Context, ubd types.UnbondingDe
 		panic(err)
 	}
 
-	store := ctx.KVStore(k.storeKey)
 	addr, err := sdk.ValAddressFromBech32(ubd.ValidatorAddress)
 	if err != nil {
 		panic(err)
 	}
-	key := types.GetUBDKey(delegatorAddress, addr)
-	store.Delete(key)
-	store.Delete(types.GetUBDByValIndexKey(delegatorAddress, addr))
+	if err := k.UnbondingDelegations.Remove(ctx, collections.Join(sdk.AccAddress(delegatorAddress), addr)); err != nil {
+		panic(err)
+	}
 }
 
 // SetUnbondingDelegationEntry adds an entry to the unbonding delegation at
@@ -359,24 +324,22 @@ func (k Keeper) SetUnbondingDelegationEntry(
 // is a slice of DVPairs corresponding to unbonding delegations that expire at a
 // certain time.
 func (k Keeper) GetUBDQueueTimeSlice(ctx sdk.Context, timestamp time.Time) (dvPairs []types.DVPair) {
-	store := ctx.KVStore(k.storeKey)
-
-	bz := store.Get(types.GetUnbondingDelegationTimeKey(timestamp))
-	if bz == nil {
+	pairs, err := k.UnbondingQueue.Get(ctx, timestamp)
+	if errors.Is(err, collections.ErrNotFound) {
 		return []types.DVPair{}
 	}
-
-	pairs := types.DVPairs{}
-	k.cdc.MustUnmarshal(bz, &pairs)
+	if err != nil {
+		panic(err)
+	}
 
 	return pairs.Pairs
 }
 
 // SetUBDQueueTimeSlice sets a specific unbonding queue timeslice.
 func (k Keeper) SetUBDQueueTimeSlice(ctx sdk.Context, timestamp time.Time, keys []types.DVPair) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshal(&types.DVPairs{Pairs: keys})
-	store.Set(types.GetUnbondingDelegationTimeKey(timestamp), bz)
+	if err := k.UnbondingQueue.Set(ctx, timestamp, types.DVPairs{Pairs: keys}); err != nil {
+		panic(err)
+	}
 }
 
 // InsertUBDQueue inserts an unbonding delegation to the appropriate timeslice
@@ -403,20 +366,18 @@ func (k Keeper) UBDQueueIterator(ctx sdk.Context, endTime time.Time) storetypes.
 // DequeueAllMatureUBDQueue returns a concatenated list of all the timeslices inclusively previous to
 // currTime, and deletes the timeslices from the queue.
 func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context, currTime time.Time) (matureUnbonds []types.DVPair) {
-	store := ctx.KVStore(k.storeKey)
-
-	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	unbondingTimesliceIterator := k.UBDQueueIterator(ctx, currTime)
-	defer unbondingTimesliceIterator.Close()
-
-	for ; unbondingTimesliceIterator.Valid(); unbondingTimesliceIterator.Next() {
-		timeslice := types.DVPairs{}
-		value := unbondingTimesliceIterator.Value()
-		k.cdc.MustUnmarshal(value, &timeslice)
-
+	// walks all timeslices from time 0 until the current Blockheader time
+	rng := new(collections.Range[time.Time]).EndInclusive(currTime)
+	err := k.UnbondingQueue.Walk(ctx, rng, func(timestamp time.Time, timeslice types.DVPairs) bool {
 		matureUnbonds = append(matureUnbonds, timeslice.Pairs...)
 
-		store.Delete(unbondingTimesliceIterator.Key())
+		if err := k.UnbondingQueue.Remove(ctx, timestamp); err != nil {
+			panic(err)
+		}
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
 	return matureUnbonds
@@ -424,35 +385,32 @@ func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context, currTime time.Time) (m
 
 // GetRedelegations returns a given amount of all the delegator redelegations.
 func (k Keeper) GetRedelegations(ctx sdk.Context, delegator sdk.AccAddress, maxRetrieve uint16) (redelegations []types.Redelegation) {
-	redelegations = make([]types.Redelegation, maxRetrieve)
-
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetREDsKey(delegator)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey)
-	defer iterator.Close()
+	redelegations = make([]types.Redelegation, 0, maxRetrieve)
+	if maxRetrieve == 0 {
+		return redelegations
+	}
 
-	i := 0
-	for ; iterator.Valid() && i < int(maxRetrieve); iterator.Next() {
-		redelegation := types.MustUnmarshalRED(k.cdc, iterator.Value())
-		redelegations[i] = redelegation
-		i++
+	rng := collections.NewPrefixedTripleRange[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress](delegator)
+	err := k.Redelegations.Walk(ctx, rng, func(_ collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress], red types.Redelegation) bool {
+		redelegations = append(redelegations, red)
+		return len(redelegations) == int(maxRetrieve)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
-	return redelegations[:i] // trim if the array length < maxRetrieve
+	return redelegations
 }
 
 // GetRedelegation returns a redelegation.
 func (k Keeper) GetRedelegation(ctx sdk.Context, delAddr sdk.AccAddress, valSrcAddr, valDstAddr sdk.ValAddress) (red types.Redelegation, found bool) {
-	store := ctx.KVStore(k.storeKey)
-	key := types.GetREDKey(delAddr, valSrcAddr, valDstAddr)
-
-	value := store.Get(key)
-	if value == nil {
+	red, err := k.Redelegations.Get(ctx, collections.Join3(delAddr, valSrcAddr, valDstAddr))
+	if errors.Is(err, collections.ErrNotFound) {
 		return red, false
 	}
-
-	red = types.MustUnmarshalRED(k.cdc, value)
+	if err != nil {
+		panic(err)
+	}
 
 	return red, true
 }
@@ -460,16 +418,18 @@ func (k Keeper) GetRedelegation(ctx sdk.Context, delAddr sdk.AccAddress, valSrcA
 // GetRedelegationsFromSrcValidator returns all redelegations from a particular
 // validator.
 func (k Keeper) GetRedelegationsFromSrcValidator(ctx sdk.Context, valAddr sdk.ValAddress) (reds []types.Redelegation) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.GetREDsFromValSrcIndexKey(valAddr))
-	defer iterator.Close()
+	rng := collections.NewPrefixedTripleRange[sdk.ValAddress, sdk.AccAddress, sdk.ValAddress](valAddr)
+	err := k.Redelegations.Indexes.SrcValidator.Walk(ctx, rng, func(_ sdk.ValAddress, pk collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress]) bool {
+		red, err := k.Redelegations.Get(ctx, pk)
+		if err != nil {
+			panic(err)
+		}
 
-	for ; iterator.Valid(); iterator.Next() {
-		key := types.GetREDKeyFromValSrcIndexKey(iterator.Key())
-		value := store.Get(key)
-		red := types.MustUnmarshalRED(k.cdc, value)
 		reds = append(reds, red)
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
 	return reds
@@ -477,13 +437,17 @@ func (k Keeper) GetRedelegationsFromSrcValidator(ctx sdk.Context, valAddr sdk.Va
 
 // HasReceivingRedelegation checks if validator is receiving a redelegation.
 func (k Keeper) HasReceivingRedelegation(ctx sdk.Context, delAddr sdk.AccAddress, valDstAddr sdk.ValAddress) bool {
-	store := ctx.KVStore(k.storeKey)
-	prefix := types.GetREDsByDelToValDstIndexKey(delAddr, valDstAddr)
-
-	iterator := storetypes.KVStorePrefixIterator(store, prefix)
-	defer iterator.Close()
+	found := false
+	rng := collections.NewSuperPrefixedTripleRange[sdk.ValAddress, sdk.AccAddress, sdk.ValAddress](valDstAddr, delAddr)
+	err := k.Redelegations.Indexes.DstValidator.Walk(ctx, rng, func(sdk.ValAddress, collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress]) bool {
+		found = true
+		return true
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
+	}
 
-	return iterator.Valid()
+	return found
 }
 
 // HasMaxRedelegationEntries checks if redelegation has maximum number of entries.
@@ -503,8 +467,6 @@ func (k Keeper) SetRedelegation(ctx sdk.Context, red types.Redelegation) {
 		panic(err)
 	}
 
-	store := ctx.KVStore(k.storeKey)
-	bz := types.MustMarshalRED(k.cdc, red)
 	valSrcAddr, err := sdk.ValAddressFromBech32(red.ValidatorSrcAddress)
 	if err != nil {
 		panic(err)
@@ -513,10 +475,9 @@ func (k Keeper) SetRedelegation(ctx sdk.Context, red types.Redelegation) {
 	if err != nil {
 		panic(err)
 	}
-	key := types.GetREDKey(delegatorAddress, valSrcAddr, valDestAddr)
-	store.Set(key, bz)
-	store.Set(types.GetREDByValSrcIndexKey(delegatorAddress, valSrcAddr, valDestAddr), []byte{})
-	store.Set(types.GetREDByValDstIndexKey(delegatorAddress, valSrcAddr, valDestAddr), []byte{})
+	if err := k.Redelegations.Set(ctx, collections.Join3(sdk.AccAddress(delegatorAddress), valSrcAddr, valDestAddr), red); err != nil {
+		panic(err)
+	}
 }
 
 // SetRedelegationEntry adds an entry to the unbonding delegation at the given
@@ -550,17 +511,16 @@ func (k Keeper) SetRedelegationEntry(ctx sdk.Context,
 
 // IterateRedelegations iterates through all redelegations.
 func (k Keeper) IterateRedelegations(ctx sdk.Context, fn func(index int64, red types.Redelegation) (stop bool)) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.RedelegationKey)
-	defer iterator.Close()
-
-	for i := int64(0); iterator.Valid(); iterator.Next() {
-		red := types.MustUnmarshalRED(k.cdc, iterator.Value())
-		if stop := fn(i, red); stop {
-			break
+	i := int64(0)
+	err := k.Redelegations.Walk(ctx, nil, func(_ collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress], red types.Redelegation) bool {
+		if fn(i, red) {
+			return true
 		}
 		i++
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
@@ -571,7 +531,6 @@ func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
 		panic(err)
 	}
 
-	store := ctx.KVStore(k.storeKey)
 	valSrcAddr, err := sdk.ValAddressFromBech32(red.ValidatorSrcAddress)
 	if err != nil {
 		panic(err)
@@ -580,10 +539,9 @@ func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
 	if err != nil {
 		panic(err)
 	}
-	redKey := types.GetREDKey(delegatorAddress, valSrcAddr, valDestAddr)
-	store.Delete(redKey)
-	store.Delete(types.GetREDByValSrcIndexKey(delegatorAddress, valSrcAddr, valDestAddr))
-	store.Delete(types.GetREDByValDstIndexKey(delegatorAddress, valSrcAddr, valDestAddr))
+	if err := k.Redelegations.Remove(ctx, collections.Join3(sdk.AccAddress(delegatorAddress), valSrcAddr, valDestAddr)); err != nil {
+		panic(err)
+	}
 }
 
 // redelegation queue timeslice operations
@@ -592,24 +550,22 @@ func (k Keeper) RemoveRedelegation(ctx sdk.Context, red types.Redelegation) {
 // timeslice is a slice of DVVTriplets corresponding to redelegations that
 // expire at a certain time.
 func (k Keeper) GetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp time.Time) (dvvTriplets []types.DVVTriplet) {
-	store := ctx.KVStore(k.storeKey)
-
-	bz := store.Get(types.GetRedelegationTimeKey(timestamp))
-	if bz == nil {
+	triplets, err := k.RedelegationQueue.Get(ctx, timestamp)
+	if errors.Is(err, collections.ErrNotFound) {
 		return []types.DVVTriplet{}
 	}
-
-	triplets := types.DVVTriplets{}
-	k.cdc.MustUnmarshal(bz, &triplets)
+	if err != nil {
+		panic(err)
+	}
 
 	return triplets.Triplets
 }
 
 // SetRedelegationQueueTimeSlice sets a specific redelegation queue timeslice.
 func (k Keeper) SetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp time.Time, keys []types.DVVTriplet) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshal(&types.DVVTriplets{Triplets: keys})
-	store.Set(types.GetRedelegationTimeKey(timestamp), bz)
+	if err := k.RedelegationQueue.Set(ctx, timestamp, types.DVVTriplets{Triplets: keys}); err != nil {
+		panic(err)
+	}
 }
 
 // InsertRedelegationQueue insert an redelegation delegation to the appropriate
@@ -641,20 +597,18 @@ func (k Keeper) RedelegationQueueIterator(ctx sdk.Context, endTime time.Time) st
 // timeslices inclusively previous to currTime, and deletes the timeslices from
 // the queue.
 func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime time.Time) (matureRedelegations []types.DVVTriplet) {
-	store := ctx.KVStore(k.storeKey)
-
-	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, ctx.BlockHeader().Time)
-	defer redelegationTimesliceIterator.Close()
-
-	for ; redelegationTimesliceIterator.Valid(); redelegationTimesliceIterator.Next() {
-		timeslice := types.DVVTriplets{}
-		value := redelegationTimesliceIterator.Value()
-		k.cdc.MustUnmarshal(value, &timeslice)
-
+	// walks all timeslices from time 0 until the current Blockheader time
+	rng := new(collections.Range[time.Time]).EndInclusive(ctx.BlockHeader().Time)
+	err := k.RedelegationQueue.Walk(ctx, rng, func(timestamp time.Time, timeslice types.DVVTriplets) bool {
 		matureRedelegations = append(matureRedelegations, timeslice.Triplets...)
 
-		store.Delete(redelegationTimesliceIterator.Key())
+		if err := k.RedelegationQueue.Remove(ctx, timestamp); err != nil {
+			panic(err)
+		}
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
 	return matureRedelegations
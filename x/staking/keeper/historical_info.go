@@ -1,7 +1,9 @@
 package keeper
 
 import (
-	storetypes "cosmossdk.io/store/types"
+	"errors"
+
+	"cosmossdk.io/collections"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -9,31 +11,29 @@ import (
 
 // GetHistoricalInfo gets the historical info at a given height
 func (k Keeper) GetHistoricalInfo(ctx sdk.Context, height int64) (types.HistoricalInfo, bool) {
-	store := ctx.KVStore(k.storeKey)
-	key := types.GetHistoricalInfoKey(height)
-
-	value := store.Get(key)
-	if value == nil {
+	hi, err := k.HistoricalInfo.Get(ctx, uint64(height))
+	if errors.Is(err, collections.ErrNotFound) {
 		return types.HistoricalInfo{}, false
 	}
+	if err != nil {
+		panic(err)
+	}
 
-	return types.MustUnmarshalHistoricalInfo(k.cdc, value), true
+	return hi, true
 }
 
 // SetHistoricalInfo sets the historical info at a given height
 func (k Keeper) SetHistoricalInfo(ctx sdk.Context, height int64, hi *types.HistoricalInfo) {
-	store := ctx.KVStore(k.storeKey)
-	key := types.GetHistoricalInfoKey(height)
-	value := k.cdc.MustMarshal(hi)
-	store.Set(key, value)
+	if err := k.HistoricalInfo.Set(ctx, uint64(height), *hi); err != nil {
+		panic(err)
+	}
 }
 
 // DeleteHistoricalInfo deletes the historical info at a given height
 func (k Keeper) DeleteHistoricalInfo(ctx sdk.Context, height int64) {
-	store := ctx.KVStore(k.storeKey)
-	key := types.GetHistoricalInfoKey(height)
-
-	store.Delete(key)
+	if err := k.HistoricalInfo.Remove(ctx, uint64(height)); err != nil {
+		panic(err)
+	}
 }
 
 // IterateHistoricalInfo provides an interator over all stored HistoricalInfo
@@ -42,16 +42,11 @@ func (k Keeper) DeleteHistoricalInfo(ctx sdk.Context, height int64) {
 //
 // true, the iterator will close and stop.
 func (k Keeper) IterateHistoricalInfo(ctx sdk.Context, cb func(types.HistoricalInfo) bool) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.HistoricalInfoKey)
-	defer iterator.Close()
-
-	for ; iterator.Valid(); iterator.Next() {
-		histInfo := types.MustUnmarshalHistoricalInfo(k.cdc, iterator.Value())
-		if cb(histInfo) {
-			break
-		}
+	err := k.HistoricalInfo.Walk(ctx, nil, func(_ uint64, histInfo types.HistoricalInfo) bool {
+		return cb(histInfo)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
@@ -1,14 +1,21 @@
 package keeper
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"cosmossdk.io/collections"
+	collcodec "cosmossdk.io/collections/codec"
+	"cosmossdk.io/collections/indexes"
 	"cosmossdk.io/log"
 	"cosmossdk.io/math"
 	abci "github.com/cometbft/cometbft/abci/types"
+	gogotypes "github.com/cosmos/gogoproto/types"
 
 	storetypes "cosmossdk.io/store/types"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/staking/types"
 )
@@ -19,6 +26,66 @@ var _ types.ValidatorSet = Keeper{}
 // Implements DelegationSet interface
 var _ types.DelegationSet = Keeper{}
 
+func newDelegationsIndexes(sb *collections.SchemaBuilder) DelegationsIndexes {
+	return DelegationsIndexes{
+		Validator: indexes.NewReversePair[types.Delegation](
+			sb, types.DelegationByValIndexKey, "delegations_by_validator_index",
+			collections.PairKeyCodec(sdk.AccAddressKey, sdk.AddressKeyAsIndexKey(sdk.ValAddressKey)), // nolint:staticcheck // Note: refer to the AddressKeyAsIndexKey docs to understand why we do this.
+		),
+	}
+}
+
+// DelegationsIndexes defines the indexes of the delegations, keyed by
+// delegator and validator addresses.
+type DelegationsIndexes struct {
+	Validator *indexes.ReversePair[sdk.AccAddress, sdk.ValAddress, types.Delegation]
+}
+
+func (d DelegationsIndexes) IndexesList() []collections.Index[collections.Pair[sdk.AccAddress, sdk.ValAddress], types.Delegation] {
+	return []collections.Index[collections.Pair[sdk.AccAddress, sdk.ValAddress], types.Delegation]{d.Validator}
+}
+
+func newUnbondingDelegationsIndexes(sb *collections.SchemaBuilder) UnbondingDelegationsIndexes {
+	return UnbondingDelegationsIndexes{
+		Validator: indexes.NewReversePair[types.UnbondingDelegation](
+			sb, types.UnbondingDelegationByValIndexKey, "unbonding_delegations_by_validator_index",
+			collections.PairKeyCodec(sdk.AddressKeyAsIndexKey(sdk.AccAddressKey), sdk.AddressKeyAsIndexKey(sdk.ValAddressKey)), // nolint:staticcheck // Note: refer to the AddressKeyAsIndexKey docs to understand why we do this.
+		),
+	}
+}
+
+// UnbondingDelegationsIndexes defines the indexes of the unbonding delegations,
+// keyed by delegator and validator addresses.
+type UnbondingDelegationsIndexes struct {
+	Validator *indexes.ReversePair[sdk.AccAddress, sdk.ValAddress, types.UnbondingDelegation]
+}
+
+func (u UnbondingDelegationsIndexes) IndexesList() []collections.Index[collections.Pair[sdk.AccAddress, sdk.ValAddress], types.UnbondingDelegation] {
+	return []collections.Index[collections.Pair[sdk.AccAddress, sdk.ValAddress], types.UnbondingDelegation]{u.Validator}
+}
+
+func newRedelegationsIndexes(sb *collections.SchemaBuilder, keyCodec redelegationKeyCodec) RedelegationsIndexes {
+	return RedelegationsIndexes{
+		SrcValidator: indexes.NewTripleByK2[types.Redelegation](sb, types.RedelegationByValSrcIndexKey, "redelegations_by_src_validator_index", keyCodec),
+		DstValidator: indexes.NewTripleByK3[types.Redelegation](sb, types.RedelegationByValDstIndexKey, "redelegations_by_dst_validator_index", keyCodec),
+	}
+}
+
+// redelegationKeyCodec is the codec of the keys of the redelegations: the
+// delegator, source validator and destination validator addresses.
+type redelegationKeyCodec = collcodec.KeyCodec[collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress]]
+
+// RedelegationsIndexes defines the indexes of the redelegations, keyed by
+// delegator, source validator and destination validator addresses.
+type RedelegationsIndexes struct {
+	SrcValidator *indexes.TripleByK2[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress, types.Redelegation]
+	DstValidator *indexes.TripleByK3[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress, types.Redelegation]
+}
+
+func (r RedelegationsIndexes) IndexesList() []collections.Index[collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress], types.Redelegation] {
+	return []collections.Index[collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress], types.Redelegation]{r.SrcValidator, r.DstValidator}
+}
+
 // Keeper of the x/staking store
 type Keeper struct {
 	storeKey   storetypes.StoreKey
@@ -27,12 +94,34 @@ type Keeper struct {
 	bankKeeper types.BankKeeper
 	hooks      types.StakingHooks
 	authority  string
+
+	// The keys of the collections are byte compatible with the ones the
+	// store used to be keyed by, see types/keys.go. The validators by power
+	// index, whose keys hold the inverted operator addresses, is not a
+	// collection: it is iterated by ValidatorsPowerStoreIterator.
+	Schema                      collections.Schema
+	LastTotalPower              collections.Item[sdk.IntProto]
+	LastValidatorPower          collections.Map[sdk.ValAddress, gogotypes.Int64Value]
+	Validators                  collections.Map[sdk.ValAddress, types.Validator]
+	ValidatorByConsensusAddress collections.Map[sdk.ConsAddress, sdk.ValAddress]
+	Delegations                 *collections.IndexedMap[collections.Pair[sdk.AccAddress, sdk.ValAddress], types.Delegation, DelegationsIndexes]
+	UnbondingDelegations        *collections.IndexedMap[collections.Pair[sdk.AccAddress, sdk.ValAddress], types.UnbondingDelegation, UnbondingDelegationsIndexes]
+	Redelegations               *collections.IndexedMap[collections.Triple[sdk.AccAddress, sdk.ValAddress, sdk.ValAddress], types.Redelegation, RedelegationsIndexes]
+	UnbondingID                 collections.Sequence
+	UnbondingIndex              collections.Map[uint64, []byte]
+	UnbondingType               collections.Map[uint64, uint64]
+	UnbondingQueue              collections.Map[time.Time, types.DVPairs]
+	RedelegationQueue           collections.Map[time.Time, types.DVVTriplets]
+	ValidatorQueue              collections.Map[collections.Triple[uint64, time.Time, uint64], types.ValAddresses]
+	HistoricalInfo              collections.Map[uint64, types.HistoricalInfo]
+	ValidatorUpdates            collections.Item[types.ValidatorUpdates]
+	Params                      collections.Item[types.Params]
 }
 
 // NewKeeper creates a new staking Keeper instance
 func NewKeeper(
 	cdc codec.BinaryCodec,
-	key storetypes.StoreKey,
+	key *storetypes.KVStoreKey,
 	ak types.AccountKeeper,
 	bk types.BankKeeper,
 	authority string,
@@ -51,14 +140,67 @@ func NewKeeper(
 		panic("authority is not a valid acc address")
 	}
 
-	return &Keeper{
-		storeKey:   key,
-		cdc:        cdc,
-		authKeeper: ak,
-		bankKeeper: bk,
-		hooks:      nil,
-		authority:  authority,
+	sb := collections.NewSchemaBuilder(runtime.NewKVStoreService(key))
+	redelegationKeyCodec := collections.TripleKeyCodec(
+		sdk.AccAddressKey,
+		sdk.AddressKeyAsIndexKey(sdk.ValAddressKey), // nolint:staticcheck // Note: refer to the AddressKeyAsIndexKey docs to understand why we do this.
+		sdk.AddressKeyAsIndexKey(sdk.ValAddressKey), // nolint:staticcheck
+	)
+	k := &Keeper{
+		storeKey:           key,
+		cdc:                cdc,
+		authKeeper:         ak,
+		bankKeeper:         bk,
+		hooks:              nil,
+		authority:          authority,
+		LastTotalPower:     collections.NewItem(sb, types.LastTotalPowerKey, "last_total_power", codec.CollValue[sdk.IntProto](cdc)),
+		LastValidatorPower: collections.NewMap(sb, types.LastValidatorPowerKey, "last_validator_power", sdk.AddressKeyAsIndexKey(sdk.ValAddressKey), codec.CollValue[gogotypes.Int64Value](cdc)), // nolint:staticcheck
+		Validators:         collections.NewMap(sb, types.ValidatorsKey, "validators", sdk.AddressKeyAsIndexKey(sdk.ValAddressKey), codec.CollValue[types.Validator](cdc)),                        // nolint:staticcheck
+		ValidatorByConsensusAddress: collections.NewMap(
+			sb, types.ValidatorsByConsAddrKey, "validator_by_consensus_address",
+			sdk.AddressKeyAsIndexKey(sdk.ConsAddressKey), // nolint:staticcheck
+			collcodec.KeyToValueCodec(sdk.ValAddressKey),
+		),
+		Delegations: collections.NewIndexedMap(
+			sb, types.DelegationKey, "delegations",
+			collections.PairKeyCodec(sdk.AccAddressKey, sdk.AddressKeyAsIndexKey(sdk.ValAddressKey)), // nolint:staticcheck
+			codec.CollValue[types.Delegation](cdc),
+			newDelegationsIndexes(sb),
+		),
+		UnbondingDelegations: collections.NewIndexedMap(
+			sb, types.UnbondingDelegationKey, "unbonding_delegations",
+			collections.PairKeyCodec(sdk.AddressKeyAsIndexKey(sdk.AccAddressKey), sdk.AddressKeyAsIndexKey(sdk.ValAddressKey)), // nolint:staticcheck
+			codec.CollValue[types.UnbondingDelegation](cdc),
+			newUnbondingDelegationsIndexes(sb),
+		),
+		Redelegations: collections.NewIndexedMap(
+			sb, types.RedelegationKey, "redelegations",
+			redelegationKeyCodec,
+			codec.CollValue[types.Redelegation](cdc),
+			newRedelegationsIndexes(sb, redelegationKeyCodec),
+		),
+		UnbondingID:       collections.NewSequence(sb, types.UnbondingIDKey, "unbonding_id"),
+		UnbondingIndex:    collections.NewMap(sb, types.UnbondingIndexKey, "unbonding_index", collections.Uint64Key, collections.BytesValue),
+		UnbondingType:     collections.NewMap(sb, types.UnbondingTypeKey, "unbonding_type", collections.Uint64Key, collections.Uint64Value),
+		UnbondingQueue:    collections.NewMap(sb, types.UnbondingQueueKey, "unbonding_queue", sdk.TimeKey, codec.CollValue[types.DVPairs](cdc)),
+		RedelegationQueue: collections.NewMap(sb, types.RedelegationQueueKey, "redelegation_queue", sdk.TimeKey, codec.CollValue[types.DVVTriplets](cdc)),
+		// the keys of the validator queue are prefixed by the length of the encoded time
+		ValidatorQueue: collections.NewMap(
+			sb, types.ValidatorQueueKey, "validator_queue",
+			collections.TripleKeyCodec(collections.Uint64Key, sdk.TimeKey, collections.Uint64Key),
+			codec.CollValue[types.ValAddresses](cdc),
+		),
+		HistoricalInfo:   collections.NewMap(sb, types.HistoricalInfoKey, "historical_info", collections.Uint64Key, codec.CollValue[types.HistoricalInfo](cdc)),
+		ValidatorUpdates: collections.NewItem(sb, types.ValidatorUpdatesKey, "validator_updates", codec.CollValue[types.ValidatorUpdates](cdc)),
+		Params:           collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
+	}
+
+	schema, err := sb.Build()
+	if err != nil {
+		panic(err)
 	}
+	k.Schema = schema
+	return k
 }
 
 // Logger returns a module-specific logger.
@@ -88,24 +230,22 @@ func (k *Keeper) SetHooks(sh types.StakingHooks) {
 
 // GetLastTotalPower Load the last total validator power.
 func (k Keeper) GetLastTotalPower(ctx sdk.Context) math.Int {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.LastTotalPowerKey)
-
-	if bz == nil {
+	ip, err := k.LastTotalPower.Get(ctx)
+	if errors.Is(err, collections.ErrNotFound) {
 		return math.ZeroInt()
 	}
-
-	ip := sdk.IntProto{}
-	k.cdc.MustUnmarshal(bz, &ip)
+	if err != nil {
+		panic(err)
+	}
 
 	return ip.Int
 }
 
 // SetLastTotalPower Set the last total validator power.
 func (k Keeper) SetLastTotalPower(ctx sdk.Context, power math.Int) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshal(&sdk.IntProto{Int: power})
-	store.Set(types.LastTotalPowerKey, bz)
+	if err := k.LastTotalPower.Set(ctx, sdk.IntProto{Int: power}); err != nil {
+		panic(err)
+	}
 }
 
 // GetAuthority returns the x/staking module's authority.
@@ -115,18 +255,17 @@ func (k Keeper) GetAuthority() string {
 
 // SetValidatorUpdates sets the ABCI validator power updates for the current block.
 func (k Keeper) SetValidatorUpdates(ctx sdk.Context, valUpdates []abci.ValidatorUpdate) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshal(&types.ValidatorUpdates{Updates: valUpdates})
-	store.Set(types.ValidatorUpdatesKey, bz)
+	if err := k.ValidatorUpdates.Set(ctx, types.ValidatorUpdates{Updates: valUpdates}); err != nil {
+		panic(err)
+	}
 }
 
 // GetValidatorUpdates returns the ABCI validator power updates within the current block.
 func (k Keeper) GetValidatorUpdates(ctx sdk.Context) []abci.ValidatorUpdate {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.ValidatorUpdatesKey)
-
-	var valUpdates types.ValidatorUpdates
-	k.cdc.MustUnmarshal(bz, &valUpdates)
+	valUpdates, err := k.ValidatorUpdates.Get(ctx)
+	if err != nil && !errors.Is(err, collections.ErrNotFound) {
+		panic(err)
+	}
 
 	return valUpdates.Updates
 }
@@ -1,8 +1,10 @@
 package keeper_test
 
 import (
+	"encoding/binary"
 	"errors"
 	"testing"
+	"time"
 
 	"cosmossdk.io/math"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -34,6 +36,7 @@ type KeeperTestSuite struct {
 	suite.Suite
 
 	ctx           sdk.Context
+	key           *storetypes.KVStoreKey
 	stakingKeeper *stakingkeeper.Keeper
 	bankKeeper    *stakingtestutil.MockBankKeeper
 	accountKeeper *stakingtestutil.MockAccountKeeper
@@ -68,6 +71,7 @@ func (s *KeeperTestSuite) SetupTest() {
 	keeper.SetParams(ctx, stakingtypes.DefaultParams())
 
 	s.ctx = ctx
+	s.key = key
 	s.stakingKeeper = keeper
 	s.bankKeeper = bankKeeper
 	s.accountKeeper = accountKeeper
@@ -101,6 +105,82 @@ func (s *KeeperTestSuite) TestLastTotalPower() {
 	require.True(expTotalPower.Equal(resTotalPower))
 }
 
+// TestCollectionsKeys checks that the collections of the keeper store their
+// entries under the keys built by the stakingtypes key functions, and that the
+// schema of the keeper decodes all of them but the power index ones.
+func (s *KeeperTestSuite) TestCollectionsKeys() {
+	ctx, keeper := s.ctx, s.stakingKeeper
+	require := s.Require()
+	store := ctx.KVStore(s.key)
+
+	valAddr, dstAddr := sdk.ValAddress(PKs[0].Address()), sdk.ValAddress(PKs[1].Address())
+	delAddr := sdk.AccAddress(PKs[2].Address())
+	s.accountKeeper.EXPECT().StringToBytes(delAddr.String()).Return(delAddr, nil).AnyTimes()
+	completionTime, height := time.Unix(1700000000, 0).UTC(), int64(10)
+
+	validator := stakingtestutil.NewValidator(s.T(), valAddr, PKs[0])
+	validator.UnbondingTime, validator.UnbondingHeight = completionTime, height
+	keeper.SetValidator(ctx, validator)
+	require.NoError(keeper.SetValidatorByConsAddr(ctx, validator))
+	keeper.SetValidatorByPowerIndex(ctx, validator)
+	keeper.SetLastValidatorPower(ctx, valAddr, 10)
+	keeper.SetLastTotalPower(ctx, math.NewInt(10))
+	keeper.InsertUnbondingValidatorQueue(ctx, validator)
+	keeper.SetDelegation(ctx, stakingtypes.NewDelegation(delAddr, valAddr, math.LegacyNewDec(10)))
+
+	id := keeper.IncrementUnbondingID(ctx)
+	require.Equal(uint64(1), id)
+	ubd := stakingtypes.NewUnbondingDelegation(delAddr, valAddr, height, completionTime, math.NewInt(5), id)
+	keeper.SetUnbondingDelegation(ctx, ubd)
+	keeper.SetUnbondingDelegationByUnbondingID(ctx, ubd, id)
+	keeper.InsertUBDQueue(ctx, ubd, completionTime)
+	red := stakingtypes.NewRedelegation(delAddr, valAddr, dstAddr, height, completionTime, math.NewInt(5), math.LegacyNewDec(5), id+1)
+	keeper.SetRedelegation(ctx, red)
+	keeper.InsertRedelegationQueue(ctx, red, completionTime)
+	keeper.SetHistoricalInfo(ctx, height, &stakingtypes.HistoricalInfo{Header: cmtproto.Header{Height: height}})
+
+	consAddr, err := validator.GetConsAddr()
+	require.NoError(err)
+	idBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(idBytes, id)
+
+	for _, key := range [][]byte{
+		stakingtypes.ParamsKey,
+		stakingtypes.LastTotalPowerKey,
+		stakingtypes.GetValidatorKey(valAddr),
+		stakingtypes.GetValidatorByConsAddrKey(consAddr),
+		stakingtypes.GetLastValidatorPowerKey(valAddr),
+		stakingtypes.GetValidatorQueueKey(completionTime, height),
+		stakingtypes.GetDelegationKey(delAddr, valAddr),
+		stakingtypes.GetDelegationsByValKey(valAddr, delAddr),
+		stakingtypes.GetUBDKey(delAddr, valAddr),
+		stakingtypes.GetUBDByValIndexKey(delAddr, valAddr),
+		stakingtypes.GetUnbondingDelegationTimeKey(completionTime),
+		stakingtypes.GetREDKey(delAddr, valAddr, dstAddr),
+		stakingtypes.GetREDByValSrcIndexKey(delAddr, valAddr, dstAddr),
+		stakingtypes.GetREDByValDstIndexKey(delAddr, valAddr, dstAddr),
+		stakingtypes.GetRedelegationTimeKey(completionTime),
+		stakingtypes.GetUnbondingIndexKey(id),
+		stakingtypes.GetUnbondingTypeKey(id),
+		stakingtypes.GetHistoricalInfoKey(height),
+	} {
+		require.True(store.Has(key), "missing key %X", key)
+	}
+	require.Equal([]byte(valAddr), store.Get(stakingtypes.GetValidatorByConsAddrKey(consAddr)))
+	require.Equal(idBytes, store.Get(stakingtypes.UnbondingIDKey))
+	require.Equal(stakingtypes.GetUBDKey(delAddr, valAddr), store.Get(stakingtypes.GetUnbondingIndexKey(id)))
+
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if iterator.Key()[0] == stakingtypes.ValidatorsByPowerIndexKey[0] {
+			continue
+		}
+		_, err := keeper.Schema.DecodeKV(iterator.Key(), iterator.Value())
+		require.NoError(err, "key %X", iterator.Key())
+	}
+}
+
 func TestKeeperTestSuite(t *testing.T) {
 	suite.Run(t, new(KeeperTestSuite))
 }
@@ -1,8 +1,6 @@
 package keeper
 
 import (
-	storetypes "cosmossdk.io/store/types"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/staking/types"
 )
@@ -11,28 +9,22 @@ import (
 func (k Keeper) GetDelegatorValidators(
 	ctx sdk.Context, delegatorAddr sdk.AccAddress, maxRetrieve uint32,
 ) types.Validators {
-	validators := make([]types.Validator, maxRetrieve)
-
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetDelegationsKey(delegatorAddr)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey) // smallest to largest
-	defer iterator.Close()
-
-	i := 0
-	for ; iterator.Valid() && i < int(maxRetrieve); iterator.Next() {
-		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Value())
+	validators := make([]types.Validator, 0, maxRetrieve)
+	if maxRetrieve == 0 {
+		return validators
+	}
 
+	k.IterateDelegatorDelegations(ctx, delegatorAddr, func(delegation types.Delegation) bool {
 		validator, found := k.GetValidator(ctx, delegation.GetValidatorAddr())
 		if !found {
 			panic(types.ErrNoValidatorFound)
 		}
 
-		validators[i] = validator
-		i++
-	}
+		validators = append(validators, validator)
+		return len(validators) == int(maxRetrieve)
+	})
 
-	return validators[:i] // trim
+	return validators
 }
 
 // return a validator that a delegator is bonded to
@@ -56,19 +48,10 @@ func (k Keeper) GetDelegatorValidator(
 func (k Keeper) GetAllDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress) []types.Delegation {
 	delegations := make([]types.Delegation, 0)
 
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetDelegationsKey(delegator)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey) // smallest to largest
-	defer iterator.Close()
-
-	i := 0
-
-	for ; iterator.Valid(); iterator.Next() {
-		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Value())
+	k.IterateDelegatorDelegations(ctx, delegator, func(delegation types.Delegation) bool {
 		delegations = append(delegations, delegation)
-		i++
-	}
+		return false
+	})
 
 	return delegations
 }
@@ -77,17 +60,10 @@ func (k Keeper) GetAllDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAdd
 func (k Keeper) GetAllUnbondingDelegations(ctx sdk.Context, delegator sdk.AccAddress) []types.UnbondingDelegation {
 	unbondingDelegations := make([]types.UnbondingDelegation, 0)
 
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetUBDsKey(delegator)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey) // smallest to largest
-	defer iterator.Close()
-
-	for i := 0; iterator.Valid(); iterator.Next() {
-		unbondingDelegation := types.MustUnmarshalUBD(k.cdc, iterator.Value())
-		unbondingDelegations = append(unbondingDelegations, unbondingDelegation)
-		i++
-	}
+	k.IterateDelegatorUnbondingDelegations(ctx, delegator, func(ubd types.UnbondingDelegation) bool {
+		unbondingDelegations = append(unbondingDelegations, ubd)
+		return false
+	})
 
 	return unbondingDelegations
 }
@@ -96,19 +72,12 @@ func (k Keeper) GetAllUnbondingDelegations(ctx sdk.Context, delegator sdk.AccAdd
 func (k Keeper) GetAllRedelegations(
 	ctx sdk.Context, delegator sdk.AccAddress, srcValAddress, dstValAddress sdk.ValAddress,
 ) []types.Redelegation {
-	store := ctx.KVStore(k.storeKey)
-	delegatorPrefixKey := types.GetREDsKey(delegator)
-
-	iterator := storetypes.KVStorePrefixIterator(store, delegatorPrefixKey) // smallest to largest
-	defer iterator.Close()
-
 	srcValFilter := !(srcValAddress.Empty())
 	dstValFilter := !(dstValAddress.Empty())
 
 	redelegations := []types.Redelegation{}
 
-	for ; iterator.Valid(); iterator.Next() {
-		redelegation := types.MustUnmarshalRED(k.cdc, iterator.Value())
+	k.IterateDelegatorRedelegations(ctx, delegator, func(redelegation types.Redelegation) bool {
 		valSrcAddr, err := sdk.ValAddressFromBech32(redelegation.ValidatorSrcAddress)
 		if err != nil {
 			panic(err)
@@ -118,15 +87,16 @@ func (k Keeper) GetAllRedelegations(
 			panic(err)
 		}
 		if srcValFilter && !(srcValAddress.Equals(valSrcAddr)) {
-			continue
+			return false
 		}
 
 		if dstValFilter && !(dstValAddress.Equals(valDstAddr)) {
-			continue
+			return false
 		}
 
 		redelegations = append(redelegations, redelegation)
-	}
+		return false
+	})
 
 	return redelegations
 }
@@ -1,8 +1,9 @@
 package keeper
 
 import (
-	"encoding/binary"
+	"errors"
 
+	"cosmossdk.io/collections"
 	errorsmod "cosmossdk.io/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -11,60 +12,57 @@ import (
 
 // IncrementUnbondingID increments and returns a unique ID for an unbonding operation
 func (k Keeper) IncrementUnbondingID(ctx sdk.Context) (unbondingID uint64) {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.UnbondingIDKey)
-	if bz != nil {
-		unbondingID = binary.BigEndian.Uint64(bz)
+	// the sequence stores the last ID returned, Next returns the one before the increment
+	unbondingID, err := k.UnbondingID.Next(ctx)
+	if err != nil {
+		panic(err)
 	}
 
-	unbondingID++
-
-	// Convert back into bytes for storage
-	bz = make([]byte, 8)
-	binary.BigEndian.PutUint64(bz, unbondingID)
-
-	store.Set(types.UnbondingIDKey, bz)
-
-	return unbondingID
+	return unbondingID + 1
 }
 
 // DeleteUnbondingIndex removes a mapping from UnbondingId to unbonding operation
 func (k Keeper) DeleteUnbondingIndex(ctx sdk.Context, id uint64) {
-	store := ctx.KVStore(k.storeKey)
-	store.Delete(types.GetUnbondingIndexKey(id))
+	if err := k.UnbondingIndex.Remove(ctx, id); err != nil {
+		panic(err)
+	}
 }
 
 func (k Keeper) GetUnbondingType(ctx sdk.Context, id uint64) (unbondingType types.UnbondingType, found bool) {
-	store := ctx.KVStore(k.storeKey)
-
-	bz := store.Get(types.GetUnbondingTypeKey(id))
-	if bz == nil {
+	ubdType, err := k.UnbondingType.Get(ctx, id)
+	if errors.Is(err, collections.ErrNotFound) {
 		return unbondingType, false
 	}
+	if err != nil {
+		panic(err)
+	}
 
-	return types.UnbondingType(binary.BigEndian.Uint64(bz)), true
+	return types.UnbondingType(ubdType), true
 }
 
 func (k Keeper) SetUnbondingType(ctx sdk.Context, id uint64, unbondingType types.UnbondingType) {
-	store := ctx.KVStore(k.storeKey)
+	if err := k.UnbondingType.Set(ctx, id, uint64(unbondingType)); err != nil {
+		panic(err)
+	}
+}
 
-	// Convert into bytes for storage
-	bz := make([]byte, 8)
-	binary.BigEndian.PutUint64(bz, uint64(unbondingType))
+// getByUnbondingID returns the value of the unbonding operation the unbonding
+// index of id points to, the index holding the raw store key of the operation.
+func (k Keeper) getByUnbondingID(ctx sdk.Context, id uint64) []byte {
+	key, err := k.UnbondingIndex.Get(ctx, id)
+	if errors.Is(err, collections.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		panic(err)
+	}
 
-	store.Set(types.GetUnbondingTypeKey(id), bz)
+	return ctx.KVStore(k.storeKey).Get(key)
 }
 
 // GetUnbondingDelegationByUnbondingID returns a unbonding delegation that has an unbonding delegation entry with a certain ID
 func (k Keeper) GetUnbondingDelegationByUnbondingID(ctx sdk.Context, id uint64) (ubd types.UnbondingDelegation, found bool) {
-	store := ctx.KVStore(k.storeKey)
-
-	ubdKey := store.Get(types.GetUnbondingIndexKey(id))
-	if ubdKey == nil {
-		return types.UnbondingDelegation{}, false
-	}
-
-	value := store.Get(ubdKey)
+	value := k.getByUnbondingID(ctx, id)
 	if value == nil {
 		return types.UnbondingDelegation{}, false
 	}
@@ -80,14 +78,7 @@ func (k Keeper) GetUnbondingDelegationByUnbondingID(ctx sdk.Context, id uint64)
 
 // GetRedelegationByUnbondingID returns a unbonding delegation that has an unbonding delegation entry with a certain ID
 func (k Keeper) GetRedelegationByUnbondingID(ctx sdk.Context, id uint64) (red types.Redelegation, found bool) {
-	store := ctx.KVStore(k.storeKey)
-
-	redKey := store.Get(types.GetUnbondingIndexKey(id))
-	if redKey == nil {
-		return types.Redelegation{}, false
-	}
-
-	value := store.Get(redKey)
+	value := k.getByUnbondingID(ctx, id)
 	if value == nil {
 		return types.Redelegation{}, false
 	}
@@ -103,14 +94,7 @@ func (k Keeper) GetRedelegationByUnbondingID(ctx sdk.Context, id uint64) (red ty
 
 // GetValidatorByUnbondingID returns the validator that is unbonding with a certain unbonding op ID
 func (k Keeper) GetValidatorByUnbondingID(ctx sdk.Context, id uint64) (val types.Validator, found bool) {
-	store := ctx.KVStore(k.storeKey)
-
-	valKey := store.Get(types.GetUnbondingIndexKey(id))
-	if valKey == nil {
-		return types.Validator{}, false
-	}
-
-	value := store.Get(valKey)
+	value := k.getByUnbondingID(ctx, id)
 	if value == nil {
 		return types.Validator{}, false
 	}
@@ -127,7 +111,6 @@ func (k Keeper) GetValidatorByUnbondingID(ctx sdk.Context, id uint64) (val types
 // SetUnbondingDelegationByUnbondingID sets an index to look up an UnbondingDelegation by the unbondingID of an UnbondingDelegationEntry that it contains
 // Note, it does not set the unbonding delegation itself, use SetUnbondingDelegation(ctx, ubd) for that
 func (k Keeper) SetUnbondingDelegationByUnbondingID(ctx sdk.Context, ubd types.UnbondingDelegation, id uint64) {
-	store := ctx.KVStore(k.storeKey)
 	delAddr, err := k.authKeeper.StringToBytes(ubd.DelegatorAddress)
 	if err != nil {
 		panic(err)
@@ -138,7 +121,9 @@ func (k Keeper) SetUnbondingDelegationByUnbondingID(ctx sdk.Context, ubd types.U
 	}
 
 	ubdKey := types.GetUBDKey(delAddr, valAddr)
-	store.Set(types.GetUnbondingIndexKey(id), ubdKey)
+	if err := k.UnbondingIndex.Set(ctx, id, ubdKey); err != nil {
+		panic(err)
+	}
 
 	// Set unbonding type so that we know how to deserialize it later
 	k.SetUnbondingType(ctx, id, types.UnbondingType_UnbondingDelegation)
@@ -147,8 +132,6 @@ func (k Keeper) SetUnbondingDelegationByUnbondingID(ctx sdk.Context, ubd types.U
 // SetRedelegationByUnbondingID sets an index to look up an Redelegation by the unbondingID of an RedelegationEntry that it contains
 // Note, it does not set the redelegation itself, use SetRedelegation(ctx, red) for that
 func (k Keeper) SetRedelegationByUnbondingID(ctx sdk.Context, red types.Redelegation, id uint64) {
-	store := ctx.KVStore(k.storeKey)
-
 	delAddr, err := k.authKeeper.StringToBytes(red.DelegatorAddress)
 	if err != nil {
 		panic(err)
@@ -165,7 +148,9 @@ func (k Keeper) SetRedelegationByUnbondingID(ctx sdk.Context, red types.Redelega
 	}
 
 	redKey := types.GetREDKey(delAddr, valSrcAddr, valDstAddr)
-	store.Set(types.GetUnbondingIndexKey(id), redKey)
+	if err := k.UnbondingIndex.Set(ctx, id, redKey); err != nil {
+		panic(err)
+	}
 
 	// Set unbonding type so that we know how to deserialize it later
 	k.SetUnbondingType(ctx, id, types.UnbondingType_Redelegation)
@@ -174,15 +159,15 @@ func (k Keeper) SetRedelegationByUnbondingID(ctx sdk.Context, red types.Redelega
 // SetValidatorByUnbondingID sets an index to look up a Validator by the unbondingID corresponding to its current unbonding
 // Note, it does not set the validator itself, use SetValidator(ctx, val) for that
 func (k Keeper) SetValidatorByUnbondingID(ctx sdk.Context, val types.Validator, id uint64) {
-	store := ctx.KVStore(k.storeKey)
-
 	valAddr, err := sdk.ValAddressFromBech32(val.OperatorAddress)
 	if err != nil {
 		panic(err)
 	}
 
 	valKey := types.GetValidatorKey(valAddr)
-	store.Set(types.GetUnbondingIndexKey(id), valKey)
+	if err := k.UnbondingIndex.Set(ctx, id, valKey); err != nil {
+		panic(err)
+	}
 
 	// Set unbonding type so that we know how to deserialize it later
 	k.SetUnbondingType(ctx, id, types.UnbondingType_ValidatorUnbonding)
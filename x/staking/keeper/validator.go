@@ -1,11 +1,13 @@
 package keeper
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	gogotypes "github.com/cosmos/gogoproto/types"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
 
@@ -15,14 +17,14 @@ import (
 
 // get a single validator
 func (k Keeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator types.Validator, found bool) {
-	store := ctx.KVStore(k.storeKey)
-
-	value := store.Get(types.GetValidatorKey(addr))
-	if value == nil {
+	validator, err := k.Validators.Get(ctx, addr)
+	if errors.Is(err, collections.ErrNotFound) {
 		return validator, false
 	}
+	if err != nil {
+		panic(err)
+	}
 
-	validator = types.MustUnmarshalValidator(k.cdc, value)
 	return validator, true
 }
 
@@ -37,12 +39,13 @@ func (k Keeper) mustGetValidator(ctx sdk.Context, addr sdk.ValAddress) types.Val
 
 // get a single validator by consensus address
 func (k Keeper) GetValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) (validator types.Validator, found bool) {
-	store := ctx.KVStore(k.storeKey)
-
-	opAddr := store.Get(types.GetValidatorByConsAddrKey(consAddr))
-	if opAddr == nil {
+	opAddr, err := k.ValidatorByConsensusAddress.Get(ctx, consAddr)
+	if errors.Is(err, collections.ErrNotFound) {
 		return validator, false
 	}
+	if err != nil {
+		panic(err)
+	}
 
 	return k.GetValidator(ctx, opAddr)
 }
@@ -58,9 +61,9 @@ func (k Keeper) mustGetValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAdd
 
 // set the main record holding validator details
 func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
-	store := ctx.KVStore(k.storeKey)
-	bz := types.MustMarshalValidator(k.cdc, &validator)
-	store.Set(types.GetValidatorKey(validator.GetOperator()), bz)
+	if err := k.Validators.Set(ctx, validator.GetOperator(), validator); err != nil {
+		panic(err)
+	}
 }
 
 // validator index
@@ -69,9 +72,7 @@ func (k Keeper) SetValidatorByConsAddr(ctx sdk.Context, validator types.Validato
 	if err != nil {
 		return err
 	}
-	store := ctx.KVStore(k.storeKey)
-	store.Set(types.GetValidatorByConsAddrKey(consPk), validator.GetOperator())
-	return nil
+	return k.ValidatorByConsensusAddress.Set(ctx, consPk, validator.GetOperator())
 }
 
 // validator index
@@ -179,10 +180,13 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 	}
 
 	// delete the old validator record
-	store := ctx.KVStore(k.storeKey)
-	store.Delete(types.GetValidatorKey(address))
-	store.Delete(types.GetValidatorByConsAddrKey(valConsAddr))
-	store.Delete(types.GetValidatorsByPowerIndexKey(validator, k.PowerReduction(ctx)))
+	if err := k.Validators.Remove(ctx, address); err != nil {
+		panic(err)
+	}
+	if err := k.ValidatorByConsensusAddress.Remove(ctx, valConsAddr); err != nil {
+		panic(err)
+	}
+	k.DeleteValidatorByPowerIndex(ctx, validator)
 
 	if err := k.Hooks().AfterValidatorRemoved(ctx, valConsAddr, validator.GetOperator()); err != nil {
 		k.Logger(ctx).Error("error in after validator removed hook", "error", err)
@@ -193,14 +197,12 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 
 // get the set of all validators with no limits, used during genesis dump
 func (k Keeper) GetAllValidators(ctx sdk.Context) (validators []types.Validator) {
-	store := ctx.KVStore(k.storeKey)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.ValidatorsKey)
-	defer iterator.Close()
-
-	for ; iterator.Valid(); iterator.Next() {
-		validator := types.MustUnmarshalValidator(k.cdc, iterator.Value())
+	err := k.Validators.Walk(ctx, nil, func(_ sdk.ValAddress, validator types.Validator) bool {
 		validators = append(validators, validator)
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
 	return validators
@@ -208,20 +210,20 @@ func (k Keeper) GetAllValidators(ctx sdk.Context) (validators []types.Validator)
 
 // return a given amount of all the validators
 func (k Keeper) GetValidators(ctx sdk.Context, maxRetrieve uint32) (validators []types.Validator) {
-	store := ctx.KVStore(k.storeKey)
-	validators = make([]types.Validator, maxRetrieve)
-
-	iterator := storetypes.KVStorePrefixIterator(store, types.ValidatorsKey)
-	defer iterator.Close()
+	validators = make([]types.Validator, 0, maxRetrieve)
+	if maxRetrieve == 0 {
+		return validators
+	}
 
-	i := 0
-	for ; iterator.Valid() && i < int(maxRetrieve); iterator.Next() {
-		validator := types.MustUnmarshalValidator(k.cdc, iterator.Value())
-		validators[i] = validator
-		i++
+	err := k.Validators.Walk(ctx, nil, func(_ sdk.ValAddress, validator types.Validator) bool {
+		validators = append(validators, validator)
+		return len(validators) == int(maxRetrieve)
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
-	return validators[:i] // trim if the array length < maxRetrieve
+	return validators
 }
 
 // get the current group of bonded validators sorted by power-rank
@@ -257,30 +259,29 @@ func (k Keeper) ValidatorsPowerStoreIterator(ctx sdk.Context) storetypes.Iterato
 // Load the last validator power.
 // Returns zero if the operator was not a validator last block.
 func (k Keeper) GetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) (power int64) {
-	store := ctx.KVStore(k.storeKey)
-
-	bz := store.Get(types.GetLastValidatorPowerKey(operator))
-	if bz == nil {
+	intV, err := k.LastValidatorPower.Get(ctx, operator)
+	if errors.Is(err, collections.ErrNotFound) {
 		return 0
 	}
-
-	intV := gogotypes.Int64Value{}
-	k.cdc.MustUnmarshal(bz, &intV)
+	if err != nil {
+		panic(err)
+	}
 
 	return intV.GetValue()
 }
 
 // Set the last validator power.
 func (k Keeper) SetLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress, power int64) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshal(&gogotypes.Int64Value{Value: power})
-	store.Set(types.GetLastValidatorPowerKey(operator), bz)
+	if err := k.LastValidatorPower.Set(ctx, operator, gogotypes.Int64Value{Value: power}); err != nil {
+		panic(err)
+	}
 }
 
 // Delete the last validator power.
 func (k Keeper) DeleteLastValidatorPower(ctx sdk.Context, operator sdk.ValAddress) {
-	store := ctx.KVStore(k.storeKey)
-	store.Delete(types.GetLastValidatorPowerKey(operator))
+	if err := k.LastValidatorPower.Remove(ctx, operator); err != nil {
+		panic(err)
+	}
 }
 
 // returns an iterator for the consensus validators in the last block
@@ -293,63 +294,46 @@ func (k Keeper) LastValidatorsIterator(ctx sdk.Context) (iterator storetypes.Ite
 
 // Iterate over last validator powers.
 func (k Keeper) IterateLastValidatorPowers(ctx sdk.Context, handler func(operator sdk.ValAddress, power int64) (stop bool)) {
-	store := ctx.KVStore(k.storeKey)
-
-	iter := storetypes.KVStorePrefixIterator(store, types.LastValidatorPowerKey)
-	defer iter.Close()
-
-	for ; iter.Valid(); iter.Next() {
-		addr := sdk.ValAddress(types.AddressFromLastValidatorPowerKey(iter.Key()))
-		intV := &gogotypes.Int64Value{}
-
-		k.cdc.MustUnmarshal(iter.Value(), intV)
-
-		if handler(addr, intV.GetValue()) {
-			break
-		}
+	err := k.LastValidatorPower.Walk(ctx, nil, func(addr sdk.ValAddress, intV gogotypes.Int64Value) bool {
+		return handler(addr, intV.GetValue())
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
 // get the group of the bonded validators
 func (k Keeper) GetLastValidators(ctx sdk.Context) (validators []types.Validator) {
-	store := ctx.KVStore(k.storeKey)
-
 	// add the actual validator power sorted store
 	maxValidators := k.MaxValidators(ctx)
-	validators = make([]types.Validator, maxValidators)
+	validators = make([]types.Validator, 0, maxValidators)
 
-	iterator := storetypes.KVStorePrefixIterator(store, types.LastValidatorPowerKey)
-	defer iterator.Close()
-
-	i := 0
-	for ; iterator.Valid(); iterator.Next() {
+	err := k.LastValidatorPower.Walk(ctx, nil, func(address sdk.ValAddress, _ gogotypes.Int64Value) bool {
 		// sanity check
-		if i >= int(maxValidators) {
+		if len(validators) >= int(maxValidators) {
 			panic("more validators than maxValidators found")
 		}
 
-		address := types.AddressFromLastValidatorPowerKey(iterator.Key())
-		validator := k.mustGetValidator(ctx, address)
-
-		validators[i] = validator
-		i++
+		validators = append(validators, k.mustGetValidator(ctx, address))
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 
-	return validators[:i] // trim
+	return validators
 }
 
 // GetUnbondingValidators returns a slice of mature validator addresses that
 // complete their unbonding at a given time and height.
 func (k Keeper) GetUnbondingValidators(ctx sdk.Context, endTime time.Time, endHeight int64) []string {
-	store := ctx.KVStore(k.storeKey)
-
-	bz := store.Get(types.GetValidatorQueueKey(endTime, endHeight))
-	if bz == nil {
+	addrs, err := k.ValidatorQueue.Get(ctx, validatorQueueKey(endTime, endHeight))
+	if errors.Is(err, collections.ErrNotFound) {
 		return []string{}
 	}
-
-	addrs := types.ValAddresses{}
-	k.cdc.MustUnmarshal(bz, &addrs)
+	if err != nil {
+		panic(err)
+	}
 
 	return addrs.Addresses
 }
@@ -357,9 +341,9 @@ func (k Keeper) GetUnbondingValidators(ctx sdk.Context, endTime time.Time, endHe
 // SetUnbondingValidatorsQueue sets a given slice of validator addresses into
 // the unbonding validator queue by a given height and time.
 func (k Keeper) SetUnbondingValidatorsQueue(ctx sdk.Context, endTime time.Time, endHeight int64, addrs []string) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshal(&types.ValAddresses{Addresses: addrs})
-	store.Set(types.GetValidatorQueueKey(endTime, endHeight), bz)
+	if err := k.ValidatorQueue.Set(ctx, validatorQueueKey(endTime, endHeight), types.ValAddresses{Addresses: addrs}); err != nil {
+		panic(err)
+	}
 }
 
 // InsertUnbondingValidatorQueue inserts a given unbonding validator address into
@@ -373,8 +357,9 @@ func (k Keeper) InsertUnbondingValidatorQueue(ctx sdk.Context, val types.Validat
 // DeleteValidatorQueueTimeSlice deletes all entries in the queue indexed by a
 // given height and time.
 func (k Keeper) DeleteValidatorQueueTimeSlice(ctx sdk.Context, endTime time.Time, endHeight int64) {
-	store := ctx.KVStore(k.storeKey)
-	store.Delete(types.GetValidatorQueueKey(endTime, endHeight))
+	if err := k.ValidatorQueue.Remove(ctx, validatorQueueKey(endTime, endHeight)); err != nil {
+		panic(err)
+	}
 }
 
 // DeleteValidatorQueue removes a validator by address from the unbonding queue
@@ -421,28 +406,19 @@ func (k Keeper) UnbondAllMatureValidators(ctx sdk.Context) {
 	blockTime := ctx.BlockTime()
 	blockHeight := ctx.BlockHeight()
 
-	// unbondingValIterator will contains all validator addresses indexed under
-	// the ValidatorQueueKey prefix. Note, the entire index key is composed as
+	// the walk ranges over all validator addresses indexed under the
+	// ValidatorQueueKey prefix. Note, the entire index key is composed as
 	// ValidatorQueueKey | timeBzLen (8-byte big endian) | timeBz | heightBz (8-byte big endian),
 	// so it may be possible that certain validator addresses that are iterated
 	// over are not ready to unbond, so an explicit check is required.
-	unbondingValIterator := k.ValidatorQueueIterator(ctx, blockTime, blockHeight)
-	defer unbondingValIterator.Close()
-
-	for ; unbondingValIterator.Valid(); unbondingValIterator.Next() {
-		key := unbondingValIterator.Key()
-		keyTime, keyHeight, err := types.ParseValidatorQueueKey(key)
-		if err != nil {
-			panic(fmt.Errorf("failed to parse unbonding key: %w", err))
-		}
+	rng := new(collections.Range[collections.Triple[uint64, time.Time, uint64]]).EndInclusive(validatorQueueKey(blockTime, blockHeight))
+	err := k.ValidatorQueue.Walk(ctx, rng, func(key collections.Triple[uint64, time.Time, uint64], addrs types.ValAddresses) bool {
+		keyTime, keyHeight := key.K2(), int64(key.K3())
 
 		// All addresses for the given key have the same unbonding height and time.
 		// We only unbond if the height and time are less than the current height
 		// and time.
 		if keyHeight <= blockHeight && (keyTime.Before(blockTime) || keyTime.Equal(blockTime)) {
-			addrs := types.ValAddresses{}
-			k.cdc.MustUnmarshal(unbondingValIterator.Value(), &addrs)
-
 			for _, valAddr := range addrs.Addresses {
 				addr, err := sdk.ValAddressFromBech32(valAddr)
 				if err != nil {
@@ -476,9 +452,19 @@ func (k Keeper) UnbondAllMatureValidators(ctx sdk.Context) {
 				}
 			}
 		}
+		return false
+	})
+	if err != nil && !errors.Is(err, collections.ErrInvalidIterator) {
+		panic(err)
 	}
 }
 
+// validatorQueueKey returns the key of the validator queue for a given height
+// and time, prefixed by the length of the encoded time.
+func validatorQueueKey(endTime time.Time, endHeight int64) collections.Triple[uint64, time.Time, uint64] {
+	return collections.Join3(uint64(len(sdk.FormatTimeBytes(endTime))), endTime, uint64(endHeight))
+}
+
 func (k Keeper) IsValidatorJailed(ctx sdk.Context, addr sdk.ConsAddress) bool {
 	v, ok := k.GetValidatorByConsAddr(ctx, addr)
 	if !ok {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
 )
 
 const (
@@ -53,6 +54,47 @@ func ParseDelegationKey(bz []byte) (sdk.AccAddress, sdk.ValAddress, error) {
 	return del, val, nil
 }
 
+// GetDelegationsByValKey creates the key for delegations by validator address
+// VALUE: staking/Delegation
+func GetDelegationsByValKey(valAddr sdk.ValAddress, delAddr sdk.AccAddress) []byte {
+	return append(GetDelegationsByValPrefixKey(valAddr), delAddr...)
+}
+
+// GetDelegationsByValPrefixKey builds a prefix key bytes with the given validator address bytes.
+func GetDelegationsByValPrefixKey(valAddr sdk.ValAddress) []byte {
+	return append(DelegationByValIndexKey, address.MustLengthPrefix(valAddr)...)
+}
+
+// ParseDelegationsByValKey parses given key and returns validator, delegator address bytes
+func ParseDelegationsByValKey(bz []byte) (sdk.ValAddress, sdk.AccAddress, error) {
+	prefixLength := len(DelegationByValIndexKey)
+	if prefix := bz[:prefixLength]; !bytes.Equal(prefix, DelegationByValIndexKey) {
+		return nil, nil, fmt.Errorf("invalid prefix; expected: %X, got: %x", DelegationByValIndexKey, prefix)
+	}
+
+	bz = bz[prefixLength:] // remove the prefix byte
+	if len(bz) == 0 {
+		return nil, nil, fmt.Errorf("no bytes left to parse: %X", bz)
+	}
+
+	valAddrLen := bz[0]
+	bz = bz[1:] // remove the length byte of validator address.
+	if len(bz) == 0 {
+		return nil, nil, fmt.Errorf("no bytes left to parse validator address: %X", bz)
+	}
+
+	val := bz[0:int(valAddrLen)]
+
+	bz = bz[int(valAddrLen):] // remove the delegator bytes
+	if len(bz) == 0 {
+		return nil, nil, fmt.Errorf("no bytes left to parse delegator address: %X", bz)
+	}
+
+	del := bz
+
+	return val, del, nil
+}
+
 // GetHistoricalInfoKey returns a key prefix for indexing HistoricalInfo objects.
 func GetHistoricalInfoKey(height int64) []byte {
 	heightBytes := make([]byte, 8)
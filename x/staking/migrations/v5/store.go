@@ -10,7 +10,6 @@ import (
 	storetypes "cosmossdk.io/store/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 func migrateDelegationsByValidatorIndex(ctx sdk.Context, storeKey storetypes.StoreKey, cdc codec.BinaryCodec) error {
@@ -25,7 +24,7 @@ func migrateDelegationsByValidatorIndex(ctx sdk.Context, storeKey storetypes.Sto
 			return err
 		}
 
-		store.Set(types.GetDelegationsByValKey(val, del), []byte{})
+		store.Set(GetDelegationsByValKey(val, del), []byte{})
 	}
 
 	return nil
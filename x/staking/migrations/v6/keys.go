@@ -0,0 +1,17 @@
+package v6
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "staking"
+)
+
+var (
+	// OldDelegationByValIndexKey is the prefix of the delegations by validator index
+	// before v6. It was also the key of the unbonding ID counter.
+	OldDelegationByValIndexKey = []byte{0x37}
+	// DelegationByValIndexKey is the prefix of the delegations by validator index
+	// from v6 on.
+	DelegationByValIndexKey = []byte{0x71}
+	// UnbondingIDKey is the key of the unbonding ID counter, it is left in place.
+	UnbondingIDKey = []byte{0x37}
+)
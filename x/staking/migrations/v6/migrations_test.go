@@ -0,0 +1,50 @@
+package v6_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	"github.com/cosmos/cosmos-sdk/testutil/sims"
+	v5 "github.com/cosmos/cosmos-sdk/x/staking/migrations/v5"
+	v6 "github.com/cosmos/cosmos-sdk/x/staking/migrations/v6"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestDelegationsByValidatorIndexMigration(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(v6.ModuleName)
+	tKey := storetypes.NewTransientStoreKey("transient_test")
+	ctx := testutil.DefaultContext(storeKey, tKey)
+	store := ctx.KVStore(storeKey)
+
+	accAddrs := sims.CreateIncrementalAccounts(11)
+	valAddrs := sims.ConvertAddrsToValAddrs(accAddrs[0:1])
+
+	// the unbonding ID counter shares the old prefix of the index
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, 42)
+	store.Set(v6.UnbondingIDKey, counter)
+
+	for _, accAddr := range accAddrs[1:] {
+		store.Set(v5.GetDelegationsByValKey(valAddrs[0], accAddr), []byte{})
+	}
+
+	require.NoError(t, v6.MigrateStore(ctx, storeKey))
+
+	for _, accAddr := range accAddrs[1:] {
+		require.False(t, store.Has(v5.GetDelegationsByValKey(valAddrs[0], accAddr)))
+		require.True(t, store.Has(stakingtypes.GetDelegationsByValKey(valAddrs[0], accAddr)))
+	}
+
+	// the counter is left untouched
+	require.Equal(t, counter, store.Get(v6.UnbondingIDKey))
+	iterator := storetypes.KVStorePrefixIterator(store, v6.OldDelegationByValIndexKey)
+	defer iterator.Close()
+	require.True(t, iterator.Valid())
+	require.Equal(t, v6.UnbondingIDKey, iterator.Key())
+	iterator.Next()
+	require.False(t, iterator.Valid())
+}
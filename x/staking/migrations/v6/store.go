@@ -0,0 +1,42 @@
+package v6
+
+import (
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MigrateStore performs in-place store migrations from v5 to v6.
+func MigrateStore(ctx sdk.Context, storeKey storetypes.StoreKey) error {
+	store := ctx.KVStore(storeKey)
+	return migrateDelegationsByValidatorIndex(store, ctx.Logger())
+}
+
+// migrateDelegationsByValidatorIndex moves the delegations by validator index out of
+// the prefix it shared with the unbonding ID counter, so that the keys of the index
+// no longer have the counter's key as a prefix.
+func migrateDelegationsByValidatorIndex(store storetypes.KVStore, logger log.Logger) error {
+	// old key is of format:
+	// prefix (0x37) || len(valAddr) || valAddr || delAddr
+	// new key is of format:
+	// prefix (0x71) || len(valAddr) || valAddr || delAddr
+	oldStore := prefix.NewStore(store, OldDelegationByValIndexKey)
+
+	oldStoreIter := oldStore.Iterator(nil, nil)
+	defer sdk.LogDeferred(logger, func() error { return oldStoreIter.Close() })
+
+	for ; oldStoreIter.Valid(); oldStoreIter.Next() {
+		key := oldStoreIter.Key()
+		// the unbonding ID counter is stored at the prefix itself
+		if len(key) == 0 {
+			continue
+		}
+
+		store.Set(append(DelegationByValIndexKey, key...), []byte{})
+		oldStore.Delete(key)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	modulev1 "cosmossdk.io/api/cosmos/staking/module/v1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/depinject"
 	store "cosmossdk.io/store/types"
@@ -31,15 +32,16 @@ import (
 )
 
 const (
-	consensusVersion uint64 = 5
+	consensusVersion uint64 = 6
 )
 
 var (
-	_ appmodule.AppModule        = AppModule{}
-	_ appmodule.HasBeginBlocker  = AppModule{}
-	_ module.HasABCIEndblock     = AppModule{}
-	_ module.AppModuleBasic      = AppModuleBasic{}
-	_ module.AppModuleSimulation = AppModule{}
+	_ appmodule.AppModule         = AppModule{}
+	_ appmodule.HasBeginBlocker   = AppModule{}
+	_ module.HasABCIEndblock      = AppModule{}
+	_ module.AppModuleBasic       = AppModuleBasic{}
+	_ module.AppModuleSimulation  = AppModule{}
+	_ module.HasCollectionsSchema = AppModule{}
 )
 
 // AppModuleBasic defines the basic application module used by the staking module.
@@ -138,6 +140,11 @@ func (am AppModule) IsOnePerModuleType() {}
 // IsAppModule implements the appmodule.AppModule interface.
 func (am AppModule) IsAppModule() {}
 
+// CollectionsSchema implements module.HasCollectionsSchema.
+func (am AppModule) CollectionsSchema() collections.Schema {
+	return am.keeper.Schema
+}
+
 // Name returns the staking module's name.
 func (AppModule) Name() string {
 	return types.ModuleName
@@ -167,6 +174,9 @@ func (am AppModule) RegisterServices(cfg module.Configurator) {
 	if err := cfg.RegisterMigration(types.ModuleName, 4, m.Migrate4to5); err != nil {
 		panic(fmt.Sprintf("failed to migrate x/%s from version 4 to 5: %v", types.ModuleName, err))
 	}
+	if err := cfg.RegisterMigration(types.ModuleName, 5, m.Migrate5to6); err != nil {
+		panic(fmt.Sprintf("failed to migrate x/%s from version 5 to 6: %v", types.ModuleName, err))
+	}
 }
 
 // InitGenesis performs genesis initialization for the staking module.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/math"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -27,33 +28,34 @@ const (
 var (
 	// Keys for store prefixes
 	// Last* values are constant during a block.
-	LastValidatorPowerKey = []byte{0x11} // prefix for each key to a validator index, for bonded validators
-	LastTotalPowerKey     = []byte{0x12} // prefix for the total power
+	LastValidatorPowerKey = collections.NewPrefix(0x11) // prefix for each key to a validator index, for bonded validators
+	LastTotalPowerKey     = collections.NewPrefix(0x12) // prefix for the total power
 
-	ValidatorsKey             = []byte{0x21} // prefix for each key to a validator
-	ValidatorsByConsAddrKey   = []byte{0x22} // prefix for each key to a validator index, by pubkey
-	ValidatorsByPowerIndexKey = []byte{0x23} // prefix for each key to a validator index, sorted by power
+	ValidatorsKey             = collections.NewPrefix(0x21) // prefix for each key to a validator
+	ValidatorsByConsAddrKey   = collections.NewPrefix(0x22) // prefix for each key to a validator index, by pubkey
+	ValidatorsByPowerIndexKey = collections.NewPrefix(0x23) // prefix for each key to a validator index, sorted by power
 
-	DelegationKey                    = []byte{0x31} // key for a delegation
-	UnbondingDelegationKey           = []byte{0x32} // key for an unbonding-delegation
-	UnbondingDelegationByValIndexKey = []byte{0x33} // prefix for each key for an unbonding-delegation, by validator operator
-	RedelegationKey                  = []byte{0x34} // key for a redelegation
-	RedelegationByValSrcIndexKey     = []byte{0x35} // prefix for each key for an redelegation, by source validator operator
-	RedelegationByValDstIndexKey     = []byte{0x36} // prefix for each key for an redelegation, by destination validator operator
-	DelegationByValIndexKey          = []byte{0x37} // key for delegations by a validator
+	DelegationKey                    = collections.NewPrefix(0x31) // key for a delegation
+	UnbondingDelegationKey           = collections.NewPrefix(0x32) // key for an unbonding-delegation
+	UnbondingDelegationByValIndexKey = collections.NewPrefix(0x33) // prefix for each key for an unbonding-delegation, by validator operator
+	RedelegationKey                  = collections.NewPrefix(0x34) // key for a redelegation
+	RedelegationByValSrcIndexKey     = collections.NewPrefix(0x35) // prefix for each key for an redelegation, by source validator operator
+	RedelegationByValDstIndexKey     = collections.NewPrefix(0x36) // prefix for each key for an redelegation, by destination validator operator
 
-	UnbondingIDKey    = []byte{0x37} // key for the counter for the incrementing id for UnbondingOperations
-	UnbondingIndexKey = []byte{0x38} // prefix for an index for looking up unbonding operations by their IDs
-	UnbondingTypeKey  = []byte{0x39} // prefix for an index containing the type of unbonding operations
+	UnbondingIDKey    = collections.NewPrefix(0x37) // key for the counter for the incrementing id for UnbondingOperations
+	UnbondingIndexKey = collections.NewPrefix(0x38) // prefix for an index for looking up unbonding operations by their IDs
+	UnbondingTypeKey  = collections.NewPrefix(0x39) // prefix for an index containing the type of unbonding operations
 
-	UnbondingQueueKey    = []byte{0x41} // prefix for the timestamps in unbonding queue
-	RedelegationQueueKey = []byte{0x42} // prefix for the timestamps in redelegations queue
-	ValidatorQueueKey    = []byte{0x43} // prefix for the timestamps in validator queue
+	UnbondingQueueKey    = collections.NewPrefix(0x41) // prefix for the timestamps in unbonding queue
+	RedelegationQueueKey = collections.NewPrefix(0x42) // prefix for the timestamps in redelegations queue
+	ValidatorQueueKey    = collections.NewPrefix(0x43) // prefix for the timestamps in validator queue
 
-	HistoricalInfoKey   = []byte{0x50} // prefix for the historical info
-	ValidatorUpdatesKey = []byte{0x61} // prefix for the end block validator updates key
+	HistoricalInfoKey   = collections.NewPrefix(0x50) // prefix for the historical info
+	ValidatorUpdatesKey = collections.NewPrefix(0x61) // prefix for the end block validator updates key
 
-	ParamsKey = []byte{0x51} // prefix for parameters for module x/staking
+	ParamsKey = collections.NewPrefix(0x51) // prefix for parameters for module x/staking
+
+	DelegationByValIndexKey = collections.NewPrefix(0x71) // key for delegations by a validator, it used to share the prefix 0x37 with UnbondingIDKey
 )
 
 // UnbondingType defines the type of unbonding operation